@@ -0,0 +1,85 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// createAPIKeyRequest is the JSON body of POST /admin/api-keys.
+type createAPIKeyRequest struct {
+	Name       string `json:"name"`
+	RateLimit  int    `json:"rate_limit"`
+	DailyQuota int    `json:"daily_quota"`
+}
+
+// APIKeysHandler handles the admin API key requests.
+type APIKeysHandler struct {
+	createAPIKeyFunc usecase.CreateAPIKeyFunc
+	getAPIKeysFunc   usecase.GetAPIKeysFunc
+	revokeAPIKeyFunc usecase.RevokeAPIKeyFunc
+}
+
+// NewAPIKeysHandler creates a new API keys handler.
+func NewAPIKeysHandler(createAPIKeyFunc usecase.CreateAPIKeyFunc, getAPIKeysFunc usecase.GetAPIKeysFunc, revokeAPIKeyFunc usecase.RevokeAPIKeyFunc) *APIKeysHandler {
+	return &APIKeysHandler{
+		createAPIKeyFunc: createAPIKeyFunc,
+		getAPIKeysFunc:   getAPIKeysFunc,
+		revokeAPIKeyFunc: revokeAPIKeyFunc,
+	}
+}
+
+// CreateAPIKey handles POST /admin/api-keys requests, answering the new key, which is not returned afterwards.
+func (h *APIKeysHandler) CreateAPIKey(c *gin.Context) {
+	var request createAPIKeyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abortWithInvalidRequest(c, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	apiKey, err := h.createAPIKeyFunc(c.Request.Context(), usecase.CreateAPIKeyInput{
+		Name:       request.Name,
+		RateLimit:  request.RateLimit,
+		DailyQuota: request.DailyQuota,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Location", fmt.Sprintf("/admin/api-keys/%d", apiKey.ID))
+	c.JSON(http.StatusCreated, apiKey)
+}
+
+// GetAPIKeys handles GET /admin/api-keys requests.
+func (h *APIKeysHandler) GetAPIKeys(c *gin.Context) {
+	response, err := h.getAPIKeysFunc(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeAPIKey handles DELETE /admin/api-keys/{id} requests.
+func (h *APIKeysHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		abortWithInvalidRequest(c, fmt.Sprintf("invalid API key id: %s", c.Param("id")))
+		return
+	}
+
+	if err := h.revokeAPIKeyFunc(c.Request.Context(), id); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// newAPIKeysTestRouter serves the admin API key routes over an in-memory database.
+func newAPIKeysTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	db := databasememory.New()
+	metricsClient := metricsnoop.New()
+	handler := NewAPIKeysHandler(
+		usecase.NewCreateAPIKeyFunc(db, metricsClient),
+		usecase.NewGetAPIKeysFunc(db, metricsClient),
+		usecase.NewRevokeAPIKeyFunc(db, metricsClient),
+	)
+
+	router := newTestRouter()
+	router.POST("/admin/api-keys", handler.CreateAPIKey)
+	router.GET("/admin/api-keys", handler.GetAPIKeys)
+	router.DELETE("/admin/api-keys/:id", handler.RevokeAPIKey)
+	return router
+}
+
+func Test_APIKeysHandler_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			body:           `{"name":"explorer","rate_limit":100,"daily_quota":1000}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "error - invalid body",
+			body:           `{"name":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request body: unexpected EOF",
+		},
+		{
+			name:           "error - missing name",
+			body:           `{"rate_limit":100}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid API key: name is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAPIKeysTestRouter()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/admin/api-keys", strings.NewReader(tt.body))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var created model.CreatedAPIKey
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+			assert.Equal(t, "/admin/api-keys/1", w.Header().Get("Location"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Equal(t, 100, created.RateLimit)
+			assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+
+			// The key is not listed afterwards.
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/admin/api-keys", nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.NotContains(t, w.Body.String(), created.Key)
+			assert.Contains(t, w.Body.String(), `"name":"explorer"`)
+		})
+	}
+}
+
+func Test_APIKeysHandler_RevokeAPIKey(t *testing.T) {
+	router := newAPIKeysTestRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/api-keys", strings.NewReader(`{"name":"explorer"}`))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			url:            "/admin/api-keys/1",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "nominal case - already revoked",
+			url:            "/admin/api-keys/1",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "error - unknown key",
+			url:            "/admin/api-keys/2",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "API key not found: 2",
+		},
+		{
+			name:           "error - invalid id",
+			url:            "/admin/api-keys/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid API key id: abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package http
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/apperror"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const (
+	// headerAPIKey is the header carrying an API key, besides the bearer token of the Authorization header.
+	headerAPIKey = "X-API-Key"
+
+	// contextKeyAPIKey is the gin context key of the API key authenticating a request.
+	contextKeyAPIKey = "apiKey"
+
+	// anonymousClient labels the requests without API key in the metrics.
+	anonymousClient = "anonymous"
+
+	// Outcomes of the requests recorded per API key.
+	outcomeAllowed       = "allowed"
+	outcomeRateLimited   = "rate_limited"
+	outcomeQuotaExceeded = "quota_exceeded"
+
+	// rateLimitWindow is the window of the per-minute rate limits.
+	rateLimitWindow = time.Minute
+
+	// maxCachedUnknownAPIKeys bounds the cache of the keys matching no API key.
+	maxCachedUnknownAPIKeys = 10000
+)
+
+// Errors answered by the AuthMiddleware.
+var (
+	errMissingAPIKey      = apperror.New(apperror.KindUnauthorized, "missing_api_key", "missing API key")
+	errInvalidAPIKey      = apperror.New(apperror.KindUnauthorized, "unknown_api_key", "invalid API key")
+	errInvalidAdminToken  = apperror.New(apperror.KindUnauthorized, "invalid_admin_token", "invalid admin token")
+	errAdminDisabled      = apperror.New(apperror.KindForbidden, "admin_disabled", "admin endpoints are disabled")
+	errRateLimitExceeded  = apperror.New(apperror.KindRateLimited, "rate_limit_exceeded", "rate limit exceeded")
+	errDailyQuotaExceeded = apperror.New(apperror.KindRateLimited, "daily_quota_exceeded", "daily quota exceeded")
+)
+
+// Secret hides a configured secret from the logged configuration.
+type Secret string
+
+// String implements Stringer.
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return "******"
+}
+
+// AuthConfig configures the API key authentication, the rate limits and the daily quotas.
+// A limit or quota of 0 disables it.
+type AuthConfig struct {
+	// DenyAnonymous refuses the requests without API key, let through at AnonymousRateLimit per client IP otherwise.
+	DenyAnonymous      bool `mapstructure:"deny_anonymous"`
+	AnonymousRateLimit int  `mapstructure:"anonymous_rate_limit"`
+	// DefaultRateLimit and DefaultDailyQuota apply to the keys created without their own.
+	DefaultRateLimit  int `mapstructure:"default_rate_limit"`
+	DefaultDailyQuota int `mapstructure:"default_daily_quota"`
+	// KeyCacheTTL is the delay after which a revoked key is refused by every instance.
+	KeyCacheTTL time.Duration `mapstructure:"key_cache_ttl"`
+	// AdminToken authenticates the /admin endpoints, which are disabled when it is empty.
+	AdminToken Secret `mapstructure:"admin_token"`
+	// TrustedProxies are the addresses or CIDRs of the reverse proxies whose X-Forwarded-For header sets the client IP
+	// of the anonymous rate limits. None is trusted by default, the client IP being the peer address.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// AuthMiddleware authenticates the API requests and enforces the rate limits and daily quotas.
+// Rate limits are counted by each API instance, daily quotas are shared through the database.
+type AuthMiddleware struct {
+	cfg                    AuthConfig
+	authenticateAPIKeyFunc usecase.AuthenticateAPIKeyFunc
+	countAPIKeyRequestFunc usecase.CountAPIKeyRequestFunc
+	limiter                *rateLimiter
+	metrics                metrics.Adapter
+	now                    func() time.Time
+}
+
+// NewAuthMiddleware creates a new authentication middleware.
+func NewAuthMiddleware(cfg AuthConfig, authenticateAPIKeyFunc usecase.AuthenticateAPIKeyFunc, countAPIKeyRequestFunc usecase.CountAPIKeyRequestFunc, metricsClient metrics.Adapter) *AuthMiddleware {
+	return &AuthMiddleware{
+		cfg:                    cfg,
+		authenticateAPIKeyFunc: authenticateAPIKeyFunc,
+		countAPIKeyRequestFunc: countAPIKeyRequestFunc,
+		limiter:                newRateLimiter(rateLimitWindow),
+		metrics:                metricsClient,
+		now:                    time.Now,
+	}
+}
+
+// Authenticate identifies the API key of a request, from `Authorization: Bearer` or X-API-Key, and answers 401 for an
+// invalid key, or for a missing one when anonymous access is disabled. It answers 429 once the rate limit of the
+// key, or of the client IP without key, or the daily quota of the key is reached.
+func (m *AuthMiddleware) Authenticate(c *gin.Context) {
+	key := requestAPIKey(c)
+	if key == "" {
+		m.authenticateAnonymous(c)
+		return
+	}
+
+	apiKey, err := m.authenticateAPIKeyFunc(c.Request.Context(), key)
+	if errors.Is(err, usecase.ErrUnauthorized) {
+		m.unauthorized(c, errInvalidAPIKey)
+		return
+	} else if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	rateLimit := apiKey.RateLimit
+	if rateLimit == 0 {
+		rateLimit = m.cfg.DefaultRateLimit
+	}
+	if !m.allow(c, "key:"+strconv.FormatInt(apiKey.ID, 10), apiKey.Prefix, rateLimit) {
+		return
+	}
+
+	dailyQuota := apiKey.DailyQuota
+	if dailyQuota == 0 {
+		dailyQuota = m.cfg.DefaultDailyQuota
+	}
+	if dailyQuota > 0 {
+		now := m.now().UTC()
+		requests, err := m.countAPIKeyRequestFunc(c.Request.Context(), apiKey.ID, now)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Header("X-RateLimit-Daily-Limit", strconv.Itoa(dailyQuota))
+		c.Header("X-RateLimit-Daily-Remaining", strconv.FormatInt(max(int64(dailyQuota)-requests, 0), 10))
+		if requests > int64(dailyQuota) {
+			tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+			m.record(apiKey.Prefix, outcomeQuotaExceeded)
+			m.tooManyRequests(c, errDailyQuotaExceeded, tomorrow.Sub(now))
+			return
+		}
+	}
+
+	m.record(apiKey.Prefix, outcomeAllowed)
+	c.Set(contextKeyAPIKey, apiKey)
+	c.Next()
+}
+
+// authenticateAnonymous lets a request without API key through at the anonymous rate limit of its client IP.
+func (m *AuthMiddleware) authenticateAnonymous(c *gin.Context) {
+	if m.cfg.DenyAnonymous {
+		m.unauthorized(c, errMissingAPIKey)
+		return
+	}
+	if !m.allow(c, "ip:"+c.ClientIP(), anonymousClient, m.cfg.AnonymousRateLimit) {
+		return
+	}
+
+	m.record(anonymousClient, outcomeAllowed)
+	c.Next()
+}
+
+// allow counts a request in the rate limit of a client, setting the X-RateLimit headers, and answers 429 when the
+// limit is reached.
+func (m *AuthMiddleware) allow(c *gin.Context, client, label string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	now := m.now()
+	remaining, reset, ok := m.limiter.allow(client, limit, now)
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	if !ok {
+		m.record(label, outcomeRateLimited)
+		m.tooManyRequests(c, errRateLimitExceeded, reset.Sub(now))
+	}
+	return ok
+}
+
+// RequireAPIKey answers 401 to the requests which Authenticate let through without API key.
+func (m *AuthMiddleware) RequireAPIKey(c *gin.Context) {
+	if _, ok := c.Get(contextKeyAPIKey); !ok {
+		m.unauthorized(c, errMissingAPIKey)
+		return
+	}
+	c.Next()
+}
+
+// RequireAdmin answers 401 to the requests without the admin token as bearer token, and 403 when no admin token is
+// configured.
+func (m *AuthMiddleware) RequireAdmin(c *gin.Context) {
+	if m.cfg.AdminToken == "" {
+		abortWithError(c, errAdminDisabled)
+		return
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(m.cfg.AdminToken)) != 1 {
+		m.unauthorized(c, errInvalidAdminToken)
+		return
+	}
+	c.Next()
+}
+
+// unauthorized answers 401 with a bearer challenge.
+func (m *AuthMiddleware) unauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", "Bearer")
+	abortWithError(c, err)
+}
+
+// tooManyRequests answers 429, retrying after the given delay rounded up to the second.
+func (m *AuthMiddleware) tooManyRequests(c *gin.Context, err error, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.FormatInt(int64((retryAfter+time.Second-1)/time.Second), 10))
+	abortWithError(c, err)
+}
+
+// record counts a request of a client by outcome.
+func (m *AuthMiddleware) record(client, outcome string) {
+	if m.metrics != nil {
+		m.metrics.RecordAPIKeyRequest(client, outcome)
+	}
+}
+
+// requestAPIKey returns the API key of a request, from the bearer token or the X-API-Key header. A bearer token
+// without the API key prefix, such as the admin token, is not an API key and is ignored.
+func requestAPIKey(c *gin.Context) string {
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		if token = strings.TrimSpace(token); strings.HasPrefix(token, model.APIKeyPrefix) {
+			return token
+		}
+	}
+	return strings.TrimSpace(c.GetHeader(headerAPIKey))
+}
+
+// rateWindow counts the requests of a client in the current window.
+type rateWindow struct {
+	start    time.Time
+	requests int
+}
+
+// rateLimiter counts the requests per client in fixed windows.
+type rateLimiter struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	clients   map[string]*rateWindow
+	nextSweep time.Time
+}
+
+// newRateLimiter creates a rate limiter counting the requests per window.
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{window: window, clients: make(map[string]*rateWindow)}
+}
+
+// allow counts a request of a client at now, and returns the remaining requests in the window, its end, and whether
+// the request is within limit.
+func (l *rateLimiter) allow(client string, limit int, now time.Time) (int, time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The windows which ended are dropped once per window, so that the clients seen once are not kept.
+	if !now.Before(l.nextSweep) {
+		for k, w := range l.clients {
+			if !now.Before(w.start.Add(l.window)) {
+				delete(l.clients, k)
+			}
+		}
+		l.nextSweep = now.Add(l.window)
+	}
+
+	start := now.Truncate(l.window)
+	w, ok := l.clients[client]
+	if !ok || !w.start.Equal(start) {
+		w = &rateWindow{start: start}
+		l.clients[client] = w
+	}
+	reset := start.Add(l.window)
+	if w.requests >= limit {
+		return 0, reset, false
+	}
+	w.requests++
+	return limit - w.requests, reset, true
+}
@@ -0,0 +1,315 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	cachenoop "github.com/tezos-delegation-service/internal/adapter/cache/impl/noop"
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// newAuthTestRouter serves a route behind the authentication middleware over an in-memory database, and returns a
+// valid API key.
+func newAuthTestRouter(t *testing.T, cfg AuthConfig, dailyQuota int) (*gin.Engine, string) {
+	gin.SetMode(gin.TestMode)
+
+	db := databasememory.New()
+	metricsClient := metricsnoop.New()
+	created, err := usecase.NewCreateAPIKeyFunc(db, metricsClient)(context.Background(), usecase.CreateAPIKeyInput{Name: "explorer", DailyQuota: dailyQuota})
+	if err != nil {
+		t.Fatalf("creating API key: %v", err)
+	}
+
+	auth := NewAuthMiddleware(cfg, usecase.NewAuthenticateAPIKeyFunc(0, nil, db, metricsClient), usecase.NewCountAPIKeyRequestFunc(db, metricsClient), metricsClient)
+	auth.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC) }
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router := newTestRouter()
+	router.GET("/xtz/delegations", auth.Authenticate, ok)
+	router.GET("/xtz/webhooks", auth.Authenticate, auth.RequireAPIKey, ok)
+	router.GET("/admin/api-keys", auth.RequireAdmin, ok)
+	return router, created.Key
+}
+
+func Test_AuthMiddleware_Authenticate(t *testing.T) {
+	tests := []struct {
+		name           string
+		cfg            AuthConfig
+		dailyQuota     int
+		headers        func(key string) map[string]string
+		requests       int
+		expectedStatus int
+		expectedError  string
+		expectedHeader map[string]string
+	}{
+		{
+			name:           "nominal case - anonymous",
+			cfg:            AuthConfig{AnonymousRateLimit: 2},
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1704110460"},
+		},
+		{
+			name:           "nominal case - bearer API key",
+			cfg:            AuthConfig{AnonymousRateLimit: 1, DefaultRateLimit: 10},
+			headers:        func(key string) map[string]string { return map[string]string{"Authorization": "Bearer " + key} },
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{"X-RateLimit-Limit": "10", "X-RateLimit-Remaining": "8"},
+		},
+		{
+			name:           "nominal case - X-API-Key header with daily quota",
+			cfg:            AuthConfig{DenyAnonymous: true},
+			dailyQuota:     5,
+			headers:        func(key string) map[string]string { return map[string]string{headerAPIKey: key} },
+			requests:       2,
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{"X-RateLimit-Daily-Limit": "5", "X-RateLimit-Daily-Remaining": "3"},
+		},
+		{
+			name:           "nominal case - admin bearer token served as anonymous",
+			cfg:            AuthConfig{AdminToken: "s3cret", AnonymousRateLimit: 2},
+			headers:        func(string) map[string]string { return map[string]string{"Authorization": "Bearer s3cret"} },
+			requests:       1,
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{"X-RateLimit-Limit": "2", "X-RateLimit-Remaining": "1"},
+		},
+		{
+			name:           "error - anonymous rate limit exceeded",
+			cfg:            AuthConfig{AnonymousRateLimit: 2},
+			requests:       3,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedError:  "rate limit exceeded",
+			expectedHeader: map[string]string{"X-RateLimit-Remaining": "0", "Retry-After": "30"},
+		},
+		{
+			name:           "error - anonymous access denied",
+			cfg:            AuthConfig{DenyAnonymous: true},
+			requests:       1,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "missing API key",
+			expectedHeader: map[string]string{"WWW-Authenticate": "Bearer"},
+		},
+		{
+			name:           "error - invalid API key",
+			headers:        func(string) map[string]string { return map[string]string{"Authorization": "Bearer tzd_unknown"} },
+			requests:       1,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid API key",
+		},
+		{
+			name:           "error - daily quota exceeded",
+			dailyQuota:     1,
+			headers:        func(key string) map[string]string { return map[string]string{headerAPIKey: key} },
+			requests:       2,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedError:  "daily quota exceeded",
+			expectedHeader: map[string]string{"X-RateLimit-Daily-Remaining": "0", "Retry-After": "43170"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, key := newAuthTestRouter(t, tt.cfg, tt.dailyQuota)
+
+			var w *httptest.ResponseRecorder
+			for i := 0; i < tt.requests; i++ {
+				w = httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", "/xtz/delegations", nil)
+				if tt.headers != nil {
+					for k, v := range tt.headers(key) {
+						req.Header.Set(k, v)
+					}
+				}
+				router.ServeHTTP(w, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for k, v := range tt.expectedHeader {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
+
+func Test_Server_anonymousRateLimitClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		expectedStatus int
+	}{
+		{
+			name:           "error - spoofed X-Forwarded-For shares the bucket of the peer",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "error - invalid trusted proxies trust none",
+			trustedProxies: []string{"not-an-ip"},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "nominal case - X-Forwarded-For of a trusted proxy",
+			trustedProxies: []string{"192.0.2.0/24"},
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			s := NewServer(0, 50, StreamConfig{}, AuthConfig{AnonymousRateLimit: 1, TrustedProxies: tt.trustedProxies}, OpenAPIConfig{},
+				usecase.QueryCacheConfig{}, cachenoop.New(), databasememory.New(), metricsnoop.New(), logrus.NewEntry(logger)).SetupRoutes()
+
+			var w *httptest.ResponseRecorder
+			for _, forwardedFor := range []string{"198.51.100.1", "198.51.100.2"} {
+				w = httptest.NewRecorder()
+				req, _ := http.NewRequest("GET", "/xtz/delegations", nil)
+				req.RemoteAddr = "192.0.2.10:54321"
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				s.router.ServeHTTP(w, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func Test_AuthMiddleware_RequireAPIKey(t *testing.T) {
+	router, key := newAuthTestRouter(t, AuthConfig{}, 0)
+
+	tests := []struct {
+		name           string
+		key            string
+		expectedStatus int
+	}{
+		{
+			name:           "nominal case",
+			key:            key,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - anonymous request",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/xtz/webhooks", nil)
+			if tt.key != "" {
+				req.Header.Set(headerAPIKey, tt.key)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func Test_AuthMiddleware_RequireAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		adminToken     Secret
+		authorization  string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			adminToken:     "admin-secret",
+			authorization:  "Bearer admin-secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid token",
+			adminToken:     "admin-secret",
+			authorization:  "Bearer admin",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid admin token",
+		},
+		{
+			name:           "error - missing token",
+			adminToken:     "admin-secret",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid admin token",
+		},
+		{
+			name:           "error - admin endpoints disabled",
+			authorization:  "Bearer ",
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "admin endpoints are disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newAuthTestRouter(t, AuthConfig{AdminToken: tt.adminToken}, 0)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/admin/api-keys", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
+
+func Test_rateLimiter_allow(t *testing.T) {
+	limiter := newRateLimiter(time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	remaining, reset, ok := limiter.allow("client", 2, start.Add(10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 1, remaining)
+	assert.Equal(t, start.Add(time.Minute), reset)
+
+	_, _, ok = limiter.allow("client", 2, start.Add(20*time.Second))
+	assert.True(t, ok)
+	_, _, ok = limiter.allow("client", 2, start.Add(30*time.Second))
+	assert.False(t, ok)
+
+	// Other clients have their own window.
+	_, _, ok = limiter.allow("other", 2, start.Add(30*time.Second))
+	assert.True(t, ok)
+
+	// The next window resets the count.
+	remaining, _, ok = limiter.allow("client", 2, start.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, 1, remaining)
+
+	// The ended windows are dropped a window after the last sweep.
+	_, _, ok = limiter.allow("client", 2, start.Add(70*time.Second))
+	assert.True(t, ok)
+	assert.Len(t, limiter.clients, 1)
+}
+
+func Test_Secret_String(t *testing.T) {
+	cfg := AuthConfig{AdminToken: "admin-secret"}
+	assert.NotContains(t, fmt.Sprintf("%+v", cfg), "admin-secret")
+	assert.Equal(t, "", Secret("").String())
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bufferedWriter buffers a response until the middleware holding it sends it, or until it is flushed or hijacked,
+// after which it writes through.
+type bufferedWriter struct {
+	gin.ResponseWriter
+
+	status      int
+	size        int
+	body        bytes.Buffer
+	passthrough bool
+}
+
+// newBufferedWriter buffers the response written to w.
+func newBufferedWriter(w gin.ResponseWriter) *bufferedWriter {
+	return &bufferedWriter{ResponseWriter: w, status: http.StatusOK, size: -1}
+}
+
+// WriteHeader records the status of the buffered response.
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code > 0 && w.size == -1 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow marks the buffered response as written.
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	if w.size == -1 {
+		w.size = 0
+	}
+}
+
+// Write buffers a part of the body.
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	w.WriteHeaderNow()
+	n, err := w.body.Write(data)
+	w.size += n
+	return n, err
+}
+
+// WriteString buffers a part of the body.
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Status returns the status of the response.
+func (w *bufferedWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+// Size returns the number of bytes of the body written, -1 before the response is written.
+func (w *bufferedWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	return w.size
+}
+
+// Written reports whether the response is written.
+func (w *bufferedWriter) Written() bool {
+	return w.Size() != -1
+}
+
+// Flush sends the buffered response, the next writes are sent as they are written.
+func (w *bufferedWriter) Flush() {
+	if !w.passthrough {
+		w.flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack hands the connection over to the handler, which writes the response itself.
+func (w *bufferedWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.passthrough = true
+	return w.ResponseWriter.Hijack()
+}
+
+// flush sends the buffered status and body, and writes through from now on.
+func (w *bufferedWriter) flush() {
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.size == -1 {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
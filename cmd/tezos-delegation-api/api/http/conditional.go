@@ -0,0 +1,117 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const (
+	// lastModifiedCacheTTL is the delay during which the date of the latest indexed level is reused by the responses.
+	lastModifiedCacheTTL = time.Second
+
+	// contextKeyLastModified is the gin context key of the routes dated by the latest indexed delegation.
+	contextKeyLastModified = "lastModified"
+)
+
+// ConditionalMiddleware answers the conditional GET requests with 304 Not Modified, from a strong ETag of the
+// response body and from the date of the latest indexed level.
+type ConditionalMiddleware struct {
+	getLastModifiedFunc usecase.GetLastModifiedFunc
+}
+
+// NewConditionalMiddleware creates a new conditional request middleware.
+func NewConditionalMiddleware(getLastModifiedFunc usecase.GetLastModifiedFunc) *ConditionalMiddleware {
+	return &ConditionalMiddleware{getLastModifiedFunc: getLastModifiedFunc}
+}
+
+// LastModified marks a route as dated by the latest indexed delegation, so that Handle sets its Last-Modified header.
+// Only the delegation collections are, the other resources change independently of the indexed delegations.
+func (m *ConditionalMiddleware) LastModified(c *gin.Context) {
+	c.Set(contextKeyLastModified, true)
+	c.Next()
+}
+
+// Handle buffers the response of a GET request to set its ETag header, and its Last-Modified header on the routes
+// marked by LastModified, unless the handler set them itself, and answers 304 when the If-None-Match or
+// If-Modified-Since precondition of the request fails.
+// The responses flushed or hijacked while written, such as streams and exports, are sent as they are written.
+func (m *ConditionalMiddleware) Handle(c *gin.Context) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		c.Next()
+		return
+	}
+
+	writer := newBufferedWriter(c.Writer)
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	if writer.passthrough {
+		return
+	}
+	if writer.status != http.StatusOK || !writer.Written() {
+		writer.flush()
+		return
+	}
+
+	header := writer.Header()
+	if header.Get("ETag") == "" {
+		sum := sha256.Sum256(writer.body.Bytes())
+		header.Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	}
+	if header.Get("Last-Modified") == "" && m.getLastModifiedFunc != nil && c.GetBool(contextKeyLastModified) {
+		// Without the date, the responses are only validated by their ETag.
+		if lastModified, err := m.getLastModifiedFunc(c.Request.Context()); err == nil && !lastModified.IsZero() {
+			header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	if notModified(c.Request, header) {
+		header.Del("Content-Length")
+		writer.ResponseWriter.WriteHeader(http.StatusNotModified)
+		writer.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	writer.flush()
+}
+
+// notModified evaluates the If-None-Match precondition of a request, or If-Modified-Since without it, against the
+// validators of its response. It reports whether the cached representation of the client is still current.
+func notModified(r *http.Request, header http.Header) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, header.Get("ETag"))
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(ifModifiedSince)
+}
+
+// etagMatches reports whether a list of entity tags of If-None-Match matches an ETag, by weak comparison:
+// W/"x" matches "x". "*" matches any ETag.
+func etagMatches(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
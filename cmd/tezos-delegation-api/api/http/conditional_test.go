@@ -0,0 +1,215 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newConditionalTestRouter serves routes behind the conditional middleware, the latest level being indexed at
+// lastModified.
+func newConditionalTestRouter(lastModified time.Time, err error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	m := NewConditionalMiddleware(func(ctx context.Context) (time.Time, error) {
+		return lastModified, err
+	})
+
+	router := gin.New()
+	router.Use(m.Handle)
+	router.GET("/xtz/delegations", m.LastModified, func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, gin.H{"data": []string{}})
+	})
+	router.GET("/xtz/bakers", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": []string{}})
+	})
+	router.GET("/xtz/accounts/:address", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
+	})
+	router.GET("/xtz/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		_, _ = c.Writer.WriteString(": connected\n\n")
+		c.Writer.Flush()
+		_, _ = c.Writer.WriteString("data: {}\n\n")
+	})
+	router.POST("/xtz/webhooks", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+	return router
+}
+
+func Test_ConditionalMiddleware_Handle(t *testing.T) {
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		headers        func(etag string) map[string]string
+		lastModifiedFn error
+		expectedStatus int
+		expectedBody   string
+		expectedHeader map[string]string
+	}{
+		{
+			name:           "nominal case - validators set",
+			url:            "/xtz/delegations",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+			expectedHeader: map[string]string{"Last-Modified": "Mon, 01 Jan 2024 12:00:00 GMT", "Cache-Control": "public, max-age=300"},
+		},
+		{
+			name:           "nominal case - matching ETag",
+			url:            "/xtz/delegations",
+			headers:        func(etag string) map[string]string { return map[string]string{"If-None-Match": etag} },
+			expectedStatus: http.StatusNotModified,
+			expectedHeader: map[string]string{"Cache-Control": "public, max-age=300"},
+		},
+		{
+			name:           "nominal case - ETag in a list, by weak comparison",
+			url:            "/xtz/delegations",
+			headers:        func(etag string) map[string]string { return map[string]string{"If-None-Match": `"other", W/` + etag} },
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "nominal case - any ETag",
+			url:            "/xtz/delegations",
+			headers:        func(string) map[string]string { return map[string]string{"If-None-Match": "*"} },
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "nominal case - other ETag",
+			url:  "/xtz/delegations",
+			headers: func(string) map[string]string {
+				return map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT"}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+		},
+		{
+			name: "nominal case - not modified since",
+			url:  "/xtz/delegations",
+			headers: func(string) map[string]string {
+				return map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT"}
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "nominal case - modified since",
+			url:  "/xtz/delegations",
+			headers: func(string) map[string]string {
+				return map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 11:59:59 GMT"}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+		},
+		{
+			name: "nominal case - unknown indexed level date",
+			url:  "/xtz/delegations",
+			headers: func(string) map[string]string {
+				return map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT"}
+			},
+			lastModifiedFn: errors.New("database unavailable"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+			expectedHeader: map[string]string{"Last-Modified": ""},
+		},
+		{
+			name: "nominal case - other collections only validated by their ETag",
+			url:  "/xtz/bakers",
+			headers: func(string) map[string]string {
+				return map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 12:00:00 GMT"}
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+			expectedHeader: map[string]string{"Last-Modified": ""},
+		},
+		{
+			name:           "nominal case - errors are not validated",
+			url:            "/xtz/accounts/tz1a",
+			headers:        func(string) map[string]string { return map[string]string{"If-None-Match": "*"} },
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"account not found"}`,
+			expectedHeader: map[string]string{"ETag": "", "Last-Modified": ""},
+		},
+		{
+			name:           "nominal case - flushed stream written through",
+			url:            "/xtz/stream",
+			headers:        func(string) map[string]string { return map[string]string{"If-None-Match": "*"} },
+			expectedStatus: http.StatusOK,
+			expectedBody:   ": connected\n\ndata: {}\n\n",
+			expectedHeader: map[string]string{"ETag": ""},
+		},
+		{
+			name:           "nominal case - other methods",
+			method:         "POST",
+			url:            "/xtz/webhooks",
+			headers:        func(string) map[string]string { return map[string]string{"If-None-Match": "*"} },
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":1}`,
+			expectedHeader: map[string]string{"ETag": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newConditionalTestRouter(lastModified, tt.lastModifiedFn)
+
+			// The ETag of the response is requested first.
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/xtz/delegations", nil)
+			router.ServeHTTP(w, req)
+			etag := w.Header().Get("ETag")
+			assert.Len(t, etag, 66)
+
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest(method, tt.url, nil)
+			if tt.headers != nil {
+				for k, v := range tt.headers(etag) {
+					req.Header.Set(k, v)
+				}
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Equal(t, etag, w.Header().Get("ETag"))
+			}
+			for k, v := range tt.expectedHeader {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func Test_etagMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		etag string
+		want bool
+	}{
+		{name: "Nominal case", list: `"a"`, etag: `"a"`, want: true},
+		{name: "Nominal case - list", list: `"b", "a"`, etag: `"a"`, want: true},
+		{name: "Nominal case - weak tag", list: `W/"a"`, etag: `"a"`, want: true},
+		{name: "Nominal case - any", list: ` * `, etag: `"a"`, want: true},
+		{name: "Error case - other tag", list: `"b"`, etag: `"a"`, want: false},
+		{name: "Error case - unquoted tag", list: `a`, etag: `"a"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.list, tt.etag))
+		})
+	}
+}
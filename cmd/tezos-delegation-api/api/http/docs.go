@@ -0,0 +1,49 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage renders the OpenAPI specification served at /openapi.yaml with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Tezos Delegation Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({url: "/openapi.yaml", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler serves the OpenAPI specification and its documentation page.
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler creates a new documentation handler.
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{spec: spec}
+}
+
+// GetSpec handles GET /openapi.yaml requests.
+func (h *DocsHandler) GetSpec(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", h.spec)
+}
+
+// GetDocs handles GET /docs requests.
+func (h *DocsHandler) GetDocs(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
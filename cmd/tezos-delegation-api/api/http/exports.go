@@ -0,0 +1,289 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// exportFormat is the format of a streaming export.
+type exportFormat string
+
+const (
+	// exportFormatCSV streams a header line followed by a CSV record per row.
+	exportFormatCSV exportFormat = "csv"
+	// exportFormatNDJSON streams a JSON object per line, as the items of the JSON lists.
+	exportFormatNDJSON exportFormat = "ndjson"
+)
+
+const (
+	contentTypeCSV    = "text/csv"
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// exportFlushRows is the number of rows written between two flushes of the response.
+const exportFlushRows = 500
+
+// delegationColumns, operationColumns and rewardColumns are the header lines of the CSV exports.
+var (
+	delegationColumns = []string{"timestamp", "level", "delegator", "delegate", "amount", "amount_tez"}
+	operationColumns  = []string{"id", "timestamp", "type", "status", "sender_address", "contract_address", "entrypoint", "block", "amount", "amount_tez"}
+	rewardColumns     = []string{"id", "timestamp", "cycle", "recipient_address", "source_address", "amount", "amount_tez"}
+)
+
+// ExportsHandler handles the streaming exports of delegations, operations and rewards, in CSV or NDJSON.
+// Exports accept the filters of the JSON lists, which validate them.
+type ExportsHandler struct {
+	exportDelegationsFunc usecase.ExportDelegationsFunc
+	exportOperationsFunc  usecase.ExportOperationsFunc
+	exportRewardsFunc     usecase.ExportRewardsFunc
+	delegations           *GetDelegationsHandler
+	operations            *GetOperationsHandler
+	rewards               *GetRewardsHandler
+}
+
+// NewExportsHandler creates a new exports handler.
+func NewExportsHandler(paginationLimit uint16, exportDelegationsFunc usecase.ExportDelegationsFunc, exportOperationsFunc usecase.ExportOperationsFunc, exportRewardsFunc usecase.ExportRewardsFunc) *ExportsHandler {
+	return &ExportsHandler{
+		exportDelegationsFunc: exportDelegationsFunc,
+		exportOperationsFunc:  exportOperationsFunc,
+		exportRewardsFunc:     exportRewardsFunc,
+		delegations:           &GetDelegationsHandler{paginationLimit: paginationLimit},
+		operations:            &GetOperationsHandler{paginationLimit: paginationLimit},
+		rewards:               &GetRewardsHandler{paginationLimit: paginationLimit},
+	}
+}
+
+// ExportDelegations handles GET /xtz/delegations/export requests.
+func (h *ExportsHandler) ExportDelegations(c *gin.Context) {
+	format, err := exportFormatParam(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	input, err := h.delegations.validateFilterParams(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+	input.Year = c.Query("year")
+
+	streamExport(c, format, "delegations", delegationColumns, func(d model.Delegation) []string {
+		return []string{d.TimestampTime, strconv.FormatInt(d.Level, 10), d.Delegator.String(), d.Delegate.String(), mutezString(d.Amount), d.AmountTez}
+	}, func(fn func(model.Delegation) error) error {
+		return h.exportDelegationsFunc(c.Request.Context(), input, fn)
+	})
+}
+
+// ExportOperations handles GET /xtz/operations/export requests.
+func (h *ExportsHandler) ExportOperations(c *gin.Context) {
+	format, err := exportFormatParam(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	_, _, fromDate, toDate, operationType, wallet, backer, err := h.operations.validateRequestParams(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	input := usecase.GetOperationsInput{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Type:     operationType,
+		Wallet:   wallet,
+		Backer:   backer,
+	}
+	streamExport(c, format, "operations", operationColumns, func(o model.Operation) []string {
+		return []string{strconv.FormatInt(o.ID, 10), o.TimestampTime, o.Type.String(), o.Status, o.SenderAddress.String(), o.ContractAddress.String(), o.Entrypoint, o.Block, mutezString(o.Amount), o.AmountTez}
+	}, func(fn func(model.Operation) error) error {
+		return h.exportOperationsFunc(c.Request.Context(), input, fn)
+	})
+}
+
+// ExportRewards handles GET /xtz/rewards/export requests.
+func (h *ExportsHandler) ExportRewards(c *gin.Context) {
+	format, err := exportFormatParam(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	_, _, fromDate, toDate, wallet, backer, err := h.rewards.validateRequestParams(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	input := usecase.GetRewardsInput{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Wallet:   wallet,
+		Backer:   backer,
+	}
+	streamExport(c, format, "rewards", rewardColumns, func(r model.Reward) []string {
+		return []string{strconv.FormatInt(r.ID, 10), r.TimestampTime, strconv.Itoa(r.Cycle), r.RecipientAddress.String(), r.SourceAddress.String(), mutezString(r.Amount), r.AmountTez}
+	}, func(fn func(model.Reward) error) error {
+		return h.exportRewardsFunc(c.Request.Context(), input, fn)
+	})
+}
+
+// negotiateExport serves a list with export when its request accepts CSV or NDJSON, and with list otherwise.
+func negotiateExport(export, list gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := acceptedExportFormat(c.GetHeader("Accept")); ok {
+			export(c)
+			return
+		}
+		list(c)
+	}
+}
+
+// exportFormatParam returns the format of an export: the format query parameter, else the Accept header, else CSV.
+func exportFormatParam(c *gin.Context) (exportFormat, error) {
+	switch format := exportFormat(c.Query("format")); format {
+	case exportFormatCSV, exportFormatNDJSON:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid 'format': %s, use csv or ndjson", format)
+	}
+
+	if format, ok := acceptedExportFormat(c.GetHeader("Accept")); ok {
+		return format, nil
+	}
+	return exportFormatCSV, nil
+}
+
+// acceptedExportFormat returns the first export format listed in an Accept header.
+func acceptedExportFormat(accept string) (exportFormat, bool) {
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case contentTypeCSV:
+			return exportFormatCSV, true
+		case contentTypeNDJSON:
+			return exportFormatNDJSON, true
+		}
+	}
+	return "", false
+}
+
+// streamExport writes the rows passed by export to the response as they come, in format, named after name.
+// The headers are sent with the first row, so that an export failing before it answers a problem. An export failing
+// after it ends the body with a last line holding the problem in NDJSON, and aborts the connection in CSV.
+func streamExport[T any](c *gin.Context, format exportFormat, name string, columns []string, record func(T) []string, export func(fn func(T) error) error) {
+	var (
+		buffer  = bufio.NewWriter(c.Writer)
+		csvW    = csv.NewWriter(buffer)
+		jsonW   = json.NewEncoder(buffer)
+		started bool
+		rows    int
+	)
+
+	start := func() error {
+		started = true
+		contentType, extension := contentTypeCSV+"; charset=utf-8", ".csv"
+		if format == exportFormatNDJSON {
+			contentType, extension = contentTypeNDJSON, ".ndjson"
+		}
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s%s"`, name, time.Now().UTC().Format("20060102T150405Z"), extension))
+		c.Header("Cache-Control", "no-store")
+		c.Header("X-Content-Type-Options", "nosniff")
+		c.Status(http.StatusOK)
+
+		if format == exportFormatCSV {
+			return csvW.Write(columns)
+		}
+		return nil
+	}
+
+	flush := func() error {
+		if format == exportFormatCSV {
+			csvW.Flush()
+			if err := csvW.Error(); err != nil {
+				return err
+			}
+		}
+		if err := buffer.Flush(); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	err := export(func(row T) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if format == exportFormatCSV {
+			err = csvW.Write(record(row))
+		} else {
+			err = jsonW.Encode(row)
+		}
+		if err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushRows == 0 {
+			return flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err != nil && !started:
+		abortWithError(c, err)
+		return
+	case err != nil && format == exportFormatCSV:
+		// A CSV cannot hold the problem, the client must not take the rows sent for the whole export
+		_ = flush()
+		abortConnection(c)
+		return
+	case err != nil:
+		_ = jsonW.Encode(newProblem(errorStatus(err), err, requestID(c)))
+	case !started:
+		if err = start(); err != nil {
+			return
+		}
+	}
+	_ = flush()
+}
+
+// abortConnection closes the connection of a started response without ending its body, so that the client reports
+// a truncated transfer instead of a complete one. A connection that cannot be hijacked is left as is.
+func abortConnection(c *gin.Context) {
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		return
+	}
+	_ = conn.Close()
+}
+
+// mutezString returns the amount in mutez, as written in the exports.
+func mutezString(amount model.Mutez) string {
+	return strconv.FormatInt(int64(amount), 10)
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const testDelegator model.WalletAddress = "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL"
+
+func Test_ExportsHandler_ExportDelegations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	delegations := []model.Delegation{
+		{Delegator: testDelegator, Delegate: testBaker, TimestampTime: "2024-01-02T00:00:00Z", Amount: 1_500_000, AmountTez: "1.5", Level: 100},
+		{Delegator: testDelegator, TimestampTime: "2024-01-01T00:00:00Z", Amount: 2_000_000, AmountTez: "2", Level: 90},
+	}
+	exportAll := func(ctx context.Context, input usecase.GetDelegationsInput, fn func(model.Delegation) error) error {
+		if input.Delegator != testDelegator || input.Year != "2024" {
+			return errors.New("unexpected input")
+		}
+		for _, d := range delegations {
+			if err := fn(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name                  string
+		exportDelegationsFunc usecase.ExportDelegationsFunc
+		url                   string
+		accept                string
+		expectedStatus        int
+		expectedContentType   string
+		expectedBody          string
+		expectedError         string
+	}{
+		{
+			name:                  "Nominal case - csv by default",
+			exportDelegationsFunc: exportAll,
+			url:                   "/xtz/delegations/export?delegator=" + testDelegator.String() + "&year=2024",
+			expectedStatus:        http.StatusOK,
+			expectedContentType:   "text/csv; charset=utf-8",
+			expectedBody: "timestamp,level,delegator,delegate,amount,amount_tez\n" +
+				"2024-01-02T00:00:00Z,100," + testDelegator.String() + "," + testBaker + ",1500000,1.5\n" +
+				"2024-01-01T00:00:00Z,90," + testDelegator.String() + ",,2000000,2\n",
+		},
+		{
+			name:                  "Nominal case - ndjson from the Accept header",
+			exportDelegationsFunc: exportAll,
+			url:                   "/xtz/delegations/export?delegator=" + testDelegator.String() + "&year=2024",
+			accept:                "application/x-ndjson",
+			expectedStatus:        http.StatusOK,
+			expectedContentType:   "application/x-ndjson",
+			expectedBody: `{"delegator":"` + testDelegator.String() + `","delegate":"` + testBaker + `","timestamp":"2024-01-02T00:00:00Z","amount":"1500000","amount_tez":"1.5","level":100}` + "\n" +
+				`{"delegator":"` + testDelegator.String() + `","delegate":"","timestamp":"2024-01-01T00:00:00Z","amount":"2000000","amount_tez":"2","level":90}` + "\n",
+		},
+		{
+			name: "Nominal case - empty csv keeps its header",
+			exportDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput, fn func(model.Delegation) error) error {
+				return nil
+			},
+			url:                 "/xtz/delegations/export?format=csv",
+			accept:              "application/x-ndjson",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "timestamp,level,delegator,delegate,amount,amount_tez\n",
+		},
+		{
+			name: "Error case - failure after the first row ends the ndjson with an error line",
+			exportDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput, fn func(model.Delegation) error) error {
+				if err := fn(delegations[1]); err != nil {
+					return err
+				}
+				return errors.New("connection reset")
+			},
+			url:                 "/xtz/delegations/export?format=ndjson",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"delegator":"` + testDelegator.String() + `","delegate":"","timestamp":"2024-01-01T00:00:00Z","amount":"2000000","amount_tez":"2","level":90}` + "\n" +
+				`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal error","code":"internal_error","request_id":"req-1"}` + "\n",
+		},
+		{
+			name: "Error case - failure before the first row",
+			exportDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput, fn func(model.Delegation) error) error {
+				return errors.New("internal error")
+			},
+			url:            "/xtz/delegations/export",
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+		{
+			name:           "Error case - invalid format",
+			url:            "/xtz/delegations/export?format=xml",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'format': xml, use csv or ndjson",
+		},
+		{
+			name:           "Error case - invalid filter",
+			url:            "/xtz/delegations/export?delegator=tz1invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid delegator address: tz1invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/delegations/export", NewExportsHandler(50, tt.exportDelegationsFunc, nil, nil).ExportDelegations)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(w.Header().Get("Content-Disposition"), `attachment; filename="delegations-`))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func Test_ExportsHandler_ExportDelegations_csvFailureAbortsConnection(t *testing.T) {
+	exportFunc := func(ctx context.Context, input usecase.GetDelegationsInput, fn func(model.Delegation) error) error {
+		if err := fn(model.Delegation{Delegator: testDelegator, TimestampTime: "2024-01-01T00:00:00Z", Amount: 2_000_000, AmountTez: "2", Level: 90}); err != nil {
+			return err
+		}
+		return errors.New("connection reset")
+	}
+
+	router := newTestRouter()
+	router.Use(NewConditionalMiddleware(nil).Handle)
+	router.GET("/xtz/delegations/export", NewExportsHandler(50, exportFunc, nil, nil).ExportDelegations)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/xtz/delegations/export")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF, "the truncated export must not read as complete")
+	assert.Equal(t, "timestamp,level,delegator,delegate,amount,amount_tez\n"+
+		"2024-01-01T00:00:00Z,90,"+testDelegator.String()+",,2000000,2\n", string(body))
+}
+
+func Test_ExportsHandler_ExportOperations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                 string
+		exportOperationsFunc usecase.ExportOperationsFunc
+		url                  string
+		expectedStatus       int
+		expectedBody         string
+		expectedError        string
+	}{
+		{
+			name: "Nominal case",
+			exportOperationsFunc: func(ctx context.Context, input usecase.GetOperationsInput, fn func(model.Operation) error) error {
+				if input.Wallet != testDelegator || input.Backer != testBaker || input.Type != model.OperationTypeDelegate || input.FromDate == nil {
+					return errors.New("unexpected input")
+				}
+				return fn(model.Operation{ID: 7, TimestampTime: "2024-01-01T00:00:00Z", Type: model.OperationTypeDelegate, Status: "applied",
+					SenderAddress: testDelegator, ContractAddress: testBaker, Entrypoint: "default", Block: "BLock", Amount: 1_000_000, AmountTez: "1"})
+			},
+			url:            "/xtz/operations/export?wallet=" + testDelegator.String() + "&backer=" + testBaker + "&type=delegate&from=2024-01-01",
+			expectedStatus: http.StatusOK,
+			expectedBody: "id,timestamp,type,status,sender_address,contract_address,entrypoint,block,amount,amount_tez\n" +
+				"7,2024-01-01T00:00:00Z,delegate,applied," + testDelegator.String() + "," + testBaker + ",default,BLock,1000000,1\n",
+		},
+		{
+			name:           "Error case - missing backer",
+			url:            "/xtz/operations/export?wallet=" + testDelegator.String(),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "missing backer address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/operations/export", NewExportsHandler(50, nil, tt.exportOperationsFunc, nil).ExportOperations)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func Test_ExportsHandler_ExportRewards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name              string
+		exportRewardsFunc usecase.ExportRewardsFunc
+		url               string
+		expectedStatus    int
+		expectedBody      string
+		expectedError     string
+	}{
+		{
+			name: "Nominal case",
+			exportRewardsFunc: func(ctx context.Context, input usecase.GetRewardsInput, fn func(model.Reward) error) error {
+				if input.Wallet != testDelegator || input.Backer != testBaker {
+					return errors.New("unexpected input")
+				}
+				return fn(model.Reward{ID: 3, TimestampTime: "2024-01-01T00:00:00Z", Cycle: 700, RecipientAddress: testDelegator,
+					SourceAddress: testBaker, Amount: 250_000, AmountTez: "0.25"})
+			},
+			url:            "/xtz/rewards/export?wallet=" + testDelegator.String() + "&backer=" + testBaker,
+			expectedStatus: http.StatusOK,
+			expectedBody: "id,timestamp,cycle,recipient_address,source_address,amount,amount_tez\n" +
+				"3,2024-01-01T00:00:00Z,700," + testDelegator.String() + "," + testBaker + ",250000,0.25\n",
+		},
+		{
+			name:           "Error case - invalid date",
+			url:            "/xtz/rewards/export?wallet=" + testDelegator.String() + "&backer=" + testBaker + "&from=01-01-2024",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'from' date format. Use YYYY-MM-DD or RFC 3339",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/rewards/export", NewExportsHandler(50, nil, nil, tt.exportRewardsFunc).ExportRewards)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func Test_negotiateExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected string
+	}{
+		{name: "Nominal case - json list", accept: "application/json", expected: "list"},
+		{name: "Nominal case - no Accept header", expected: "list"},
+		{name: "Nominal case - csv export", accept: "text/csv", expected: "export"},
+		{name: "Nominal case - ndjson export with parameters", accept: "application/json;q=0.5, application/x-ndjson;q=1", expected: "export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/delegations", negotiateExport(
+				func(c *gin.Context) { c.String(http.StatusOK, "export") },
+				func(c *gin.Context) { c.String(http.StatusOK, "list") },
+			))
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/xtz/delegations", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// GetAccountProfileHandler handles the account profile API requests.
+type GetAccountProfileHandler struct {
+	getAccountProfileFunc usecase.GetAccountProfileFunc
+}
+
+// NewGetAccountProfileHandler creates a new account profile handler.
+func NewGetAccountProfileHandler(getAccountProfileFunc usecase.GetAccountProfileFunc) *GetAccountProfileHandler {
+	return &GetAccountProfileHandler{
+		getAccountProfileFunc: getAccountProfileFunc,
+	}
+}
+
+// GetAccountProfile handles GET /xtz/accounts/{address} requests.
+func (h *GetAccountProfileHandler) GetAccountProfile(c *gin.Context) {
+	profile, ok := h.getAccountProfile(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache, the profile changes with every synced batch
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetAccountProfileV1 handles GET /v1/accounts/{address} requests.
+func (h *GetAccountProfileHandler) GetAccountProfileV1(c *gin.Context) {
+	profile, ok := h.getAccountProfile(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache, the profile changes with every synced batch
+	c.JSON(http.StatusOK, Envelope[V1Account]{
+		Data: newV1Account(profile),
+		Meta: newV1Meta(),
+	})
+}
+
+// getAccountProfile returns the profile of the address of a request, or aborts it and returns false.
+func (h *GetAccountProfileHandler) getAccountProfile(c *gin.Context) (*model.AccountProfile, bool) {
+	address := model.WalletAddress(c.Param("address"))
+	if !address.IsValid() {
+		abortWithInvalidRequest(c, fmt.Sprintf("invalid address: %s", address.String()))
+		return nil, false
+	}
+
+	profile, err := h.getAccountProfileFunc(c.Request.Context(), address)
+	if err != nil {
+		abortWithError(c, err)
+		return nil, false
+	}
+	return profile, true
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+func Test_GetAccountProfileHandler_GetAccountProfile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                  string
+		getAccountProfileFunc usecase.GetAccountProfileFunc
+		url                   string
+		expectedStatus        int
+		expectedError         string
+	}{
+		{
+			name: "nominal case",
+			getAccountProfileFunc: func(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error) {
+				if address != testBaker {
+					return nil, errors.New("unexpected address")
+				}
+				return &model.AccountProfile{Address: address, Type: model.AccountTypeDelegate}, nil
+			},
+			url:            "/xtz/accounts/" + testBaker,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid address",
+			url:            "/xtz/accounts/tz1invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid address: tz1invalid",
+		},
+		{
+			name: "error - unknown account",
+			getAccountProfileFunc: func(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error) {
+				return nil, fmt.Errorf("%w: %s", usecase.ErrAccountNotFound, address)
+			},
+			url:            "/xtz/accounts/" + testBaker,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "account not found: " + testBaker,
+		},
+		{
+			name: "error - internal service",
+			getAccountProfileFunc: func(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error) {
+				return nil, errors.New("internal error")
+			},
+			url:            "/xtz/accounts/" + testBaker,
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/accounts/:address", NewGetAccountProfileHandler(tt.getAccountProfileFunc).GetAccountProfile)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var profile model.AccountProfile
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+			assert.Equal(t, model.WalletAddress(testBaker), profile.Address)
+			assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func Test_GetAccountProfileHandler_GetAccountProfileV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := newTestRouter()
+	router.GET("/v1/accounts/:address", NewGetAccountProfileHandler(func(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error) {
+		return &model.AccountProfile{
+			Address:         address,
+			Type:            model.AccountTypeUser,
+			TotalRewards:    1_500_000,
+			TotalRewardsTez: "1.500000",
+			DelegationTimeline: []model.BakerChange{
+				{Baker: testBaker, Level: 100, TimestampTime: "2024-03-01T12:00:00Z"},
+				{Level: 200, TimestampTime: "2024-04-01T12:00:00Z"},
+			},
+		}, nil
+	}).GetAccountProfileV1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/v1/accounts/"+testDelegator.String(), nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"data": {
+			"address": "`+testDelegator.String()+`",
+			"type": "user",
+			"delegation_timeline": [
+				{"baker": "`+testBaker+`", "level": 100, "timestamp": "2024-03-01T12:00:00Z"},
+				{"level": 200, "timestamp": "2024-04-01T12:00:00Z"}
+			],
+			"total_rewards": "1500000",
+			"staking_operations": {}
+		},
+		"meta": {"version": "v1"}
+	}`, w.Body.String())
+}
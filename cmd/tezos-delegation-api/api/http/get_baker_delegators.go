@@ -0,0 +1,131 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// GetBakerDelegatorsHandler handles the delegators of a baker API requests.
+type GetBakerDelegatorsHandler struct {
+	getBakerDelegatorsFunc usecase.GetBakerDelegatorsFunc
+}
+
+// NewGetBakerDelegatorsHandler creates a new baker delegators handler.
+func NewGetBakerDelegatorsHandler(getBakerDelegatorsFunc usecase.GetBakerDelegatorsFunc) *GetBakerDelegatorsHandler {
+	return &GetBakerDelegatorsHandler{
+		getBakerDelegatorsFunc: getBakerDelegatorsFunc,
+	}
+}
+
+// GetBakerDelegators handles GET /xtz/bakers/{address}/delegators requests.
+func (h *GetBakerDelegatorsHandler) GetBakerDelegators(c *gin.Context) {
+	input, err := h.parseInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerDelegatorsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	h.setPaginationHeaders(c, response.Pagination)
+	h.setCacheHeaders(c, response.AsOfLevel)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBakerDelegatorsV1 handles GET /v1/bakers/{address}/delegators requests.
+func (h *GetBakerDelegatorsHandler) GetBakerDelegatorsV1(c *gin.Context) {
+	input, err := h.parseInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerDelegatorsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	meta := newV1Meta()
+	meta.AsOfLevel, meta.AsOfCycle = response.AsOfLevel, response.AsOfCycle
+	h.setCacheHeaders(c, response.AsOfLevel)
+	c.JSON(http.StatusOK, Envelope[[]V1Delegator]{
+		Data:       newV1Delegators(response.Delegators),
+		Pagination: newV1Pagination(response.Pagination, "", ""),
+		Meta:       meta,
+	})
+}
+
+// parseInput validates and parses the path and query parameters of a baker delegators request.
+func (h *GetBakerDelegatorsHandler) parseInput(c *gin.Context) (usecase.GetBakerDelegatorsInput, error) {
+	baker := model.WalletAddress(c.Param("address"))
+	if !baker.IsValid() {
+		return usecase.GetBakerDelegatorsInput{}, fmt.Errorf("invalid baker address: %s", baker.String())
+	}
+
+	input := usecase.GetBakerDelegatorsInput{
+		Baker: baker,
+		Page:  c.Query("page"),
+		Limit: c.Query("limit"),
+	}
+	err := h.parseAsOf(c, &input)
+	return input, err
+}
+
+// parseAsOf parses the optional as_of_level and as_of_cycle query parameters, which are mutually exclusive.
+func (h *GetBakerDelegatorsHandler) parseAsOf(c *gin.Context, input *usecase.GetBakerDelegatorsInput) error {
+	levelParam, cycleParam := c.Query("as_of_level"), c.Query("as_of_cycle")
+	if levelParam != "" && cycleParam != "" {
+		return fmt.Errorf("as_of_level and as_of_cycle cannot be used together")
+	}
+
+	if levelParam != "" {
+		level, err := strconv.ParseInt(levelParam, 10, 64)
+		if err != nil || level <= 0 {
+			return fmt.Errorf("invalid as_of_level: %s", levelParam)
+		}
+		input.AsOfLevel = level
+	}
+
+	if cycleParam != "" {
+		cycle, err := strconv.Atoi(cycleParam)
+		if err != nil || cycle <= 0 {
+			return fmt.Errorf("invalid as_of_cycle: %s", cycleParam)
+		}
+		input.AsOfCycle = cycle
+	}
+
+	return nil
+}
+
+// setCacheHeaders sets the cache headers for the response, snapshots at an indexed level never change.
+func (h *GetBakerDelegatorsHandler) setCacheHeaders(c *gin.Context, asOfLevel int64) {
+	if asOfLevel > 0 {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	}
+}
+
+// setPaginationHeaders sets pagination headers for the response.
+func (h *GetBakerDelegatorsHandler) setPaginationHeaders(c *gin.Context, pInfo model.PaginationInfo) {
+	c.Header("X-Page-Current", strconv.Itoa(pInfo.CurrentPage))
+	c.Header("X-Page-Per-Page", strconv.Itoa(pInfo.PerPage))
+
+	if pInfo.HasPrevPage {
+		c.Header("X-Page-Prev", strconv.Itoa(pInfo.PrevPage))
+	}
+	if pInfo.HasNextPage {
+		c.Header("X-Page-Next", strconv.Itoa(pInfo.NextPage))
+	}
+}
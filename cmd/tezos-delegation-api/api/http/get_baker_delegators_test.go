@@ -0,0 +1,205 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+func Test_GetBakerDelegatorsHandler_GetBakerDelegators(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                   string
+		getBakerDelegatorsFunc usecase.GetBakerDelegatorsFunc
+		url                    string
+		expectedStatus         int
+		expectedHeader         map[string]string
+		expectedError          string
+	}{
+		{
+			name: "nominal case",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				if input.Baker != testBaker || input.Page != "2" || input.Limit != "10" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakerDelegatorsResponse{
+					Delegators: []model.CurrentDelegation{{Delegator: "tz1a", Baker: testBaker}},
+					Pagination: model.PaginationInfo{CurrentPage: 2, PerPage: 10, HasPrevPage: true, PrevPage: 1},
+				}, nil
+			},
+			url:            "/xtz/bakers/" + testBaker + "/delegators?page=2&limit=10",
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{
+				"X-Page-Current": "2",
+				"X-Page-Prev":    "1",
+				"X-Page-Next":    "",
+				"Cache-Control":  "public, max-age=300",
+			},
+		},
+		{
+			name: "nominal case - as of cycle",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				if input.AsOfCycle != 700 || input.AsOfLevel != 0 {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakerDelegatorsResponse{
+					Delegators: []model.CurrentDelegation{{Delegator: "tz1a", Baker: testBaker, SinceLevel: 100}},
+					AsOfLevel:  1000,
+					AsOfCycle:  700,
+				}, nil
+			},
+			url:            "/xtz/bakers/" + testBaker + "/delegators?as_of_cycle=700",
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{
+				"Cache-Control": "public, max-age=31536000, immutable",
+			},
+		},
+		{
+			name:           "error - invalid as_of_level",
+			url:            "/xtz/bakers/" + testBaker + "/delegators?as_of_level=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid as_of_level: abc",
+		},
+		{
+			name:           "error - as_of_level and as_of_cycle",
+			url:            "/xtz/bakers/" + testBaker + "/delegators?as_of_level=10&as_of_cycle=1",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "as_of_level and as_of_cycle cannot be used together",
+		},
+		{
+			name: "error - snapshot unavailable",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				return nil, fmt.Errorf("%w: level %d is not indexed yet, the highest indexed level is %d", usecase.ErrSnapshotUnavailable, input.AsOfLevel, 10)
+			},
+			url:            "/xtz/bakers/" + testBaker + "/delegators?as_of_level=20",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "snapshot unavailable: level 20 is not indexed yet, the highest indexed level is 10",
+		},
+		{
+			name:                   "error - limit above the maximum",
+			getBakerDelegatorsFunc: usecase.NewGetBakerDelegatorsFunc(50, databasememory.New(), metricsnoop.New()),
+			url:                    "/xtz/bakers/" + testBaker + "/delegators?limit=1000",
+			expectedStatus:         http.StatusBadRequest,
+			expectedError:          "invalid request: limit exceeds maximum allowed value of 500",
+		},
+		{
+			name:           "error - invalid baker",
+			url:            "/xtz/bakers/tz1invalid/delegators",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid baker address: tz1invalid",
+		},
+		{
+			name: "error - database unavailable",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				return nil, database.NewQueryError("GetBakerDelegators", database.ErrUnavailable, errors.New("connection refused"))
+			},
+			url:            "/xtz/bakers/" + testBaker + "/delegators",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  "database unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/bakers/:address/delegators", NewGetBakerDelegatorsHandler(tt.getBakerDelegatorsFunc).GetBakerDelegators)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+			for key, value := range tt.expectedHeader {
+				assert.Equal(t, value, w.Header().Get(key))
+			}
+		})
+	}
+}
+
+func Test_GetBakerDelegatorsHandler_GetBakerDelegatorsV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                   string
+		getBakerDelegatorsFunc usecase.GetBakerDelegatorsFunc
+		url                    string
+		expectedStatus         int
+		expectedBody           string
+		expectedCacheControl   string
+		expectedError          string
+	}{
+		{
+			name: "nominal case - as of cycle",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				if input.Baker != testBaker || input.AsOfCycle != 700 {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakerDelegatorsResponse{
+					Delegators: []model.CurrentDelegation{{Delegator: "tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", Baker: testBaker, SinceLevel: 100, Balance: 1_500_000, BalanceTez: "1.500000"}},
+					AsOfLevel:  1000,
+					AsOfCycle:  700,
+					Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 100},
+				}, nil
+			},
+			url:            "/v1/bakers/" + testBaker + "/delegators?as_of_cycle=700",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"delegator":"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL","baker":"` + testBaker + `","since_level":100,"balance":"1500000"}],` +
+				`"pagination":{"page":1,"per_page":100,"has_next":false},"meta":{"version":"v1","as_of_level":1000,"as_of_cycle":700}}`,
+			expectedCacheControl: "public, max-age=31536000, immutable",
+		},
+		{
+			name:           "error - invalid baker",
+			url:            "/v1/bakers/tz1invalid/delegators",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid baker address: tz1invalid",
+		},
+		{
+			name: "error - snapshot unavailable",
+			getBakerDelegatorsFunc: func(ctx context.Context, input usecase.GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+				return nil, fmt.Errorf("%w: level %d is not indexed yet, the highest indexed level is %d", usecase.ErrSnapshotUnavailable, input.AsOfLevel, 10)
+			},
+			url:            "/v1/bakers/" + testBaker + "/delegators?as_of_level=20",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "snapshot unavailable: level 20 is not indexed yet, the highest indexed level is 10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/v1/bakers/:address/delegators", NewGetBakerDelegatorsHandler(tt.getBakerDelegatorsFunc).GetBakerDelegatorsV1)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedCacheControl, w.Header().Get("Cache-Control"))
+		})
+	}
+}
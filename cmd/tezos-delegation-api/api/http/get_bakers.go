@@ -0,0 +1,190 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// GetBakersHandler handles baker directory API requests.
+type GetBakersHandler struct {
+	getBakersFunc usecase.GetBakersFunc
+	getBakerFunc  usecase.GetBakerFunc
+}
+
+// NewGetBakersHandler creates a new baker directory handler.
+func NewGetBakersHandler(getBakersFunc usecase.GetBakersFunc, getBakerFunc usecase.GetBakerFunc) *GetBakersHandler {
+	return &GetBakersHandler{
+		getBakersFunc: getBakersFunc,
+		getBakerFunc:  getBakerFunc,
+	}
+}
+
+// GetBakers handles GET /xtz/bakers requests.
+func (h *GetBakersHandler) GetBakers(c *gin.Context) {
+	input, err := h.parseBakersInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakersFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	h.setPaginationHeaders(c, response.Pagination)
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBakersV1 handles GET /v1/bakers requests.
+func (h *GetBakersHandler) GetBakersV1(c *gin.Context) {
+	input, err := h.parseBakersInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakersFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.JSON(http.StatusOK, Envelope[[]V1Baker]{
+		Data:       newV1Bakers(response.Bakers),
+		Pagination: newV1Pagination(response.Pagination, "", ""),
+		Meta:       newV1Meta(),
+	})
+}
+
+// parseBakersInput validates and parses the query parameters of a baker directory request.
+func (h *GetBakersHandler) parseBakersInput(c *gin.Context) (usecase.GetBakersInput, error) {
+	input := usecase.GetBakersInput{
+		Search: c.Query("q"),
+		Sort:   model.BakerSort(c.Query("sort")),
+		Order:  model.SortOrder(c.Query("order")),
+		Page:   c.Query("page"),
+		Limit:  c.Query("limit"),
+	}
+
+	if input.Sort != "" && !input.Sort.IsValid() {
+		return input, fmt.Errorf("invalid 'sort': %s, use delegators, delegated_volume, rewards or net_inflow", input.Sort)
+	}
+	if input.Order != "" && !input.Order.IsValid() {
+		return input, fmt.Errorf("invalid 'order': %s, use asc or desc", input.Order)
+	}
+
+	var err error
+	input.FromDate, input.ToDate, err = h.parsePeriod(c)
+	return input, err
+}
+
+// GetBaker handles GET /xtz/bakers/{address} requests.
+func (h *GetBakersHandler) GetBaker(c *gin.Context) {
+	input, err := h.parseBakerInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBakerV1 handles GET /v1/bakers/{address} requests.
+func (h *GetBakersHandler) GetBakerV1(c *gin.Context) {
+	input, err := h.parseBakerInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300") // 5m cache
+	c.JSON(http.StatusOK, Envelope[V1BakerDetail]{
+		Data: newV1Baker(response),
+		Meta: newV1Meta(),
+	})
+}
+
+// parseBakerInput validates and parses the address and query parameters of a baker request.
+func (h *GetBakersHandler) parseBakerInput(c *gin.Context) (usecase.GetBakerInput, error) {
+	input := usecase.GetBakerInput{Address: model.WalletAddress(c.Param("address"))}
+	if !input.Address.IsValid() {
+		return input, fmt.Errorf("invalid baker address: %s", input.Address.String())
+	}
+
+	var err error
+	if input.FromDate, input.ToDate, err = h.parsePeriod(c); err != nil {
+		return input, err
+	}
+	if input.FromCycle, err = parseCycle(c, "from_cycle"); err != nil {
+		return input, err
+	}
+	if input.ToCycle, err = parseCycle(c, "to_cycle"); err != nil {
+		return input, err
+	}
+	if input.FromCycle > 0 && input.ToCycle > 0 && input.ToCycle < input.FromCycle {
+		return input, errors.New("'to_cycle' must not be before 'from_cycle'")
+	}
+	return input, nil
+}
+
+// parsePeriod parses the optional from and to query parameters, UTC days in the YYYY-MM-DD format, both included.
+func (h *GetBakersHandler) parsePeriod(c *gin.Context) (fromDate, toDate *time.Time, err error) {
+	for _, param := range []struct {
+		name  string
+		value **time.Time
+	}{{"from", &fromDate}, {"to", &toDate}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, errParsing := time.Parse("2006-01-02", value)
+		if errParsing != nil {
+			return nil, nil, fmt.Errorf("invalid '%s' date format. Use YYYY-MM-DD", param.name)
+		}
+		*param.value = &t
+	}
+
+	if fromDate != nil && toDate != nil && toDate.Before(*fromDate) {
+		return nil, nil, errors.New("'to' date must not be before 'from' date")
+	}
+
+	return fromDate, toDate, nil
+}
+
+// setPaginationHeaders sets pagination headers for the response.
+func (h *GetBakersHandler) setPaginationHeaders(c *gin.Context, pInfo model.PaginationInfo) {
+	c.Header("X-Page-Current", strconv.Itoa(pInfo.CurrentPage))
+	c.Header("X-Page-Per-Page", strconv.Itoa(pInfo.PerPage))
+
+	if pInfo.HasPrevPage {
+		c.Header("X-Page-Prev", strconv.Itoa(pInfo.PrevPage))
+	}
+	if pInfo.HasNextPage {
+		c.Header("X-Page-Next", strconv.Itoa(pInfo.NextPage))
+	}
+}
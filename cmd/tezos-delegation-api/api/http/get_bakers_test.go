@@ -0,0 +1,246 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+func Test_GetBakersHandler_GetBakers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		getBakersFunc  usecase.GetBakersFunc
+		url            string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "nominal case",
+			getBakersFunc: func(ctx context.Context, input usecase.GetBakersInput) (*model.BakersResponse, error) {
+				from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+				if input.Search != "pool" || !input.FromDate.Equal(from) || !input.ToDate.Equal(to) || input.Sort != model.BakerSortNetInflow ||
+					input.Order != model.SortOrderAsc || input.Page != "2" || input.Limit != "10" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakersResponse{
+					Bakers:     []model.Baker{{Address: testBaker, Delegators: 3}},
+					Pagination: model.PaginationInfo{CurrentPage: 2, PerPage: 10, HasPrevPage: true, PrevPage: 1},
+				}, nil
+			},
+			url:            "/xtz/bakers?q=pool&from=2024-01-01&to=2024-01-31&sort=net_inflow&order=asc&page=2&limit=10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid sort",
+			url:            "/xtz/bakers?sort=alias",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'sort': alias, use delegators, delegated_volume, rewards or net_inflow",
+		},
+		{
+			name:           "error - invalid order",
+			url:            "/xtz/bakers?order=up",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'order': up, use asc or desc",
+		},
+		{
+			name:           "error - invalid date",
+			url:            "/xtz/bakers?from=01-01-2024",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'from' date format. Use YYYY-MM-DD",
+		},
+		{
+			name:           "error - reversed period",
+			url:            "/xtz/bakers?from=2024-02-01&to=2024-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "'to' date must not be before 'from' date",
+		},
+		{
+			name: "error - internal service",
+			getBakersFunc: func(ctx context.Context, input usecase.GetBakersInput) (*model.BakersResponse, error) {
+				return nil, errors.New("internal error")
+			},
+			url:            "/xtz/bakers",
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/bakers", NewGetBakersHandler(tt.getBakersFunc, nil).GetBakers)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var response model.BakersResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if assert.Len(t, response.Bakers, 1) {
+				assert.Equal(t, int64(3), response.Bakers[0].Delegators)
+			}
+			assert.Equal(t, "2", w.Header().Get("X-Page-Current"))
+			assert.Equal(t, "1", w.Header().Get("X-Page-Prev"))
+			assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func Test_GetBakersHandler_GetBakersV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		getBakersFunc  usecase.GetBakersFunc
+		url            string
+		expectedStatus int
+		expectedBody   string
+		expectedError  string
+	}{
+		{
+			name: "nominal case",
+			getBakersFunc: func(ctx context.Context, input usecase.GetBakersInput) (*model.BakersResponse, error) {
+				if input.Sort != model.BakerSortRewards || input.Page != "1" || input.Limit != "1" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakersResponse{
+					Bakers:     []model.Baker{{Address: testBaker, Alias: "Baker", Delegators: 3, StakingBalance: 2_000_000, StakingBalanceTez: "2.000000", Rewards: 25_000}},
+					Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 1, HasNextPage: true, NextPage: 2},
+				}, nil
+			},
+			url:            "/v1/bakers?sort=rewards&page=1&limit=1",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"address":"` + testBaker + `","alias":"Baker","delegators":3,"staking_balance":"2000000","delegated_volume":"0",` +
+				`"rewards":"25000","new_delegations":0,"undelegations":0,"net_inflow":0}],` +
+				`"pagination":{"page":1,"per_page":1,"has_next":true,"next_page":2},"meta":{"version":"v1"}}`,
+		},
+		{
+			name:           "error - invalid sort",
+			url:            "/v1/bakers?sort=alias",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'sort': alias, use delegators, delegated_volume, rewards or net_inflow",
+		},
+		{
+			name: "error - internal service",
+			getBakersFunc: func(ctx context.Context, input usecase.GetBakersInput) (*model.BakersResponse, error) {
+				return nil, errors.New("internal error")
+			},
+			url:            "/v1/bakers",
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/v1/bakers", NewGetBakersHandler(tt.getBakersFunc, nil).GetBakersV1)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func Test_GetBakersHandler_GetBaker(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		getBakerFunc   usecase.GetBakerFunc
+		url            string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "nominal case",
+			getBakerFunc: func(ctx context.Context, input usecase.GetBakerInput) (*model.BakerResponse, error) {
+				if input.Address != testBaker || input.FromCycle != 700 || input.ToCycle != 710 || input.FromDate == nil || input.ToDate != nil {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.BakerResponse{
+					Baker:   model.Baker{Address: input.Address},
+					History: []model.BakerCycleRewards{{Cycle: 700, Baker: input.Address}},
+				}, nil
+			},
+			url:            "/xtz/bakers/" + testBaker + "?from=2024-01-01&from_cycle=700&to_cycle=710",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid address",
+			url:            "/xtz/bakers/tz1invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid baker address: tz1invalid",
+		},
+		{
+			name:           "error - reversed cycles",
+			url:            "/xtz/bakers/" + testBaker + "?from_cycle=710&to_cycle=700",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "'to_cycle' must not be before 'from_cycle'",
+		},
+		{
+			name: "error - unknown baker",
+			getBakerFunc: func(ctx context.Context, input usecase.GetBakerInput) (*model.BakerResponse, error) {
+				return nil, fmt.Errorf("%w: %s", usecase.ErrBakerNotFound, input.Address)
+			},
+			url:            "/xtz/bakers/" + testBaker,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "baker not found: " + testBaker,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/bakers/:address", NewGetBakersHandler(nil, tt.getBakerFunc).GetBaker)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var response model.BakerResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, model.WalletAddress(testBaker), response.Address)
+			assert.Len(t, response.History, 1)
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package http
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -32,34 +29,78 @@ func NewGetDelegationsHandler(paginationLimit uint16, getDelegationsFunc usecase
 
 // GetDelegations handles GET /xtz/delegations requests.
 func (h *GetDelegationsHandler) GetDelegations(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	page, limit, year, err := h.validateRequestParams(c)
+	input, err := h.parseInput(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithInvalidRequest(c, err.Error())
 		return
 	}
 
-	maxDelegationID := h.extractMaxDelegationID(c)
-
-	response, err := h.getDelegationsFunc(ctx, strconv.Itoa(page), strconv.Itoa(limit), year, maxDelegationID)
+	response, err := h.getDelegationsFunc(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
-	h.setPaginationHeaders(c, response.Pagination)
+	if input.Cursor == "" {
+		h.setPaginationHeaders(c, response.Pagination)
+	}
+	setNextLinkHeader(c, response.NextCursor)
 	h.setRequestIDHeader(c)
-	h.setCacheHeaders(c, year)
+	h.setCacheHeaders(c, input.Year)
 	h.setMaxDelegationIDHeader(c, response.MaxDelegationID)
-	h.setETagHeader(c, response)
+	c.JSON(http.StatusOK, response)
+}
 
-	if c.GetHeader("If-None-Match") == c.Writer.Header().Get("ETag") {
-		c.Status(http.StatusNotModified)
+// GetDelegationsV1 handles GET /v1/delegations requests.
+func (h *GetDelegationsHandler) GetDelegationsV1(c *gin.Context) {
+	input, err := h.parseInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response, err := h.getDelegationsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	h.setCacheHeaders(c, input.Year)
+	h.setMaxDelegationIDHeader(c, response.MaxDelegationID)
+	c.JSON(http.StatusOK, Envelope[[]V1Delegation]{
+		Data:       newV1Delegations(response.Delegations),
+		Pagination: newV1Pagination(response.Pagination, input.Cursor, response.NextCursor),
+		Meta:       newV1Meta(),
+	})
+}
+
+// parseInput validates and parses the query parameters and headers of a delegations request.
+func (h *GetDelegationsHandler) parseInput(c *gin.Context) (usecase.GetDelegationsInput, error) {
+	page, limit, year, err := h.validateRequestParams(c)
+	if err != nil {
+		return usecase.GetDelegationsInput{}, err
+	}
+
+	cursor, err := cursorParam(c)
+	if err != nil {
+		return usecase.GetDelegationsInput{}, err
+	}
+
+	input, err := h.validateFilterParams(c)
+	if err != nil {
+		return usecase.GetDelegationsInput{}, err
+	}
+
+	if cursor != "" && (input.Sort != "" && input.Sort != model.DelegationSortTimestamp || input.Order == model.SortOrderAsc) {
+		return usecase.GetDelegationsInput{}, errors.New("cursor can only be used with the default sort by decreasing timestamp")
+	}
+
+	input.Page = strconv.Itoa(page)
+	input.Limit = strconv.Itoa(limit)
+	input.Year = year
+	input.Cursor = cursor
+	input.MaxDelegationID = h.extractMaxDelegationID(c)
+	return input, nil
 }
 
 // extractMaxDelegationID extracts and parses the X-Max-Delegation-ID header.
@@ -91,6 +132,128 @@ func (h *GetDelegationsHandler) validateRequestParams(c *gin.Context) (int, int,
 	return page, limit, year, nil
 }
 
+// delegationFilterParams lists the query parameters filtering or sorting delegations.
+var delegationFilterParams = []string{"delegator", "delegate", "from_level", "to_level", "from", "to", "min_amount", "max_amount", "kind", "sort", "order"}
+
+// validateFilterParams validates and parses the filter and sort parameters.
+func (h *GetDelegationsHandler) validateFilterParams(c *gin.Context) (input usecase.GetDelegationsInput, err error) {
+	if delegator := model.WalletAddress(c.Query("delegator")); delegator != "" {
+		if !delegator.IsValid() {
+			return input, fmt.Errorf("invalid delegator address: %s", delegator)
+		}
+		input.Delegator = delegator
+	}
+
+	if delegate := model.WalletAddress(c.Query("delegate")); delegate != "" {
+		if !delegate.IsValid() {
+			return input, fmt.Errorf("invalid delegate address: %s", delegate)
+		}
+		input.Delegate = delegate
+	}
+
+	if input.FromLevel, err = levelParam(c, "from_level"); err != nil {
+		return input, err
+	}
+	if input.ToLevel, err = levelParam(c, "to_level"); err != nil {
+		return input, err
+	}
+
+	if input.FromDate, input.ToDate, err = dateRangeParams(c); err != nil {
+		return input, err
+	}
+
+	if input.MinAmount, err = amountParam(c, "min_amount"); err != nil {
+		return input, err
+	}
+	if input.MaxAmount, err = amountParam(c, "max_amount"); err != nil {
+		return input, err
+	}
+
+	if kind := model.DelegationKind(c.Query("kind")); kind != "" {
+		if !kind.IsValid() {
+			return input, fmt.Errorf("invalid kind: %s, use delegation or undelegation", kind)
+		}
+		input.Kind = kind
+	}
+
+	if sortField := model.DelegationSort(c.Query("sort")); sortField != "" {
+		if !sortField.IsValid() {
+			return input, fmt.Errorf("invalid sort: %s, use timestamp, level or amount", sortField)
+		}
+		input.Sort = sortField
+	}
+
+	if order := model.SortOrder(c.Query("order")); order != "" {
+		if !order.IsValid() {
+			return input, fmt.Errorf("invalid order: %s, use asc or desc", order)
+		}
+		input.Order = order
+	}
+
+	return input, nil
+}
+
+// levelParam parses a block level query parameter, 0 when absent.
+func levelParam(c *gin.Context, name string) (int64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	level, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || level < 1 {
+		return 0, fmt.Errorf("invalid '%s' level, use a positive number", name)
+	}
+	return level, nil
+}
+
+// dateRangeParams parses the from and to query parameters, both included, of the delegations, operations and rewards.
+// The range is returned with an exclusive end: a date (YYYY-MM-DD) extends to the end of that day, an RFC 3339
+// timestamp to the end of its second. The use cases check that the range is not reversed.
+func dateRangeParams(c *gin.Context) (from, to *time.Time, err error) {
+	if from, _, err = timeParam(c, "from"); err != nil {
+		return nil, nil, err
+	}
+
+	to, isDate, err := timeParam(c, "to")
+	if err != nil || to == nil {
+		return from, nil, err
+	}
+	end := to.Truncate(time.Second).Add(time.Second)
+	if isDate {
+		end = to.AddDate(0, 0, 1)
+	}
+	return from, &end, nil
+}
+
+// timeParam parses a date (YYYY-MM-DD) or RFC 3339 timestamp query parameter, nil when absent, and whether it is a date.
+func timeParam(c *gin.Context, name string) (*time.Time, bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, false, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, false, nil
+	}
+	return nil, false, fmt.Errorf("invalid '%s' date format. Use YYYY-MM-DD or RFC 3339", name)
+}
+
+// amountParam parses an amount in mutez query parameter, nil when absent.
+func amountParam(c *gin.Context, name string) (*model.Mutez, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || amount < 0 {
+		return nil, fmt.Errorf("invalid '%s', use a number of mutez", name)
+	}
+	mutez := model.Mutez(amount)
+	return &mutez, nil
+}
+
 // setPaginationHeaders sets pagination headers for the response.
 func (h *GetDelegationsHandler) setPaginationHeaders(c *gin.Context, pInfo model.PaginationInfo) {
 	c.Header("X-Page-Current", strconv.Itoa(pInfo.CurrentPage))
@@ -123,44 +286,6 @@ func (h *GetDelegationsHandler) setCacheHeaders(c *gin.Context, year string) {
 	c.Header("Vary", "X-Max-Delegation-ID")
 }
 
-// setETagHeader sets the ETag header for the response.
-// It takes into account the X-Max-Delegation-ID header to ensure proper caching.
-func (h *GetDelegationsHandler) setETagHeader(c *gin.Context, response *model.DelegationsResponse) {
-	jsonData, err := json.Marshal(response)
-	if err != nil {
-		etag := `"` + strconv.FormatInt(time.Now().UnixNano(), 36) + `"`
-		c.Header("ETag", etag)
-		return
-	}
-
-	hasher := sha256.New()
-	hasher.Write(jsonData)
-
-	maxDelegationIDHeader := c.GetHeader("X-Max-Delegation-ID")
-	hasher.Write([]byte("X-Max-Delegation-ID:" + maxDelegationIDHeader))
-
-	page := c.Query("page")
-	if page == "" {
-		page = "1"
-	}
-	hasher.Write([]byte("page:" + page))
-
-	limit := c.Query("limit")
-	if limit == "" {
-		limit = fmt.Sprintf("%d", h.paginationLimit)
-	}
-	hasher.Write([]byte("limit:" + limit))
-
-	year := c.Query("year")
-	if year != "" {
-		hasher.Write([]byte("year:" + year))
-	}
-
-	hashBytes := hasher.Sum(nil)
-	etag := `"` + hex.EncodeToString(hashBytes) + `"`
-	c.Header("ETag", etag)
-}
-
 // setMaxDelegationIDHeader sets the X-Max-Delegation-ID header with the highest delegation ID.
 func (h *GetDelegationsHandler) setMaxDelegationIDHeader(c *gin.Context, maxDelegationID int64) {
 	if maxDelegationID > 0 {
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/tezos-delegation-service/internal/adapter/database"
 	"github.com/tezos-delegation-service/internal/model"
 	"github.com/tezos-delegation-service/internal/usecase"
 )
@@ -30,7 +32,7 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 	}{
 		{
 			name: "nominal case",
-			getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return &model.DelegationsResponse{
 					Delegations: []model.Delegation{{ID: 1}},
 					Pagination: model.PaginationInfo{
@@ -52,6 +54,145 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 			},
 			expectedCalled: true,
 		},
+		{
+			name: "nominal case - after a cursor",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				if input.Cursor != "MTAwOjE" {
+					return nil, errors.New("unexpected cursor")
+				}
+				return &model.DelegationsResponse{
+					Delegations:     []model.Delegation{{ID: 1}},
+					NextCursor:      "OTA6MQ",
+					Pagination:      model.PaginationInfo{CurrentPage: 1, PerPage: 1},
+					MaxDelegationID: 1,
+				}, nil
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/xtz/delegations?cursor=MTAwOjE&limit=1", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeader: map[string]string{
+				"Link":           `</xtz/delegations?cursor=OTA6MQ&limit=1>; rel="next"`,
+				"X-Page-Current": "",
+			},
+			expectedCalled: true,
+		},
+		{
+			name: "error - cursor and page",
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?cursor=MTAwOjE&page=2&limit=50", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "cursor and page cannot be used together",
+			expectedCalled: false,
+		},
+		{
+			name: "error - invalid cursor",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return nil, model.ErrInvalidCursor
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?cursor=invalid&limit=50", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid cursor",
+			expectedCalled: true,
+		},
+		{
+			name: "nominal case - filters and sort",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				minAmount := model.Mutez(1000)
+				if input.Delegate != "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb" || input.FromLevel != 100 || input.ToLevel != 200 ||
+					input.FromDate == nil || input.FromDate.Unix() != 1735689600 || input.MinAmount == nil || *input.MinAmount != minAmount ||
+					input.Kind != model.DelegationKindUndelegation || input.Sort != model.DelegationSortAmount || input.Order != model.SortOrderAsc {
+					return nil, fmt.Errorf("unexpected input %+v", input)
+				}
+				return &model.DelegationsResponse{Delegations: []model.Delegation{{ID: 1}}}, nil
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&delegate=tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb&from_level=100&to_level=200&from=2025-01-01&min_amount=1000&kind=undelegation&sort=amount&order=asc", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalled: true,
+		},
+		{
+			name: "error - cursor with a sort",
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?cursor=MTAwOjE&limit=50&sort=level", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "cursor can only be used with the default sort by decreasing timestamp",
+			expectedCalled: false,
+		},
+		{
+			name: "error - invalid delegator",
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&delegator=abc", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid delegator address: abc",
+			expectedCalled: false,
+		},
+		{
+			name: "nominal case - a date-only to covers its whole day",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				if input.FromDate == nil || !input.FromDate.Equal(from) || input.ToDate == nil || !input.ToDate.Equal(from.AddDate(0, 0, 1)) {
+					return nil, fmt.Errorf("unexpected range: %v - %v", input.FromDate, input.ToDate)
+				}
+				return &model.DelegationsResponse{Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 50}}, nil
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&from=2024-03-01&to=2024-03-01", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalled: true,
+		},
+		{
+			name: "nominal case - an RFC 3339 to covers its second",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				to := time.Date(2024, 3, 1, 12, 0, 1, 0, time.UTC)
+				if input.ToDate == nil || !input.ToDate.Equal(to) {
+					return nil, fmt.Errorf("unexpected to: %v", input.ToDate)
+				}
+				return &model.DelegationsResponse{Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 50}}, nil
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&to=2024-03-01T12:00:00Z", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedCalled: true,
+		},
+		{
+			name: "error - inverted level range",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return nil, fmt.Errorf("%w: from_level cannot exceed to_level", usecase.ErrInvalidRequest)
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&from_level=200&to_level=100", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request: from_level cannot exceed to_level",
+			expectedCalled: true,
+		},
+		{
+			name: "error - invalid amount",
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&max_amount=-1", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'max_amount', use a number of mutez",
+			expectedCalled: false,
+		},
+		{
+			name: "error - invalid sort",
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?limit=50&sort=delegator", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid sort: delegator, use timestamp, level or amount",
+			expectedCalled: false,
+		},
 		{
 			name: "error - invalid page",
 			setupContext: func(c *gin.Context) {
@@ -72,7 +213,7 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 		},
 		{
 			name: "error - internal service",
-			getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return nil, errors.New("internal error")
 			},
 			setupContext: func(c *gin.Context) {
@@ -82,23 +223,30 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 			expectedError:  "internal error",
 			expectedCalled: true,
 		},
-		/* {
-			name: "cache hit - not modified",
-			getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
-				return &model.DelegationsResponse{
-					Data: []model.Delegation{
-						{ID: 1},
-						{ID: 2},
-					},
-				}, nil
+		{
+			name: "error - database timeout",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return nil, database.NewQueryError("GetDelegations", database.ErrTimeout, context.DeadlineExceeded)
 			},
 			setupContext: func(c *gin.Context) {
 				c.Request, _ = http.NewRequest("GET", "/?page=1&limit=50", nil)
-				c.Request.Header.Set("If-None-Match", `"0b2c321dd2ca3add2f21a02b646398f3daed6b82f43fd5ba663631c1e9e3e903"`)
 			},
-			expectedStatus: http.StatusNotModified,
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedError:  "database query timed out",
 			expectedCalled: true,
-		}, */
+		},
+		{
+			name: "error - database unavailable",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return nil, database.NewQueryError("GetDelegations", database.ErrUnavailable, errors.New("connection refused"))
+			},
+			setupContext: func(c *gin.Context) {
+				c.Request, _ = http.NewRequest("GET", "/?page=1&limit=50", nil)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  "database unavailable",
+			expectedCalled: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,14 +261,15 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 				getDelegationsFunc: tt.getDelegationsFunc,
 			}
 			h.GetDelegations(c)
+			answerProblem(c)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
 			if tt.expectedError != "" {
-				var response map[string]string
+				var response Problem
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedError, response["error"])
+				assert.Equal(t, tt.expectedError, response.Detail)
 			}
 
 			if tt.expectedHeader != nil {
@@ -132,6 +281,98 @@ func Test_GetDelegationHandler_GetDelegations(t *testing.T) {
 	}
 }
 
+func Test_GetDelegationHandler_GetDelegationsV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const delegator = model.WalletAddress("tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL")
+	timestamp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Unix()
+
+	tests := []struct {
+		name               string
+		getDelegationsFunc usecase.GetDelegationsFunc
+		url                string
+		expectedStatus     int
+		expectedBody       string
+		expectedError      string
+	}{
+		{
+			name: "nominal case",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				if input.Page != "2" || input.Limit != "2" || input.Year != "2024" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.DelegationsResponse{
+					Delegations: []model.Delegation{
+						{ID: 2, Delegator: delegator, Delegate: testBaker, Timestamp: timestamp, Amount: 1_500_000, AmountTez: "1.500000", Level: 100},
+						{ID: 1, Delegator: delegator, Timestamp: timestamp, Amount: 1_500_000, Level: 99},
+					},
+					Pagination:      model.PaginationInfo{CurrentPage: 2, PerPage: 2, HasPrevPage: true, HasNextPage: true, PrevPage: 1, NextPage: 3},
+					NextCursor:      "next",
+					MaxDelegationID: 2,
+				}, nil
+			},
+			url:            "/v1/delegations?page=2&limit=2&year=2024",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[` +
+				`{"kind":"delegation","delegator":"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL","delegate":"` + testBaker + `","amount":"1500000","level":100,"timestamp":"2024-03-01T12:00:00Z"},` +
+				`{"kind":"undelegation","delegator":"tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL","amount":"1500000","level":99,"timestamp":"2024-03-01T12:00:00Z"}],` +
+				`"pagination":{"page":2,"per_page":2,"has_next":true,"prev_page":1,"next_page":3,"next_cursor":"next"},"meta":{"version":"v1"}}`,
+		},
+		{
+			name: "nominal case - cursor",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				if input.Cursor != "abc" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.DelegationsResponse{
+					Delegations: []model.Delegation{},
+					Pagination:  model.PaginationInfo{CurrentPage: 1, PerPage: 50},
+				}, nil
+			},
+			url:            "/v1/delegations?cursor=abc",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"pagination":{"per_page":50,"has_next":false},"meta":{"version":"v1"}}`,
+		},
+		{
+			name:           "error - invalid page",
+			url:            "/v1/delegations?page=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid page number",
+		},
+		{
+			name: "error - database timeout",
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return nil, database.NewQueryError("GetDelegations", database.ErrTimeout, context.DeadlineExceeded)
+			},
+			url:            "/v1/delegations",
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedError:  "database query timed out",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/v1/delegations", NewGetDelegationsHandler(50, tt.getDelegationsFunc).GetDelegationsV1)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Empty(t, w.Header().Get("X-Page-Current"))
+			assert.Empty(t, w.Header().Get("Link"))
+		})
+	}
+}
+
 func Test_GetDelegationHandler_extractMaxDelegationID(t *testing.T) {
 	tests := []struct {
 		name string
@@ -257,69 +498,6 @@ func Test_GetDelegationHandler_setCacheHeaders(t *testing.T) {
 	}
 }
 
-func Test_GetDelegationHandler_setETagHeader(t *testing.T) {
-	tests := []struct {
-		name           string
-		response       *model.DelegationsResponse
-		expectedPrefix string
-		expectedLen    int
-	}{
-		{
-			name: "Nominal case - complete response object",
-			response: &model.DelegationsResponse{
-				Delegations: []model.Delegation{
-					{ID: 1, Amount: 100},
-					{ID: 2, Amount: 200},
-				},
-				Pagination: model.PaginationInfo{
-					CurrentPage: 1,
-					PerPage:     10,
-				},
-				MaxDelegationID: 100,
-			},
-			expectedPrefix: "\"",
-			expectedLen:    66,
-		},
-		{
-			name:           "Empty response object",
-			response:       &model.DelegationsResponse{},
-			expectedPrefix: "\"",
-			expectedLen:    66,
-		},
-		{
-			name:           "Response object nil",
-			response:       nil,
-			expectedPrefix: "\"",
-			expectedLen:    66,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			w := httptest.NewRecorder()
-			c, _ := gin.CreateTestContext(w)
-			c.Request, _ = http.NewRequest("GET", "/", nil)
-
-			h := &GetDelegationsHandler{}
-			h.setETagHeader(c, tt.response)
-
-			etag := w.Header().Get("ETag")
-			assert.NotEmpty(t, etag)
-			assert.True(t, len(etag) == tt.expectedLen, "ETag should have length %d but has %d (%s)", tt.expectedLen, len(etag), etag)
-			assert.True(t, etag[0:1] == tt.expectedPrefix, "ETag should start with %s", tt.expectedPrefix)
-			assert.True(t, etag[len(etag)-1:] == "\"", "ETag should end with '\"'")
-
-			if tt.response != nil {
-				w2 := httptest.NewRecorder()
-				c2, _ := gin.CreateTestContext(w2)
-				c2.Request, _ = http.NewRequest("GET", "/", nil)
-				h.setETagHeader(c2, tt.response)
-				assert.Equal(t, etag, w2.Header().Get("ETag"), "The same object should generate the same ETag")
-			}
-		})
-	}
-}
-
 func Test_GetDelegationHandler_setMaxDelegationIDHeader(t *testing.T) {
 	type args struct {
 		c               *gin.Context
@@ -549,7 +727,7 @@ func Test_NewGetDelegationHandler(t *testing.T) {
 	}{
 		{
 			name: "nominal case",
-			getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return &model.DelegationsResponse{}, nil
 			},
 			want: nil,
@@ -563,11 +741,11 @@ func Test_NewGetDelegationHandler(t *testing.T) {
 		},
 		{
 			name: "error case - function returns error",
-			getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
+			getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return nil, errors.New("internal error")
 			},
 			want: &GetDelegationsHandler{
-				getDelegationsFunc: func(ctx context.Context, page, limit, year string, maxID int64) (*model.DelegationsResponse, error) {
+				getDelegationsFunc: func(ctx context.Context, input usecase.GetDelegationsInput) (*model.DelegationsResponse, error) {
 					return nil, errors.New("internal error")
 				},
 			},
@@ -579,13 +757,13 @@ func Test_NewGetDelegationHandler(t *testing.T) {
 			assert.NotNil(t, handler)
 
 			if tt.name == "nominal case" {
-				resp, err := handler.getDelegationsFunc(context.Background(), "1", "10", "2023", 0)
+				resp, err := handler.getDelegationsFunc(context.Background(), usecase.GetDelegationsInput{Page: "1", Limit: "10", Year: "2023"})
 				assert.NoError(t, err)
 				assert.NotNil(t, resp)
 			} else if tt.name == "error case - nil function" {
 				assert.Nil(t, handler.getDelegationsFunc)
 			} else if tt.name == "error case - function returns error" {
-				_, err := handler.getDelegationsFunc(context.Background(), "1", "10", "2023", 0)
+				_, err := handler.getDelegationsFunc(context.Background(), usecase.GetDelegationsInput{Page: "1", Limit: "10", Year: "2023"})
 				assert.Error(t, err)
 				assert.Equal(t, "internal error", err.Error())
 			}
@@ -1,9 +1,6 @@
 package http
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -33,39 +30,69 @@ func NewGetOperationsHandler(paginationLimit uint16, getOperationsFunc usecase.G
 // GetOperations handles GET /xtz/operations requests for retrieving all on-chain
 // transactions related to staking (delegate/undelegate, stake/unstake, rewards payments).
 func (h *GetOperationsHandler) GetOperations(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	page, limit, fromDate, toDate, operationType, wallet, backer, err := h.validateRequestParams(c)
+	input, err := h.parseInput(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithInvalidRequest(c, err.Error())
 		return
 	}
 
-	input := usecase.GetOperationsInput{
-		FromDate: fromDate,
-		ToDate:   toDate,
-		Page:     strconv.Itoa(page),
-		Limit:    strconv.Itoa(limit),
-		Type:     operationType,
-		Wallet:   wallet,
-		Backer:   backer,
-	}
-	response, err := h.getOperationsFunc(ctx, input)
+	response, err := h.getOperationsFunc(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
-	h.setPaginationHeaders(c, response.Pagination)
+	if input.Cursor == "" {
+		h.setPaginationHeaders(c, response.Pagination)
+	}
+	setNextLinkHeader(c, response.NextCursor)
 	h.setRequestIDHeader(c)
-	/* h.setETagHeader(c, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetOperationsV1 handles GET /v1/operations requests.
+func (h *GetOperationsHandler) GetOperationsV1(c *gin.Context) {
+	input, err := h.parseInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
 
-	if c.GetHeader("If-None-Match") == c.Writer.Header().Get("ETag") {
-		c.Status(http.StatusNotModified)
+	response, err := h.getOperationsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
 		return
-	}*/
+	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, Envelope[[]V1Operation]{
+		Data:       newV1Operations(response.Operations),
+		Pagination: newV1Pagination(response.Pagination, input.Cursor, response.NextCursor),
+		Meta:       newV1Meta(),
+	})
+}
+
+// parseInput validates and parses the query parameters of an operations request.
+func (h *GetOperationsHandler) parseInput(c *gin.Context) (usecase.GetOperationsInput, error) {
+	page, limit, fromDate, toDate, operationType, wallet, backer, err := h.validateRequestParams(c)
+	if err != nil {
+		return usecase.GetOperationsInput{}, err
+	}
+
+	cursor, err := cursorParam(c)
+	if err != nil {
+		return usecase.GetOperationsInput{}, err
+	}
+
+	return usecase.GetOperationsInput{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Page:     strconv.Itoa(page),
+		Limit:    strconv.Itoa(limit),
+		Cursor:   cursor,
+		Type:     operationType,
+		Wallet:   wallet,
+		Backer:   backer,
+	}, nil
 }
 
 // validateRequestParams validates and parses request parameters.
@@ -76,22 +103,8 @@ func (h *GetOperationsHandler) validateRequestParams(c *gin.Context) (page, limi
 		return
 	}
 
-	if fromStr := c.DefaultQuery("from", ""); fromStr != "" {
-		t, errParsing := time.Parse("2006-01-02", fromStr)
-		if errParsing != nil {
-			err = errors.New("invalid 'from' date format. Use YYYY-MM-DD")
-			return
-		}
-		fromDate = &t
-	}
-
-	if toStr := c.DefaultQuery("to", ""); toStr != "" {
-		t, errParsing := time.Parse("2006-01-02", toStr)
-		if errParsing != nil {
-			err = errors.New("invalid 'to' date format. Use YYYY-MM-DD")
-			return
-		}
-		toDate = &t
+	if fromDate, toDate, err = dateRangeParams(c); err != nil {
+		return
 	}
 
 	limit, err = strconv.Atoi(c.DefaultQuery("limit", fmt.Sprintf("%d", h.paginationLimit)))
@@ -158,45 +171,3 @@ func (h *GetOperationsHandler) setCacheHeaders(c *gin.Context, year string) {
 		c.Header("Cache-Control", "public, max-age=300") // 5m cache
 	}
 }
-
-// setETagHeader sets the ETag header for the response.
-func (h *GetOperationsHandler) setETagHeader(c *gin.Context, response *model.OperationsResponse) {
-	jsonData, err := json.Marshal(response)
-	if err != nil {
-		etag := `"` + strconv.FormatInt(time.Now().UnixNano(), 36) + `"`
-		c.Header("ETag", etag)
-		return
-	}
-
-	hasher := sha256.New()
-	hasher.Write(jsonData)
-
-	maxDelegationIDHeader := c.GetHeader("X-Max-Delegation-ID")
-	hasher.Write([]byte("X-Max-Delegation-ID:" + maxDelegationIDHeader))
-
-	page := c.Query("page")
-	if page == "" {
-		page = "1"
-	}
-	hasher.Write([]byte("page:" + page))
-
-	limit := c.Query("limit")
-	if limit == "" {
-		limit = fmt.Sprintf("%d", h.paginationLimit)
-	}
-	hasher.Write([]byte("limit:" + limit))
-
-	year := c.Query("year")
-	if year != "" {
-		hasher.Write([]byte("year:" + year))
-	}
-
-	opType := c.Query("type")
-	if opType != "" {
-		hasher.Write([]byte("type:" + opType))
-	}
-
-	hashBytes := hasher.Sum(nil)
-	etag := `"` + hex.EncodeToString(hashBytes) + `"`
-	c.Header("ETag", etag)
-}
@@ -1,9 +1,6 @@
 package http
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -30,58 +27,88 @@ func NewGetRewardsHandler(paginationLimit uint16, getRewardsFunc usecase.GetRewa
 	}
 }
 
-// GetRewards handles GET /xtz/delegations requests.
+// GetRewards handles GET /xtz/rewards requests.
 func (h *GetRewardsHandler) GetRewards(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	fromDate, toDate, wallet, backer, err := h.validateRequestParams(c)
+	input, err := h.parseInput(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithInvalidRequest(c, err.Error())
 		return
 	}
 
-	input := usecase.GetRewardsInput{
-		FromDate: fromDate,
-		ToDate:   toDate,
-		Wallet:   wallet,
-		Backer:   backer,
-	}
-	response, err := h.getRewardsFunc(ctx, input)
+	response, err := h.getRewardsFunc(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
+	if input.Cursor == "" {
+		h.setPaginationHeaders(c, response.Pagination)
+	}
+	setNextLinkHeader(c, response.NextCursor)
 	h.setRequestIDHeader(c)
-	/* h.setETagHeader(c, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRewardsV1 handles GET /v1/rewards requests.
+func (h *GetRewardsHandler) GetRewardsV1(c *gin.Context) {
+	input, err := h.parseInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
 
-	if c.GetHeader("If-None-Match") == c.Writer.Header().Get("ETag") {
-		c.Status(http.StatusNotModified)
+	response, err := h.getRewardsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
 		return
-	}*/
+	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, Envelope[[]V1Reward]{
+		Data:       newV1Rewards(response.Rewards),
+		Pagination: newV1Pagination(response.Pagination, input.Cursor, response.NextCursor),
+		Meta:       newV1Meta(),
+	})
+}
+
+// parseInput validates and parses the query parameters of a rewards request.
+func (h *GetRewardsHandler) parseInput(c *gin.Context) (usecase.GetRewardsInput, error) {
+	page, limit, fromDate, toDate, wallet, backer, err := h.validateRequestParams(c)
+	if err != nil {
+		return usecase.GetRewardsInput{}, err
+	}
+
+	cursor, err := cursorParam(c)
+	if err != nil {
+		return usecase.GetRewardsInput{}, err
+	}
+
+	return usecase.GetRewardsInput{
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Page:     strconv.Itoa(page),
+		Limit:    strconv.Itoa(limit),
+		Cursor:   cursor,
+		Wallet:   wallet,
+		Backer:   backer,
+	}, nil
 }
 
 // validateRequestParams validates and parses request parameters.
-func (h *GetRewardsHandler) validateRequestParams(c *gin.Context) (fromDate, toDate *time.Time, wallet, backer model.WalletAddress, err error) {
+func (h *GetRewardsHandler) validateRequestParams(c *gin.Context) (page, limit int, fromDate, toDate *time.Time, wallet, backer model.WalletAddress, err error) {
+	page, err = strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		err = errors.New("invalid page number")
+		return
+	}
 
-	if fromStr := c.DefaultQuery("from", ""); fromStr != "" {
-		t, errParsing := time.Parse("2006-01-02", fromStr)
-		if errParsing != nil {
-			err = errors.New("invalid 'from' date format. Use YYYY-MM-DD")
-			return
-		}
-		fromDate = &t
+	limit, err = strconv.Atoi(c.DefaultQuery("limit", fmt.Sprintf("%d", h.paginationLimit)))
+	if err != nil || limit < 1 || limit > model.MaxRewardsLimit {
+		err = fmt.Errorf("limit must be between 1 and %d, got %d", model.MaxRewardsLimit, limit)
+		return
 	}
 
-	if toStr := c.DefaultQuery("to", ""); toStr != "" {
-		t, errParsing := time.Parse("2006-01-02", toStr)
-		if errParsing != nil {
-			err = errors.New("invalid 'to' date format. Use YYYY-MM-DD")
-			return
-		}
-		toDate = &t
+	if fromDate, toDate, err = dateRangeParams(c); err != nil {
+		return
 	}
 
 	walletStr := c.DefaultQuery("wallet", "")
@@ -134,32 +161,3 @@ func (h *GetRewardsHandler) setCacheHeaders(c *gin.Context, year string) {
 		c.Header("Cache-Control", "public, max-age=300") // 5m cache
 	}
 }
-
-// setETagHeader sets the ETag header for the response.
-func (h *GetRewardsHandler) setETagHeader(c *gin.Context, response *model.RewardsResponse) {
-	jsonData, err := json.Marshal(response)
-	if err != nil {
-		etag := `"` + strconv.FormatInt(time.Now().UnixNano(), 36) + `"`
-		c.Header("ETag", etag)
-		return
-	}
-
-	hasher := sha256.New()
-	hasher.Write(jsonData)
-
-	page := c.Query("page")
-	if page == "" {
-		page = "1"
-	}
-	hasher.Write([]byte("page:" + page))
-
-	limit := c.Query("limit")
-	if limit == "" {
-		limit = fmt.Sprintf("%d", h.paginationLimit)
-	}
-	hasher.Write([]byte("limit:" + limit))
-
-	hashBytes := hasher.Sum(nil)
-	etag := `"` + hex.EncodeToString(hashBytes) + `"`
-	c.Header("ETag", etag)
-}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const contentTypeHTML = "text/html"
+
+// GetRewardsReportHandler handles rewards tax report API requests.
+type GetRewardsReportHandler struct {
+	getRewardsReportFunc usecase.GetRewardsReportFunc
+}
+
+// NewGetRewardsReportHandler creates a new rewards report handler.
+func NewGetRewardsReportHandler(getRewardsReportFunc usecase.GetRewardsReportFunc) *GetRewardsReportHandler {
+	return &GetRewardsReportHandler{
+		getRewardsReportFunc: getRewardsReportFunc,
+	}
+}
+
+// GetRewardsReport handles GET /xtz/reports/rewards requests, answering JSON, CSV or printable HTML.
+func (h *GetRewardsReportHandler) GetRewardsReport(c *gin.Context) {
+	format, err := h.reportFormat(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	input, err := h.parseReportInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	report, err := h.getRewardsReportFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300") // 5m cache
+	filename := fmt.Sprintf("rewards-%s-%d-%s", input.Wallet, input.Year, input.Currency)
+
+	var buf bytes.Buffer
+	switch format {
+	case "csv":
+		if err := report.WriteCSV(&buf); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		c.Data(http.StatusOK, contentTypeCSV+"; charset=utf-8", buf.Bytes())
+	case "html":
+		if err := report.WriteHTML(&buf); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.html"`, filename))
+		c.Data(http.StatusOK, contentTypeHTML+"; charset=utf-8", buf.Bytes())
+	default:
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// GetRewardsReportV1 handles GET /v1/reports/rewards requests, answering JSON only.
+func (h *GetRewardsReportHandler) GetRewardsReportV1(c *gin.Context) {
+	input, err := h.parseReportInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	report, err := h.getRewardsReportFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300") // 5m cache
+	c.JSON(http.StatusOK, Envelope[V1RewardsReport]{
+		Data: newV1RewardsReport(report),
+		Meta: newV1Meta(),
+	})
+}
+
+// deprecateJSON marks the JSON reports of GET /xtz/reports/rewards as deprecated, the CSV and HTML reports have no
+// /v1 successor.
+func (h *GetRewardsReportHandler) deprecateJSON(c *gin.Context) {
+	if format, err := h.reportFormat(c); err != nil || format != "json" {
+		c.Next()
+		return
+	}
+	deprecateLegacy(c)
+}
+
+// parseReportInput validates and parses the query parameters of a rewards report request.
+func (h *GetRewardsReportHandler) parseReportInput(c *gin.Context) (usecase.GetRewardsReportInput, error) {
+	input := usecase.GetRewardsReportInput{
+		Wallet:   model.WalletAddress(c.Query("wallet")),
+		Currency: model.Currency(strings.ToUpper(c.Query("currency"))),
+	}
+	if input.Wallet == "" {
+		return input, errors.New("missing wallet address")
+	}
+	if !input.Wallet.IsValid() {
+		return input, fmt.Errorf("invalid wallet address: %s", input.Wallet.String())
+	}
+	var err error
+	if input.Year, err = strconv.Atoi(c.Query("year")); err != nil || input.Year <= 0 || input.Year > time.Now().UTC().Year() {
+		return input, errors.New("'year' must be a past or current year")
+	}
+	if !input.Currency.IsValid() {
+		return input, fmt.Errorf("invalid 'currency': %q, use an ISO 4217 code such as EUR", c.Query("currency"))
+	}
+	return input, nil
+}
+
+// reportFormat returns the format of a report: the format query parameter, else the first of CSV or HTML listed in
+// the Accept header, else JSON.
+func (h *GetRewardsReportHandler) reportFormat(c *gin.Context) (string, error) {
+	switch format := c.Query("format"); format {
+	case "json", "csv", "html":
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid 'format': %s, use json, csv or html", format)
+	}
+
+	for _, value := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case contentTypeCSV:
+			return "csv", nil
+		case contentTypeHTML:
+			return "html", nil
+		}
+	}
+	return "json", nil
+}
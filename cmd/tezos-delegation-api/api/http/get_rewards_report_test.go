@@ -0,0 +1,169 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+func Test_GetRewardsReportHandler_GetRewardsReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	report := func(ctx context.Context, input usecase.GetRewardsReportInput) (*model.RewardsReport, error) {
+		if input.Wallet != testDelegator || input.Year != 2024 || input.Currency != "EUR" {
+			return nil, errors.New("unexpected input")
+		}
+		return model.NewRewardsReport(input.Wallet, input.Year, input.Currency,
+			[]model.Reward{{SourceAddress: testBaker, Cycle: 700, Amount: 2_000_000, Timestamp: 1704067200}},
+			[]model.Price{{Day: 1704067200, Currency: "EUR", Price: "0.5"}}), nil
+	}
+	query := "/xtz/reports/rewards?wallet=" + testDelegator.String() + "&year=2024&currency=eur"
+
+	tests := []struct {
+		name                 string
+		getRewardsReportFunc usecase.GetRewardsReportFunc
+		url                  string
+		accept               string
+		expectedStatus       int
+		expectedContentType  string
+		expectedBody         string
+		expectedError        string
+	}{
+		{
+			name:                 "Nominal case - json",
+			getRewardsReportFunc: report,
+			url:                  query,
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedBody:         `"total_value":"1.00"`,
+		},
+		{
+			name:                 "Nominal case - csv",
+			getRewardsReportFunc: report,
+			url:                  query + "&format=csv",
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedBody:         "total,,,2000000,2.000000,,1.00\n",
+		},
+		{
+			name:                 "Nominal case - html from the Accept header",
+			getRewardsReportFunc: report,
+			url:                  query,
+			accept:               "text/html,application/xhtml+xml,*/*;q=0.8",
+			expectedStatus:       http.StatusOK,
+			expectedContentType:  "text/html; charset=utf-8",
+			expectedBody:         "<h1>Staking rewards 2024</h1>",
+		},
+		{
+			name:           "Error case - missing wallet",
+			url:            "/xtz/reports/rewards?year=2024&currency=EUR",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "missing wallet address",
+		},
+		{
+			name:           "Error case - future year",
+			url:            "/xtz/reports/rewards?wallet=" + testDelegator.String() + "&year=3000&currency=EUR",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "'year' must be a past or current year",
+		},
+		{
+			name:           "Error case - invalid currency",
+			url:            "/xtz/reports/rewards?wallet=" + testDelegator.String() + "&year=2024&currency=euro",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  `invalid 'currency': "euro", use an ISO 4217 code such as EUR`,
+		},
+		{
+			name:           "Error case - invalid format",
+			url:            query + "&format=pdf",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'format': pdf, use json, csv or html",
+		},
+		{
+			name: "Error case - internal service",
+			getRewardsReportFunc: func(ctx context.Context, input usecase.GetRewardsReportInput) (*model.RewardsReport, error) {
+				return nil, errors.New("internal error")
+			},
+			url:            query,
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/reports/rewards", NewGetRewardsReportHandler(tt.getRewardsReportFunc).GetRewardsReport)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.True(t, strings.Contains(w.Body.String(), tt.expectedBody), w.Body.String())
+		})
+	}
+}
+
+func Test_GetRewardsReportHandler_deprecateJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		url        string
+		accept     string
+		deprecated bool
+	}{
+		{
+			name:       "nominal case - json",
+			url:        "/xtz/reports/rewards?year=2024",
+			deprecated: true,
+		},
+		{
+			name: "nominal case - csv",
+			url:  "/xtz/reports/rewards?year=2024&format=csv",
+		},
+		{
+			name:   "nominal case - html from the Accept header",
+			url:    "/xtz/reports/rewards?year=2024",
+			accept: "text/html",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/xtz/reports/rewards", NewGetRewardsReportHandler(nil).deprecateJSON, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.deprecated, w.Header().Get("Deprecation") != "")
+			if tt.deprecated {
+				assert.Equal(t, `</v1/reports/rewards?year=2024>; rel="successor-version"`, w.Header().Get("Link"))
+			}
+		})
+	}
+}
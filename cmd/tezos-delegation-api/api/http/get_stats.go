@@ -0,0 +1,231 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// maxStatsDays is the maximum number of days of a daily statistics request.
+const maxStatsDays = 366
+
+// GetStatsHandler handles statistics API requests.
+type GetStatsHandler struct {
+	getDelegationStatsFunc      usecase.GetDelegationStatsFunc
+	getBakerRewardStatsFunc     usecase.GetBakerRewardStatsFunc
+	getDelegatorRewardStatsFunc usecase.GetDelegatorRewardStatsFunc
+	now                         func() time.Time
+}
+
+// NewGetStatsHandler creates a new statistics handler.
+func NewGetStatsHandler(getDelegationStatsFunc usecase.GetDelegationStatsFunc, getBakerRewardStatsFunc usecase.GetBakerRewardStatsFunc, getDelegatorRewardStatsFunc usecase.GetDelegatorRewardStatsFunc) *GetStatsHandler {
+	return &GetStatsHandler{
+		getDelegationStatsFunc:      getDelegationStatsFunc,
+		getBakerRewardStatsFunc:     getBakerRewardStatsFunc,
+		getDelegatorRewardStatsFunc: getDelegatorRewardStatsFunc,
+		now:                         time.Now,
+	}
+}
+
+// GetDelegationStats handles GET /xtz/stats/delegations requests.
+func (h *GetStatsHandler) GetDelegationStats(c *gin.Context) {
+	input, err := h.validateDelegationStatsParams(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getDelegationStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBakerRewardStats handles GET /xtz/stats/rewards/bakers requests.
+func (h *GetStatsHandler) GetBakerRewardStats(c *gin.Context) {
+	input, err := h.validateRewardStatsParams(c, "baker")
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerRewardStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDelegatorRewardStats handles GET /xtz/stats/rewards/delegators requests.
+func (h *GetStatsHandler) GetDelegatorRewardStats(c *gin.Context) {
+	input, err := h.validateRewardStatsParams(c, "delegator")
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getDelegatorRewardStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDelegationStatsV1 handles GET /v1/stats/delegations requests.
+func (h *GetStatsHandler) GetDelegationStatsV1(c *gin.Context) {
+	input, err := h.validateDelegationStatsParams(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getDelegationStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Envelope[[]V1DailyDelegationStats]{
+		Data: newV1DailyDelegationStats(response.Stats),
+		Meta: newV1Meta(),
+	})
+}
+
+// GetBakerRewardStatsV1 handles GET /v1/stats/rewards/bakers requests.
+func (h *GetStatsHandler) GetBakerRewardStatsV1(c *gin.Context) {
+	input, err := h.validateRewardStatsParams(c, "baker")
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getBakerRewardStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Envelope[[]V1BakerCycle]{
+		Data: newV1BakerCycles(response.Stats),
+		Meta: newV1Meta(),
+	})
+}
+
+// GetDelegatorRewardStatsV1 handles GET /v1/stats/rewards/delegators requests.
+func (h *GetStatsHandler) GetDelegatorRewardStatsV1(c *gin.Context) {
+	input, err := h.validateRewardStatsParams(c, "delegator")
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	response, err := h.getDelegatorRewardStatsFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Envelope[[]V1DelegatorCycle]{
+		Data: newV1DelegatorCycles(response.Stats),
+		Meta: newV1Meta(),
+	})
+}
+
+// validateDelegationStatsParams validates and parses the daily statistics request parameters.
+func (h *GetStatsHandler) validateDelegationStatsParams(c *gin.Context) (input usecase.GetDelegationStatsInput, err error) {
+	if fromStr := c.Query("from"); fromStr != "" {
+		t, errParsing := time.Parse("2006-01-02", fromStr)
+		if errParsing != nil {
+			return input, errors.New("invalid 'from' date format. Use YYYY-MM-DD")
+		}
+		input.FromDate = &t
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		t, errParsing := time.Parse("2006-01-02", toStr)
+		if errParsing != nil {
+			return input, errors.New("invalid 'to' date format. Use YYYY-MM-DD")
+		}
+		input.ToDate = &t
+	}
+
+	// A range with a single bound ends today or spans the maximum number of days, so that it is bounded too.
+	if input.FromDate != nil && input.ToDate == nil {
+		today := h.now().UTC().Truncate(24 * time.Hour)
+		input.ToDate = &today
+	}
+	if input.ToDate != nil && input.FromDate == nil {
+		from := input.ToDate.AddDate(0, 0, -(maxStatsDays - 1))
+		input.FromDate = &from
+	}
+
+	if input.FromDate != nil && input.ToDate != nil {
+		if input.ToDate.Before(*input.FromDate) {
+			return input, errors.New("'to' date must not be before 'from' date")
+		}
+		if input.ToDate.Sub(*input.FromDate) >= maxStatsDays*24*time.Hour {
+			return input, fmt.Errorf("date range must not exceed %d days", maxStatsDays)
+		}
+	}
+
+	if bakerStr := c.Query("baker"); bakerStr != "" {
+		input.Baker = model.WalletAddress(bakerStr)
+		if !input.Baker.IsValid() {
+			return input, fmt.Errorf("invalid baker address: %s", bakerStr)
+		}
+	}
+
+	return input, nil
+}
+
+// validateRewardStatsParams validates and parses the per-cycle statistics request parameters, addressParam naming the address filter.
+func (h *GetStatsHandler) validateRewardStatsParams(c *gin.Context, addressParam string) (input usecase.GetRewardStatsInput, err error) {
+	if input.FromCycle, err = parseCycle(c, "from_cycle"); err != nil {
+		return input, err
+	}
+
+	if input.ToCycle, err = parseCycle(c, "to_cycle"); err != nil {
+		return input, err
+	}
+
+	if input.FromCycle > 0 && input.ToCycle > 0 && input.ToCycle < input.FromCycle {
+		return input, errors.New("'to_cycle' must not be before 'from_cycle'")
+	}
+
+	if addressStr := c.Query(addressParam); addressStr != "" {
+		input.Address = model.WalletAddress(addressStr)
+		if !input.Address.IsValid() {
+			return input, fmt.Errorf("invalid %s address: %s", addressParam, addressStr)
+		}
+	}
+
+	return input, nil
+}
+
+// parseCycle parses the cycle query parameter name, 0 when absent.
+func parseCycle(c *gin.Context, name string) (int, error) {
+	cycleStr := c.Query(name)
+	if cycleStr == "" {
+		return 0, nil
+	}
+
+	cycle, err := strconv.Atoi(cycleStr)
+	if err != nil || cycle < 0 {
+		return 0, fmt.Errorf("invalid '%s': must be a non-negative integer", name)
+	}
+	return cycle, nil
+}
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const testBaker = "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"
+
+func Test_GetStatsHandler_GetDelegationStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name                   string
+		getDelegationStatsFunc usecase.GetDelegationStatsFunc
+		url                    string
+		expectedStatus         int
+		expectedError          string
+	}{
+		{
+			name: "nominal case",
+			getDelegationStatsFunc: func(ctx context.Context, input usecase.GetDelegationStatsInput) (*model.DelegationStatsResponse, error) {
+				if input.FromDate == nil || input.ToDate == nil || input.Baker != testBaker {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.DelegationStatsResponse{}, nil
+			},
+			url:            "/?from=2025-01-01&to=2025-01-31&baker=" + testBaker,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid from date",
+			url:            "/?from=01-01-2025",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'from' date format. Use YYYY-MM-DD",
+		},
+		{
+			name:           "error - to before from",
+			url:            "/?from=2025-02-01&to=2025-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "'to' date must not be before 'from' date",
+		},
+		{
+			name:           "error - range too large",
+			url:            "/?from=2023-01-01&to=2025-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "date range must not exceed 366 days",
+		},
+		{
+			name: "nominal case - missing from covers the maximum range",
+			getDelegationStatsFunc: func(ctx context.Context, input usecase.GetDelegationStatsInput) (*model.DelegationStatsResponse, error) {
+				if input.FromDate == nil || input.FromDate.Format("2006-01-02") != "2024-01-01" {
+					return nil, errors.New("unexpected input")
+				}
+				return &model.DelegationStatsResponse{}, nil
+			},
+			url:            "/?to=2024-12-31",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - missing to defaults to today",
+			url:            "/?from=2000-01-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "date range must not exceed 366 days",
+		},
+		{
+			name:           "error - invalid baker",
+			url:            "/?baker=tz1invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid baker address: tz1invalid",
+		},
+		{
+			name: "error - database timeout",
+			getDelegationStatsFunc: func(ctx context.Context, input usecase.GetDelegationStatsInput) (*model.DelegationStatsResponse, error) {
+				return nil, database.NewQueryError("GetDailyDelegationStats", database.ErrTimeout, context.DeadlineExceeded)
+			},
+			url:            "/",
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedError:  "database query timed out",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", tt.url, nil)
+
+			h := NewGetStatsHandler(tt.getDelegationStatsFunc, nil, nil)
+			h.GetDelegationStats(c)
+			answerProblem(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
+
+func Test_GetStatsHandler_GetRewardStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewGetStatsHandler(nil,
+		func(ctx context.Context, input usecase.GetRewardStatsInput) (*model.BakerRewardStatsResponse, error) {
+			if input.FromCycle != 700 || input.ToCycle != 710 || input.Address != testBaker {
+				return nil, errors.New("unexpected input")
+			}
+			return &model.BakerRewardStatsResponse{}, nil
+		},
+		func(ctx context.Context, input usecase.GetRewardStatsInput) (*model.DelegatorRewardStatsResponse, error) {
+			return nil, errors.New("internal error")
+		},
+	)
+
+	tests := []struct {
+		name           string
+		handler        gin.HandlerFunc
+		url            string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case - bakers",
+			handler:        h.GetBakerRewardStats,
+			url:            "/?from_cycle=700&to_cycle=710&baker=" + testBaker,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid cycle",
+			handler:        h.GetBakerRewardStats,
+			url:            "/?from_cycle=-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'from_cycle': must be a non-negative integer",
+		},
+		{
+			name:           "error - to_cycle before from_cycle",
+			handler:        h.GetBakerRewardStats,
+			url:            "/?from_cycle=710&to_cycle=700",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "'to_cycle' must not be before 'from_cycle'",
+		},
+		{
+			name:           "error - invalid delegator",
+			handler:        h.GetDelegatorRewardStats,
+			url:            "/?delegator=foo",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid delegator address: foo",
+		},
+		{
+			name:           "error - internal service",
+			handler:        h.GetDelegatorRewardStats,
+			url:            "/",
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", tt.url, nil)
+
+			tt.handler(c)
+			answerProblem(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tezos-delegation-service/internal/apperror"
+	"github.com/tezos-delegation-service/pkg/openapi"
+)
+
+const (
+	// OpenAPIValidationOff disables the validation against the OpenAPI specification.
+	OpenAPIValidationOff = "off"
+	// OpenAPIValidationLog logs the requests and responses which do not match the specification, the default.
+	OpenAPIValidationLog = "log"
+	// OpenAPIValidationEnforce answers 400 to the requests and replaces with 500 the responses which do not match the
+	// specification, for the tests.
+	OpenAPIValidationEnforce = "enforce"
+)
+
+// OpenAPIConfig configures the validation of the requests and responses against the OpenAPI specification.
+type OpenAPIConfig struct {
+	// Validation is off, log or enforce, log when empty.
+	Validation string `mapstructure:"validation"`
+}
+
+// OpenAPIMiddleware validates the requests and responses of the documented routes against the OpenAPI specification.
+type OpenAPIMiddleware struct {
+	spec       *openapi.Spec
+	validation string
+	logger     *logrus.Entry
+}
+
+// NewOpenAPIMiddleware creates a new OpenAPI validation middleware.
+func NewOpenAPIMiddleware(cfg OpenAPIConfig, spec *openapi.Spec, logger *logrus.Entry) *OpenAPIMiddleware {
+	validation := cfg.Validation
+	if validation == "" {
+		validation = OpenAPIValidationLog
+	}
+	return &OpenAPIMiddleware{spec: spec, validation: validation, logger: logger}
+}
+
+// Validate checks the query and path parameters and the JSON body of a request, then the status, media type and JSON
+// body of its response. The routes missing from the specification and the responses flushed or hijacked while
+// written, such as streams and exports, are not checked.
+func (m *OpenAPIMiddleware) Validate(c *gin.Context) {
+	if m.validation == OpenAPIValidationOff {
+		c.Next()
+		return
+	}
+	op, ok := m.spec.Operation(c.Request.Method, openAPIPath(c.FullPath()))
+	if !ok {
+		c.Next()
+		return
+	}
+
+	pathParams := make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		pathParams[param.Key] = param.Value
+	}
+	if err := m.spec.ValidateRequest(op, c.Request, pathParams); err != nil {
+		m.log(c, "Request does not match the API specification", err)
+		if m.validation == OpenAPIValidationEnforce {
+			writeProblem(c, m.logger, http.StatusBadRequest, apperror.New(apperror.KindValidation, apperror.CodeInvalidRequest, err.Error()))
+			return
+		}
+	}
+
+	writer := newBufferedWriter(c.Writer)
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	if writer.passthrough {
+		return
+	}
+	err := m.spec.ValidateResponse(op, writer.status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+	if err == nil {
+		writer.flush()
+		return
+	}
+
+	if m.validation != OpenAPIValidationEnforce {
+		m.log(c, "Response does not match the API specification", err)
+		writer.flush()
+		return
+	}
+	header := writer.Header()
+	for _, name := range []string{"Content-Length", "Content-Disposition", "ETag", "Last-Modified"} {
+		header.Del(name)
+	}
+	writeProblem(c, m.logger, http.StatusInternalServerError, apperror.Wrap(err, apperror.KindInternal, "invalid_response", "response does not match the API specification"))
+}
+
+// log logs a mismatch between a request or response and the specification.
+func (m *OpenAPIMiddleware) log(c *gin.Context, message string, err error) {
+	if m.logger == nil {
+		return
+	}
+	m.logger.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"route":  c.FullPath(),
+	}).WithError(err).Warn(message)
+}
+
+// openAPIPath converts a gin route, such as /bakers/:address, to an OpenAPI path template, such as /bakers/{address}.
+func openAPIPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
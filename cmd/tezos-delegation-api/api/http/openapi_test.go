@@ -0,0 +1,312 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-api/specs"
+	cachenoop "github.com/tezos-delegation-service/internal/adapter/cache/impl/noop"
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+	"github.com/tezos-delegation-service/pkg/openapi"
+)
+
+const testOpenAPISpec = `
+paths:
+  /items/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 10
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+        '304':
+          description: Not modified
+`
+
+// newOpenAPITestRouter serves /items/:id, answering body, behind the OpenAPI middleware.
+func newOpenAPITestRouter(validation string, body string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	m := NewOpenAPIMiddleware(OpenAPIConfig{Validation: validation}, openapi.MustLoad([]byte(testOpenAPISpec)), logrus.NewEntry(logger))
+	router := gin.New()
+	router.Use(m.Validate)
+	router.GET("/items/:id", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(body))
+	})
+	router.GET("/stream/:id", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(body))
+		c.Writer.Flush()
+	})
+	return router
+}
+
+func Test_OpenAPIMiddleware_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		validation     string
+		url            string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "nominal case",
+			validation:     OpenAPIValidationEnforce,
+			url:            "/items/1?limit=5",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"a"}`,
+		},
+		{
+			name:           "nominal case - route missing from the specification",
+			validation:     OpenAPIValidationEnforce,
+			url:            "/stream/0?other=1",
+			body:           `{}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{}`,
+		},
+		{
+			name:           "nominal case - invalid request logged",
+			validation:     OpenAPIValidationLog,
+			url:            "/items/0?limit=50",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"a"}`,
+		},
+		{
+			name:           "nominal case - invalid response logged",
+			validation:     "",
+			url:            "/items/1",
+			body:           `{"name":1}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":1}`,
+		},
+		{
+			name:           "nominal case - validation off",
+			validation:     OpenAPIValidationOff,
+			url:            "/items/x?other=1",
+			body:           `{}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{}`,
+		},
+		{
+			name:           "error - invalid path parameter",
+			validation:     OpenAPIValidationEnforce,
+			url:            "/items/0",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"path parameter 'id': 0 is less than the minimum 1","code":"invalid_request","request_id":"req-1"}`,
+		},
+		{
+			name:           "error - invalid and unknown query parameters",
+			validation:     OpenAPIValidationEnforce,
+			url:            "/items/1?limit=50&other=1",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"query parameter 'limit': 50 is greater than the maximum 10\nunknown query parameter 'other'","code":"invalid_request","request_id":"req-1"}`,
+		},
+		{
+			name:           "error - invalid response",
+			validation:     OpenAPIValidationEnforce,
+			url:            "/items/1",
+			body:           `{"name":1}`,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"response does not match the API specification","code":"invalid_response","request_id":"req-1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newOpenAPITestRouter(tt.validation, tt.body)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+			if tt.expectedStatus == http.StatusInternalServerError {
+				assert.Empty(t, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func Test_openAPIPath(t *testing.T) {
+	assert.Equal(t, "/xtz/bakers/{address}/delegators", openAPIPath("/xtz/bakers/:address/delegators"))
+	assert.Equal(t, "/files/{path}", openAPIPath("/files/*path"))
+	assert.Equal(t, "/health", openAPIPath("/health"))
+}
+
+// newOpenAPIConformanceServer creates a server validating its requests and responses against the specification, on
+// a memory database holding a delegation, an operation and a reward of the testBaker staking pool.
+func newOpenAPIConformanceServer(t *testing.T) *Server {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	const delegator = model.WalletAddress("tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL")
+	timestamp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC).Unix()
+
+	db := databasememory.New()
+	assert.NoError(t, db.SaveAccounts(ctx, []model.Account{
+		{Address: testBaker, Alias: "Baker", Type: model.AccountTypeDelegate},
+		{Address: delegator, Type: model.AccountTypeUser},
+	}))
+	assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{
+		{ID: 1, Delegator: delegator, Delegate: testBaker, Timestamp: timestamp, Amount: 1_500_000, Level: 100},
+	}))
+	assert.NoError(t, db.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: delegator, SourceAddress: testBaker, Cycle: 700, Amount: 25_000, Timestamp: timestamp},
+	}))
+	assert.NoError(t, db.SaveStakingPools(ctx, []model.StakingPool{{Address: testBaker, Name: "Baker"}}))
+	assert.NoError(t, db.SaveCurrentDelegations(ctx, []model.CurrentDelegation{
+		{Delegator: delegator, Baker: testBaker, SinceLevel: 100, Balance: 1_500_000},
+	}))
+	db.SaveOperations([]model.Operation{
+		{SenderAddress: delegator, ContractAddress: testBaker, Entrypoint: "stake", Amount: 1_000_000, Block: "BL1", Timestamp: timestamp, Status: "applied", Type: model.OperationTypeStake},
+	})
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewServer(0, 50, StreamConfig{}, AuthConfig{AdminToken: "admin-token"}, OpenAPIConfig{Validation: OpenAPIValidationEnforce},
+		usecase.QueryCacheConfig{}, cachenoop.New(), db, metricsnoop.New(), logrus.NewEntry(logger)).SetupRoutes()
+}
+
+func Test_Server_openAPIRoutes(t *testing.T) {
+	s := newOpenAPIConformanceServer(t)
+	spec := openapi.MustLoad(specs.OpenAPI)
+
+	routed := make(map[string]bool)
+	for _, route := range s.router.Routes() {
+		if strings.HasPrefix(route.Path, "/debug/pprof") {
+			continue
+		}
+		path := openAPIPath(route.Path)
+		routed[route.Method+" "+path] = true
+		_, ok := spec.Operation(route.Method, path)
+		assert.True(t, ok, "%s %s is missing from specs/openapi.yaml", route.Method, path)
+	}
+	for _, route := range spec.Routes() {
+		assert.True(t, routed[route], "%s is documented in specs/openapi.yaml but not routed", route)
+	}
+}
+
+func Test_Server_openAPIConformance(t *testing.T) {
+	s := newOpenAPIConformanceServer(t)
+	const wallet = "wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL&backer=" + testBaker
+
+	serve := func(method, url, body string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The API key created by the admin subscribes a webhook.
+	admin := map[string]string{"Authorization": "Bearer admin-token", "Content-Type": "application/json"}
+	w := serve("POST", "/admin/api-keys", `{"name":"conformance"}`, admin)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created model.CreatedAPIKey
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	apiKey := map[string]string{"X-API-Key": created.Key, "Content-Type": "application/json"}
+	w = serve("POST", "/xtz/webhooks", `{"url":"https://93.184.215.14/hook","event_types":["delegation"]}`, apiKey)
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	w = serve("POST", "/xtz/webhooks/1/enable", "", apiKey)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	tests := []struct {
+		name           string
+		url            string
+		headers        map[string]string
+		expectedStatus int
+		deprecated     bool
+	}{
+		{name: "nominal case - delegations", url: "/xtz/delegations?year=2024&sort=amount", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - delegations cursor", url: "/xtz/delegations?limit=1", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - delegations CSV", url: "/xtz/delegations", headers: map[string]string{"Accept": "text/csv"}, expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - delegations export", url: "/xtz/delegations/export?format=ndjson", expectedStatus: http.StatusOK},
+		{name: "nominal case - operations", url: "/xtz/operations?" + wallet, expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - operations export", url: "/xtz/operations/export?" + wallet, expectedStatus: http.StatusOK},
+		{name: "nominal case - rewards", url: "/xtz/rewards?" + wallet, expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - rewards export", url: "/xtz/rewards/export?" + wallet, expectedStatus: http.StatusOK},
+		{name: "nominal case - delegation stats", url: "/xtz/stats/delegations?from=2024-01-01&to=2024-12-31", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - baker reward stats", url: "/xtz/stats/rewards/bakers", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - delegator reward stats", url: "/xtz/stats/rewards/delegators", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - bakers", url: "/xtz/bakers?sort=delegators", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - baker", url: "/xtz/bakers/" + testBaker, expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - baker delegators", url: "/xtz/bakers/" + testBaker + "/delegators", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - account", url: "/xtz/accounts/tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - rewards report", url: "/xtz/reports/rewards?wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL&year=2024&currency=eur", expectedStatus: http.StatusOK, deprecated: true},
+		{name: "nominal case - rewards report HTML", url: "/xtz/reports/rewards?wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL&year=2024&currency=EUR&format=html", expectedStatus: http.StatusOK},
+		{name: "nominal case - webhooks", url: "/xtz/webhooks", headers: apiKey, expectedStatus: http.StatusOK},
+		{name: "nominal case - webhook deliveries", url: "/xtz/webhooks/1/deliveries?limit=10", headers: apiKey, expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 delegations", url: "/v1/delegations?year=2024&sort=amount", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 delegations cursor", url: "/v1/delegations?limit=1", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 operations", url: "/v1/operations?" + wallet, expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 rewards", url: "/v1/rewards?" + wallet, expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 bakers", url: "/v1/bakers?sort=delegators", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 baker delegators", url: "/v1/bakers/" + testBaker + "/delegators", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 baker", url: "/v1/bakers/" + testBaker, expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 account", url: "/v1/accounts/tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 delegation stats", url: "/v1/stats/delegations?from=2024-01-01&to=2024-12-31", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 baker reward stats", url: "/v1/stats/rewards/bakers", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 delegator reward stats", url: "/v1/stats/rewards/delegators", expectedStatus: http.StatusOK},
+		{name: "nominal case - v1 rewards report", url: "/v1/reports/rewards?wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL&year=2024&currency=EUR", expectedStatus: http.StatusOK},
+		{name: "nominal case - API keys", url: "/admin/api-keys", headers: admin, expectedStatus: http.StatusOK},
+		{name: "nominal case - health", url: "/health", expectedStatus: http.StatusOK},
+		{name: "nominal case - liveness", url: "/health/live", expectedStatus: http.StatusOK},
+		{name: "nominal case - readiness before start", url: "/health/ready", expectedStatus: http.StatusServiceUnavailable},
+		{name: "nominal case - metrics", url: "/metrics", expectedStatus: http.StatusOK},
+		{name: "nominal case - specification", url: "/openapi.yaml", expectedStatus: http.StatusOK},
+		{name: "nominal case - docs", url: "/docs", expectedStatus: http.StatusOK},
+		{name: "error - unknown account", url: "/xtz/accounts/tz1eY5Aqa1kXDFoiebL28emyXFoneAoVg1zh", expectedStatus: http.StatusNotFound, deprecated: true},
+		{name: "error - missing backer", url: "/xtz/rewards?wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL", expectedStatus: http.StatusBadRequest},
+		{name: "error - limit above maximum", url: "/xtz/delegations?limit=500", expectedStatus: http.StatusBadRequest},
+		{name: "error - unknown parameter", url: "/xtz/delegations?pagee=2", expectedStatus: http.StatusBadRequest},
+		{name: "error - webhooks without API key", url: "/xtz/webhooks", expectedStatus: http.StatusUnauthorized},
+		{name: "error - v1 unknown parameter", url: "/v1/delegations?pagee=2", expectedStatus: http.StatusBadRequest},
+		{name: "error - v1 missing wallet", url: "/v1/rewards?backer=" + testBaker, expectedStatus: http.StatusBadRequest},
+		{name: "error - v1 rewards report format", url: "/v1/reports/rewards?wallet=tz1a1SAaXRt9yoGMx29rh9FsBF4UzmvojdTL&year=2024&currency=EUR&format=csv", expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve("GET", tt.url, "", tt.headers)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Equal(t, tt.deprecated, w.Header().Get("Deprecation") != "")
+		})
+	}
+}
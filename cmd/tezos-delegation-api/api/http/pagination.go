@@ -0,0 +1,29 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cursorParam returns the cursor query parameter, which selects the page after it and cannot be combined with page.
+func cursorParam(c *gin.Context) (string, error) {
+	cursor := c.Query("cursor")
+	if cursor != "" && c.Query("page") != "" {
+		return "", errors.New("cursor and page cannot be used together")
+	}
+	return cursor, nil
+}
+
+// setNextLinkHeader adds a Link header to the request URL of the page after nextCursor, keeping the other query parameters.
+func setNextLinkHeader(c *gin.Context, nextCursor string) {
+	if nextCursor == "" {
+		return
+	}
+
+	query := c.Request.URL.Query()
+	query.Del("page")
+	query.Set("cursor", nextCursor)
+	c.Writer.Header().Add("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, c.Request.URL.Path, query.Encode()))
+}
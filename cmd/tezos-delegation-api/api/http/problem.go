@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tezos-delegation-service/internal/apperror"
+)
+
+// problemContentType is the media type of the RFC 7807 problem details.
+const problemContentType = "application/problem+json"
+
+// internalErrorDetail is the detail of the problems answering an untyped error, whose message is not shown.
+const internalErrorDetail = "internal error"
+
+// Problem is an RFC 7807 problem details response, with the stable code of its error and the id of its request.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id"`
+}
+
+// kindStatuses maps the kinds of errors to the statuses answering them, 500 for the other kinds.
+var kindStatuses = map[apperror.Kind]int{
+	apperror.KindValidation:   http.StatusBadRequest,
+	apperror.KindUnauthorized: http.StatusUnauthorized,
+	apperror.KindForbidden:    http.StatusForbidden,
+	apperror.KindNotFound:     http.StatusNotFound,
+	apperror.KindRateLimited:  http.StatusTooManyRequests,
+	apperror.KindUnavailable:  http.StatusServiceUnavailable,
+	apperror.KindTimeout:      http.StatusGatewayTimeout,
+}
+
+// errorStatus returns the status answering err, from the kind of its typed error.
+func errorStatus(err error) int {
+	if status, ok := kindStatuses[apperror.KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// newProblem describes err to the clients. The detail of a client error is its message, the detail of a server error
+// is only the message of its typed error, without its cause, so that no internal detail is shown.
+func newProblem(status int, err error, requestID string) Problem {
+	detail := err.Error()
+	if status >= http.StatusInternalServerError {
+		detail = internalErrorDetail
+		if e, ok := apperror.As(err); ok {
+			detail = e.Message
+		}
+	}
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      apperror.CodeOf(err),
+		RequestID: requestID,
+	}
+}
+
+// ProblemMiddleware answers the requests aborted with an error as RFC 7807 problems.
+type ProblemMiddleware struct {
+	logger *logrus.Entry
+}
+
+// NewProblemMiddleware creates a new problem middleware.
+func NewProblemMiddleware(logger *logrus.Entry) *ProblemMiddleware {
+	return &ProblemMiddleware{logger: logger}
+}
+
+// Handle answers the last error of a request whose handler wrote no response, with the status of its kind.
+func (m *ProblemMiddleware) Handle(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+	err := c.Errors.Last().Err
+	writeProblem(c, m.logger, errorStatus(err), err)
+}
+
+// abortWithError aborts a request with err, answered by the ProblemMiddleware.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// abortWithInvalidRequest aborts a request whose parameters or body are invalid.
+func abortWithInvalidRequest(c *gin.Context, message string) {
+	abortWithError(c, apperror.New(apperror.KindValidation, apperror.CodeInvalidRequest, message))
+}
+
+// writeProblem answers a request with the problem describing err, logging the server errors with their cause.
+func writeProblem(c *gin.Context, logger *logrus.Entry, status int, err error) {
+	problem := newProblem(status, err, requestID(c))
+	if status >= http.StatusInternalServerError && logger != nil {
+		logger.WithFields(logrus.Fields{
+			"method":     c.Request.Method,
+			"route":      c.FullPath(),
+			"code":       problem.Code,
+			"request_id": problem.RequestID,
+		}).WithError(err).Error("Request failed")
+	}
+
+	body, _ := json.Marshal(problem)
+	c.Header("Content-Type", problemContentType)
+	c.Header("Cache-Control", "no-store")
+	c.Abort()
+	c.Data(status, problemContentType, body)
+}
+
+// requestID returns the id of a request, from the X-Request-ID header of its response or of the request, or a new
+// one then set on the response.
+func requestID(c *gin.Context) string {
+	if id := c.Writer.Header().Get("X-Request-ID"); id != "" {
+		return id
+	}
+	if id := c.GetHeader("X-Request-ID"); id != "" {
+		return id
+	}
+	id := strconv.FormatInt(time.Now().UnixNano(), 36)
+	c.Header("X-Request-ID", id)
+	return id
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/tzktapi"
+	"github.com/tezos-delegation-service/internal/apperror"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// newTestRouter returns a router answering the aborted requests with problems, as the server does.
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewProblemMiddleware(nil).Handle)
+	return router
+}
+
+// answerProblem answers the error a handler called without router aborted its request with, as the
+// ProblemMiddleware does.
+func answerProblem(c *gin.Context) {
+	NewProblemMiddleware(nil).Handle(c)
+}
+
+func Test_ProblemMiddleware_Handle(t *testing.T) {
+	tests := []struct {
+		name            string
+		handler         gin.HandlerFunc
+		requestID       string
+		expectedStatus  int
+		expectedProblem Problem
+		expectedBody    string
+	}{
+		{
+			name: "nominal case",
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ok": true})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name: "nominal case - response written despite the error",
+			handler: func(c *gin.Context) {
+				_ = c.Error(errors.New("ignored"))
+				c.JSON(http.StatusOK, gin.H{"ok": true})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"ok":true}`,
+		},
+		{
+			name: "error - invalid request",
+			handler: func(c *gin.Context) {
+				abortWithInvalidRequest(c, "invalid page number")
+			},
+			requestID:      "req-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: Problem{Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "invalid page number", Code: "invalid_request", RequestID: "req-1"},
+		},
+		{
+			name: "error - wrapped not found",
+			handler: func(c *gin.Context) {
+				abortWithError(c, fmt.Errorf("%w: %s", usecase.ErrBakerNotFound, testBaker))
+			},
+			requestID:      "req-2",
+			expectedStatus: http.StatusNotFound,
+			expectedProblem: Problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound,
+				Detail: "baker not found: " + testBaker, Code: "baker_not_found", RequestID: "req-2"},
+		},
+		{
+			name: "error - invalid cursor",
+			handler: func(c *gin.Context) {
+				abortWithError(c, model.ErrInvalidCursor)
+			},
+			requestID:      "req-3",
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: Problem{Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "invalid cursor", Code: "invalid_cursor", RequestID: "req-3"},
+		},
+		{
+			name: "error - database timeout hides the query",
+			handler: func(c *gin.Context) {
+				abortWithError(c, fmt.Errorf("get delegations: %w", database.NewQueryError("GetDelegations", database.ErrTimeout, context.DeadlineExceeded)))
+			},
+			requestID:      "req-4",
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedProblem: Problem{Type: "about:blank", Title: "Gateway Timeout", Status: http.StatusGatewayTimeout,
+				Detail: "database query timed out", Code: "database_timeout", RequestID: "req-4"},
+		},
+		{
+			name: "error - upstream unavailable",
+			handler: func(c *gin.Context) {
+				abortWithError(c, fmt.Errorf("%w: dial tcp: connection refused", tzktapi.ErrUnavailable))
+			},
+			requestID:      "req-5",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedProblem: Problem{Type: "about:blank", Title: "Service Unavailable", Status: http.StatusServiceUnavailable,
+				Detail: "TzKT API unavailable", Code: "upstream_unavailable", RequestID: "req-5"},
+		},
+		{
+			name: "error - untyped error hides its message",
+			handler: func(c *gin.Context) {
+				c.Header("Cache-Control", "public, max-age=300")
+				abortWithError(c, errors.New("pq: relation \"delegations\" does not exist"))
+			},
+			requestID:      "req-6",
+			expectedStatus: http.StatusInternalServerError,
+			expectedProblem: Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				Detail: "internal error", Code: "internal_error", RequestID: "req-6"},
+		},
+		{
+			name: "error - typed internal error keeps its message only",
+			handler: func(c *gin.Context) {
+				abortWithError(c, apperror.Wrap(errors.New("secret"), apperror.KindInternal, "invalid_response", "response does not match the API specification"))
+			},
+			requestID:      "req-7",
+			expectedStatus: http.StatusInternalServerError,
+			expectedProblem: Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				Detail: "response does not match the API specification", Code: "invalid_response", RequestID: "req-7"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter()
+			router.GET("/test", tt.handler)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if tt.requestID != "" {
+				req.Header.Set("X-Request-ID", tt.requestID)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+				return
+			}
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			var problem Problem
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedProblem, problem)
+		})
+	}
+}
+
+func Test_requestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/", nil)
+	id := requestID(c)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, w.Header().Get("X-Request-ID"))
+	assert.Equal(t, id, requestID(c))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/", nil)
+	c.Request.Header.Set("X-Request-ID", "client-id")
+	assert.Equal(t, "client-id", requestID(c))
+}
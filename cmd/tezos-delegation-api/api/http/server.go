@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/http/pprof"
 	"os"
 	"os/signal"
@@ -12,9 +13,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-api/specs"
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/lru"
 	"github.com/tezos-delegation-service/internal/adapter/database"
 	"github.com/tezos-delegation-service/internal/adapter/metrics"
 	"github.com/tezos-delegation-service/internal/usecase"
+	"github.com/tezos-delegation-service/pkg/openapi"
 )
 
 // handlers holds the HTTP handlers.
@@ -22,6 +27,29 @@ type handlers struct {
 	getDelegationsHandler *GetDelegationsHandler
 	getOperationsHandler  *GetOperationsHandler
 	getRewardsHandler     *GetRewardsHandler
+	getStatsHandler       *GetStatsHandler
+
+	getBakerDelegatorsHandler *GetBakerDelegatorsHandler
+	getAccountProfileHandler  *GetAccountProfileHandler
+	getBakersHandler          *GetBakersHandler
+
+	exportsHandler *ExportsHandler
+
+	getRewardsReportHandler *GetRewardsReportHandler
+
+	streamHandler *StreamHandler
+
+	webhooksHandler *WebhooksHandler
+
+	apiKeysHandler *APIKeysHandler
+	authMiddleware *AuthMiddleware
+
+	conditionalMiddleware *ConditionalMiddleware
+
+	docsHandler       *DocsHandler
+	openAPIMiddleware *OpenAPIMiddleware
+
+	problemMiddleware *ProblemMiddleware
 }
 
 // usecases holds the use case functions.
@@ -29,6 +57,37 @@ type usecases struct {
 	getDelegationsFunc usecase.GetDelegationsFunc
 	getOperationsFunc  usecase.GetOperationsFunc
 	getRewardsFunc     usecase.GetRewardsFunc
+
+	getDelegationStatsFunc      usecase.GetDelegationStatsFunc
+	getBakerRewardStatsFunc     usecase.GetBakerRewardStatsFunc
+	getDelegatorRewardStatsFunc usecase.GetDelegatorRewardStatsFunc
+
+	getBakerDelegatorsFunc usecase.GetBakerDelegatorsFunc
+	getAccountProfileFunc  usecase.GetAccountProfileFunc
+	getBakersFunc          usecase.GetBakersFunc
+	getBakerFunc           usecase.GetBakerFunc
+
+	exportDelegationsFunc usecase.ExportDelegationsFunc
+	exportOperationsFunc  usecase.ExportOperationsFunc
+	exportRewardsFunc     usecase.ExportRewardsFunc
+
+	getRewardsReportFunc usecase.GetRewardsReportFunc
+
+	subscribeDelegationsFunc usecase.SubscribeDelegationsFunc
+
+	createWebhookFunc        usecase.CreateWebhookFunc
+	getWebhooksFunc          usecase.GetWebhooksFunc
+	deleteWebhookFunc        usecase.DeleteWebhookFunc
+	enableWebhookFunc        usecase.EnableWebhookFunc
+	getWebhookDeliveriesFunc usecase.GetWebhookDeliveriesFunc
+
+	createAPIKeyFunc       usecase.CreateAPIKeyFunc
+	getAPIKeysFunc         usecase.GetAPIKeysFunc
+	revokeAPIKeyFunc       usecase.RevokeAPIKeyFunc
+	authenticateAPIKeyFunc usecase.AuthenticateAPIKeyFunc
+	countAPIKeyRequestFunc usecase.CountAPIKeyRequestFunc
+
+	getLastModifiedFunc usecase.GetLastModifiedFunc
 }
 
 // Server represents the HTTP server.
@@ -39,29 +98,100 @@ type Server struct {
 	port          uint16
 	router        *gin.Engine
 	handlers      *handlers
+
+	delegationStream *usecase.DelegationStream
+	queryCache       *usecase.QueryCache
+	stopStream       context.CancelFunc
 }
 
 // NewServer creates a new HTTP server.
-func NewServer(port, defaultPaginationLimit uint16, dbAdapter database.Adapter, metricClient metrics.Adapter, logger *logrus.Entry) *Server {
+func NewServer(port, defaultPaginationLimit uint16, streamCfg StreamConfig, authCfg AuthConfig, openAPICfg OpenAPIConfig, cacheCfg usecase.QueryCacheConfig, cacheAdapter cache.Adapter, dbAdapter database.Adapter, metricClient metrics.Adapter, logger *logrus.Entry) *Server {
+	delegationStream := usecase.NewDelegationStream(dbAdapter, metricClient, streamCfg.DelegationStreamConfig)
+	queryCache := usecase.NewQueryCache(cacheAdapter, dbAdapter, metricClient, cacheCfg)
+
 	u := &usecases{
-		getDelegationsFunc: usecase.NewGetDelegationsFunc(defaultPaginationLimit, dbAdapter, metricClient),
-		getOperationsFunc:  usecase.NewGetOperationsFunc(defaultPaginationLimit, dbAdapter, metricClient),
-		getRewardsFunc:     usecase.NewGetRewardsFunc(defaultPaginationLimit, dbAdapter, metricClient),
+		getDelegationsFunc: queryCache.CacheGetDelegations(usecase.NewGetDelegationsFunc(defaultPaginationLimit, dbAdapter, metricClient)),
+		getOperationsFunc:  queryCache.CacheGetOperations(usecase.NewGetOperationsFunc(defaultPaginationLimit, dbAdapter, metricClient)),
+		getRewardsFunc:     queryCache.CacheGetRewards(usecase.NewGetRewardsFunc(defaultPaginationLimit, dbAdapter, metricClient)),
+
+		getDelegationStatsFunc:      usecase.NewGetDelegationStatsFunc(dbAdapter, metricClient),
+		getBakerRewardStatsFunc:     usecase.NewGetBakerRewardStatsFunc(dbAdapter, metricClient),
+		getDelegatorRewardStatsFunc: usecase.NewGetDelegatorRewardStatsFunc(dbAdapter, metricClient),
+
+		getBakerDelegatorsFunc: usecase.NewGetBakerDelegatorsFunc(defaultPaginationLimit, dbAdapter, metricClient),
+		getAccountProfileFunc:  usecase.NewGetAccountProfileFunc(dbAdapter, metricClient),
+		getBakersFunc:          usecase.NewGetBakersFunc(defaultPaginationLimit, dbAdapter, metricClient),
+		getBakerFunc:           usecase.NewGetBakerFunc(dbAdapter, metricClient),
+
+		exportDelegationsFunc: usecase.NewExportDelegationsFunc(dbAdapter, metricClient),
+		exportOperationsFunc:  usecase.NewExportOperationsFunc(dbAdapter, metricClient),
+		exportRewardsFunc:     usecase.NewExportRewardsFunc(dbAdapter, metricClient),
+
+		getRewardsReportFunc: usecase.NewGetRewardsReportFunc(dbAdapter, metricClient),
+
+		subscribeDelegationsFunc: delegationStream.Subscribe,
+
+		createWebhookFunc:        usecase.NewCreateWebhookFunc(dbAdapter, metricClient),
+		getWebhooksFunc:          usecase.NewGetWebhooksFunc(dbAdapter, metricClient),
+		deleteWebhookFunc:        usecase.NewDeleteWebhookFunc(dbAdapter, metricClient),
+		enableWebhookFunc:        usecase.NewEnableWebhookFunc(dbAdapter, metricClient),
+		getWebhookDeliveriesFunc: usecase.NewGetWebhookDeliveriesFunc(dbAdapter, metricClient),
+
+		createAPIKeyFunc:       usecase.NewCreateAPIKeyFunc(dbAdapter, metricClient),
+		getAPIKeysFunc:         usecase.NewGetAPIKeysFunc(dbAdapter, metricClient),
+		revokeAPIKeyFunc:       usecase.NewRevokeAPIKeyFunc(dbAdapter, metricClient),
+		authenticateAPIKeyFunc: usecase.NewAuthenticateAPIKeyFunc(authCfg.KeyCacheTTL, lru.New(lru.Config{MaxEntries: maxCachedUnknownAPIKeys}), dbAdapter, metricClient),
+		countAPIKeyRequestFunc: usecase.NewCountAPIKeyRequestFunc(dbAdapter, metricClient),
+
+		getLastModifiedFunc: usecase.NewGetLastModifiedFunc(lastModifiedCacheTTL, dbAdapter, metricClient),
 	}
 
 	h := &handlers{
 		getDelegationsHandler: NewGetDelegationsHandler(defaultPaginationLimit, u.getDelegationsFunc),
 		getOperationsHandler:  NewGetOperationsHandler(defaultPaginationLimit, u.getOperationsFunc),
 		getRewardsHandler:     NewGetRewardsHandler(defaultPaginationLimit, u.getRewardsFunc),
+		getStatsHandler:       NewGetStatsHandler(u.getDelegationStatsFunc, u.getBakerRewardStatsFunc, u.getDelegatorRewardStatsFunc),
+
+		getBakerDelegatorsHandler: NewGetBakerDelegatorsHandler(u.getBakerDelegatorsFunc),
+		getAccountProfileHandler:  NewGetAccountProfileHandler(u.getAccountProfileFunc),
+		getBakersHandler:          NewGetBakersHandler(u.getBakersFunc, u.getBakerFunc),
+
+		exportsHandler: NewExportsHandler(defaultPaginationLimit, u.exportDelegationsFunc, u.exportOperationsFunc, u.exportRewardsFunc),
+
+		getRewardsReportHandler: NewGetRewardsReportHandler(u.getRewardsReportFunc),
+
+		streamHandler: NewStreamHandler(streamCfg, u.subscribeDelegationsFunc),
+
+		webhooksHandler: NewWebhooksHandler(u.createWebhookFunc, u.getWebhooksFunc, u.deleteWebhookFunc, u.enableWebhookFunc, u.getWebhookDeliveriesFunc),
+
+		apiKeysHandler: NewAPIKeysHandler(u.createAPIKeyFunc, u.getAPIKeysFunc, u.revokeAPIKeyFunc),
+		authMiddleware: NewAuthMiddleware(authCfg, u.authenticateAPIKeyFunc, u.countAPIKeyRequestFunc, metricClient),
+
+		conditionalMiddleware: NewConditionalMiddleware(u.getLastModifiedFunc),
+
+		docsHandler:       NewDocsHandler(specs.OpenAPI),
+		openAPIMiddleware: NewOpenAPIMiddleware(openAPICfg, openapi.MustLoad(specs.OpenAPI), logger),
+
+		problemMiddleware: NewProblemMiddleware(logger),
+	}
+
+	// The client IP is read from X-Forwarded-For only when the peer is a trusted proxy, so that a client cannot pick
+	// the bucket of its anonymous rate limit. An invalid list trusts no proxy.
+	router := gin.Default()
+	if err := router.SetTrustedProxies(authCfg.TrustedProxies); err != nil {
+		logger.Errorf("Invalid auth.trusted_proxies, trusting no proxy: %v", err)
+		_ = router.SetTrustedProxies(nil)
 	}
 
 	return &Server{
-		healthService: NewHealthService(dbAdapter),
-		handlers:      h,
-		logger:        logger,
-		metrics:       metricClient,
-		port:          port,
-		router:        gin.Default(),
+		healthService:    NewHealthService(dbAdapter),
+		handlers:         h,
+		delegationStream: delegationStream,
+		queryCache:       queryCache,
+		logger:           logger,
+		metrics:          metricClient,
+		port:             port,
+		router:           router,
 	}
 }
 
@@ -69,8 +199,9 @@ func NewServer(port, defaultPaginationLimit uint16, dbAdapter database.Adapter,
 func (s *Server) corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Max-Delegation-ID, X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Max-Delegation-ID, X-Request-ID, Last-Event-ID, If-None-Match, If-Modified-Since")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Last-Modified, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-RateLimit-Daily-Limit, X-RateLimit-Daily-Remaining, Retry-After, Deprecation, Link")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -87,11 +218,65 @@ func (s *Server) SetupRoutes() *Server {
 
 	s.router.Use(metrics.Middleware(s.metrics))
 
-	xtzGroup := s.router.Group("/xtz")
+	s.router.Use(s.handlers.openAPIMiddleware.Validate)
+
+	s.router.Use(s.handlers.problemMiddleware.Handle)
+
+	// The legacy /xtz routes keep their response shape, those with a /v1 successor are deprecated.
+	xtzGroup := s.router.Group("/xtz", s.handlers.authMiddleware.Authenticate, s.handlers.conditionalMiddleware.Handle)
 	{
-		xtzGroup.GET("/delegations", s.handlers.getDelegationsHandler.GetDelegations)
-		xtzGroup.GET("/operations", s.handlers.getOperationsHandler.GetOperations)
-		xtzGroup.GET("/rewards", s.handlers.getRewardsHandler.GetRewards)
+		xtzGroup.GET("/delegations", s.handlers.conditionalMiddleware.LastModified, deprecateLegacy, negotiateExport(s.handlers.exportsHandler.ExportDelegations, s.handlers.getDelegationsHandler.GetDelegations))
+		xtzGroup.GET("/operations", deprecateLegacy, negotiateExport(s.handlers.exportsHandler.ExportOperations, s.handlers.getOperationsHandler.GetOperations))
+		xtzGroup.GET("/rewards", deprecateLegacy, negotiateExport(s.handlers.exportsHandler.ExportRewards, s.handlers.getRewardsHandler.GetRewards))
+		xtzGroup.GET("/delegations/export", s.handlers.exportsHandler.ExportDelegations)
+		xtzGroup.GET("/operations/export", s.handlers.exportsHandler.ExportOperations)
+		xtzGroup.GET("/rewards/export", s.handlers.exportsHandler.ExportRewards)
+
+		statsGroup := xtzGroup.Group("/stats", deprecateLegacy)
+		statsGroup.GET("/delegations", s.handlers.getStatsHandler.GetDelegationStats)
+		statsGroup.GET("/rewards/bakers", s.handlers.getStatsHandler.GetBakerRewardStats)
+		statsGroup.GET("/rewards/delegators", s.handlers.getStatsHandler.GetDelegatorRewardStats)
+
+		xtzGroup.GET("/bakers", deprecateLegacy, s.handlers.getBakersHandler.GetBakers)
+		xtzGroup.GET("/bakers/:address", deprecateLegacy, s.handlers.getBakersHandler.GetBaker)
+		xtzGroup.GET("/bakers/:address/delegators", deprecateLegacy, s.handlers.getBakerDelegatorsHandler.GetBakerDelegators)
+		xtzGroup.GET("/accounts/:address", deprecateLegacy, s.handlers.getAccountProfileHandler.GetAccountProfile)
+
+		xtzGroup.GET("/reports/rewards", s.handlers.getRewardsReportHandler.deprecateJSON, s.handlers.getRewardsReportHandler.GetRewardsReport)
+
+		xtzGroup.GET("/stream", s.handlers.streamHandler.Stream)
+
+		webhooksGroup := xtzGroup.Group("/webhooks", s.handlers.authMiddleware.RequireAPIKey)
+		webhooksGroup.POST("", s.handlers.webhooksHandler.CreateWebhook)
+		webhooksGroup.GET("", s.handlers.webhooksHandler.GetWebhooks)
+		webhooksGroup.DELETE("/:id", s.handlers.webhooksHandler.DeleteWebhook)
+		webhooksGroup.POST("/:id/enable", s.handlers.webhooksHandler.EnableWebhook)
+		webhooksGroup.GET("/:id/deliveries", s.handlers.webhooksHandler.GetWebhookDeliveries)
+	}
+
+	v1Group := s.router.Group("/v1", s.handlers.authMiddleware.Authenticate, s.handlers.conditionalMiddleware.Handle)
+	{
+		v1Group.GET("/delegations", s.handlers.conditionalMiddleware.LastModified, s.handlers.getDelegationsHandler.GetDelegationsV1)
+		v1Group.GET("/operations", s.handlers.getOperationsHandler.GetOperationsV1)
+		v1Group.GET("/rewards", s.handlers.getRewardsHandler.GetRewardsV1)
+		v1Group.GET("/bakers", s.handlers.getBakersHandler.GetBakersV1)
+		v1Group.GET("/bakers/:address", s.handlers.getBakersHandler.GetBakerV1)
+		v1Group.GET("/bakers/:address/delegators", s.handlers.getBakerDelegatorsHandler.GetBakerDelegatorsV1)
+		v1Group.GET("/accounts/:address", s.handlers.getAccountProfileHandler.GetAccountProfileV1)
+
+		v1Group.GET("/reports/rewards", s.handlers.getRewardsReportHandler.GetRewardsReportV1)
+
+		v1StatsGroup := v1Group.Group("/stats")
+		v1StatsGroup.GET("/delegations", s.handlers.getStatsHandler.GetDelegationStatsV1)
+		v1StatsGroup.GET("/rewards/bakers", s.handlers.getStatsHandler.GetBakerRewardStatsV1)
+		v1StatsGroup.GET("/rewards/delegators", s.handlers.getStatsHandler.GetDelegatorRewardStatsV1)
+	}
+
+	adminGroup := s.router.Group("/admin", s.handlers.authMiddleware.RequireAdmin)
+	{
+		adminGroup.POST("/api-keys", s.handlers.apiKeysHandler.CreateAPIKey)
+		adminGroup.GET("/api-keys", s.handlers.apiKeysHandler.GetAPIKeys)
+		adminGroup.DELETE("/api-keys/:id", s.handlers.apiKeysHandler.RevokeAPIKey)
 	}
 
 	healthGroup := s.router.Group("/health")
@@ -117,14 +302,35 @@ func (s *Server) SetupRoutes() *Server {
 	}
 
 	s.router.GET("/metrics", metrics.PrometheusHandler())
+
+	s.router.GET("/openapi.yaml", s.handlers.docsHandler.GetSpec)
+	s.router.GET("/docs", s.handlers.docsHandler.GetDocs)
 	return s
 }
 
-// Start starts the HTTP server.
+// Start starts the live delegation stream, the invalidation of the query cache and the HTTP server.
 func (s *Server) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopStream = cancel
+	go func() {
+		if err := s.delegationStream.Run(ctx); err != nil {
+			s.logger.Errorf("Live delegation stream stopped: %v", err)
+		}
+	}()
+	go func() {
+		if err := s.queryCache.Run(ctx); err != nil {
+			s.logger.Errorf("Query cache invalidation stopped, live results expire after their TTL: %v", err)
+		}
+	}()
+
 	s.healthService.SetReady(true)
 	s.logger.Infof("Tezos Delegation API Server starting on port %d...", s.port)
-	return s.router.Run(fmt.Sprintf(":%d", s.port))
+	server := &http.Server{
+		Addr:        fmt.Sprintf(":%d", s.port),
+		Handler:     s.router,
+		ConnContext: withConn,
+	}
+	return server.ListenAndServe()
 }
 
 // WaitForShutdown waits for a shutdown signal and initiates graceful shutdown.
@@ -137,6 +343,9 @@ func (s *Server) WaitForShutdown() {
 
 	l.Info("Shutting down Tezos Delegation service server...")
 	s.healthService.StartShutdown()
+	if s.stopStream != nil {
+		s.stopStream()
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -8,16 +8,24 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	cachenoop "github.com/tezos-delegation-service/internal/adapter/cache/impl/noop"
 	"github.com/tezos-delegation-service/internal/adapter/database"
 	databaseadaptermock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
 	"github.com/tezos-delegation-service/internal/adapter/metrics"
 	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/usecase"
 )
 
 func Test_NewServer(t *testing.T) {
 	type args struct {
 		port         uint16
 		defaultLimit uint16
+		streamCfg    StreamConfig
+		authCfg      AuthConfig
+		openAPICfg   OpenAPIConfig
+		cacheCfg     usecase.QueryCacheConfig
+		cacheAdapter cache.Adapter
 		dbAdapter    database.Adapter
 		metricClient metrics.Adapter
 		logger       *logrus.Entry
@@ -37,6 +45,7 @@ func Test_NewServer(t *testing.T) {
 			args: args{
 				port:         8080,
 				defaultLimit: 50,
+				cacheAdapter: cachenoop.New(),
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -48,6 +57,14 @@ func Test_NewServer(t *testing.T) {
 				assert.NotNil(t, s.router)
 				assert.NotNil(t, s.handlers)
 				assert.NotNil(t, s.handlers.getDelegationsHandler)
+				assert.NotNil(t, s.handlers.streamHandler)
+				assert.NotNil(t, s.handlers.authMiddleware)
+				assert.NotNil(t, s.handlers.conditionalMiddleware)
+				assert.NotNil(t, s.handlers.docsHandler)
+				assert.NotNil(t, s.handlers.openAPIMiddleware)
+				assert.NotNil(t, s.handlers.problemMiddleware)
+				assert.NotNil(t, s.delegationStream)
+				assert.NotNil(t, s.queryCache)
 				assert.Equal(t, logger, s.logger)
 				assert.Equal(t, mockMetrics, s.metrics)
 			},
@@ -57,6 +74,7 @@ func Test_NewServer(t *testing.T) {
 			args: args{
 				port:         9090,
 				defaultLimit: 50,
+				cacheAdapter: cachenoop.New(),
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -70,6 +88,7 @@ func Test_NewServer(t *testing.T) {
 			args: args{
 				port:         8080,
 				defaultLimit: 50,
+				cacheAdapter: cachenoop.New(),
 				dbAdapter:    nil,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -84,6 +103,7 @@ func Test_NewServer(t *testing.T) {
 			args: args{
 				port:         8080,
 				defaultLimit: 50,
+				cacheAdapter: cachenoop.New(),
 				dbAdapter:    mockDB,
 				metricClient: nil,
 				logger:       logger,
@@ -98,6 +118,7 @@ func Test_NewServer(t *testing.T) {
 			args: args{
 				port:         8080,
 				defaultLimit: 50,
+				cacheAdapter: cachenoop.New(),
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       nil,
@@ -111,7 +132,7 @@ func Test_NewServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := NewServer(tt.args.port, tt.args.defaultLimit, tt.args.dbAdapter, tt.args.metricClient, tt.args.logger)
+			server := NewServer(tt.args.port, tt.args.defaultLimit, tt.args.streamCfg, tt.args.authCfg, tt.args.openAPICfg, tt.args.cacheCfg, tt.args.cacheAdapter, tt.args.dbAdapter, tt.args.metricClient, tt.args.logger)
 			tt.check(t, server)
 		})
 	}
@@ -162,10 +183,26 @@ func Test_Server_SetupRoutes(t *testing.T) {
 				}
 
 				assert.True(t, routePaths["/xtz/delegations"])
+				assert.True(t, routePaths["/xtz/delegations/export"])
+				assert.True(t, routePaths["/xtz/operations/export"])
+				assert.True(t, routePaths["/xtz/rewards/export"])
+				assert.True(t, routePaths["/xtz/stream"])
+				assert.True(t, routePaths["/xtz/webhooks"])
+				assert.True(t, routePaths["/xtz/webhooks/:id"])
+				assert.True(t, routePaths["/xtz/webhooks/:id/deliveries"])
+				assert.True(t, routePaths["/v1/delegations"])
+				assert.True(t, routePaths["/v1/operations"])
+				assert.True(t, routePaths["/v1/rewards"])
+				assert.True(t, routePaths["/v1/bakers"])
+				assert.True(t, routePaths["/v1/bakers/:address/delegators"])
+				assert.True(t, routePaths["/admin/api-keys"])
+				assert.True(t, routePaths["/admin/api-keys/:id"])
 				assert.True(t, routePaths["/health"])
 				assert.True(t, routePaths["/health/live"])
 				assert.True(t, routePaths["/health/ready"])
 				assert.True(t, routePaths["/metrics"])
+				assert.True(t, routePaths["/openapi.yaml"])
+				assert.True(t, routePaths["/docs"])
 			},
 		},
 		{
@@ -0,0 +1,259 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/apperror"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const (
+	contentTypeEventStream = "text/event-stream"
+
+	defaultStreamHeartbeatInterval = 15 * time.Second
+	defaultStreamWriteTimeout      = 10 * time.Second
+
+	// streamRetry is the reconnection delay advised to the SSE clients, in milliseconds.
+	streamRetry = 3000
+)
+
+// StreamConfig configures the live delegation stream, zero values select the defaults.
+type StreamConfig struct {
+	usecase.DelegationStreamConfig `mapstructure:",squash"`
+
+	// HeartbeatInterval is the delay between two keep-alive messages: SSE comments or WebSocket pings.
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	// WriteTimeout bounds every write, a client not reading its connection is disconnected.
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+}
+
+// connContextKey is the context key of the connection of a request, set by the HTTP server.
+type connContextKey struct{}
+
+// withConn stores the connection of the requests in their context, so that streams can bound their writes.
+func withConn(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+// StreamHandler handles the live delegation stream, over Server-Sent Events or WebSocket.
+type StreamHandler struct {
+	subscribeDelegationsFunc usecase.SubscribeDelegationsFunc
+	heartbeatInterval        time.Duration
+	writeTimeout             time.Duration
+}
+
+// NewStreamHandler creates a new stream handler.
+func NewStreamHandler(cfg StreamConfig, subscribeDelegationsFunc usecase.SubscribeDelegationsFunc) *StreamHandler {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = defaultStreamHeartbeatInterval
+	}
+	if cfg.WriteTimeout <= 0 {
+		cfg.WriteTimeout = defaultStreamWriteTimeout
+	}
+	return &StreamHandler{
+		subscribeDelegationsFunc: subscribeDelegationsFunc,
+		heartbeatInterval:        cfg.HeartbeatInterval,
+		writeTimeout:             cfg.WriteTimeout,
+	}
+}
+
+// Stream handles GET /xtz/stream requests, upgraded to WebSocket when asked, answered with Server-Sent Events otherwise.
+func (h *StreamHandler) Stream(c *gin.Context) {
+	input, err := h.streamInput(c)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+
+	if isWebSocketUpgrade(c.Request) {
+		h.streamWebSocket(c, input)
+		return
+	}
+	h.streamEvents(c, input)
+}
+
+// streamInput parses the baker, delegator and kind filters, and the id of the last event received by the client
+// from the Last-Event-ID header or, for the clients unable to set it, the last_event_id query parameter.
+func (h *StreamHandler) streamInput(c *gin.Context) (usecase.SubscribeDelegationsInput, error) {
+	var input usecase.SubscribeDelegationsInput
+
+	if baker := model.WalletAddress(c.Query("baker")); baker != "" {
+		if !baker.IsValid() {
+			return input, fmt.Errorf("invalid baker address: %s", baker.String())
+		}
+		input.Filter.Delegate = baker
+	}
+	if delegator := model.WalletAddress(c.Query("delegator")); delegator != "" {
+		if !delegator.IsValid() {
+			return input, fmt.Errorf("invalid delegator address: %s", delegator.String())
+		}
+		input.Filter.Delegator = delegator
+	}
+	if kind := model.DelegationKind(c.Query("kind")); kind != "" {
+		if !kind.IsValid() {
+			return input, fmt.Errorf("invalid 'kind': %s, use delegation or undelegation", kind)
+		}
+		input.Filter.Kind = kind
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			return input, fmt.Errorf("invalid last event id: %s", lastEventID)
+		}
+		input.LastEventID = id
+	}
+
+	return input, nil
+}
+
+// streamEvents streams the events as Server-Sent Events, named after their kind, with a comment as heartbeat.
+// The stream ends with an error event when the subscription ends early, the client reconnects with its last event id.
+func (h *StreamHandler) streamEvents(c *gin.Context, input usecase.SubscribeDelegationsInput) {
+	ctx := c.Request.Context()
+	subscription, err := h.subscribeDelegationsFunc(ctx, input)
+	if err != nil {
+		h.abortSubscription(c, err)
+		return
+	}
+	defer subscription.Close()
+
+	conn, _ := ctx.Value(connContextKey{}).(net.Conn)
+	if conn != nil {
+		defer func() { _ = conn.SetWriteDeadline(time.Time{}) }()
+	}
+	write := func(message string) error {
+		if conn != nil {
+			if err := conn.SetWriteDeadline(time.Now().Add(h.writeTimeout)); err != nil {
+				return err
+			}
+		}
+		if _, err := c.Writer.WriteString(message); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	c.Header("Content-Type", contentTypeEventStream)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // proxies must not buffer the stream
+	c.Status(http.StatusOK)
+	if err := write(fmt.Sprintf("retry: %d\n\n", streamRetry)); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			if !ok {
+				if err := subscription.Err(); err != nil {
+					data, _ := json.Marshal(newProblem(errorStatus(err), err, requestID(c)))
+					_ = write(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+				}
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			if err := write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamWebSocket streams the events as WebSocket text messages, with a ping as heartbeat.
+// A client not answering the pings is disconnected, and the connection is closed with a status telling why the
+// subscription ended early.
+func (h *StreamHandler) streamWebSocket(c *gin.Context, input usecase.SubscribeDelegationsInput) {
+	if status, err := checkWebSocketHandshake(c.Request); err != nil {
+		if status == http.StatusUpgradeRequired {
+			c.Header("Sec-WebSocket-Version", "13")
+		}
+		writeProblem(c, nil, status, apperror.New(apperror.KindValidation, "invalid_websocket_handshake", err.Error()))
+		return
+	}
+
+	// The request context is canceled once the handler returns, which ends the subscription.
+	subscription, err := h.subscribeDelegationsFunc(c.Request.Context(), input)
+	if err != nil {
+		h.abortSubscription(c, err)
+		return
+	}
+	defer subscription.Close()
+
+	ws, err := upgradeWebSocket(c, h.writeTimeout)
+	if err != nil {
+		return
+	}
+	clientGone := ws.ReadLoop(2*h.heartbeatInterval + h.writeTimeout)
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-clientGone:
+			_ = ws.conn.Close()
+			return
+		case <-heartbeat.C:
+			if err := ws.Ping(); err != nil {
+				_ = ws.conn.Close()
+				return
+			}
+		case event, ok := <-subscription.Events():
+			if !ok {
+				code, reason := wsCloseGoingAway, "stream closed"
+				switch err := subscription.Err(); {
+				case errors.Is(err, usecase.ErrSubscriberTooSlow):
+					code, reason = wsCloseTryAgainLater, err.Error()
+				case err != nil && !errors.Is(err, usecase.ErrStreamClosed):
+					code, reason = wsCloseInternalError, newProblem(http.StatusInternalServerError, err, "").Detail
+				}
+				_ = ws.Close(code, reason)
+				return
+			}
+			data, err := json.Marshal(event)
+			if err == nil {
+				err = ws.WriteText(data)
+			}
+			if err != nil {
+				_ = ws.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// abortSubscription answers a refused subscription, advising the client to retry later when the stream is busy.
+func (h *StreamHandler) abortSubscription(c *gin.Context, err error) {
+	if errorStatus(err) == http.StatusServiceUnavailable {
+		c.Header("Retry-After", strconv.Itoa(streamRetry/1000))
+	}
+	abortWithError(c, err)
+}
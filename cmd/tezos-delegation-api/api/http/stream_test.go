@@ -0,0 +1,227 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// newStreamTestServer serves /xtz/stream from a delegation stream over an in-memory database until the test ends.
+func newStreamTestServer(t *testing.T) (*httptest.Server, *databasememory.Memory) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db := databasememory.New()
+	stream := usecase.NewDelegationStream(db, metricsnoop.New(), usecase.DelegationStreamConfig{PollInterval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { _ = stream.Run(ctx) }()
+
+	router := newTestRouter()
+	router.GET("/xtz/stream", NewStreamHandler(StreamConfig{}, stream.Subscribe).Stream)
+	server := httptest.NewUnstartedServer(router)
+	server.Config.ConnContext = withConn
+	server.Start()
+
+	t.Cleanup(func() {
+		cancel()
+		server.Close()
+	})
+	return server, db
+}
+
+func Test_StreamHandler_Stream_SSE(t *testing.T) {
+	server, db := newStreamTestServer(t)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/xtz/stream?baker="+testBaker, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, contentTypeEventStream, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	assert.NoError(t, db.SaveDelegations(context.Background(), []*model.Delegation{
+		{Delegator: testDelegator, Delegate: "tz1other", Timestamp: 1704067200, Amount: 10, Level: 10},
+		{Delegator: testDelegator, Delegate: testBaker, Timestamp: 1704067260, Amount: 1500000, Level: 11},
+	}))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, "retry: 3000\n", readLine(t, reader))
+	assert.Equal(t, "\n", readLine(t, reader))
+	assert.Equal(t, "id: 2\n", readLine(t, reader))
+	assert.Equal(t, "event: delegation\n", readLine(t, reader))
+
+	data := readLine(t, reader)
+	var event model.DelegationEvent
+	assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &event))
+	assert.Equal(t, int64(2), event.ID)
+	assert.Equal(t, model.DelegationKindDelegation, event.Kind)
+	assert.Equal(t, model.WalletAddress(testBaker), event.Delegation.Delegate)
+	assert.Equal(t, "2024-01-01T00:01:00Z", event.Delegation.TimestampTime)
+}
+
+func Test_StreamHandler_Stream_WebSocket(t *testing.T) {
+	server, db := newStreamTestServer(t)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial error = %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// Handshake example of RFC 6455 section 1.3.
+	_, err = fmt.Fprintf(conn, "GET /xtz/stream?kind=undelegation HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	assert.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("handshake error = %v", err)
+	}
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	assert.NoError(t, db.SaveDelegations(context.Background(), []*model.Delegation{
+		{Delegator: testDelegator, Delegate: testBaker, Timestamp: 1704067200, Level: 10},
+		{Delegator: testDelegator, Delegate: "", Timestamp: 1704067260, Level: 11},
+	}))
+
+	opcode, payload := readServerFrame(t, reader)
+	assert.Equal(t, byte(wsOpText), opcode)
+	var event model.DelegationEvent
+	assert.NoError(t, json.Unmarshal(payload, &event))
+	assert.Equal(t, int64(2), event.ID)
+	assert.Equal(t, model.DelegationKindUndelegation, event.Kind)
+
+	// A masked close frame with status 1000 is echoed by the server.
+	mask := []byte{1, 2, 3, 4}
+	closeFrame := []byte{0x88, 0x82}
+	closeFrame = append(closeFrame, mask...)
+	closeFrame = append(closeFrame, 0x03^mask[0], 0xE8^mask[1])
+	_, err = conn.Write(closeFrame)
+	assert.NoError(t, err)
+
+	opcode, payload = readServerFrame(t, reader)
+	assert.Equal(t, byte(wsOpClose), opcode)
+	assert.Equal(t, []byte{0x03, 0xE8}, payload)
+}
+
+func Test_StreamHandler_Stream_errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		query      string
+		header     http.Header
+		subscribe  usecase.SubscribeDelegationsFunc
+		wantStatus int
+		wantHeader http.Header
+	}{
+		{
+			name:       "Error case - invalid baker",
+			query:      "baker=invalid",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Error case - invalid kind",
+			query:      "kind=transfer",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Error case - invalid last event id",
+			header:     http.Header{"Last-Event-Id": {"last"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Error case - unsupported websocket version",
+			header:     http.Header{"Connection": {"Upgrade"}, "Upgrade": {"websocket"}, "Sec-Websocket-Version": {"8"}, "Sec-Websocket-Key": {"dGhlIHNhbXBsZSBub25jZQ=="}},
+			wantStatus: http.StatusUpgradeRequired,
+			wantHeader: http.Header{"Sec-Websocket-Version": {"13"}},
+		},
+		{
+			name: "Error case - too many subscribers",
+			subscribe: func(ctx context.Context, input usecase.SubscribeDelegationsInput) (*usecase.DelegationSubscription, error) {
+				return nil, usecase.ErrTooManySubscribers
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantHeader: http.Header{"Retry-After": {"3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStreamHandler(StreamConfig{}, tt.subscribe)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/xtz/stream?"+tt.query, nil)
+			for name, values := range tt.header {
+				c.Request.Header[name] = values
+			}
+
+			h.Stream(c)
+			answerProblem(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+			for name, values := range tt.wantHeader {
+				assert.Equal(t, values, w.Header()[name])
+			}
+		})
+	}
+}
+
+// readLine reads a line of an event stream.
+func readLine(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	return line
+}
+
+// readServerFrame reads an unmasked frame sent by the server.
+func readServerFrame(t *testing.T, reader *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, _ = io.ReadFull(reader, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, _ = io.ReadFull(reader, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	return header[0] & 0x0F, payload
+}
@@ -0,0 +1,399 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// apiVersion is the version of the /v1 routes, sent in the meta of their responses.
+const apiVersion = "v1"
+
+// legacyDeprecation is the date the /xtz routes with a /v1 successor were deprecated.
+var legacyDeprecation = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+// Envelope is the body of the /v1 responses.
+type Envelope[T any] struct {
+	Data       T           `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Meta       Meta        `json:"meta"`
+}
+
+// Pagination describes the page of a /v1 collection and how to get the next one.
+type Pagination struct {
+	// Page is the page number, omitted when the page is selected by a cursor.
+	Page       int    `json:"page,omitempty"`
+	PerPage    int    `json:"per_page"`
+	HasNext    bool   `json:"has_next"`
+	PrevPage   int    `json:"prev_page,omitempty"`
+	NextPage   int    `json:"next_page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Meta describes a /v1 response. It only holds what the data depends on, so that the response keeps its ETag.
+type Meta struct {
+	Version   string `json:"version"`
+	AsOfLevel int64  `json:"as_of_level,omitempty"`
+	AsOfCycle int    `json:"as_of_cycle,omitempty"`
+}
+
+// V1Delegation is a delegation in the /v1 responses.
+type V1Delegation struct {
+	Kind      model.DelegationKind `json:"kind"`
+	Delegator model.WalletAddress  `json:"delegator"`
+	Delegate  model.WalletAddress  `json:"delegate,omitempty"`
+	Amount    model.Mutez          `json:"amount"`
+	Level     int64                `json:"level"`
+	Timestamp string               `json:"timestamp"`
+}
+
+// V1Operation is a staking operation in the /v1 responses.
+type V1Operation struct {
+	ID         int64               `json:"id"`
+	Type       model.OperationType `json:"type"`
+	Sender     model.WalletAddress `json:"sender"`
+	Contract   model.WalletAddress `json:"contract"`
+	Entrypoint string              `json:"entrypoint"`
+	Amount     model.Mutez         `json:"amount"`
+	Block      string              `json:"block"`
+	Status     string              `json:"status"`
+	Timestamp  string              `json:"timestamp"`
+}
+
+// V1Reward is a reward payment in the /v1 responses.
+type V1Reward struct {
+	ID        int64               `json:"id"`
+	Recipient model.WalletAddress `json:"recipient"`
+	Source    model.WalletAddress `json:"source"`
+	Cycle     int                 `json:"cycle"`
+	Amount    model.Mutez         `json:"amount"`
+	Timestamp string              `json:"timestamp"`
+}
+
+// V1Baker is a baker of the directory in the /v1 responses.
+type V1Baker struct {
+	Address         model.WalletAddress `json:"address"`
+	Alias           string              `json:"alias,omitempty"`
+	Delegators      int64               `json:"delegators"`
+	StakingBalance  model.Mutez         `json:"staking_balance"`
+	DelegatedVolume model.Mutez         `json:"delegated_volume"`
+	Rewards         model.Mutez         `json:"rewards"`
+	NewDelegations  int64               `json:"new_delegations"`
+	Undelegations   int64               `json:"undelegations"`
+	NetInflow       int64               `json:"net_inflow"`
+}
+
+// V1Delegator is a delegator of a baker in the /v1 responses.
+type V1Delegator struct {
+	Delegator  model.WalletAddress `json:"delegator"`
+	Baker      model.WalletAddress `json:"baker"`
+	SinceLevel int64               `json:"since_level"`
+	Balance    model.Mutez         `json:"balance"`
+}
+
+// V1BakerDetail is a baker of the directory with its rewards per cycle in the /v1 responses.
+type V1BakerDetail struct {
+	V1Baker
+	History []V1BakerCycle `json:"history"`
+}
+
+// V1BakerCycle is the rewards paid by a baker in a cycle in the /v1 responses.
+type V1BakerCycle struct {
+	Cycle      int                 `json:"cycle"`
+	Baker      model.WalletAddress `json:"baker"`
+	Rewards    model.Mutez         `json:"rewards"`
+	Delegators int64               `json:"delegators"`
+}
+
+// V1DelegatorCycle is the rewards received by a delegator in a cycle in the /v1 responses.
+type V1DelegatorCycle struct {
+	Cycle     int                 `json:"cycle"`
+	Delegator model.WalletAddress `json:"delegator"`
+	Rewards   model.Mutez         `json:"rewards"`
+	Bakers    int64               `json:"bakers"`
+}
+
+// V1DailyDelegationStats is the delegation activity of a baker on a UTC day in the /v1 responses.
+type V1DailyDelegationStats struct {
+	Day             string              `json:"day"`
+	Baker           model.WalletAddress `json:"baker"`
+	NewDelegations  int64               `json:"new_delegations"`
+	Undelegations   int64               `json:"undelegations"`
+	DelegatedVolume model.Mutez         `json:"delegated_volume"`
+}
+
+// V1Account is the profile of an address in the /v1 responses.
+type V1Account struct {
+	Address            model.WalletAddress `json:"address"`
+	Alias              string              `json:"alias,omitempty"`
+	Type               model.AccountType   `json:"type"`
+	FirstSeenLevel     int64               `json:"first_seen_level,omitempty"`
+	LastActiveLevel    int64               `json:"last_active_level,omitempty"`
+	CurrentBaker       model.WalletAddress `json:"current_baker,omitempty"`
+	DelegationTimeline []V1BakerChange     `json:"delegation_timeline"`
+	TotalRewards       model.Mutez         `json:"total_rewards"`
+	StakingOperations  map[string]int64    `json:"staking_operations"`
+}
+
+// V1BakerChange is a delegator moving to a baker, or leaving it when Baker is empty, in the /v1 responses.
+type V1BakerChange struct {
+	Baker     model.WalletAddress `json:"baker,omitempty"`
+	Level     int64               `json:"level"`
+	Timestamp string              `json:"timestamp"`
+}
+
+// V1RewardsReport is the yearly rewards report of a wallet in the /v1 responses.
+type V1RewardsReport struct {
+	Wallet        model.WalletAddress `json:"wallet"`
+	Year          int                 `json:"year"`
+	Currency      model.Currency      `json:"currency"`
+	Rewards       []V1ReportReward    `json:"rewards"`
+	TotalAmount   model.Mutez         `json:"total_amount"`
+	TotalValue    string              `json:"total_value"`
+	MissingPrices int                 `json:"missing_prices"`
+}
+
+// V1ReportReward is a reward of a rewards report in the /v1 responses.
+type V1ReportReward struct {
+	Cycle  int                 `json:"cycle"`
+	Date   string              `json:"date"`
+	Baker  model.WalletAddress `json:"baker"`
+	Amount model.Mutez         `json:"amount"`
+	Price  string              `json:"price,omitempty"`
+	Value  string              `json:"value,omitempty"`
+}
+
+// newV1Pagination returns the pagination of a page selected by cursor or by the page of info, as computed by the use case.
+func newV1Pagination(info model.PaginationInfo, cursor, nextCursor string) *Pagination {
+	p := &Pagination{
+		PerPage:    info.PerPage,
+		HasNext:    info.HasNextPage,
+		NextCursor: nextCursor,
+	}
+	if cursor != "" {
+		return p
+	}
+
+	p.Page = info.CurrentPage
+	p.PrevPage = info.PrevPage
+	p.NextPage = info.NextPage
+	return p
+}
+
+// newV1Meta returns the meta of a /v1 response.
+func newV1Meta() Meta {
+	return Meta{Version: apiVersion}
+}
+
+// rfc3339 formats a Unix timestamp in seconds as an RFC 3339 UTC date.
+func rfc3339(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
+
+// newV1Delegations converts delegations to their /v1 representation.
+func newV1Delegations(delegations []model.Delegation) []V1Delegation {
+	data := make([]V1Delegation, 0, len(delegations))
+	for _, d := range delegations {
+		kind := model.DelegationKindDelegation
+		if d.Delegate == "" {
+			kind = model.DelegationKindUndelegation
+		}
+		data = append(data, V1Delegation{
+			Kind:      kind,
+			Delegator: d.Delegator,
+			Delegate:  d.Delegate,
+			Amount:    d.Amount,
+			Level:     d.Level,
+			Timestamp: rfc3339(d.Timestamp),
+		})
+	}
+	return data
+}
+
+// newV1Operations converts operations to their /v1 representation.
+func newV1Operations(operations []model.Operation) []V1Operation {
+	data := make([]V1Operation, 0, len(operations))
+	for _, o := range operations {
+		data = append(data, V1Operation{
+			ID:         o.ID,
+			Type:       o.Type,
+			Sender:     o.SenderAddress,
+			Contract:   o.ContractAddress,
+			Entrypoint: o.Entrypoint,
+			Amount:     o.Amount,
+			Block:      o.Block,
+			Status:     o.Status,
+			Timestamp:  rfc3339(o.Timestamp),
+		})
+	}
+	return data
+}
+
+// newV1Rewards converts rewards to their /v1 representation.
+func newV1Rewards(rewards []model.Reward) []V1Reward {
+	data := make([]V1Reward, 0, len(rewards))
+	for _, r := range rewards {
+		data = append(data, V1Reward{
+			ID:        r.ID,
+			Recipient: r.RecipientAddress,
+			Source:    r.SourceAddress,
+			Cycle:     r.Cycle,
+			Amount:    r.Amount,
+			Timestamp: rfc3339(r.Timestamp),
+		})
+	}
+	return data
+}
+
+// newV1Bakers converts bakers to their /v1 representation.
+func newV1Bakers(bakers []model.Baker) []V1Baker {
+	data := make([]V1Baker, 0, len(bakers))
+	for _, b := range bakers {
+		data = append(data, V1Baker{
+			Address:         b.Address,
+			Alias:           b.Alias,
+			Delegators:      b.Delegators,
+			StakingBalance:  b.StakingBalance,
+			DelegatedVolume: b.DelegatedVolume,
+			Rewards:         b.Rewards,
+			NewDelegations:  b.NewDelegations,
+			Undelegations:   b.Undelegations,
+			NetInflow:       b.NetInflow,
+		})
+	}
+	return data
+}
+
+// newV1Delegators converts the current delegations of a baker to their /v1 representation.
+func newV1Delegators(delegators []model.CurrentDelegation) []V1Delegator {
+	data := make([]V1Delegator, 0, len(delegators))
+	for _, d := range delegators {
+		data = append(data, V1Delegator{
+			Delegator:  d.Delegator,
+			Baker:      d.Baker,
+			SinceLevel: d.SinceLevel,
+			Balance:    d.Balance,
+		})
+	}
+	return data
+}
+
+// newV1Baker converts a baker with its history to its /v1 representation.
+func newV1Baker(baker *model.BakerResponse) V1BakerDetail {
+	return V1BakerDetail{
+		V1Baker: newV1Bakers([]model.Baker{baker.Baker})[0],
+		History: newV1BakerCycles(baker.History),
+	}
+}
+
+// newV1BakerCycles converts the rewards of bakers per cycle to their /v1 representation.
+func newV1BakerCycles(cycles []model.BakerCycleRewards) []V1BakerCycle {
+	data := make([]V1BakerCycle, 0, len(cycles))
+	for _, c := range cycles {
+		data = append(data, V1BakerCycle{
+			Cycle:      c.Cycle,
+			Baker:      c.Baker,
+			Rewards:    c.Rewards,
+			Delegators: c.Delegators,
+		})
+	}
+	return data
+}
+
+// newV1DelegatorCycles converts the rewards of delegators per cycle to their /v1 representation.
+func newV1DelegatorCycles(cycles []model.DelegatorCycleRewards) []V1DelegatorCycle {
+	data := make([]V1DelegatorCycle, 0, len(cycles))
+	for _, c := range cycles {
+		data = append(data, V1DelegatorCycle{
+			Cycle:     c.Cycle,
+			Delegator: c.Delegator,
+			Rewards:   c.Rewards,
+			Bakers:    c.Bakers,
+		})
+	}
+	return data
+}
+
+// newV1DailyDelegationStats converts the daily delegation statistics to their /v1 representation.
+func newV1DailyDelegationStats(stats []model.DailyDelegationStats) []V1DailyDelegationStats {
+	data := make([]V1DailyDelegationStats, 0, len(stats))
+	for _, s := range stats {
+		data = append(data, V1DailyDelegationStats{
+			Day:             s.DayDate,
+			Baker:           s.Baker,
+			NewDelegations:  s.NewDelegations,
+			Undelegations:   s.Undelegations,
+			DelegatedVolume: s.DelegatedVolume,
+		})
+	}
+	return data
+}
+
+// newV1Account converts an account profile to its /v1 representation.
+func newV1Account(profile *model.AccountProfile) V1Account {
+	timeline := make([]V1BakerChange, 0, len(profile.DelegationTimeline))
+	for _, change := range profile.DelegationTimeline {
+		timeline = append(timeline, V1BakerChange{
+			Baker:     change.Baker,
+			Level:     change.Level,
+			Timestamp: change.TimestampTime,
+		})
+	}
+	operations := profile.StakingOperations
+	if operations == nil {
+		operations = map[string]int64{}
+	}
+	return V1Account{
+		Address:            profile.Address,
+		Alias:              profile.Alias,
+		Type:               profile.Type,
+		FirstSeenLevel:     profile.FirstSeenLevel,
+		LastActiveLevel:    profile.LastActiveLevel,
+		CurrentBaker:       profile.CurrentBaker,
+		DelegationTimeline: timeline,
+		TotalRewards:       profile.TotalRewards,
+		StakingOperations:  operations,
+	}
+}
+
+// newV1RewardsReport converts a rewards report to its /v1 representation.
+func newV1RewardsReport(report *model.RewardsReport) V1RewardsReport {
+	rewards := make([]V1ReportReward, 0, len(report.Rewards))
+	for _, r := range report.Rewards {
+		rewards = append(rewards, V1ReportReward{
+			Cycle:  r.Cycle,
+			Date:   r.Date,
+			Baker:  r.Baker,
+			Amount: r.Amount,
+			Price:  r.Price,
+			Value:  r.Value,
+		})
+	}
+	return V1RewardsReport{
+		Wallet:        report.Wallet,
+		Year:          report.Year,
+		Currency:      report.Currency,
+		Rewards:       rewards,
+		TotalAmount:   report.TotalAmount,
+		TotalValue:    report.TotalValue,
+		MissingPrices: report.MissingPrices,
+	}
+}
+
+// deprecateLegacy marks the response of a legacy /xtz route as deprecated (RFC 9745), with a link to the same
+// request on its /v1 successor. The exports negotiated by the Accept header get no link, /v1 only answers JSON.
+func deprecateLegacy(c *gin.Context) {
+	c.Header("Deprecation", fmt.Sprintf("@%d", legacyDeprecation.Unix()))
+	if _, ok := acceptedExportFormat(c.GetHeader("Accept")); ok {
+		c.Next()
+		return
+	}
+	successor := url.URL{Path: "/" + apiVersion + strings.TrimPrefix(c.Request.URL.Path, "/xtz"), RawQuery: c.Request.URL.RawQuery}
+	c.Writer.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor.String()))
+	c.Next()
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_newV1Pagination(t *testing.T) {
+	type args struct {
+		info       model.PaginationInfo
+		cursor     string
+		nextCursor string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Pagination
+	}{
+		{
+			name: "Nominal case - first page",
+			args: args{info: model.PaginationInfo{CurrentPage: 1, PerPage: 2, HasNextPage: true, NextPage: 2}, nextCursor: "next"},
+			want: &Pagination{Page: 1, PerPage: 2, HasNext: true, NextPage: 2, NextCursor: "next"},
+		},
+		{
+			name: "Nominal case - last page",
+			args: args{info: model.PaginationInfo{CurrentPage: 3, PerPage: 2, HasPrevPage: true, PrevPage: 2}},
+			want: &Pagination{Page: 3, PerPage: 2, PrevPage: 2},
+		},
+		{
+			name: "Nominal case - cursor",
+			args: args{info: model.PaginationInfo{CurrentPage: 1, PerPage: 2, HasNextPage: true, NextPage: 2}, cursor: "abc", nextCursor: "next"},
+			want: &Pagination{PerPage: 2, HasNext: true, NextCursor: "next"},
+		},
+		{
+			name: "Nominal case - empty page",
+			args: args{info: model.PaginationInfo{CurrentPage: 1}},
+			want: &Pagination{Page: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newV1Pagination(tt.args.info, tt.args.cursor, tt.args.nextCursor))
+		})
+	}
+}
+
+func Test_deprecateLegacy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		route        string
+		url          string
+		accept       string
+		expectedLink []string
+	}{
+		{
+			name:         "nominal case",
+			route:        "/xtz/delegations",
+			url:          "/xtz/delegations?cursor=abc&limit=10",
+			expectedLink: []string{`</v1/delegations?cursor=abc&limit=10>; rel="successor-version"`, `</xtz/delegations?cursor=next&limit=10>; rel="next"`},
+		},
+		{
+			name:         "nominal case - path parameter",
+			route:        "/xtz/bakers/:address/delegators",
+			url:          "/xtz/bakers/" + testBaker + "/delegators",
+			expectedLink: []string{`</v1/bakers/` + testBaker + `/delegators>; rel="successor-version"`},
+		},
+		{
+			name:   "nominal case - export",
+			route:  "/xtz/delegations",
+			url:    "/xtz/delegations?year=2024",
+			accept: "text/csv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET(tt.route, deprecateLegacy, func(c *gin.Context) {
+				if c.Query("cursor") != "" {
+					setNextLinkHeader(c, "next")
+				}
+				c.JSON(http.StatusOK, gin.H{})
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "@1792281600", w.Header().Get("Deprecation"))
+			assert.Equal(t, tt.expectedLink, w.Header().Values("Link"))
+		})
+	}
+}
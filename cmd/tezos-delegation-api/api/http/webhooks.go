@@ -0,0 +1,215 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// maxWebhookDescriptionLength bounds the description of a webhook.
+const maxWebhookDescriptionLength = 255
+
+// createWebhookRequest is the JSON body of POST /xtz/webhooks.
+type createWebhookRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Baker       string   `json:"baker"`
+	Delegator   string   `json:"delegator"`
+	Description string   `json:"description"`
+}
+
+// WebhooksHandler handles the webhook subscription API requests.
+type WebhooksHandler struct {
+	createWebhookFunc        usecase.CreateWebhookFunc
+	getWebhooksFunc          usecase.GetWebhooksFunc
+	deleteWebhookFunc        usecase.DeleteWebhookFunc
+	enableWebhookFunc        usecase.EnableWebhookFunc
+	getWebhookDeliveriesFunc usecase.GetWebhookDeliveriesFunc
+}
+
+// NewWebhooksHandler creates a new webhooks handler.
+func NewWebhooksHandler(createWebhookFunc usecase.CreateWebhookFunc, getWebhooksFunc usecase.GetWebhooksFunc, deleteWebhookFunc usecase.DeleteWebhookFunc, enableWebhookFunc usecase.EnableWebhookFunc, getWebhookDeliveriesFunc usecase.GetWebhookDeliveriesFunc) *WebhooksHandler {
+	return &WebhooksHandler{
+		createWebhookFunc:        createWebhookFunc,
+		getWebhooksFunc:          getWebhooksFunc,
+		deleteWebhookFunc:        deleteWebhookFunc,
+		enableWebhookFunc:        enableWebhookFunc,
+		getWebhookDeliveriesFunc: getWebhookDeliveriesFunc,
+	}
+}
+
+// CreateWebhook handles POST /xtz/webhooks requests, answering the new webhook with its signing secret.
+func (h *WebhooksHandler) CreateWebhook(c *gin.Context) {
+	apiKeyID, ok := h.apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	var request createWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		abortWithInvalidRequest(c, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	input, err := h.createWebhookInput(request)
+	if err != nil {
+		abortWithInvalidRequest(c, err.Error())
+		return
+	}
+	input.APIKeyID = apiKeyID
+
+	webhook, err := h.createWebhookFunc(c.Request.Context(), input)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/xtz/webhooks/%d", webhook.ID))
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// createWebhookInput checks the body of a webhook creation.
+func (h *WebhooksHandler) createWebhookInput(request createWebhookRequest) (usecase.CreateWebhookInput, error) {
+	input := usecase.CreateWebhookInput{
+		URL:         request.URL,
+		Baker:       model.WalletAddress(request.Baker),
+		Delegator:   model.WalletAddress(request.Delegator),
+		Description: request.Description,
+	}
+
+	if input.URL == "" {
+		return input, fmt.Errorf("missing 'url'")
+	}
+	for _, t := range request.EventTypes {
+		eventType := model.WebhookEventType(t)
+		if !eventType.IsValid() {
+			return input, fmt.Errorf("invalid event type: %s, use delegation, undelegation or rewards", t)
+		}
+		input.EventTypes = append(input.EventTypes, eventType)
+	}
+	if input.Baker != "" && !input.Baker.IsValid() {
+		return input, fmt.Errorf("invalid baker address: %s", input.Baker.String())
+	}
+	if input.Delegator != "" && !input.Delegator.IsValid() {
+		return input, fmt.Errorf("invalid delegator address: %s", input.Delegator.String())
+	}
+	if len(input.Description) > maxWebhookDescriptionLength {
+		return input, fmt.Errorf("'description' exceeds %d characters", maxWebhookDescriptionLength)
+	}
+	return input, nil
+}
+
+// GetWebhooks handles GET /xtz/webhooks requests.
+func (h *WebhooksHandler) GetWebhooks(c *gin.Context) {
+	apiKeyID, ok := h.apiKeyID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.getWebhooksFunc(c.Request.Context(), apiKeyID)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /xtz/webhooks/{id} requests.
+func (h *WebhooksHandler) DeleteWebhook(c *gin.Context) {
+	apiKeyID, ok := h.apiKeyID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteWebhookFunc(c.Request.Context(), apiKeyID, id); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// EnableWebhook handles POST /xtz/webhooks/{id}/enable requests, resuming the deliveries to a disabled webhook.
+func (h *WebhooksHandler) EnableWebhook(c *gin.Context) {
+	apiKeyID, ok := h.apiKeyID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.enableWebhookFunc(c.Request.Context(), apiKeyID, id)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// GetWebhookDeliveries handles GET /xtz/webhooks/{id}/deliveries requests.
+func (h *WebhooksHandler) GetWebhookDeliveries(c *gin.Context) {
+	apiKeyID, ok := h.apiKeyID(c)
+	if !ok {
+		return
+	}
+	id, ok := h.webhookID(c)
+	if !ok {
+		return
+	}
+
+	var limit uint16
+	if limitStr := c.Query("limit"); limitStr != "" {
+		l, err := strconv.ParseUint(limitStr, 10, 16)
+		if err != nil || l == 0 {
+			abortWithInvalidRequest(c, fmt.Sprintf("invalid 'limit': %s", limitStr))
+			return
+		}
+		limit = uint16(l)
+	}
+
+	response, err := h.getWebhookDeliveriesFunc(c.Request.Context(), apiKeyID, id, limit)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// apiKeyID returns the id of the API key authenticating the request, the owner of the webhooks, answering 401 when
+// the request has none.
+func (h *WebhooksHandler) apiKeyID(c *gin.Context) (int64, bool) {
+	if value, ok := c.Get(contextKeyAPIKey); ok {
+		if apiKey, ok := value.(*model.APIKey); ok && apiKey.ID > 0 {
+			return apiKey.ID, true
+		}
+	}
+	c.Header("WWW-Authenticate", "Bearer")
+	abortWithError(c, errMissingAPIKey)
+	return 0, false
+}
+
+// webhookID parses the id path parameter, answering 400 when it is invalid.
+func (h *WebhooksHandler) webhookID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		abortWithInvalidRequest(c, fmt.Sprintf("invalid webhook id: %s", c.Param("id")))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,336 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// testAPIKeyHeader carries the id of the API key authenticating the test requests.
+const testAPIKeyHeader = "X-Test-API-Key"
+
+// newWebhookRequest returns a request authenticated by the API key apiKeyID.
+func newWebhookRequest(method, url, body string, apiKeyID int64) *http.Request {
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	req.Header.Set(testAPIKeyHeader, strconv.FormatInt(apiKeyID, 10))
+	return req
+}
+
+// newWebhooksTestRouter serves the webhook routes over an in-memory database, the requests being authenticated by
+// the API key of their testAPIKeyHeader.
+func newWebhooksTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	db := databasememory.New()
+	metricsClient := metricsnoop.New()
+	handler := NewWebhooksHandler(
+		usecase.NewCreateWebhookFunc(db, metricsClient),
+		usecase.NewGetWebhooksFunc(db, metricsClient),
+		usecase.NewDeleteWebhookFunc(db, metricsClient),
+		usecase.NewEnableWebhookFunc(db, metricsClient),
+		usecase.NewGetWebhookDeliveriesFunc(db, metricsClient),
+	)
+
+	router := newTestRouter()
+	router.Use(func(c *gin.Context) {
+		if id, err := strconv.ParseInt(c.GetHeader(testAPIKeyHeader), 10, 64); err == nil {
+			c.Set(contextKeyAPIKey, &model.APIKey{ID: id})
+		}
+	})
+	router.POST("/xtz/webhooks", handler.CreateWebhook)
+	router.GET("/xtz/webhooks", handler.GetWebhooks)
+	router.DELETE("/xtz/webhooks/:id", handler.DeleteWebhook)
+	router.POST("/xtz/webhooks/:id/enable", handler.EnableWebhook)
+	router.GET("/xtz/webhooks/:id/deliveries", handler.GetWebhookDeliveries)
+	return router
+}
+
+func Test_WebhooksHandler_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			body:           fmt.Sprintf(`{"url":"https://93.184.215.14/hook","event_types":["delegation","rewards"],"baker":"%s"}`, testBaker),
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "error - private endpoint",
+			body:           `{"url":"http://169.254.169.254/latest/meta-data"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid webhook: url host 169.254.169.254 is not a public address",
+		},
+		{
+			name:           "error - invalid body",
+			body:           `{"url":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid request body: unexpected EOF",
+		},
+		{
+			name:           "error - missing url",
+			body:           `{"event_types":["delegation"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "missing 'url'",
+		},
+		{
+			name:           "error - invalid event type",
+			body:           `{"url":"https://93.184.215.14/hook","event_types":["transfer"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid event type: transfer, use delegation, undelegation or rewards",
+		},
+		{
+			name:           "error - invalid baker",
+			body:           `{"url":"https://93.184.215.14/hook","baker":"tz1invalid"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid baker address: tz1invalid",
+		},
+		{
+			name:           "error - invalid url",
+			body:           `{"url":"ftp://example.com/hook"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid webhook: url must be an absolute http or https URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newWebhooksTestRouter()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("POST", "/xtz/webhooks", tt.body, 1))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var created model.CreatedWebhook
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+			assert.Equal(t, "/xtz/webhooks/1", w.Header().Get("Location"))
+			assert.Equal(t, model.WalletAddress(testBaker), created.Baker)
+			assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+
+			// The secret is not listed afterwards.
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("GET", "/xtz/webhooks", "", 1))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.NotContains(t, w.Body.String(), created.Secret)
+			assert.Contains(t, w.Body.String(), `"url":"https://93.184.215.14/hook"`)
+
+			// Nor listed to another API key.
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("GET", "/xtz/webhooks", "", 2))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+		})
+	}
+}
+
+func Test_WebhooksHandler_missingAPIKey(t *testing.T) {
+	router := newWebhooksTestRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/xtz/webhooks", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+}
+
+func Test_WebhooksHandler_DeleteWebhook(t *testing.T) {
+	router := newWebhooksTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest("POST", "/xtz/webhooks", `{"url":"https://93.184.215.14/hook"}`, 1))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name           string
+		url            string
+		apiKeyID       int64
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "error - webhook of another API key",
+			url:            "/xtz/webhooks/1",
+			apiKeyID:       2,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "webhook not found: 1",
+		},
+		{
+			name:           "nominal case",
+			url:            "/xtz/webhooks/1",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "error - already deleted",
+			url:            "/xtz/webhooks/1",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "webhook not found: 1",
+		},
+		{
+			name:           "error - invalid id",
+			url:            "/xtz/webhooks/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid webhook id: abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyID := tt.apiKeyID
+			if apiKeyID == 0 {
+				apiKeyID = 1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("DELETE", tt.url, "", apiKeyID))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+			}
+		})
+	}
+}
+
+func Test_WebhooksHandler_GetWebhookDeliveries(t *testing.T) {
+	router := newWebhooksTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest("POST", "/xtz/webhooks", `{"url":"https://93.184.215.14/hook"}`, 1))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name           string
+		url            string
+		apiKeyID       int64
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			url:            "/xtz/webhooks/1/deliveries?limit=10",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - webhook of another API key",
+			url:            "/xtz/webhooks/1/deliveries",
+			apiKeyID:       2,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "webhook not found: 1",
+		},
+		{
+			name:           "error - invalid limit",
+			url:            "/xtz/webhooks/1/deliveries?limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid 'limit': 0",
+		},
+		{
+			name:           "error - invalid id",
+			url:            "/xtz/webhooks/-1/deliveries",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid webhook id: -1",
+		},
+		{
+			name:           "error - unknown webhook",
+			url:            "/xtz/webhooks/2/deliveries",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "webhook not found: 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyID := tt.apiKeyID
+			if apiKeyID == 0 {
+				apiKeyID = 1
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("GET", tt.url, "", apiKeyID))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+			assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+		})
+	}
+}
+
+func Test_WebhooksHandler_EnableWebhook(t *testing.T) {
+	router := newWebhooksTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newWebhookRequest("POST", "/xtz/webhooks", `{"url":"https://93.184.215.14/hook"}`, 1))
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name           string
+		url            string
+		apiKeyID       int64
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "nominal case",
+			url:            "/xtz/webhooks/1/enable",
+			apiKeyID:       1,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - webhook of another API key",
+			url:            "/xtz/webhooks/1/enable",
+			apiKeyID:       2,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "webhook not found: 1",
+		},
+		{
+			name:           "error - invalid id",
+			url:            "/xtz/webhooks/abc/enable",
+			apiKeyID:       1,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid webhook id: abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newWebhookRequest("POST", tt.url, "", tt.apiKeyID))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Detail)
+				return
+			}
+
+			var webhook model.Webhook
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhook))
+			assert.Equal(t, int64(1), webhook.ID)
+			assert.Nil(t, webhook.DisabledAt)
+		})
+	}
+}
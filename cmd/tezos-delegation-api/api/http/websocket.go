@@ -0,0 +1,269 @@
+package http
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// websocketGUID is concatenated to the key of a handshake to compute its accept key (RFC 6455 section 1.3).
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Opcodes of the WebSocket frames.
+const (
+	wsOpText   = 0x1
+	wsOpBinary = 0x2
+	wsOpClose  = 0x8
+	wsOpPing   = 0x9
+	wsOpPong   = 0xA
+)
+
+// Status codes of the WebSocket close frames.
+const (
+	wsCloseGoingAway     = 1001
+	wsCloseProtocolError = 1002
+	wsCloseTooBig        = 1009
+	wsCloseInternalError = 1011
+	wsCloseTryAgainLater = 1013
+)
+
+// wsMaxFramePayload bounds the frames read from the clients, which are only expected to send control frames.
+const wsMaxFramePayload = 64 << 10
+
+// errWebSocketProtocol is returned when a client sends a frame breaking RFC 6455.
+var errWebSocketProtocol = errors.New("websocket protocol error")
+
+// isWebSocketUpgrade reports whether the request asks to switch to the WebSocket protocol.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") && headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+// headerContainsToken reports whether a comma-separated header lists token, case-insensitively.
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkWebSocketHandshake returns the status and error answering an invalid opening handshake, 0 and nil when valid.
+func checkWebSocketHandshake(r *http.Request) (int, error) {
+	if r.Method != http.MethodGet {
+		return http.StatusMethodNotAllowed, errors.New("websocket handshake must be a GET request")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return http.StatusUpgradeRequired, errors.New("unsupported websocket version, use 13")
+	}
+	key, err := base64.StdEncoding.DecodeString(r.Header.Get("Sec-WebSocket-Key"))
+	if err != nil || len(key) != 16 {
+		return http.StatusBadRequest, errors.New("invalid Sec-WebSocket-Key")
+	}
+	return 0, nil
+}
+
+// websocketAcceptKey returns the Sec-WebSocket-Accept header answering the Sec-WebSocket-Key of a handshake.
+func websocketAcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// wsConn is a server side WebSocket connection, sending unfragmented text messages.
+type wsConn struct {
+	conn         net.Conn
+	reader       *bufio.Reader
+	writeTimeout time.Duration
+
+	writeMu sync.Mutex
+}
+
+// upgradeWebSocket completes the opening handshake checked by checkWebSocketHandshake and takes over the connection.
+func upgradeWebSocket(c *gin.Context, writeTimeout time.Duration) (*wsConn, error) {
+	conn, rw, err := c.Writer.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &wsConn{conn: conn, reader: rw.Reader, writeTimeout: writeTimeout}
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAcceptKey(c.GetHeader("Sec-WebSocket-Key")) + "\r\n\r\n"
+
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if err := ws.setWriteDeadline(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if _, err := io.WriteString(conn, response); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// WriteText sends a text message.
+func (ws *wsConn) WriteText(payload []byte) error {
+	return ws.writeFrame(wsOpText, payload)
+}
+
+// Ping sends a ping, which the client answers with a pong.
+func (ws *wsConn) Ping() error {
+	return ws.writeFrame(wsOpPing, nil)
+}
+
+// Close sends a close frame with code and reason, then closes the connection.
+func (ws *wsConn) Close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > 125 {
+		payload = payload[:125]
+	}
+
+	_ = ws.writeFrame(wsOpClose, payload)
+	return ws.conn.Close()
+}
+
+// ReadLoop reads the frames of the client until it closes the connection or stays silent for idleTimeout,
+// answering its pings and ignoring its messages. The returned channel is closed once reading stops.
+func (ws *wsConn) ReadLoop(idleTimeout time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if err := ws.conn.SetReadDeadline(time.Now().Add(idleTimeout)); err != nil {
+				return
+			}
+
+			opcode, payload, err := ws.readFrame()
+			switch {
+			case errors.Is(err, errWebSocketProtocol):
+				_ = ws.Close(wsCloseProtocolError, err.Error())
+				return
+			case err != nil:
+				return
+			}
+
+			switch opcode {
+			case wsOpPing:
+				if err := ws.writeFrame(wsOpPong, payload); err != nil {
+					return
+				}
+			case wsOpClose:
+				// Echo the status code of the client, if any, to complete the closing handshake.
+				if len(payload) > 2 {
+					payload = payload[:2]
+				}
+				_ = ws.writeFrame(wsOpClose, payload)
+				return
+			}
+		}
+	}()
+	return done
+}
+
+// writeFrame writes a final frame, unmasked as sent by servers.
+func (ws *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if err := ws.setWriteDeadline(); err != nil {
+		return err
+	}
+	if _, err := ws.conn.Write(header); err != nil {
+		return err
+	}
+	_, err := ws.conn.Write(payload)
+	return err
+}
+
+// setWriteDeadline bounds the next write, so that a client not reading its connection is disconnected.
+func (ws *wsConn) setWriteDeadline() error {
+	if ws.writeTimeout <= 0 {
+		return nil
+	}
+	return ws.conn.SetWriteDeadline(time.Now().Add(ws.writeTimeout))
+}
+
+// readFrame reads a frame of the client, which must be masked, and returns its opcode and unmasked payload.
+func (ws *wsConn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(ws.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	fin, rsv, opcode := header[0]&0x80 != 0, header[0]&0x70, header[0]&0x0F
+	masked, length := header[1]&0x80 != 0, uint64(header[1]&0x7F)
+	isControl := opcode&0x8 != 0
+
+	switch {
+	case rsv != 0:
+		return 0, nil, fmt.Errorf("%w: reserved bits set", errWebSocketProtocol)
+	case !masked:
+		return 0, nil, fmt.Errorf("%w: unmasked client frame", errWebSocketProtocol)
+	case isControl && (!fin || length > 125):
+		return 0, nil, fmt.Errorf("%w: fragmented or oversized control frame", errWebSocketProtocol)
+	case opcode > wsOpBinary && !isControl, opcode > wsOpPong:
+		return 0, nil, fmt.Errorf("%w: unknown opcode %d", errWebSocketProtocol, opcode)
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(ws.reader, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(ws.reader, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxFramePayload {
+		_ = ws.Close(wsCloseTooBig, "frame too big")
+		return 0, nil, errors.New("websocket frame too big")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(ws.reader, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(ws.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
@@ -3,8 +3,11 @@ package config
 import (
 	"github.com/spf13/viper"
 
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-api/api/http"
+	cachefactory "github.com/tezos-delegation-service/internal/adapter/cache/factory"
 	datbasefactory "github.com/tezos-delegation-service/internal/adapter/database/factory"
 	metricsfactory "github.com/tezos-delegation-service/internal/adapter/metrics/factory"
+	"github.com/tezos-delegation-service/internal/usecase"
 	"github.com/tezos-delegation-service/pkg/logger"
 )
 
@@ -14,6 +17,10 @@ type Config struct {
 
 	DatabaseAdapter datbasefactory.Config `mapstructure:"database"`
 	Pagination      PaginationConfig      `mapstructure:"pagination"`
+	Stream          http.StreamConfig     `mapstructure:"stream"`
+	Auth            http.AuthConfig       `mapstructure:"auth"`
+	OpenAPI         http.OpenAPIConfig    `mapstructure:"openapi"`
+	Cache           CacheConfig           `mapstructure:"cache"`
 	Metrics         metricsfactory.Config `mapstructure:"metrics"`
 	Logging         logger.Config         `mapstructure:"logging"`
 }
@@ -28,6 +35,12 @@ type PaginationConfig struct {
 	Limit uint16 `mapstructure:"limit"`
 }
 
+// CacheConfig represents the configuration of the query result cache.
+type CacheConfig struct {
+	Adapter                  cachefactory.Config `mapstructure:",squash"`
+	usecase.QueryCacheConfig `mapstructure:",squash"`
+}
+
 // Load loads the configuration from the specified file.
 func Load(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/tezos-delegation-service/cmd/tezos-delegation-api/api/http"
 	"github.com/tezos-delegation-service/cmd/tezos-delegation-api/config"
+	cachefactory "github.com/tezos-delegation-service/internal/adapter/cache/factory"
 	databaseadapterfactory "github.com/tezos-delegation-service/internal/adapter/database/factory"
 	metricsfactory "github.com/tezos-delegation-service/internal/adapter/metrics/factory"
 	"github.com/tezos-delegation-service/pkg/logger"
@@ -35,7 +37,16 @@ func main() {
 		}
 	}()
 
-	server := http.NewServer(cfg.Server.Port, cfg.Pagination.Limit, dbAdapter, metricsClient, l).SetupRoutes()
+	if err := dbAdapter.CheckSchemaVersion(context.Background()); err != nil {
+		l.Fatalf("Refusing to start, run `tezos-delegation-job migrate up` first: %v", err)
+	}
+
+	cacheAdapter, err := cachefactory.New(cfg.Cache.Adapter)
+	if err != nil {
+		l.Fatalf("Failed to create query cache: %v", err)
+	}
+
+	server := http.NewServer(cfg.Server.Port, cfg.Pagination.Limit, cfg.Stream, cfg.Auth, cfg.OpenAPI, cfg.Cache.QueryCacheConfig, cacheAdapter, dbAdapter, metricsClient, l).SetupRoutes()
 
 	if err := server.Start(); err != nil {
 		l.Fatalf("Failed to start server: %v", err)
@@ -0,0 +1,8 @@
+package specs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3.0 document of the API, in YAML.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
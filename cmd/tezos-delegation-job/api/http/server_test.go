@@ -1,6 +1,8 @@
 package http
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -17,7 +19,6 @@ import (
 func Test_NewServer(t *testing.T) {
 	type args struct {
 		port         uint16
-		defaultLimit uint16
 		dbAdapter    database.Adapter
 		metricClient metrics.Adapter
 		logger       *logrus.Entry
@@ -36,7 +37,6 @@ func Test_NewServer(t *testing.T) {
 			name: "Nominal case",
 			args: args{
 				port:         8080,
-				defaultLimit: 50,
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -46,8 +46,6 @@ func Test_NewServer(t *testing.T) {
 				assert.Equal(t, 8080, int(s.port))
 				assert.NotNil(t, s.healthService)
 				assert.NotNil(t, s.router)
-				assert.NotNil(t, s.handlers)
-				assert.NotNil(t, s.handlers.getDelegationHandler)
 				assert.Equal(t, logger, s.logger)
 				assert.Equal(t, mockMetrics, s.metrics)
 			},
@@ -56,7 +54,6 @@ func Test_NewServer(t *testing.T) {
 			name: "Alternate port",
 			args: args{
 				port:         9090,
-				defaultLimit: 50,
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -69,7 +66,6 @@ func Test_NewServer(t *testing.T) {
 			name: "With DB nil adapter",
 			args: args{
 				port:         8080,
-				defaultLimit: 50,
 				dbAdapter:    nil,
 				metricClient: mockMetrics,
 				logger:       logger,
@@ -83,7 +79,6 @@ func Test_NewServer(t *testing.T) {
 			name: "With nil metrics",
 			args: args{
 				port:         8080,
-				defaultLimit: 50,
 				dbAdapter:    mockDB,
 				metricClient: nil,
 				logger:       logger,
@@ -97,7 +92,6 @@ func Test_NewServer(t *testing.T) {
 			name: "With logger nil",
 			args: args{
 				port:         8080,
-				defaultLimit: 50,
 				dbAdapter:    mockDB,
 				metricClient: mockMetrics,
 				logger:       nil,
@@ -111,7 +105,7 @@ func Test_NewServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := NewServer(tt.args.port, tt.args.defaultLimit, tt.args.dbAdapter, tt.args.metricClient, tt.args.logger)
+			server := NewServer(tt.args.port, tt.args.dbAdapter, tt.args.metricClient, tt.args.logger)
 			tt.check(t, server)
 		})
 	}
@@ -126,18 +120,12 @@ func Test_Server_SetupRoutes(t *testing.T) {
 		metrics       metrics.Adapter
 		port          uint16
 		router        *gin.Engine
-		handlers      *handlers
 	}
 
 	mockDB := databaseadaptermock.New()
 	mockMetrics := metricsnoop.New()
 	logger := logrus.NewEntry(logrus.New())
 
-	mockHandler := &GetDelegationHandler{}
-	mockHandlers := &handlers{
-		getDelegationHandler: mockHandler,
-	}
-
 	tests := []struct {
 		name     string
 		fields   fields
@@ -151,7 +139,6 @@ func Test_Server_SetupRoutes(t *testing.T) {
 				metrics:       mockMetrics,
 				port:          8080,
 				router:        gin.New(),
-				handlers:      mockHandlers,
 			},
 			testFunc: func(t *testing.T, s *Server) {
 				routes := s.router.Routes()
@@ -161,37 +148,15 @@ func Test_Server_SetupRoutes(t *testing.T) {
 					routePaths[route.Path] = true
 				}
 
-				assert.True(t, routePaths["/xtz/delegations"])
 				assert.True(t, routePaths["/health"])
 				assert.True(t, routePaths["/health/live"])
 				assert.True(t, routePaths["/health/ready"])
 				assert.True(t, routePaths["/metrics"])
-			},
-		},
-		{
-			name: "With a nil handler",
-			fields: fields{
-				healthService: NewHealthService(mockDB),
-				logger:        logger,
-				metrics:       mockMetrics,
-				port:          8080,
-				router:        gin.New(),
-				handlers: &handlers{
-					getDelegationHandler: nil,
-				},
-			},
-			testFunc: func(t *testing.T, s *Server) {
-				routes := s.router.Routes()
+				assert.True(t, routePaths["/debug/pprof/"])
 
-				routePaths := make(map[string]bool)
-				for _, route := range routes {
-					routePaths[route.Path] = true
-				}
-
-				assert.True(t, routePaths["/health"])
-				assert.True(t, routePaths["/health/live"])
-				assert.True(t, routePaths["/health/ready"])
-				assert.True(t, routePaths["/metrics"])
+				w := httptest.NewRecorder()
+				s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+				assert.Equal(t, http.StatusOK, w.Code)
 			},
 		},
 		{
@@ -202,7 +167,6 @@ func Test_Server_SetupRoutes(t *testing.T) {
 				metrics:       nil,
 				port:          8080,
 				router:        gin.New(),
-				handlers:      mockHandlers,
 			},
 			testFunc: func(t *testing.T, s *Server) {
 				routes := s.router.Routes()
@@ -213,8 +177,8 @@ func Test_Server_SetupRoutes(t *testing.T) {
 					routePaths[route.Path] = true
 				}
 
-				assert.True(t, routePaths["/xtz/delegations"])
 				assert.True(t, routePaths["/health"])
+				assert.True(t, routePaths["/metrics"])
 			},
 		},
 	}
@@ -227,7 +191,6 @@ func Test_Server_SetupRoutes(t *testing.T) {
 				metrics:       tt.fields.metrics,
 				port:          tt.fields.port,
 				router:        tt.fields.router,
-				handlers:      tt.fields.handlers,
 			}
 
 			result := s.SetupRoutes()
@@ -238,16 +201,6 @@ func Test_Server_SetupRoutes(t *testing.T) {
 	}
 }
 
-func Test_PrepareShutdown_v2(t *testing.T) {
-	logger := logrus.NewEntry(logrus.New())
-
-	server := NewServer(uint16(8080), uint16(50), databaseadaptermock.New(), metricsnoop.New(), logger).SetupRoutes()
-	assert.False(t, server.healthService.shutdownStarted)
-
-	server.PrepareShutdown()
-	assert.True(t, server.healthService.shutdownStarted)
-}
-
 func Test_Server_WaitForShutdown(t *testing.T) {
 	mockLogger := logrus.NewEntry(logrus.New())
 
@@ -257,29 +210,27 @@ func Test_Server_WaitForShutdown(t *testing.T) {
 
 	router := gin.New()
 
-	h := &handlers{
-		getDelegationHandler: &GetDelegationHandler{},
-	}
-
 	s := &Server{
 		healthService: healthService,
 		logger:        mockLogger,
 		metrics:       metricClient,
 		port:          8080,
 		router:        router,
-		handlers:      h,
 	}
 
+	cancelled := make(chan bool, 1)
 	done := make(chan bool)
 	go func() {
-		s.WaitForShutdown()
+		s.WaitForShutdown(func() { cancelled <- true })
 		done <- true
 	}()
 
 	select {
 	case <-done:
+		t.Fatal("WaitForShutdown returned without a shutdown signal")
 	case <-time.After(200 * time.Millisecond):
 	}
 
-	assert.True(t, true)
+	assert.Empty(t, cancelled)
+	assert.False(t, healthService.IsShuttingDown())
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/spf13/viper"
 
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/job/webhooks"
 	datbasefactory "github.com/tezos-delegation-service/internal/adapter/database/factory"
 	metricsfactory "github.com/tezos-delegation-service/internal/adapter/metrics/factory"
 	tzktapifactory "github.com/tezos-delegation-service/internal/adapter/tzktapi/factory"
@@ -18,6 +19,7 @@ type Config struct {
 	Pagination      PaginationConfig      `mapstructure:"pagination"`
 	Metrics         metricsfactory.Config `mapstructure:"metrics"`
 	Logging         logger.Config         `mapstructure:"logging"`
+	Webhooks        webhooks.Config       `mapstructure:"webhooks"`
 }
 
 // ServerConfig represents the server configuration.
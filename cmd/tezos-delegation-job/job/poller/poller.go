@@ -15,6 +15,9 @@ import (
 	"github.com/tezos-delegation-service/internal/usecase"
 )
 
+// partitionMonthsAhead is the number of future monthly partitions kept ready ahead of the current month.
+const partitionMonthsAhead = 3
+
 // usecases holds the use case functions.
 type usecases struct {
 	ucSyncDelegations model.SyncFunc
@@ -66,6 +69,8 @@ func (p *Poller) Run(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	p.ensurePartitions(ctx)
+
 	failedOps := make(map[string]model.SyncFunc)
 	if err := p.performMultiSync(ctx, "historical", p.allSyncFuncs, failedOps); err != nil {
 		p.logger.WithError(err).Error("Historical sync failed, aborting polling")
@@ -92,6 +97,10 @@ func (p *Poller) Run(ctx context.Context) {
 					now := time.Now().UTC()
 					syncType := determineSyncType(now)
 
+					if syncType == "historical" {
+						p.ensurePartitions(ctx)
+					}
+
 					syncFuncs := p.getSyncFuncsByType(syncType)
 					failedOps = make(map[string]model.SyncFunc)
 
@@ -126,6 +135,22 @@ func (p *Poller) Run(ctx context.Context) {
 	}
 }
 
+// ensurePartitions creates the monthly partitions needed for the coming months,
+// so that new rows never land in the default partition.
+func (p *Poller) ensurePartitions(ctx context.Context) {
+	if p.dbAdapter == nil {
+		return
+	}
+
+	until := time.Now().UTC().AddDate(0, partitionMonthsAhead, 0)
+	if err := p.dbAdapter.EnsurePartitions(ctx, until); err != nil {
+		p.logger.WithError(err).Error("Failed to create future partitions")
+		return
+	}
+
+	p.logger.Infof("Partitions ensured up to %s", until.Format("2006-01"))
+}
+
 // determineSyncType determines the type of sync to perform based on the current time.
 func determineSyncType(now time.Time) string {
 	hour := now.Hour()
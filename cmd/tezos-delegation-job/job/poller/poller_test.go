@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/tezos-delegation-service/internal/adapter/database"
@@ -16,7 +17,7 @@ import (
 	metrisnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
 	"github.com/tezos-delegation-service/internal/adapter/tzktapi"
 	tzktapimock "github.com/tezos-delegation-service/internal/adapter/tzktapi/impl/mock"
-	"github.com/tezos-delegation-service/internal/usecase"
+	"github.com/tezos-delegation-service/internal/model"
 )
 
 func Test_New(t *testing.T) {
@@ -48,11 +49,9 @@ func Test_New(t *testing.T) {
 				logger:          logger,
 			},
 			want: &Poller{
-				dbAdapter:         dbAdapter,
-				logger:            logger.WithField("component", "poller"),
-				pollingInterval:   pollingInterval,
-				tzktAdapter:       tzktAdapter,
-				ucSyncDelegations: usecase.NewSyncDelegationsFunc(tzktAdapter, dbAdapter, metricClient, logger),
+				dbAdapter:       dbAdapter,
+				pollingInterval: pollingInterval,
+				tzktAdapter:     tzktAdapter,
 			},
 		},
 		{
@@ -65,11 +64,9 @@ func Test_New(t *testing.T) {
 				logger:          logger,
 			},
 			want: &Poller{
-				dbAdapter:         dbAdapter,
-				logger:            logger.WithField("component", "poller"),
-				pollingInterval:   pollingInterval,
-				tzktAdapter:       nil,
-				ucSyncDelegations: usecase.NewSyncDelegationsFunc(nil, dbAdapter, metricClient, logger),
+				dbAdapter:       dbAdapter,
+				pollingInterval: pollingInterval,
+				tzktAdapter:     nil,
 			},
 		},
 		{
@@ -82,11 +79,24 @@ func Test_New(t *testing.T) {
 				logger:          logger,
 			},
 			want: &Poller{
-				dbAdapter:         nil,
-				logger:            logger.WithField("component", "poller"),
-				pollingInterval:   pollingInterval,
-				tzktAdapter:       tzktAdapter,
-				ucSyncDelegations: usecase.NewSyncDelegationsFunc(tzktAdapter, nil, metricClient, logger),
+				dbAdapter:       nil,
+				pollingInterval: pollingInterval,
+				tzktAdapter:     tzktAdapter,
+			},
+		},
+		{
+			name: "Case with nil metrics client",
+			args: args{
+				tzktAdapter:     tzktAdapter,
+				dbAdapter:       dbAdapter,
+				pollingInterval: pollingInterval,
+				metricClient:    nil,
+				logger:          logger,
+			},
+			want: &Poller{
+				dbAdapter:       dbAdapter,
+				pollingInterval: pollingInterval,
+				tzktAdapter:     tzktAdapter,
 			},
 		},
 	}
@@ -98,211 +108,231 @@ func Test_New(t *testing.T) {
 				tt.args.metricClient,
 				tt.args.logger)
 
-			if got.dbAdapter != tt.want.dbAdapter ||
-				got.pollingInterval != tt.want.pollingInterval ||
-				got.tzktAdapter != tt.want.tzktAdapter {
-				t.Errorf("New() = %v, want %v", got, tt.want)
-			}
+			assert.Equal(t, tt.want.dbAdapter, got.dbAdapter)
+			assert.Equal(t, tt.want.tzktAdapter, got.tzktAdapter)
+			assert.Equal(t, tt.want.pollingInterval, got.pollingInterval)
+			assert.Len(t, got.allSyncFuncs, 3)
+			assert.Contains(t, got.allSyncFuncs, "delegations")
+			assert.Contains(t, got.allSyncFuncs, "operations")
+			assert.Contains(t, got.allSyncFuncs, "rewards")
 		})
 	}
 }
 
-func Test_Poller_Run(t *testing.T) {
-	type fields struct {
-		dbAdapter         database.Adapter
-		pollingCancel     context.CancelFunc
-		pollingWg         *sync.WaitGroup
-		ucSyncDelegations usecase.SyncDelegationsFunc
+func Test_determineSyncType(t *testing.T) {
+	tests := []struct {
+		name string
+		hour int
+		want string
+	}{
+		{
+			name: "Historical sync at 4 AM",
+			hour: 4,
+			want: "historical",
+		},
+		{
+			name: "Rewards sync every 6 hours",
+			hour: 12,
+			want: "rewards",
+		},
+		{
+			name: "Regular sync otherwise",
+			hour: 13,
+			want: "regular",
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, 1, 1, tt.hour, 0, 0, 0, time.UTC)
+			assert.Equal(t, tt.want, determineSyncType(now))
+		})
+	}
+}
+
+func Test_Poller_getSyncFuncsByType(t *testing.T) {
+	noopSync := func(ctx context.Context) error { return nil }
+	p := &Poller{
+		allSyncFuncs: map[string]model.SyncFunc{
+			"delegations": noopSync,
+			"operations":  noopSync,
+			"rewards":     noopSync,
+		},
+	}
+
 	tests := []struct {
-		name   string
-		fields fields
+		name     string
+		syncType string
+		wantKeys []string
 	}{
 		{
-			name: "Nominal case with highestLevel 0",
-			fields: fields{
-				dbAdapter: func() database.Adapter {
-					db := databasemock.New()
-					db.On("GetHighestBlockLevel", mock.Anything).
-						Return(uint64(0), nil)
-					return db
-				}(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return nil
-				},
-			},
+			name:     "Historical",
+			syncType: "historical",
+			wantKeys: []string{"delegations", "operations", "rewards"},
 		},
 		{
-			name: "Nominal case with highestLevel over 0",
-			fields: fields{
-				dbAdapter: func() database.Adapter {
-					db := databasemock.New()
-					db.On("GetHighestBlockLevel", mock.Anything).
-						Return(uint64(100), nil)
-					return db
-				}(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return nil
-				},
-			},
+			name:     "Rewards",
+			syncType: "rewards",
+			wantKeys: []string{"rewards"},
 		},
 		{
-			name: "Error case - GetHighestBlockLevel returns error",
-			fields: fields{
-				dbAdapter: func() database.Adapter {
-					m := databasemock.New()
-					m.On("GetHighestBlockLevel", mock.Anything).
-						Return(uint64(0), errors.New("db error"))
-					return m
-				}(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return nil
-				},
+			name:     "Regular",
+			syncType: "regular",
+			wantKeys: []string{"delegations", "operations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.getSyncFuncsByType(tt.syncType)
+			assert.Len(t, got, len(tt.wantKeys))
+			for _, key := range tt.wantKeys {
+				assert.Contains(t, got, key)
+			}
+		})
+	}
+}
+
+func Test_Poller_performMultiSync(t *testing.T) {
+	tests := []struct {
+		name          string
+		syncFuncs     map[string]model.SyncFunc
+		wantFailedOps []string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			syncFuncs: map[string]model.SyncFunc{
+				"delegations": func(ctx context.Context) error { return nil },
+				"operations":  func(ctx context.Context) error { return nil },
 			},
+			wantErr: assert.NoError,
 		},
 		{
-			name: "Error case - ucSyncDelegations returns error",
-			fields: fields{
-				dbAdapter: func() database.Adapter {
-					m := databasemock.New()
-					m.On("GetHighestBlockLevel", mock.Anything).
-						Return(uint64(0), nil)
-					return m
-				}(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return errors.New("sync error")
-				},
+			name: "Error case - one sync fails",
+			syncFuncs: map[string]model.SyncFunc{
+				"delegations": func(ctx context.Context) error { return nil },
+				"rewards":     func(ctx context.Context) error { return errors.New("sync error") },
 			},
+			wantFailedOps: []string{"rewards"},
+			wantErr:       assert.Error,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
-			defer cancel()
-
 			p := &Poller{
-				dbAdapter:         tt.fields.dbAdapter,
-				logger:            logrus.NewEntry(logrus.New()),
-				pollingInterval:   time.Second,
-				pollingCtx:        ctx,
-				pollingCancel:     tt.fields.pollingCancel,
-				pollingWg:         tt.fields.pollingWg,
-				ucSyncDelegations: tt.fields.ucSyncDelegations,
+				logger: logrus.NewEntry(logrus.New()),
+			}
+			failedOps := make(map[string]model.SyncFunc)
+			err := p.performMultiSync(context.Background(), "regular", tt.syncFuncs, failedOps)
+			tt.wantErr(t, err)
+
+			assert.Len(t, failedOps, len(tt.wantFailedOps))
+			for _, name := range tt.wantFailedOps {
+				assert.Contains(t, failedOps, name)
 			}
-			p.Run(ctx)
 		})
 	}
 }
 
-func Test_Poller_StartPolling(t *testing.T) {
-	type fields struct {
-		dbAdapter         database.Adapter
-		pollingCancel     context.CancelFunc
-		pollingWg         *sync.WaitGroup
-		ucSyncDelegations usecase.SyncDelegationsFunc
+func Test_Poller_Run(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	countingSync := func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return nil
 	}
+
 	tests := []struct {
-		name   string
-		fields fields
-		ctx    context.Context
+		name      string
+		syncFuncs map[string]model.SyncFunc
+		wantCalls int
 	}{
 		{
-			name: "Nominal case",
-			fields: fields{
-				dbAdapter:     databasemock.New(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return nil
-				},
+			name: "Nominal case - historical sync then stop",
+			syncFuncs: map[string]model.SyncFunc{
+				"delegations": countingSync,
+				"operations":  countingSync,
+				"rewards":     countingSync,
 			},
-			ctx: context.Background(),
+			wantCalls: 3,
 		},
 		{
-			name: "Error case - ucSyncDelegations returns error",
-			fields: fields{
-				dbAdapter:     databasemock.New(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
-				ucSyncDelegations: func(ctx context.Context) error {
-					return errors.New("sync error")
-				},
+			name: "Error case - historical sync fails",
+			syncFuncs: map[string]model.SyncFunc{
+				"delegations": func(ctx context.Context) error { return errors.New("sync error") },
 			},
-			ctx: context.Background(),
+			wantCalls: 0,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			calls = 0
+			p := &Poller{
+				logger:          logrus.NewEntry(logrus.New()),
+				pollingInterval: time.Hour,
+				pollingWg:       &sync.WaitGroup{},
+				allSyncFuncs:    tt.syncFuncs,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 			defer cancel()
 
-			p := &Poller{
-				dbAdapter:         tt.fields.dbAdapter,
-				logger:            logrus.NewEntry(logrus.New()),
-				pollingInterval:   time.Second,
-				pollingCtx:        ctx,
-				pollingCancel:     tt.fields.pollingCancel,
-				pollingWg:         tt.fields.pollingWg,
-				ucSyncDelegations: tt.fields.ucSyncDelegations,
+			done := make(chan struct{})
+			go func() {
+				p.Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("Run() did not stop after context cancellation")
 			}
-			p.StartPolling(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, tt.wantCalls, calls)
 		})
 	}
 }
 
-func Test_Poller_StopPolling(t *testing.T) {
-	type fields struct {
-		dbAdapter         database.Adapter
-		pollingCancel     context.CancelFunc
-		pollingWg         *sync.WaitGroup
-		ucSyncDelegations usecase.SyncDelegationsFunc
-	}
+func Test_Poller_ensurePartitions(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
+		name      string
+		dbAdapter func() *databasemock.Mock
 	}{
 		{
 			name: "Nominal case",
-			fields: fields{
-				dbAdapter:     databasemock.New(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
+			dbAdapter: func() *databasemock.Mock {
+				m := databasemock.New()
+				m.On("EnsurePartitions", mock.Anything, mock.MatchedBy(func(until time.Time) bool {
+					return until.After(time.Now().AddDate(0, partitionMonthsAhead-1, 0))
+				})).Return(nil).Once()
+				return m
 			},
 		},
 		{
-			name: "Error case - pollingCancel is nil",
-			fields: fields{
-				dbAdapter:     databasemock.New(),
-				pollingCancel: nil,
-				pollingWg:     &sync.WaitGroup{},
+			name: "Error case - partitions creation fails",
+			dbAdapter: func() *databasemock.Mock {
+				m := databasemock.New()
+				m.On("EnsurePartitions", mock.Anything, mock.Anything).
+					Return(errors.New("db error")).Once()
+				return m
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
-			defer cancel()
-
+			db := tt.dbAdapter()
 			p := &Poller{
-				dbAdapter:         tt.fields.dbAdapter,
-				logger:            logrus.NewEntry(logrus.New()),
-				pollingInterval:   time.Second,
-				pollingCtx:        ctx,
-				pollingCancel:     tt.fields.pollingCancel,
-				pollingWg:         tt.fields.pollingWg,
-				ucSyncDelegations: tt.fields.ucSyncDelegations,
+				dbAdapter: db,
+				logger:    logrus.NewEntry(logrus.New()),
 			}
-			p.StopPolling()
+			p.ensurePartitions(context.Background())
+			db.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// defaultInterval is the delay between two runs when none is configured.
+const defaultInterval = 10 * time.Second
+
+// Config configures the webhook delivery worker.
+type Config struct {
+	usecase.WebhookDeliveryConfig `mapstructure:",squash"`
+
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the delay between two runs, each one queuing the new events then sending the due deliveries.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Worker queues the indexed events for the webhooks subscribing to them and delivers them.
+type Worker struct {
+	interval  time.Duration
+	logger    *logrus.Entry
+	ucEnqueue model.SyncFunc
+	ucDeliver model.SyncFunc
+}
+
+// New creates a new Worker instance with the provided configuration, database adapter and logger.
+func New(cfg Config, dbAdapter database.Adapter, metricClient metrics.Adapter, logger *logrus.Entry) *Worker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+
+	return &Worker{
+		interval:  cfg.Interval,
+		logger:    logger.WithField("component", "webhooks"),
+		ucEnqueue: usecase.NewEnqueueWebhookEventsFunc(dbAdapter, metricClient, logger),
+		ucDeliver: usecase.NewDeliverWebhooksFunc(cfg.WebhookDeliveryConfig, dbAdapter, metricClient, logger),
+	}
+}
+
+// Run queues and delivers the webhook events every interval until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	w.logger.Info("Starting webhook delivery worker...")
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Webhook delivery worker stopped")
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+// runOnce queues the new events, then sends the due deliveries, the failed deliveries being retried on later runs.
+func (w *Worker) runOnce(ctx context.Context) {
+	if err := w.ucEnqueue(ctx); err != nil {
+		w.logger.WithError(err).Error("Failed to queue webhook events")
+	}
+	if err := w.ucDeliver(ctx); err != nil {
+		w.logger.WithError(err).Error("Failed to deliver webhook events")
+	}
+}
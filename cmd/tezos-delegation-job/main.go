@@ -3,13 +3,19 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/api/http"
 	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/config"
 	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/job/poller"
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/job/webhooks"
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/migrate"
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/prices"
+	"github.com/tezos-delegation-service/cmd/tezos-delegation-job/report"
 	databaseadapterfactory "github.com/tezos-delegation-service/internal/adapter/database/factory"
 	metricsfactory "github.com/tezos-delegation-service/internal/adapter/metrics/factory"
 	tzktapiadapterfactory "github.com/tezos-delegation-service/internal/adapter/tzktapi/factory"
+	"github.com/tezos-delegation-service/internal/usecase"
 	"github.com/tezos-delegation-service/pkg/logger"
 )
 
@@ -23,6 +29,22 @@ func main() {
 	l := logger.Log.WithField("component", "tezos-delegation-job")
 	l.Infof("Service configuration: %+v", cfg)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrator, err := databaseadapterfactory.NewMigrator(cfg.DatabaseAdapter)
+		if err != nil {
+			l.Fatalf("Failed to create migrator: %v", err)
+		}
+
+		err = migrate.Run(context.Background(), migrator, os.Args[2:], os.Stdout)
+		if errClose := migrator.Close(); errClose != nil {
+			l.Errorf("Failed to close migrator: %v", errClose)
+		}
+		if err != nil {
+			l.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	metricsClient, err := metricsfactory.New(cfg.Metrics)
 	if err != nil {
 		l.Fatalf("Failed to create metrics client: %v", err)
@@ -38,6 +60,22 @@ func main() {
 		}
 	}()
 
+	if err := dbAdapter.CheckSchemaVersion(context.Background()); err != nil {
+		l.Fatalf("Refusing to start, run `tezos-delegation-job migrate up` first: %v", err)
+	}
+
+	if len(os.Args) > 1 && (os.Args[1] == "report" || os.Args[1] == "prices") {
+		if os.Args[1] == "report" {
+			err = report.Run(context.Background(), usecase.NewGetRewardsReportFunc(dbAdapter, metricsClient), os.Args[2:], os.Stdout)
+		} else {
+			err = prices.Run(context.Background(), usecase.NewImportPricesFunc(dbAdapter, metricsClient), os.Args[2:], os.Stdin, os.Stdout)
+		}
+		if err != nil {
+			l.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	tzktAPIAdapter, err := tzktapiadapterfactory.New(cfg.TZKTApiAdapter, metricsClient, l)
 	if err != nil {
 		l.Fatalf("Failed to create TzKT API factory: %v", err)
@@ -51,6 +89,10 @@ func main() {
 	pollerInstance := poller.New(tzktAPIAdapter, dbAdapter, cfg.TZKTApiAdapter.PollingInterval, metricsClient, l)
 	go pollerInstance.Run(ctx)
 
+	if cfg.Webhooks.Enabled {
+		go webhooks.New(cfg.Webhooks, dbAdapter, metricsClient, l).Run(ctx)
+	}
+
 	if err := server.Start(); err != nil {
 		l.Fatalf("Failed to start server: %v", err)
 	}
@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+// Usage describes the migrate command line.
+const Usage = "usage: tezos-delegation-job migrate up | down [steps] | status"
+
+// Run executes the migrate subcommand described by args with the given migrator and writes its report to out.
+func Run(ctx context.Context, migrator database.Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(Usage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.MigrateUp(ctx)
+		for _, m := range applied {
+			fmt.Fprintf(out, "applied %s\n", m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "schema is up to date")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("steps must be a positive number: %s", args[1])
+			}
+			steps = n
+		}
+
+		reverted, err := migrator.MigrateDown(ctx, steps)
+		for _, m := range reverted {
+			fmt.Fprintf(out, "rolled back %s\n", m.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, %s", args[0], Usage)
+	}
+}
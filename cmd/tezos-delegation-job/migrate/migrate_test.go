@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+type fakeMigrator struct {
+	applied   []database.MigrationStatus
+	reverted  []database.MigrationStatus
+	statuses  []database.MigrationStatus
+	downSteps int
+	err       error
+}
+
+func (f *fakeMigrator) MigrateUp(_ context.Context) ([]database.MigrationStatus, error) {
+	return f.applied, f.err
+}
+
+func (f *fakeMigrator) MigrateDown(_ context.Context, steps int) ([]database.MigrationStatus, error) {
+	f.downSteps = steps
+	return f.reverted, f.err
+}
+
+func (f *fakeMigrator) MigrationStatus(_ context.Context) ([]database.MigrationStatus, error) {
+	return f.statuses, f.err
+}
+
+func (f *fakeMigrator) Close() error {
+	return nil
+}
+
+func Test_Run(t *testing.T) {
+	appliedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		migrator      *fakeMigrator
+		args          []string
+		wantOutput    string
+		wantDownSteps int
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - up",
+			migrator: &fakeMigrator{
+				applied: []database.MigrationStatus{{Version: 10, Name: "10_reconcile_schema", AppliedAt: &appliedAt}},
+			},
+			args:       []string{"up"},
+			wantOutput: "applied 10_reconcile_schema\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "Nominal case - up to date",
+			migrator:   &fakeMigrator{},
+			args:       []string{"up"},
+			wantOutput: "schema is up to date\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "Nominal case - down with steps",
+			migrator: &fakeMigrator{
+				reverted: []database.MigrationStatus{{Version: 10, Name: "10_reconcile_schema"}, {Version: 9, Name: "09_partitions"}},
+			},
+			args:          []string{"down", "2"},
+			wantOutput:    "rolled back 10_reconcile_schema\nrolled back 09_partitions\n",
+			wantDownSteps: 2,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:          "Nominal case - down defaults to one step",
+			migrator:      &fakeMigrator{},
+			args:          []string{"down"},
+			wantDownSteps: 1,
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "Nominal case - status",
+			migrator: &fakeMigrator{
+				statuses: []database.MigrationStatus{
+					{Version: 1, Name: "01_appschema", AppliedAt: &appliedAt},
+					{Version: 2, Name: "02_accounts"},
+				},
+			},
+			args: []string{"status"},
+			wantOutput: "VERSION  NAME          APPLIED AT\n" +
+				"1        01_appschema  2025-05-01T10:00:00Z\n" +
+				"2        02_accounts   pending\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "Error case - migration fails",
+			migrator: &fakeMigrator{err: errors.New("syntax error")},
+			args:     []string{"up"},
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "Error case - invalid steps",
+			migrator: &fakeMigrator{},
+			args:     []string{"down", "zero"},
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "Error case - missing command",
+			migrator: &fakeMigrator{},
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "Error case - unknown command",
+			migrator: &fakeMigrator{},
+			args:     []string{"redo"},
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(context.Background(), tt.migrator, tt.args, &out)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantOutput, out.String())
+			assert.Equal(t, tt.wantDownSteps, tt.migrator.downSteps)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// Usage describes the prices command line.
+const Usage = "usage: tezos-delegation-job prices import [-currency <code>] <file.csv | ->"
+
+// Run executes the prices subcommand described by args, reading the CSV named by args or stdin for "-", and writes
+// its report to out.
+func Run(ctx context.Context, importPricesFunc usecase.ImportPricesFunc, args []string, stdin io.Reader, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(Usage)
+	}
+	if args[0] != "import" {
+		return fmt.Errorf("unknown prices command %q, %s", args[0], Usage)
+	}
+
+	flags := flag.NewFlagSet("prices import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	currency := flags.String("currency", "", "ISO 4217 code of the prices, when the file has no currency column")
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%v, %s", err, Usage)
+	}
+	if flags.NArg() != 1 {
+		return errors.New(Usage)
+	}
+
+	in := stdin
+	if path := flags.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		in = f
+	}
+
+	count, err := importPricesFunc(ctx, in, model.Currency(strings.ToUpper(*currency)))
+	if err != nil {
+		return fmt.Errorf("imported %d prices: %w", count, err)
+	}
+	fmt.Fprintf(out, "imported %d prices\n", count)
+	return nil
+}
@@ -0,0 +1,71 @@
+package prices
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Run(t *testing.T) {
+	importPrices := func(ctx context.Context, r io.Reader, currency model.Currency) (int, error) {
+		content, _ := io.ReadAll(r)
+		if currency != "EUR" {
+			return 0, errors.New("unexpected currency")
+		}
+		return strings.Count(string(content), "\n") - 1, nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "Nominal case - standard input",
+			args:       []string{"import", "-currency", "eur", "-"},
+			wantOutput: "imported 2 prices\n",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "Error case - import fails",
+			args:    []string{"import", "-currency", "USD", "-"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - missing file",
+			args:    []string{"import", "-currency", "EUR"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - unreadable file",
+			args:    []string{"import", "-currency", "EUR", "does-not-exist.csv"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - unknown command",
+			args:    []string{"export"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - missing command",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stdin := strings.NewReader("date,price\n2024-01-01,1\n2024-01-02,2\n")
+			err := Run(context.Background(), importPrices, tt.args, stdin, &out)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantOutput, out.String())
+		})
+	}
+}
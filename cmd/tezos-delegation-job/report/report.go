@@ -0,0 +1,87 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+// Usage describes the report command line.
+const Usage = "usage: tezos-delegation-job report rewards -wallet <address> -year <year> -currency <code> [-format json|csv|html] [-output <file>]"
+
+// Run executes the report subcommand described by args and writes the report to out, or to the -output file.
+func Run(ctx context.Context, getRewardsReportFunc usecase.GetRewardsReportFunc, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(Usage)
+	}
+	if args[0] != "rewards" {
+		return fmt.Errorf("unknown report %q, %s", args[0], Usage)
+	}
+
+	flags := flag.NewFlagSet("report rewards", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	wallet := flags.String("wallet", "", "address receiving the rewards")
+	year := flags.Int("year", 0, "fiscal year, a UTC calendar year")
+	currency := flags.String("currency", "", "ISO 4217 code of the fiat currency valuing the rewards")
+	format := flags.String("format", "json", "json, csv or html")
+	output := flags.String("output", "", "file written instead of the standard output")
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%v, %s", err, Usage)
+	}
+
+	input := usecase.GetRewardsReportInput{
+		Wallet:   model.WalletAddress(*wallet),
+		Year:     *year,
+		Currency: model.Currency(strings.ToUpper(*currency)),
+	}
+	if !input.Wallet.IsValid() {
+		return fmt.Errorf("invalid wallet address %q, %s", *wallet, Usage)
+	}
+
+	var write func(*model.RewardsReport, io.Writer) error
+	switch *format {
+	case "json":
+		write = func(r *model.RewardsReport, w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(r)
+		}
+	case "csv":
+		write = (*model.RewardsReport).WriteCSV
+	case "html":
+		write = (*model.RewardsReport).WriteHTML
+	default:
+		return fmt.Errorf("invalid format %q, use json, csv or html", *format)
+	}
+
+	report, err := getRewardsReportFunc(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return write(report, out)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(report, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "wrote %d rewards to %s\n", len(report.Rewards), *output)
+	return nil
+}
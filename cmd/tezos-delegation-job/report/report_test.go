@@ -0,0 +1,92 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+	"github.com/tezos-delegation-service/internal/usecase"
+)
+
+const testWallet = "tz1VSUr8wwNhLAzempoch5d6hLRiTh8Cjcjb"
+
+func Test_Run(t *testing.T) {
+	getRewardsReport := func(ctx context.Context, input usecase.GetRewardsReportInput) (*model.RewardsReport, error) {
+		if input.Wallet != testWallet || input.Year != 2024 || input.Currency != "EUR" {
+			return nil, errors.New("unexpected input")
+		}
+		return model.NewRewardsReport(input.Wallet, input.Year, input.Currency,
+			[]model.Reward{{SourceAddress: "tz1baker", Cycle: 700, Amount: 1_000_000, Timestamp: 1704067200}},
+			[]model.Price{{Day: 1704067200, Currency: "EUR", Price: "2"}}), nil
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - csv",
+			args: []string{"rewards", "-wallet", testWallet, "-year", "2024", "-currency", "eur", "-format", "csv"},
+			wantOutput: "cycle,date,baker,amount,amount_tez,price,value\n" +
+				"700,2024-01-01,tz1baker,1000000,1.000000,2,2.00\n" +
+				"total,,,1000000,1.000000,,2.00\n",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Error case - invalid format",
+			args:    []string{"rewards", "-wallet", testWallet, "-year", "2024", "-currency", "EUR", "-format", "pdf"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - invalid wallet",
+			args:    []string{"rewards", "-year", "2024", "-currency", "EUR"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - unknown flag",
+			args:    []string{"rewards", "-baker", testWallet},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - unknown report",
+			args:    []string{"delegations"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - missing report",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := Run(context.Background(), getRewardsReport, tt.args, &out)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantOutput, out.String())
+		})
+	}
+}
+
+func Test_Run_output(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	getRewardsReport := func(ctx context.Context, input usecase.GetRewardsReportInput) (*model.RewardsReport, error) {
+		return model.NewRewardsReport(input.Wallet, input.Year, input.Currency, nil, nil), nil
+	}
+
+	var out bytes.Buffer
+	err := Run(context.Background(), getRewardsReport, []string{"rewards", "-wallet", testWallet, "-year", "2024", "-currency", "EUR", "-format", "html", "-output", path}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "wrote 0 rewards to "+path+"\n", out.String())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "<h1>Staking rewards 2024</h1>")
+}
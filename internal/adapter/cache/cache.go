@@ -0,0 +1,12 @@
+package cache
+
+import "time"
+
+// Adapter defines the interface of the caches of query results.
+type Adapter interface {
+	// Get returns the value cached for a key, false when it is missing or expired.
+	Get(key string) (any, bool)
+
+	// Set caches the value of a key for ttl, replacing the one already cached.
+	Set(key string, value any, ttl time.Duration)
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"fmt"
+
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/lru"
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/noop"
+)
+
+// Implementation defines the type of cache implementation to use.
+type Implementation string
+
+const (
+	// ImplLRU is the in-process LRU implementation of the cache.
+	ImplLRU Implementation = "lru"
+
+	// ImplNoop is the no-op implementation of the cache, which caches nothing.
+	ImplNoop Implementation = "noop"
+)
+
+// String returns the string representation of the Implementation.
+func (i Implementation) String() string {
+	return string(i)
+}
+
+// Config represents the cache configuration.
+type Config struct {
+	Impl Implementation `mapstructure:"impl"`
+	LRU  lru.Config     `mapstructure:"lru"`
+}
+
+// New creates a new cache based on the provided implementation, the no-op one when none is configured.
+func New(cfg Config) (cache.Adapter, error) {
+	switch cfg.Impl {
+	case ImplLRU:
+		return lru.New(cfg.LRU), nil
+	case ImplNoop, "":
+		return noop.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache implementation: %s", cfg.Impl)
+	}
+}
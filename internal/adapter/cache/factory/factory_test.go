@@ -0,0 +1,72 @@
+package factory
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/lru"
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/noop"
+)
+
+func Test_Implementation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		i    Implementation
+		want string
+	}{
+		{name: "LRU", i: ImplLRU, want: "lru"},
+		{name: "Noop", i: ImplNoop, want: "noop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantType cache.Adapter
+		wantErr  bool
+	}{
+		{
+			name:     "Nominal case - LRU",
+			cfg:      Config{Impl: ImplLRU, LRU: lru.Config{MaxEntries: 100}},
+			wantType: &lru.Cache{},
+		},
+		{
+			name:     "Nominal case - Noop",
+			cfg:      Config{Impl: ImplNoop},
+			wantType: &noop.Cache{},
+		},
+		{
+			name:     "Nominal case - no implementation",
+			cfg:      Config{},
+			wantType: &noop.Cache{},
+		},
+		{
+			name:    "Error case - unknown implementation",
+			cfg:     Config{Impl: "redis"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(tt.wantType) {
+				t.Errorf("New() type = %T, want %T", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultMaxEntries bounds the cache when no max is configured.
+const defaultMaxEntries = 10000
+
+// Config represents the configuration of the in-process LRU cache.
+type Config struct {
+	// MaxEntries is the number of values kept, the least recently used one is evicted beyond.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// entry is a cached value with its key, kept in the recency list.
+type entry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// Cache implements the cache.Adapter interface with an in-process least recently used cache.
+type Cache struct {
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	recency *list.List
+}
+
+// New creates a new LRU cache.
+func New(cfg Config) *Cache {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+
+	return &Cache{
+		maxEntries: cfg.MaxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		recency:    list.New(),
+	}
+}
+
+// Get returns the value cached for a key, false when it is missing or expired.
+func (c *Cache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if !c.now().Before(e.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.recency.MoveToFront(elem)
+	return e.value, true
+}
+
+// Set caches the value of a key for ttl, evicting the least recently used value when the cache is full.
+func (c *Cache) Set(key string, value any, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		c.recency.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.recency.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	if c.recency.Len() > c.maxEntries {
+		c.remove(c.recency.Back())
+	}
+}
+
+// Len returns the number of values cached, expired ones included until they are evicted.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.recency.Len()
+}
+
+// remove drops an element of the cache.
+func (c *Cache) remove(elem *list.Element) {
+	c.recency.Remove(elem)
+	delete(c.entries, elem.Value.(*entry).key)
+}
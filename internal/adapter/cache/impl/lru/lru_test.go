@@ -0,0 +1,80 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cache_Get(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		key       string
+		ttl       time.Duration
+		elapsed   time.Duration
+		wantValue any
+		wantOK    bool
+	}{
+		{
+			name:      "Nominal case",
+			key:       "delegations",
+			ttl:       time.Minute,
+			elapsed:   59 * time.Second,
+			wantValue: 42,
+			wantOK:    true,
+		},
+		{
+			name:    "Error case - expired value",
+			key:     "delegations",
+			ttl:     time.Minute,
+			elapsed: time.Minute,
+		},
+		{
+			name: "Error case - unknown key",
+			key:  "rewards",
+			ttl:  time.Minute,
+		},
+		{
+			name: "Error case - value not cached without ttl",
+			key:  "delegations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{})
+			c.now = func() time.Time { return now }
+			c.Set("delegations", 42, tt.ttl)
+
+			c.now = func() time.Time { return now.Add(tt.elapsed) }
+			got, ok := c.Get(tt.key)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantValue, got)
+		})
+	}
+}
+
+func Test_Cache_Set(t *testing.T) {
+	c := New(Config{MaxEntries: 2})
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+
+	// Reading a makes b the least recently used value, evicted by c.
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+	c.Set("c", 3, time.Minute)
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.Len())
+
+	// Replacing a value does not evict another one.
+	c.Set("a", 4, time.Minute)
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 4, got)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
@@ -0,0 +1,19 @@
+package noop
+
+import "time"
+
+// Cache implements the cache.Adapter interface without caching anything.
+type Cache struct{}
+
+// New creates a new no-op cache.
+func New() *Cache {
+	return &Cache{}
+}
+
+// Get is a no-op implementation, every lookup misses.
+func (c *Cache) Get(key string) (any, bool) {
+	return nil, false
+}
+
+// Set is a no-op implementation.
+func (c *Cache) Set(key string, value any, ttl time.Duration) {}
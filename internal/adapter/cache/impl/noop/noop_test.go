@@ -0,0 +1,15 @@
+package noop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_Get(t *testing.T) {
+	c := New()
+	c.Set("delegations", 42, time.Minute)
+
+	if got, ok := c.Get("delegations"); ok || got != nil {
+		t.Errorf("Get() = %v, %v, want nil, false", got, ok)
+	}
+}
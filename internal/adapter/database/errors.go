@@ -0,0 +1,51 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tezos-delegation-service/internal/apperror"
+)
+
+var (
+	// ErrTimeout is returned when a query exceeds its statement timeout.
+	ErrTimeout = apperror.New(apperror.KindTimeout, "database_timeout", "database query timed out")
+
+	// ErrUnavailable is returned when the database cannot serve a query, e.g. connection failure or too many connections.
+	ErrUnavailable = apperror.New(apperror.KindUnavailable, "database_unavailable", "database unavailable")
+)
+
+// QueryError is a failed adapter operation, classified by Kind.
+type QueryError struct {
+	// Operation is the name of the adapter method, e.g. GetOperations.
+	Operation string
+	// Kind is ErrTimeout or ErrUnavailable.
+	Kind error
+	// Err is the driver error.
+	Err error
+}
+
+// NewQueryError returns a QueryError of kind for operation.
+func NewQueryError(operation string, kind, err error) *QueryError {
+	return &QueryError{Operation: operation, Kind: kind, Err: err}
+}
+
+// Error implements the error interface.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s: %v: %v", e.Operation, e.Kind, e.Err)
+}
+
+// Unwrap allows errors.Is and errors.As to match both the kind and the driver error.
+func (e *QueryError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// IsTimeout reports whether err is a database query timeout.
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
+
+// IsUnavailable reports whether err is due to the database being unavailable.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
 	"github.com/tezos-delegation-service/internal/adapter/database/impl/psql"
+	"github.com/tezos-delegation-service/internal/adapter/database/impl/sqlite"
 	"github.com/tezos-delegation-service/internal/adapter/database/proxy"
 	"github.com/tezos-delegation-service/internal/adapter/metrics"
 )
@@ -16,6 +18,9 @@ const (
 	// ImplPSQL is the PostgreSQL implementation of the repository.
 	ImplPSQL Implementation = "psql"
 
+	// ImplSQLite is the SQLite implementation of the repository, meant for local development.
+	ImplSQLite Implementation = "sqlite"
+
 	// ImplMemory is the in-memory implementation of the repository.
 	ImplMemory Implementation = "memory"
 )
@@ -27,8 +32,9 @@ func (i Implementation) String() string {
 
 // Config represents the database configuration.
 type Config struct {
-	Impl Implementation `mapstructure:"impl"`
-	PSQL *psql.Config   `mapstructure:"psql"`
+	Impl   Implementation `mapstructure:"impl"`
+	PSQL   *psql.Config   `mapstructure:"psql"`
+	SQLite *sqlite.Config `mapstructure:"sqlite"`
 }
 
 // New creates a new repository factory.
@@ -47,11 +53,37 @@ func New(cfg Config, metricsClient metrics.Adapter) (database.Adapter, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQL repository: %w", err)
 		}
-		// case ImplMemory:
-	// 	adapter = memory.New()
+	case ImplSQLite:
+		if cfg.SQLite == nil {
+			return nil, fmt.Errorf("SQLite config is required for SQLite implementation")
+		}
+		adapter, err = sqlite.New(*cfg.SQLite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SQLite repository: %w", err)
+		}
+	case ImplMemory:
+		adapter = memory.New()
 	default:
 		return nil, fmt.Errorf("unsupported implementation type: %s", cfg.Impl)
 	}
 
 	return proxy.New(adapter, cfg.Impl.String(), metricsClient), nil
 }
+
+// NewMigrator creates the schema migrator of the configured implementation.
+// Only the PostgreSQL implementation has versioned migrations, the others create their schema on startup.
+func NewMigrator(cfg Config) (database.Migrator, error) {
+	if cfg.Impl != ImplPSQL {
+		return nil, fmt.Errorf("migrations are not supported by implementation type: %s", cfg.Impl)
+	}
+	if cfg.PSQL == nil {
+		return nil, fmt.Errorf("PSQL config is required for SQL implementation")
+	}
+
+	migrator, err := psql.NewMigrator(*cfg.PSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SQL migrator: %w", err)
+	}
+
+	return migrator, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	databasesql "github.com/tezos-delegation-service/internal/adapter/database/impl/psql"
+	databasesqlite "github.com/tezos-delegation-service/internal/adapter/database/impl/sqlite"
 	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
 )
 
@@ -73,6 +74,29 @@ func Test_New(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Nominal case - SQLite",
+			cfg: Config{
+				Impl:   ImplSQLite,
+				SQLite: &databasesqlite.Config{Path: ":memory:"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case - Missing SQLite config",
+			cfg: Config{
+				Impl:   ImplSQLite,
+				SQLite: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nominal case - Memory",
+			cfg: Config{
+				Impl: ImplMemory,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Error case - Unsupported implementation",
 			cfg: Config{
@@ -97,3 +121,45 @@ func Test_New(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewMigrator(t *testing.T) {
+	os.Setenv("GO_TESTING", "1")
+	defer os.Unsetenv("GO_TESTING")
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - PSQL",
+			cfg: Config{
+				Impl: ImplPSQL,
+				PSQL: &databasesql.Config{Host: "localhost", Port: 5432, DBName: "tezos"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - Missing PSQL config",
+			cfg: Config{
+				Impl: ImplPSQL,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error case - SQLite has no migrations",
+			cfg: Config{
+				Impl:   ImplSQLite,
+				SQLite: &databasesqlite.Config{Path: ":memory:"},
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMigrator(tt.cfg)
+			tt.wantErr(t, err)
+			assert.Equal(t, err == nil, got != nil)
+		})
+	}
+}
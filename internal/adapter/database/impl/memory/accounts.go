@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetAccount returns an account, or sql.ErrNoRows when the address is unknown.
+func (m *Memory) GetAccount(_ context.Context, address model.WalletAddress) (*model.Account, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	account, ok := m.accounts[address]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &account, nil
+}
+
+// GetDelegatorDelegations returns every delegation of a delegator by increasing level.
+func (m *Memory) GetDelegatorDelegations(_ context.Context, delegator model.WalletAddress) ([]model.Delegation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	delegations := make([]model.Delegation, 0)
+	for _, d := range m.delegations {
+		if d.Delegator == delegator {
+			delegations = append(delegations, d)
+		}
+	}
+
+	sort.Slice(delegations, func(i, j int) bool {
+		return delegations[i].Level < delegations[j].Level
+	})
+	return delegations, nil
+}
+
+// GetTotalRewards returns the sum of the rewards received by a wallet.
+func (m *Memory) GetTotalRewards(_ context.Context, recipient model.WalletAddress) (model.Mutez, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var total model.Mutez
+	for _, r := range m.rewards {
+		if r.RecipientAddress == recipient {
+			total += r.Amount
+		}
+	}
+	return total, nil
+}
+
+// GetStakingOperationCounts returns the number of staking operations sent by a wallet, per entrypoint.
+func (m *Memory) GetStakingOperationCounts(_ context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[string]int64)
+	for _, o := range m.operations {
+		if o.SenderAddress == wallet {
+			counts[o.Entrypoint]++
+		}
+	}
+
+	result := make([]model.StakingOperationCount, 0, len(counts))
+	for entrypoint, count := range counts {
+		result = append(result, model.StakingOperationCount{Entrypoint: entrypoint, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Entrypoint < result[j].Entrypoint
+	})
+	return result, nil
+}
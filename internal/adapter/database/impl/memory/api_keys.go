@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// apiKeyUsageKey identifies the requests of a key on a UTC day.
+type apiKeyUsageKey struct {
+	apiKeyID int64
+	day      string
+}
+
+// SaveAPIKey saves a new API key, setting its id and creation date.
+func (m *Memory) SaveAPIKey(_ context.Context, key *model.APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key.ID = int64(len(m.apiKeys)) + 1
+	key.CreatedAt = time.Now().UTC()
+
+	saved := *key
+	m.apiKeys = append(m.apiKeys, &saved)
+	return nil
+}
+
+// GetAPIKeys returns every API key, revoked ones included, by increasing id.
+func (m *Memory) GetAPIKeys(_ context.Context) ([]model.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]model.APIKey, 0, len(m.apiKeys))
+	for _, k := range m.apiKeys {
+		keys = append(keys, *k)
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByHash returns the API key of a hash, or sql.ErrNoRows when it is unknown.
+func (m *Memory) GetAPIKeyByHash(_ context.Context, hash string) (*model.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, k := range m.apiKeys {
+		if k.Hash == hash {
+			key := *k
+			return &key, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// RevokeAPIKey stops a key from authenticating requests from the given date, a revoked key keeps its first date.
+// It returns sql.ErrNoRows when the key is unknown.
+func (m *Memory) RevokeAPIKey(_ context.Context, id int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range m.apiKeys {
+		if k.ID == id {
+			if k.RevokedAt == nil {
+				k.RevokedAt = &at
+			}
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// IncrementAPIKeyUsage counts a request of a key on the UTC day of the given date, and returns the requests of that day.
+func (m *Memory) IncrementAPIKeyUsage(_ context.Context, id int64, at time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := apiKeyUsageKey{apiKeyID: id, day: at.UTC().Format("2006-01-02")}
+	m.apiKeyUsage[key]++
+	return m.apiKeyUsage[key], nil
+}
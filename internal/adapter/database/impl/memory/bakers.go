@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetBakers returns the staking pools with their current delegators and their activity over the period of filter, by page.
+func (m *Memory) GetBakers(_ context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bakers := make([]model.Baker, 0, len(m.stakingPools))
+	for _, pool := range m.stakingPools {
+		if filter.MatchSearch(pool.Name) {
+			bakers = append(bakers, m.baker(pool, filter))
+		}
+	}
+
+	desc := filter.SortOrder() == model.SortOrderDesc
+	sort.Slice(bakers, func(i, j int) bool {
+		a, b := bakerSortValue(bakers[i], filter.SortField()), bakerSortValue(bakers[j], filter.SortField())
+		if a != b {
+			return (a > b) == desc
+		}
+		return bakers[i].Address < bakers[j].Address
+	})
+
+	if page < 1 {
+		page = 1
+	}
+	return paginate(bakers, (int(page)-1)*int(limit), int(limit)), nil
+}
+
+// GetBaker returns a staking pool with its activity between fromDate and toDate, or sql.ErrNoRows when it is unknown.
+func (m *Memory) GetBaker(_ context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pool, ok := m.stakingPools[address]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	baker := m.baker(pool, model.BakerFilter{FromDate: fromDate, ToDate: toDate})
+	return &baker, nil
+}
+
+// baker returns a staking pool with its current delegators and its activity over the period of filter.
+func (m *Memory) baker(pool model.StakingPool, filter model.BakerFilter) model.Baker {
+	baker := model.Baker{Address: pool.Address, Alias: pool.Name}
+
+	for _, d := range m.currentDelegations {
+		if d.Baker == pool.Address {
+			baker.Delegators++
+			baker.StakingBalance += d.Balance
+		}
+	}
+
+	for key, s := range m.delegationStats {
+		if key.baker == pool.Address && filter.InPeriod(key.day) {
+			baker.NewDelegations += s.NewDelegations
+			baker.Undelegations += s.Undelegations
+			baker.DelegatedVolume += s.DelegatedVolume
+		}
+	}
+	baker.NetInflow = baker.NewDelegations - baker.Undelegations
+
+	for _, r := range m.rewards {
+		if r.SourceAddress == pool.Address && filter.InPeriod(r.Timestamp) {
+			baker.Rewards += r.Amount
+		}
+	}
+
+	return baker
+}
+
+// bakerSortValue returns the value of the sort field of a baker.
+func bakerSortValue(b model.Baker, field model.BakerSort) int64 {
+	switch field {
+	case model.BakerSortDelegatedVolume:
+		return int64(b.DelegatedVolume)
+	case model.BakerSortRewards:
+		return int64(b.Rewards)
+	case model.BakerSortNetInflow:
+		return b.NetInflow
+	default:
+		return b.Delegators
+	}
+}
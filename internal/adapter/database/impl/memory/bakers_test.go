@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Memory_Bakers(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	delegations := []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1pool1", Timestamp: model.SecondsPerDay, Amount: 10, Level: 10},
+		{Delegator: "tz1b", Delegate: "tz1pool1", Timestamp: model.SecondsPerDay, Amount: 20, Level: 11},
+		{Delegator: "tz1c", Delegate: "tz1pool2", Timestamp: 2 * model.SecondsPerDay, Amount: 100, Level: 20},
+		// tz1a leaves tz1pool1 for tz1pool2 on the third day.
+		{Delegator: "tz1a", Delegate: "tz1pool2", Timestamp: 3 * model.SecondsPerDay, Amount: 10, Level: 30},
+	}
+	assert.NoError(t, m.SaveStakingPools(ctx, []model.StakingPool{
+		{Address: "tz1pool1", Name: "Alpha Baker", StakingToken: "XTZ"},
+		{Address: "tz1pool2", Name: "Beta_Baker", StakingToken: "XTZ"},
+	}))
+	assert.NoError(t, m.SaveDelegations(ctx, delegations))
+	assert.NoError(t, m.SaveCurrentDelegations(ctx, model.CurrentDelegationsFromDelegations(delegations)))
+	assert.NoError(t, m.RefreshDelegationStats(ctx, 0, 3*model.SecondsPerDay))
+	assert.NoError(t, m.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1pool1", Cycle: 1, Amount: 50, Timestamp: model.SecondsPerDay},
+		{RecipientAddress: "tz1c", SourceAddress: "tz1pool2", Cycle: 3, Amount: 5, Timestamp: 3 * model.SecondsPerDay},
+	}))
+
+	pool1 := model.Baker{Address: "tz1pool1", Alias: "Alpha Baker", Delegators: 1, StakingBalance: 20, DelegatedVolume: 30, Rewards: 50, NewDelegations: 2, Undelegations: 1, NetInflow: 1}
+	pool2 := model.Baker{Address: "tz1pool2", Alias: "Beta_Baker", Delegators: 2, StakingBalance: 110, DelegatedVolume: 110, Rewards: 5, NewDelegations: 2, NetInflow: 2}
+
+	bakers, err := m.GetBakers(ctx, model.BakerFilter{}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Baker{pool2, pool1}, bakers)
+
+	bakers, err = m.GetBakers(ctx, model.BakerFilter{Sort: model.BakerSortRewards}, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Baker{pool1}, bakers)
+
+	// The underscore of the search is not a wildcard, "Alpha Baker" does not match.
+	bakers, err = m.GetBakers(ctx, model.BakerFilter{Search: "A_b"}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Baker{pool2}, bakers)
+
+	period := model.BakerFilter{FromDate: 3 * model.SecondsPerDay, Sort: model.BakerSortNetInflow, Order: model.SortOrderAsc}
+	bakers, err = m.GetBakers(ctx, period, 1, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, bakers, 2) {
+		assert.Equal(t, model.WalletAddress("tz1pool1"), bakers[0].Address)
+		assert.Equal(t, int64(-1), bakers[0].NetInflow)
+		assert.Equal(t, model.Mutez(0), bakers[0].Rewards)
+	}
+
+	baker, err := m.GetBaker(ctx, "tz1pool2", 3*model.SecondsPerDay, 4*model.SecondsPerDay)
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.Baker{Address: "tz1pool2", Alias: "Beta_Baker", Delegators: 2, StakingBalance: 110, DelegatedVolume: 10, Rewards: 5, NewDelegations: 1, NetInflow: 1}, *baker)
+	}
+
+	_, err = m.GetBaker(ctx, "tz1unknown", 0, 0)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
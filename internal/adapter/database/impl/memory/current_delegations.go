@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SaveCurrentDelegations upserts the current delegation state of delegators, keeping the most recent one.
+func (m *Memory) SaveCurrentDelegations(_ context.Context, delegations []model.CurrentDelegation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range delegations {
+		if current, ok := m.currentDelegations[d.Delegator]; ok && current.SinceLevel >= d.SinceLevel {
+			continue
+		}
+		d.BalanceTez = ""
+		m.currentDelegations[d.Delegator] = d
+	}
+	return nil
+}
+
+// GetActiveDelegators returns the sorted delegators currently delegated to a baker.
+func (m *Memory) GetActiveDelegators(_ context.Context) ([]model.WalletAddress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	delegators := make([]model.WalletAddress, 0, len(m.currentDelegations))
+	for _, d := range m.currentDelegations {
+		if d.Baker != "" {
+			delegators = append(delegators, d.Delegator)
+		}
+	}
+
+	sort.Slice(delegators, func(i, j int) bool {
+		return delegators[i] < delegators[j]
+	})
+
+	return delegators, nil
+}
+
+// GetBakerForDelegatorAtLevel returns the baker of a delegator at the end of asOfLevel, or from its current delegation
+// state when asOfLevel is 0. The baker is empty when the delegator was undelegated, sql.ErrNoRows when it is unknown.
+func (m *Memory) GetBakerForDelegatorAtLevel(_ context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	states := m.currentDelegations
+	if asOfLevel > 0 {
+		states = m.delegationsAt(asOfLevel)
+	}
+
+	state, ok := states[delegator]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return state.Baker, nil
+}
+
+// GetBakerDelegators returns the delegators delegated to baker, by decreasing balance.
+// With a positive asOfLevel, the delegation set is reconstructed from the history as it was at the end of that level.
+func (m *Memory) GetBakerDelegators(_ context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	states := m.currentDelegations
+	if asOfLevel > 0 {
+		states = m.delegationsAt(asOfLevel)
+	}
+
+	delegators := make([]model.CurrentDelegation, 0)
+	for _, d := range states {
+		if d.Baker == baker {
+			delegators = append(delegators, d)
+		}
+	}
+
+	sort.Slice(delegators, func(i, j int) bool {
+		if delegators[i].Balance != delegators[j].Balance {
+			return delegators[i].Balance > delegators[j].Balance
+		}
+		return delegators[i].Delegator < delegators[j].Delegator
+	})
+
+	if page < 1 {
+		page = 1
+	}
+	return paginate(delegators, (int(page)-1)*int(limit), int(limit)), nil
+}
+
+// delegationsAt returns the delegation state of every delegator at the end of level.
+func (m *Memory) delegationsAt(level int64) map[model.WalletAddress]model.CurrentDelegation {
+	history := make([]*model.Delegation, 0, len(m.delegations))
+	for i, d := range m.delegations {
+		if d.Level <= level {
+			history = append(history, &m.delegations[i])
+		}
+	}
+
+	states := make(map[model.WalletAddress]model.CurrentDelegation)
+	for _, d := range model.CurrentDelegationsFromDelegations(history) {
+		states[d.Delegator] = d
+	}
+	return states
+}
+
+// SaveCycles saves the levels of cycles, replacing the ones already saved.
+func (m *Memory) SaveCycles(_ context.Context, cycles []model.Cycle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range cycles {
+		m.cycles[c.Index] = c
+	}
+	return nil
+}
+
+// GetCycle returns the levels of a cycle, or sql.ErrNoRows when the cycle is not synced.
+func (m *Memory) GetCycle(_ context.Context, cycle int) (*model.Cycle, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	c, ok := m.cycles[cycle]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &c, nil
+}
+
+// GetMissingCycles returns the cycles from fromCycle to toCycle, both included, whose levels are not saved.
+func (m *Memory) GetMissingCycles(_ context.Context, fromCycle, toCycle int) ([]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cycles := []int{}
+	for cycle := fromCycle; cycle <= toCycle; cycle++ {
+		if _, ok := m.cycles[cycle]; !ok {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles, nil
+}
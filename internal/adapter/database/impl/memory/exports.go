@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// StreamDelegations calls fn for every delegation matching the filter, in its order.
+// The matching delegations are copied first, so that fn runs without holding the lock.
+func (m *Memory) StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error {
+	var startDate, endDate int64
+	if filter.Year > 0 {
+		startDate = time.Date(int(filter.Year), 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		endDate = time.Date(int(filter.Year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	}
+
+	m.mu.RLock()
+	delegations := make([]model.Delegation, 0)
+	for _, d := range m.delegations {
+		if filter.Year > 0 && (d.Timestamp < startDate || d.Timestamp >= endDate) {
+			continue
+		}
+		if filter.Match(d) {
+			delegations = append(delegations, d)
+		}
+	}
+	m.mu.RUnlock()
+
+	sortDelegations(delegations, filter)
+	return streamEach(ctx, delegations, fn)
+}
+
+// StreamOperations calls fn for every operation matching the filters, by decreasing timestamp.
+func (m *Memory) StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error {
+	m.mu.RLock()
+	operations := m.operationsMatching(fromDate, toDate, operationType, wallet, baker, nil)
+	m.mu.RUnlock()
+
+	return streamEach(ctx, operations, fn)
+}
+
+// StreamRewards calls fn for every reward matching the filters, by decreasing timestamp.
+func (m *Memory) StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error {
+	m.mu.RLock()
+	rewards := m.rewardsMatching(fromDate, toDate, wallet, baker, nil)
+	m.mu.RUnlock()
+
+	return streamEach(ctx, rewards, fn)
+}
+
+// streamEach calls fn for every item until it fails or ctx is done.
+func streamEach[T any](ctx context.Context, items []T, fn func(T) error) error {
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Memory_Stream(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	assert.NoError(t, m.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1pool1", Timestamp: model.SecondsPerDay, Amount: 30, Level: 10},
+		{Delegator: "tz1b", Delegate: "tz1pool1", Timestamp: 2 * model.SecondsPerDay, Amount: 10, Level: 20},
+		{Delegator: "tz1a", Delegate: "tz1pool2", Timestamp: 3 * model.SecondsPerDay, Amount: 20, Level: 30},
+	}))
+	assert.NoError(t, m.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1pool1", Cycle: 1, Amount: 5, Timestamp: model.SecondsPerDay},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1pool1", Cycle: 2, Amount: 6, Timestamp: 2 * model.SecondsPerDay},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1pool1", Cycle: 2, Amount: 7, Timestamp: 2 * model.SecondsPerDay},
+	}))
+
+	var levels []int64
+	err := m.StreamDelegations(ctx, model.DelegationFilter{Delegator: "tz1a", Sort: model.DelegationSortAmount, Order: model.SortOrderAsc}, func(d model.Delegation) error {
+		levels = append(levels, d.Level)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{30, 10}, levels)
+
+	var cycles []int
+	err = m.StreamRewards(ctx, model.SecondsPerDay, 0, "tz1a", "tz1pool1", func(r model.Reward) error {
+		cycles = append(cycles, r.Cycle)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, cycles)
+
+	// A failing fn stops the stream with its error.
+	errWrite := errors.New("broken pipe")
+	calls := 0
+	err = m.StreamRewards(ctx, 0, 0, "", "", func(r model.Reward) error {
+		calls++
+		return errWrite
+	})
+	assert.ErrorIs(t, err, errWrite)
+	assert.Equal(t, 1, calls)
+}
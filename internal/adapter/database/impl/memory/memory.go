@@ -0,0 +1,463 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+type delegationKey struct {
+	delegator model.WalletAddress
+	level     int64
+}
+
+type rewardKey struct {
+	recipient model.WalletAddress
+	source    model.WalletAddress
+	cycle     int
+}
+
+// Memory implements database.Adapter by keeping every row in memory.
+// It follows the filtering, ordering and pagination rules of the psql adapter.
+type Memory struct {
+	mu sync.RWMutex
+
+	accounts     map[model.WalletAddress]model.Account
+	delegations  []model.Delegation
+	operations   []model.Operation
+	rewards      []model.Reward
+	stakingPools map[model.WalletAddress]model.StakingPool
+
+	delegationKeys map[delegationKey]struct{}
+	rewardKeys     map[rewardKey]struct{}
+
+	delegationStats  map[dailyStatsKey]*model.DailyDelegationStats
+	bakerRewards     map[cycleStatsKey]*model.BakerCycleRewards
+	delegatorRewards map[cycleStatsKey]*model.DelegatorCycleRewards
+
+	currentDelegations map[model.WalletAddress]model.CurrentDelegation
+	cycles             map[int]model.Cycle
+	prices             map[priceKey]string
+
+	webhooks          map[int64]*model.Webhook
+	webhookDeliveries []*model.WebhookDelivery
+	webhookEventKeys  map[webhookEventKey]struct{}
+	syncCursors       map[string]int64
+
+	apiKeys     []*model.APIKey
+	apiKeyUsage map[apiKeyUsageKey]int64
+
+	listeners []chan struct{}
+
+	lastSyncedRewardCycle *int
+	lastID                int64
+
+	lastWebhookID         int64
+	lastWebhookDeliveryID int64
+}
+
+var _ database.Adapter = (*Memory)(nil)
+
+// New creates an empty in-memory database.
+func New() *Memory {
+	return &Memory{
+		accounts:       make(map[model.WalletAddress]model.Account),
+		stakingPools:   make(map[model.WalletAddress]model.StakingPool),
+		delegationKeys: make(map[delegationKey]struct{}),
+		rewardKeys:     make(map[rewardKey]struct{}),
+
+		delegationStats:  make(map[dailyStatsKey]*model.DailyDelegationStats),
+		bakerRewards:     make(map[cycleStatsKey]*model.BakerCycleRewards),
+		delegatorRewards: make(map[cycleStatsKey]*model.DelegatorCycleRewards),
+
+		currentDelegations: make(map[model.WalletAddress]model.CurrentDelegation),
+		cycles:             make(map[int]model.Cycle),
+		prices:             make(map[priceKey]string),
+
+		webhooks:         make(map[int64]*model.Webhook),
+		webhookEventKeys: make(map[webhookEventKey]struct{}),
+		syncCursors:      make(map[string]int64),
+
+		apiKeyUsage: make(map[apiKeyUsageKey]int64),
+	}
+}
+
+// Ping always succeeds.
+func (m *Memory) Ping() error {
+	return nil
+}
+
+// GetDelegations returns delegations by page or after a cursor, with optional filters, order and maxDelegationID filter.
+func (m *Memory) GetDelegations(_ context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > 200 {
+		limit = 200
+	}
+
+	year := filter.Year
+	applyMaxIDFilter := maxDelegationID > 0 && page > 1 && (year == 0 || int(year) == time.Now().Year())
+
+	var startDate, endDate int64
+	if year > 0 {
+		startDate = time.Date(int(year), 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		endDate = time.Date(int(year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	}
+
+	delegations := make([]model.Delegation, 0)
+	for _, d := range m.delegations {
+		if year > 0 && (d.Timestamp < startDate || d.Timestamp >= endDate) {
+			continue
+		}
+		if !filter.Match(d) {
+			continue
+		}
+		if applyMaxIDFilter && uint64(d.ID) > maxDelegationID {
+			continue
+		}
+		if cursor != nil && !cursor.After(d.Timestamp, d.ID) {
+			continue
+		}
+		delegations = append(delegations, d)
+	}
+
+	sortDelegations(delegations, filter)
+
+	return paginate(delegations, int(page-1)*int(limit), int(limit)), nil
+}
+
+// sortDelegations sorts delegations in the order of the filter, ties broken by id in the same direction.
+func sortDelegations(delegations []model.Delegation, filter model.DelegationFilter) {
+	key := func(d model.Delegation) int64 {
+		switch filter.SortField() {
+		case model.DelegationSortLevel:
+			return d.Level
+		case model.DelegationSortAmount:
+			return int64(d.Amount)
+		default:
+			return d.Timestamp
+		}
+	}
+	asc := filter.SortOrder() == model.SortOrderAsc
+
+	sort.Slice(delegations, func(i, j int) bool {
+		ki, kj := key(delegations[i]), key(delegations[j])
+		if ki == kj {
+			ki, kj = delegations[i].ID, delegations[j].ID
+		}
+		if asc {
+			return ki < kj
+		}
+		return ki > kj
+	})
+}
+
+// GetLatestDelegation returns the delegation with the highest level.
+func (m *Memory) GetLatestDelegation(_ context.Context) (*model.Delegation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var latest *model.Delegation
+	for i := range m.delegations {
+		if latest == nil || m.delegations[i].Level > latest.Level {
+			latest = &m.delegations[i]
+		}
+	}
+
+	if latest == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	delegation := *latest
+	return &delegation, nil
+}
+
+// GetHighestBlockLevel returns the highest block level, or 0 when there is no delegation.
+func (m *Memory) GetHighestBlockLevel(_ context.Context) (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var level int64
+	for _, d := range m.delegations {
+		if d.Level > level {
+			level = d.Level
+		}
+	}
+	return uint64(level), nil
+}
+
+// GetOperations returns operations with pagination and optional operationType, wallet and baker filters.
+func (m *Memory) GetOperations(_ context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > 200 {
+		limit = 200
+	}
+
+	operations := m.operationsMatching(fromDate, toDate, operationType, wallet, baker, cursor)
+	return paginate(operations, int(page-1)*int(limit), int(limit)), nil
+}
+
+// operationsMatching returns the operations matching the filters after cursor, by decreasing timestamp.
+func (m *Memory) operationsMatching(fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) []model.Operation {
+	operations := make([]model.Operation, 0)
+	for _, o := range m.operations {
+		if fromDate > 0 && o.Timestamp < fromDate {
+			continue
+		}
+		if toDate > 0 && o.Timestamp >= toDate {
+			continue
+		}
+		if operationType != "" && o.Entrypoint != operationType.String() {
+			continue
+		}
+		if wallet != "" && o.SenderAddress != wallet {
+			continue
+		}
+		if baker != "" && o.ContractAddress != baker {
+			continue
+		}
+		if cursor != nil && !cursor.After(o.Timestamp, o.ID) {
+			continue
+		}
+		operations = append(operations, o)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].Timestamp != operations[j].Timestamp {
+			return operations[i].Timestamp > operations[j].Timestamp
+		}
+		return operations[i].ID > operations[j].ID
+	})
+
+	return operations
+}
+
+// GetRewards returns rewards for a given wallet and baker within a date range, by page or after a cursor.
+func (m *Memory) GetRewards(_ context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > model.MaxRewardsLimit {
+		limit = model.MaxRewardsLimit
+	}
+
+	rewards := m.rewardsMatching(fromDate, toDate, wallet, baker, cursor)
+	return paginate(rewards, int(page-1)*int(limit), int(limit)), nil
+}
+
+// rewardsMatching returns the rewards matching the filters after cursor, by decreasing timestamp.
+func (m *Memory) rewardsMatching(fromDate, toDate int64, wallet, baker model.WalletAddress, cursor *model.Cursor) []model.Reward {
+	rewards := make([]model.Reward, 0)
+	for _, r := range m.rewards {
+		if fromDate > 0 && r.Timestamp < fromDate {
+			continue
+		}
+		if toDate > 0 && r.Timestamp >= toDate {
+			continue
+		}
+		if wallet != "" && r.RecipientAddress != wallet {
+			continue
+		}
+		if baker != "" && r.SourceAddress != baker {
+			continue
+		}
+		if cursor != nil && !cursor.After(r.Timestamp, r.ID) {
+			continue
+		}
+		rewards = append(rewards, r)
+	}
+
+	sort.Slice(rewards, func(i, j int) bool {
+		if rewards[i].Timestamp != rewards[j].Timestamp {
+			return rewards[i].Timestamp > rewards[j].Timestamp
+		}
+		return rewards[i].ID > rewards[j].ID
+	})
+
+	return rewards
+}
+
+// GetLastSyncedRewardCycle returns the last synced reward cycle, or sql.ErrNoRows when none was saved.
+func (m *Memory) GetLastSyncedRewardCycle(_ context.Context) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.lastSyncedRewardCycle == nil {
+		return 0, sql.ErrNoRows
+	}
+	return *m.lastSyncedRewardCycle, nil
+}
+
+// SaveAccount saves an account, ignoring it if its address already exists.
+func (m *Memory) SaveAccount(_ context.Context, account model.Account) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.saveAccount(account)
+	return nil
+}
+
+// SaveAccounts saves multiple accounts, ignoring the addresses which already exist.
+func (m *Memory) SaveAccounts(_ context.Context, accounts []model.Account) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, account := range accounts {
+		m.saveAccount(account)
+	}
+	return nil
+}
+
+// SaveDelegation saves a delegation, ignoring it if the delegator already has one at the same level.
+func (m *Memory) SaveDelegation(_ context.Context, delegation *model.Delegation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.saveDelegation(*delegation)
+	return nil
+}
+
+// SaveDelegations saves multiple delegations, ignoring the ones which already exist.
+func (m *Memory) SaveDelegations(_ context.Context, delegations []*model.Delegation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, delegation := range delegations {
+		m.saveDelegation(*delegation)
+	}
+	m.notifyListeners()
+	return nil
+}
+
+// SaveStakingPools saves multiple staking pools, ignoring the addresses which already exist.
+func (m *Memory) SaveStakingPools(_ context.Context, stakingPools []model.StakingPool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, stakingPool := range stakingPools {
+		if _, ok := m.stakingPools[stakingPool.Address]; ok {
+			continue
+		}
+		stakingPool.ID = m.nextID()
+		m.stakingPools[stakingPool.Address] = stakingPool
+	}
+	return nil
+}
+
+// SaveRewards saves multiple rewards, ignoring the ones already saved for the same recipient, source and cycle.
+func (m *Memory) SaveRewards(_ context.Context, rewards []model.Reward) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reward := range rewards {
+		key := rewardKey{recipient: reward.RecipientAddress, source: reward.SourceAddress, cycle: reward.Cycle}
+		if _, ok := m.rewardKeys[key]; ok {
+			continue
+		}
+		m.rewardKeys[key] = struct{}{}
+		reward.ID = m.nextID()
+		m.rewards = append(m.rewards, reward)
+	}
+	return nil
+}
+
+// SaveLastSyncedRewardCycle saves the last synced reward cycle.
+func (m *Memory) SaveLastSyncedRewardCycle(_ context.Context, cycle int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastSyncedRewardCycle = &cycle
+	return nil
+}
+
+// SaveOperations stores operations, which the sync use cases never write, so that tests can seed them.
+func (m *Memory) SaveOperations(operations []model.Operation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, operation := range operations {
+		operation.ID = m.nextID()
+		m.operations = append(m.operations, operation)
+	}
+}
+
+// EnsurePartitions does nothing, the in-memory tables are not partitioned.
+func (m *Memory) EnsurePartitions(_ context.Context, _ time.Time) error {
+	return nil
+}
+
+// CheckSchemaVersion always succeeds, the in-memory tables have no versioned schema.
+func (m *Memory) CheckSchemaVersion(_ context.Context) error {
+	return nil
+}
+
+// Close always succeeds, stored rows are kept.
+func (m *Memory) Close() error {
+	return nil
+}
+
+// saveAccount stores account unless its address exists. The caller must hold the write lock.
+func (m *Memory) saveAccount(account model.Account) {
+	if _, ok := m.accounts[account.Address]; ok {
+		return
+	}
+	account.ID = m.nextID()
+	account.CreatedAt = time.Now()
+	m.accounts[account.Address] = account
+}
+
+// saveDelegation stores delegation unless it exists. The caller must hold the write lock.
+func (m *Memory) saveDelegation(delegation model.Delegation) {
+	key := delegationKey{delegator: delegation.Delegator, level: delegation.Level}
+	if _, ok := m.delegationKeys[key]; ok {
+		return
+	}
+	m.delegationKeys[key] = struct{}{}
+	delegation.ID = m.nextID()
+	delegation.CreatedAt = time.Now()
+	m.delegations = append(m.delegations, delegation)
+}
+
+// nextID returns the next row identifier. The caller must hold the write lock.
+func (m *Memory) nextID() int64 {
+	m.lastID++
+	return m.lastID
+}
+
+// paginate returns the page of items starting at offset, or an empty slice past the end.
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
@@ -0,0 +1,368 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func seedDelegations(t *testing.T, m *Memory) {
+	t.Helper()
+
+	currentYear := time.Now().Year()
+	delegations := []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1baker", Timestamp: time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC).Unix(), Amount: 10, Level: 100},
+		{Delegator: "tz1b", Delegate: "tz1baker", Timestamp: time.Date(currentYear, 1, 2, 0, 0, 0, 0, time.UTC).Unix(), Amount: 20, Level: 200},
+		{Delegator: "tz1c", Delegate: "tz1other", Timestamp: time.Date(currentYear, 1, 3, 0, 0, 0, 0, time.UTC).Unix(), Amount: 0, Level: 300},
+		{Delegator: "tz1d", Delegate: "tz1other", Timestamp: time.Date(currentYear, 1, 4, 0, 0, 0, 0, time.UTC).Unix(), Amount: 40, Level: 400},
+	}
+	assert.NoError(t, m.SaveDelegations(context.Background(), delegations))
+}
+
+// mutez returns a pointer to an amount, for the optional amount filters.
+func mutez(amount model.Mutez) *model.Mutez {
+	return &amount
+}
+
+func Test_Memory_GetDelegations(t *testing.T) {
+	m := New()
+	seedDelegations(t, m)
+	currentYear := uint16(time.Now().Year())
+
+	tests := []struct {
+		name            string
+		page            uint32
+		limit           uint16
+		filter          model.DelegationFilter
+		maxDelegationID uint64
+		cursor          *model.Cursor
+		wantDelegators  []model.WalletAddress
+	}{
+		{
+			name:           "Nominal case - newest first",
+			wantDelegators: []model.WalletAddress{"tz1d", "tz1c", "tz1b", "tz1a"},
+		},
+		{
+			name:           "Filter by year",
+			filter:         model.DelegationFilter{Year: 2022},
+			wantDelegators: []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:           "Second page",
+			page:           2,
+			limit:          3,
+			wantDelegators: []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:           "Page past the end",
+			page:           3,
+			limit:          3,
+			wantDelegators: []model.WalletAddress{},
+		},
+		{
+			name:            "maxDelegationID hides rows inserted after the first page",
+			page:            2,
+			limit:           1,
+			filter:          model.DelegationFilter{Year: currentYear},
+			maxDelegationID: 3,
+			wantDelegators:  []model.WalletAddress{"tz1b"},
+		},
+		{
+			name:            "maxDelegationID ignored on the first page",
+			page:            1,
+			limit:           1,
+			maxDelegationID: 1,
+			wantDelegators:  []model.WalletAddress{"tz1d"},
+		},
+		{
+			name:            "maxDelegationID ignored for a past year",
+			page:            1,
+			filter:          model.DelegationFilter{Year: 2022},
+			maxDelegationID: 0,
+			wantDelegators:  []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:           "Filter by delegate and level range",
+			filter:         model.DelegationFilter{Delegate: "tz1other", FromLevel: 350, ToLevel: 400},
+			wantDelegators: []model.WalletAddress{"tz1d"},
+		},
+		{
+			name:           "Filter by amount range",
+			filter:         model.DelegationFilter{MinAmount: mutez(10), MaxAmount: mutez(20)},
+			wantDelegators: []model.WalletAddress{"tz1b", "tz1a"},
+		},
+		{
+			name:           "Filter by kind",
+			filter:         model.DelegationFilter{Kind: model.DelegationKindUndelegation},
+			wantDelegators: []model.WalletAddress{},
+		},
+		{
+			name:           "Sort by increasing amount",
+			filter:         model.DelegationFilter{Sort: model.DelegationSortAmount, Order: model.SortOrderAsc},
+			wantDelegators: []model.WalletAddress{"tz1c", "tz1a", "tz1b", "tz1d"},
+		},
+		{
+			name:           "After a cursor, page ignored",
+			page:           3,
+			limit:          1,
+			cursor:         model.NewCursor(time.Date(int(currentYear), 1, 3, 0, 0, 0, 0, time.UTC).Unix(), 3),
+			wantDelegators: []model.WalletAddress{"tz1b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.GetDelegations(context.Background(), tt.page, tt.limit, tt.filter, tt.maxDelegationID, tt.cursor)
+			assert.NoError(t, err)
+
+			delegators := make([]model.WalletAddress, 0, len(got))
+			for _, d := range got {
+				delegators = append(delegators, d.Delegator)
+			}
+			assert.Equal(t, tt.wantDelegators, delegators)
+		})
+	}
+}
+
+func Test_Memory_SaveDelegations(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	seedDelegations(t, m)
+
+	// Same delegator and level as an existing row, ignored like ON CONFLICT DO NOTHING.
+	assert.NoError(t, m.SaveDelegation(ctx, &model.Delegation{Delegator: "tz1a", Delegate: "tz1new", Level: 100}))
+
+	got, err := m.GetDelegations(ctx, 1, 50, model.DelegationFilter{}, 0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 4)
+
+	latest, err := m.GetLatestDelegation(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(400), latest.Level)
+	}
+
+	level, err := m.GetHighestBlockLevel(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(400), level)
+
+	// The active delegators are those of the current delegation state, which the sync saves along with the delegations.
+	saved := make([]*model.Delegation, 0, len(got))
+	for i := range got {
+		saved = append(saved, &got[i])
+	}
+	assert.NoError(t, m.SaveCurrentDelegations(ctx, model.CurrentDelegationsFromDelegations(saved)))
+
+	delegators, err := m.GetActiveDelegators(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WalletAddress{"tz1a", "tz1b", "tz1c", "tz1d"}, delegators)
+}
+
+func Test_Memory_empty(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	_, err := m.GetLatestDelegation(ctx)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = m.GetLastSyncedRewardCycle(ctx)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = m.GetBakerForDelegatorAtLevel(ctx, "tz1a", 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	level, err := m.GetHighestBlockLevel(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), level)
+
+	delegations, err := m.GetDelegations(ctx, 1, 10, model.DelegationFilter{}, 0, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, delegations)
+
+	assert.NoError(t, m.Ping())
+	assert.NoError(t, m.EnsurePartitions(ctx, time.Now()))
+	assert.NoError(t, m.Close())
+}
+
+func Test_Memory_GetOperations(t *testing.T) {
+	m := New()
+	m.SaveOperations([]model.Operation{
+		{SenderAddress: "tz1a", ContractAddress: "KT1pool", Entrypoint: "stake", Amount: 1, Timestamp: 1000},
+		{SenderAddress: "tz1b", ContractAddress: "KT1pool", Entrypoint: "unstake", Amount: 2, Timestamp: 2000},
+		{SenderAddress: "tz1a", ContractAddress: "KT1other", Entrypoint: "stake", Amount: 3, Timestamp: 3000},
+	})
+
+	tests := []struct {
+		name          string
+		fromDate      int64
+		toDate        int64
+		page          uint16
+		limit         uint16
+		operationType model.OperationType
+		wallet        model.WalletAddress
+		baker         model.WalletAddress
+		wantAmounts   []model.Mutez
+	}{
+		{
+			name:        "Nominal case",
+			wantAmounts: []model.Mutez{3, 2, 1},
+		},
+		{
+			name:          "Filter by operation type and wallet",
+			operationType: "stake",
+			wallet:        "tz1a",
+			wantAmounts:   []model.Mutez{3, 1},
+		},
+		{
+			name:        "Filter by baker",
+			baker:       "KT1pool",
+			wantAmounts: []model.Mutez{2, 1},
+		},
+		{
+			name:        "Filter by date range",
+			fromDate:    1500,
+			toDate:      2500,
+			wantAmounts: []model.Mutez{2},
+		},
+		{
+			name:        "Pagination",
+			page:        2,
+			limit:       2,
+			wantAmounts: []model.Mutez{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.GetOperations(context.Background(), tt.fromDate, tt.toDate, tt.page, tt.limit, tt.operationType, tt.wallet, tt.baker, nil)
+			assert.NoError(t, err)
+
+			amounts := make([]model.Mutez, 0, len(got))
+			for _, o := range got {
+				amounts = append(amounts, o.Amount)
+			}
+			assert.Equal(t, tt.wantAmounts, amounts)
+		})
+	}
+}
+
+func Test_Memory_Rewards(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	assert.NoError(t, m.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 1, Amount: 10, Timestamp: 1000},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 1, Amount: 99, Timestamp: 1000},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1baker", Cycle: 2, Amount: 20, Timestamp: 2000},
+	}))
+
+	got, err := m.GetRewards(ctx, 0, 0, "", "tz1baker", 1, 50, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, model.Mutez(20), got[0].Amount)
+		assert.Equal(t, model.Mutez(10), got[1].Amount)
+	}
+
+	got, err = m.GetRewards(ctx, 0, 1500, "tz1a", "", 1, 50, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	assert.NoError(t, m.SaveLastSyncedRewardCycle(ctx, 5))
+	assert.NoError(t, m.SaveLastSyncedRewardCycle(ctx, 6))
+	cycle, err := m.GetLastSyncedRewardCycle(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, cycle)
+}
+
+func Test_Memory_GetBakerForDelegatorAtLevel(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	assert.NoError(t, m.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1old", Level: 1},
+		{Delegator: "tz1a", Delegate: "tz1new", Level: 5},
+	}))
+	assert.NoError(t, m.SaveCurrentDelegations(ctx, []model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1new", SinceLevel: 5}}))
+
+	baker, err := m.GetBakerForDelegatorAtLevel(ctx, "tz1a", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, model.WalletAddress("tz1old"), baker)
+
+	baker, err = m.GetBakerForDelegatorAtLevel(ctx, "tz1a", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, model.WalletAddress("tz1new"), baker)
+
+	_, err = m.GetBakerForDelegatorAtLevel(ctx, "tz1b", 0)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_Memory_concurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(level int64) {
+			defer wg.Done()
+			_ = m.SaveDelegation(ctx, &model.Delegation{Delegator: "tz1a", Level: level, Amount: 1})
+			_ = m.SaveAccounts(ctx, []model.Account{{Address: "tz1a"}})
+		}(int64(i))
+		go func() {
+			defer wg.Done()
+			_, _ = m.GetDelegations(ctx, 1, 10, model.DelegationFilter{}, 0, nil)
+			_, _ = m.GetActiveDelegators(ctx)
+		}()
+	}
+	wg.Wait()
+
+	level, err := m.GetHighestBlockLevel(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(19), level)
+	assert.Len(t, m.accounts, 1)
+}
+
+func Test_Memory_AccountProfile(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	seedDelegations(t, m)
+
+	assert.NoError(t, m.SaveAccounts(ctx, []model.Account{{Address: "tz1a", Alias: "Alice", Type: model.AccountTypeUser}}))
+	assert.NoError(t, m.SaveDelegation(ctx, &model.Delegation{Delegator: "tz1a", Delegate: "", Timestamp: 50, Level: 50}))
+	assert.NoError(t, m.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 1, Amount: 10},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 2, Amount: 20},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1baker", Cycle: 1, Amount: 30},
+	}))
+	m.SaveOperations([]model.Operation{
+		{SenderAddress: "tz1a", Entrypoint: "unstake"},
+		{SenderAddress: "tz1a", Entrypoint: "stake"},
+		{SenderAddress: "tz1a", Entrypoint: "stake"},
+		{SenderAddress: "tz1b", Entrypoint: "stake"},
+	})
+
+	account, err := m.GetAccount(ctx, "tz1a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Alice", account.Alias)
+	}
+	_, err = m.GetAccount(ctx, "tz1unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	delegations, err := m.GetDelegatorDelegations(ctx, "tz1a")
+	assert.NoError(t, err)
+	if assert.Len(t, delegations, 2) {
+		assert.Equal(t, int64(50), delegations[0].Level)
+		assert.Equal(t, int64(100), delegations[1].Level)
+	}
+
+	total, err := m.GetTotalRewards(ctx, "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Mutez(30), total)
+
+	counts, err := m.GetStakingOperationCounts(ctx, "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.StakingOperationCount{{Entrypoint: "stake", Count: 2}, {Entrypoint: "unstake", Count: 1}}, counts)
+}
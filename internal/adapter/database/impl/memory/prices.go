@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// priceKey identifies the price of a currency on a day.
+type priceKey struct {
+	currency model.Currency
+	day      int64
+}
+
+// SavePrices saves daily prices, replacing the ones already saved for their day and currency.
+func (m *Memory) SavePrices(_ context.Context, prices []model.Price) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range prices {
+		m.prices[priceKey{currency: p.Currency, day: p.Day}] = p.Price
+	}
+	return nil
+}
+
+// GetPrices returns the daily prices of a currency for the UTC days between fromDay and toDay, excluded, by increasing day.
+func (m *Memory) GetPrices(_ context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var prices []model.Price
+	for key, price := range m.prices {
+		if key.currency == currency && key.day >= fromDay && key.day < toDay {
+			prices = append(prices, model.Price{Day: key.day, Currency: key.currency, Price: price})
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Day < prices[j].Day })
+	return prices, nil
+}
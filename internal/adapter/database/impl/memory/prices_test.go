@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Memory_Prices(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	assert.NoError(t, m.SavePrices(ctx, []model.Price{
+		{Day: model.SecondsPerDay, Currency: "EUR", Price: "0.9"},
+		{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.95"},
+		{Day: 3 * model.SecondsPerDay, Currency: "EUR", Price: "1.1"},
+		{Day: 2 * model.SecondsPerDay, Currency: "USD", Price: "1.05"},
+	}))
+	// A new import replaces the price of a day.
+	assert.NoError(t, m.SavePrices(ctx, []model.Price{{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.12345678901"}}))
+
+	prices, err := m.GetPrices(ctx, "EUR", 2*model.SecondsPerDay, 4*model.SecondsPerDay)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Price{
+		{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.12345678901"},
+		{Day: 3 * model.SecondsPerDay, Currency: "EUR", Price: "1.1"},
+	}, prices)
+
+	prices, err = m.GetPrices(ctx, "CHF", 0, 4*model.SecondsPerDay)
+	assert.NoError(t, err)
+	assert.Empty(t, prices)
+}
@@ -0,0 +1,198 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+type dailyStatsKey struct {
+	day   int64
+	baker model.WalletAddress
+}
+
+type cycleStatsKey struct {
+	cycle   int
+	address model.WalletAddress
+}
+
+// RefreshDelegationStats recomputes the daily delegation statistics of the UTC days between fromDate and toDate, included.
+func (m *Memory) RefreshDelegationStats(_ context.Context, fromDate, toDate int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fromDay, toDay := model.DayStart(fromDate), model.DayStart(toDate)+model.SecondsPerDay
+
+	for key := range m.delegationStats {
+		if key.day >= fromDay && key.day < toDay {
+			delete(m.delegationStats, key)
+		}
+	}
+
+	history := make(map[model.WalletAddress][]model.Delegation)
+	for _, d := range m.delegations {
+		history[d.Delegator] = append(history[d.Delegator], d)
+	}
+
+	for _, delegations := range history {
+		sort.Slice(delegations, func(i, j int) bool {
+			return delegations[i].Level < delegations[j].Level
+		})
+
+		for i, d := range delegations {
+			if d.Timestamp < fromDay || d.Timestamp >= toDay {
+				continue
+			}
+			day := model.DayStart(d.Timestamp)
+
+			if d.Delegate != "" {
+				stats := m.dailyStats(day, d.Delegate)
+				stats.NewDelegations++
+				stats.DelegatedVolume += d.Amount
+			}
+
+			// A delegator leaves the baker of its previous delegation when it undelegates or delegates to another baker.
+			if i > 0 && delegations[i-1].Delegate != "" && delegations[i-1].Delegate != d.Delegate {
+				m.dailyStats(day, delegations[i-1].Delegate).Undelegations++
+			}
+		}
+	}
+
+	return nil
+}
+
+// dailyStats returns the statistics of baker on day, creating them if needed.
+func (m *Memory) dailyStats(day int64, baker model.WalletAddress) *model.DailyDelegationStats {
+	key := dailyStatsKey{day: day, baker: baker}
+	stats, ok := m.delegationStats[key]
+	if !ok {
+		stats = &model.DailyDelegationStats{Day: day, Baker: baker}
+		m.delegationStats[key] = stats
+	}
+	return stats
+}
+
+// RefreshRewardStats recomputes the per-cycle reward statistics of the cycles between fromCycle and toCycle, included.
+func (m *Memory) RefreshRewardStats(_ context.Context, fromCycle, toCycle int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.bakerRewards {
+		if key.cycle >= fromCycle && key.cycle <= toCycle {
+			delete(m.bakerRewards, key)
+		}
+	}
+	for key := range m.delegatorRewards {
+		if key.cycle >= fromCycle && key.cycle <= toCycle {
+			delete(m.delegatorRewards, key)
+		}
+	}
+
+	for _, r := range m.rewards {
+		if r.Cycle < fromCycle || r.Cycle > toCycle {
+			continue
+		}
+
+		// Rewards are unique per recipient, source and cycle, so each one adds a distinct delegator and baker.
+		bakerKey := cycleStatsKey{cycle: r.Cycle, address: r.SourceAddress}
+		baker, ok := m.bakerRewards[bakerKey]
+		if !ok {
+			baker = &model.BakerCycleRewards{Cycle: r.Cycle, Baker: r.SourceAddress}
+			m.bakerRewards[bakerKey] = baker
+		}
+		baker.Rewards += r.Amount
+		baker.Delegators++
+
+		delegatorKey := cycleStatsKey{cycle: r.Cycle, address: r.RecipientAddress}
+		delegator, ok := m.delegatorRewards[delegatorKey]
+		if !ok {
+			delegator = &model.DelegatorCycleRewards{Cycle: r.Cycle, Delegator: r.RecipientAddress}
+			m.delegatorRewards[delegatorKey] = delegator
+		}
+		delegator.Rewards += r.Amount
+		delegator.Bakers++
+	}
+
+	return nil
+}
+
+// GetDailyDelegationStats returns the daily delegation statistics within a date range, optionally of a baker.
+func (m *Memory) GetDailyDelegationStats(_ context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]model.DailyDelegationStats, 0)
+	for _, s := range m.delegationStats {
+		if fromDate > 0 && s.Day < model.DayStart(fromDate) {
+			continue
+		}
+		if toDate > 0 && s.Day > toDate {
+			continue
+		}
+		if baker != "" && s.Baker != baker {
+			continue
+		}
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Day != stats[j].Day {
+			return stats[i].Day < stats[j].Day
+		}
+		return stats[i].Baker < stats[j].Baker
+	})
+
+	return stats, nil
+}
+
+// GetBakerRewardStats returns the per-cycle rewards of bakers within a cycle range, optionally of a baker.
+func (m *Memory) GetBakerRewardStats(_ context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]model.BakerCycleRewards, 0)
+	for _, s := range m.bakerRewards {
+		if !inCycleRange(s.Cycle, fromCycle, toCycle) || (baker != "" && s.Baker != baker) {
+			continue
+		}
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Cycle != stats[j].Cycle {
+			return stats[i].Cycle < stats[j].Cycle
+		}
+		return stats[i].Baker < stats[j].Baker
+	})
+
+	return stats, nil
+}
+
+// GetDelegatorRewardStats returns the per-cycle rewards of delegators within a cycle range, optionally of a delegator.
+func (m *Memory) GetDelegatorRewardStats(_ context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]model.DelegatorCycleRewards, 0)
+	for _, s := range m.delegatorRewards {
+		if !inCycleRange(s.Cycle, fromCycle, toCycle) || (delegator != "" && s.Delegator != delegator) {
+			continue
+		}
+		stats = append(stats, *s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Cycle != stats[j].Cycle {
+			return stats[i].Cycle < stats[j].Cycle
+		}
+		return stats[i].Delegator < stats[j].Delegator
+	})
+
+	return stats, nil
+}
+
+// inCycleRange reports whether cycle is between fromCycle and toCycle, where 0 leaves a bound open.
+func inCycleRange(cycle, fromCycle, toCycle int) bool {
+	return (fromCycle <= 0 || cycle >= fromCycle) && (toCycle <= 0 || cycle <= toCycle)
+}
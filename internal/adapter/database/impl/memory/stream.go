@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetDelegationsAfter returns up to limit delegations matching the filter with an id above afterID, by increasing id.
+func (m *Memory) GetDelegationsAfter(_ context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error) {
+	var startDate, endDate int64
+	if filter.Year > 0 {
+		startDate = time.Date(int(filter.Year), 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+		endDate = time.Date(int(filter.Year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Delegations are appended with increasing ids.
+	delegations := make([]model.Delegation, 0)
+	for _, d := range m.delegations {
+		if len(delegations) >= int(limit) {
+			break
+		}
+		if d.ID <= afterID || (filter.Year > 0 && (d.Timestamp < startDate || d.Timestamp >= endDate)) {
+			continue
+		}
+		if filter.Match(d) {
+			delegations = append(delegations, d)
+		}
+	}
+	return delegations, nil
+}
+
+// ListenDelegations returns a channel signaled after every SaveDelegations of this process, until ctx is done.
+func (m *Memory) ListenDelegations(ctx context.Context) (<-chan struct{}, error) {
+	signals := make(chan struct{}, 1)
+
+	m.mu.Lock()
+	m.listeners = append(m.listeners, signals)
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i, listener := range m.listeners {
+			if listener == signals {
+				m.listeners = append(m.listeners[:i], m.listeners[i+1:]...)
+				break
+			}
+		}
+		close(signals)
+	}()
+
+	return signals, nil
+}
+
+// notifyListeners signals the listeners of ListenDelegations without blocking. The caller must hold the write lock.
+func (m *Memory) notifyListeners() {
+	for _, listener := range m.listeners {
+		select {
+		case listener <- struct{}{}:
+		default: // a signal is already pending
+		}
+	}
+}
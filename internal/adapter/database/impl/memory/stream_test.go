@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Memory_GetDelegationsAfter(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	assert.NoError(t, m.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1pool1", Level: 10},
+		{Delegator: "tz1b", Delegate: "", Level: 10},
+		{Delegator: "tz1c", Delegate: "tz1pool1", Level: 11},
+		{Delegator: "tz1d", Delegate: "tz1pool1", Level: 12},
+	}))
+
+	delegations, err := m.GetDelegationsAfter(ctx, 1, model.DelegationFilter{Delegate: "tz1pool1"}, 10)
+	assert.NoError(t, err)
+	var ids []int64
+	for _, d := range delegations {
+		ids = append(ids, d.ID)
+	}
+	assert.Equal(t, []int64{3, 4}, ids)
+
+	delegations, err = m.GetDelegationsAfter(ctx, 0, model.DelegationFilter{}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, delegations, 1)
+	assert.Equal(t, int64(1), delegations[0].ID)
+}
+
+func Test_Memory_ListenDelegations(t *testing.T) {
+	m := New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals, err := m.ListenDelegations(ctx)
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.SaveDelegations(context.Background(), []*model.Delegation{{Delegator: "tz1a", Level: 1}}))
+	assert.NoError(t, m.SaveDelegations(context.Background(), []*model.Delegation{{Delegator: "tz1b", Level: 2}}))
+
+	select {
+	case _, ok := <-signals:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatalf("no signal received")
+	}
+
+	cancel()
+	for range signals { // drains the pending signal, if any, until the channel is closed
+	}
+	m.mu.RLock()
+	assert.Empty(t, m.listeners)
+	m.mu.RUnlock()
+}
@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// webhookEventKey identifies an event queued for a webhook.
+type webhookEventKey struct {
+	webhookID int64
+	eventID   string
+}
+
+// SaveWebhook saves a new webhook, setting its id and creation date.
+func (m *Memory) SaveWebhook(_ context.Context, webhook *model.Webhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastWebhookID++
+	webhook.ID = m.lastWebhookID
+	webhook.CreatedAt = time.Now().UTC()
+
+	saved := *webhook
+	saved.EventTypes = append([]model.WebhookEventType{}, webhook.EventTypes...)
+	m.webhooks[saved.ID] = &saved
+	return nil
+}
+
+// GetWebhooks returns the webhooks of an API key, or every webhook when apiKeyID is 0, disabled ones included,
+// by increasing id.
+func (m *Memory) GetWebhooks(_ context.Context, apiKeyID int64) ([]model.Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	webhooks := make([]model.Webhook, 0, len(m.webhooks))
+	for _, w := range m.webhooks {
+		if apiKeyID == 0 || w.APIKeyID == apiKeyID {
+			webhooks = append(webhooks, *w)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook, or sql.ErrNoRows when it is unknown.
+func (m *Memory) GetWebhook(_ context.Context, id int64) (*model.Webhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	webhook, ok := m.webhooks[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	w := *webhook
+	return &w, nil
+}
+
+// DeleteWebhook deletes a webhook with its deliveries, or returns sql.ErrNoRows when it is unknown.
+func (m *Memory) DeleteWebhook(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.webhooks[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.webhooks, id)
+
+	deliveries := m.webhookDeliveries[:0]
+	for _, d := range m.webhookDeliveries {
+		if d.WebhookID == id {
+			delete(m.webhookEventKeys, webhookEventKey{webhookID: id, eventID: d.EventID})
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	m.webhookDeliveries = deliveries
+	return nil
+}
+
+// DisableWebhook stops the deliveries to a webhook from the given date, a disabled webhook keeps its first date.
+func (m *Memory) DisableWebhook(_ context.Context, id int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if webhook, ok := m.webhooks[id]; ok && webhook.DisabledAt == nil {
+		webhook.DisabledAt = &at
+	}
+	return nil
+}
+
+// EnableWebhook resumes the deliveries to a disabled webhook and resets its consecutive failures, or returns
+// sql.ErrNoRows when it is unknown.
+func (m *Memory) EnableWebhook(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	webhook, ok := m.webhooks[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	webhook.DisabledAt = nil
+	webhook.ConsecutiveFailures = 0
+	return nil
+}
+
+// SaveWebhookDeliveries queues deliveries, skipping the events already queued for their webhook.
+func (m *Memory) SaveWebhookDeliveries(_ context.Context, deliveries []model.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range deliveries {
+		key := webhookEventKey{webhookID: d.WebhookID, eventID: d.EventID}
+		if _, ok := m.webhookEventKeys[key]; ok {
+			continue
+		}
+		m.webhookEventKeys[key] = struct{}{}
+
+		m.lastWebhookDeliveryID++
+		d.ID = m.lastWebhookDeliveryID
+		d.Status = model.WebhookDeliveryPending
+		d.AttemptCount = 0
+		d.LastError = ""
+		d.Attempts = nil
+		d.CreatedAt = time.Now().UTC()
+		delivery := d
+		m.webhookDeliveries = append(m.webhookDeliveries, &delivery)
+	}
+	return nil
+}
+
+// GetDueWebhookDeliveries returns up to limit pending deliveries of enabled webhooks due at now, by next attempt date.
+func (m *Memory) GetDueWebhookDeliveries(_ context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var deliveries []model.WebhookDelivery
+	for _, d := range m.webhookDeliveries {
+		webhook, ok := m.webhooks[d.WebhookID]
+		if !ok || webhook.IsDisabled() || d.Status != model.WebhookDeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery := *d
+		delivery.Attempts = nil
+		deliveries = append(deliveries, delivery)
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].NextAttemptAt.Equal(deliveries[j].NextAttemptAt) {
+			return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	if len(deliveries) > int(limit) {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// SaveWebhookDeliveryAttempt records an attempt with the resulting state of its delivery, and returns the consecutive
+// failures of its webhook, reset by a successful attempt.
+func (m *Memory) SaveWebhookDeliveryAttempt(_ context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt.DeliveryID = delivery.ID
+	for _, d := range m.webhookDeliveries {
+		if d.ID == delivery.ID {
+			d.Status = delivery.Status
+			d.AttemptCount = delivery.AttemptCount
+			d.NextAttemptAt = delivery.NextAttemptAt
+			d.LastError = delivery.LastError
+			d.Attempts = append(d.Attempts, attempt)
+			break
+		}
+	}
+
+	webhook, ok := m.webhooks[delivery.WebhookID]
+	if !ok {
+		return 0, nil // deleted while delivering
+	}
+	if attempt.Succeeded() {
+		webhook.ConsecutiveFailures = 0
+	} else {
+		webhook.ConsecutiveFailures++
+	}
+	return webhook.ConsecutiveFailures, nil
+}
+
+// GetWebhookDeliveries returns the last limit deliveries of a webhook with their attempts, latest first.
+func (m *Memory) GetWebhookDeliveries(_ context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var deliveries []model.WebhookDelivery
+	for i := len(m.webhookDeliveries) - 1; i >= 0 && len(deliveries) < int(limit); i-- {
+		if d := m.webhookDeliveries[i]; d.WebhookID == webhookID {
+			delivery := *d
+			delivery.Attempts = append([]model.WebhookDeliveryAttempt(nil), d.Attempts...)
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+// GetSyncCursor returns the position saved by a reader of the indexed data, or sql.ErrNoRows when none was saved.
+func (m *Memory) GetSyncCursor(_ context.Context, source string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	position, ok := m.syncCursors[source]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return position, nil
+}
+
+// SaveSyncCursor saves the position of a reader of the indexed data.
+func (m *Memory) SaveSyncCursor(_ context.Context, source string, position int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.syncCursors[source] = position
+	return nil
+}
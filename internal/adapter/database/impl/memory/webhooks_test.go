@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_Memory_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	webhook := &model.Webhook{URL: "https://example.com/hook", Secret: "whsec_a", EventTypes: []model.WebhookEventType{model.WebhookEventDelegation}, APIKeyID: 1}
+	assert.NoError(t, m.SaveWebhook(ctx, webhook))
+	assert.Equal(t, int64(1), webhook.ID)
+	other := &model.Webhook{URL: "https://example.com/other", Secret: "whsec_b", APIKeyID: 2}
+	assert.NoError(t, m.SaveWebhook(ctx, other))
+
+	// An event is queued once per webhook.
+	assert.NoError(t, m.SaveWebhookDeliveries(ctx, []model.WebhookDelivery{
+		{WebhookID: webhook.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+		{WebhookID: webhook.ID, EventID: "delegation-2", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now.Add(time.Hour)},
+		{WebhookID: other.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+	}))
+	assert.NoError(t, m.SaveWebhookDeliveries(ctx, []model.WebhookDelivery{
+		{WebhookID: webhook.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+	}))
+
+	due, err := m.GetDueWebhookDeliveries(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 2)
+
+	// Failures are counted per webhook, until a success resets them.
+	delivery := due[0]
+	delivery.AttemptCount, delivery.NextAttemptAt = 1, now.Add(time.Minute)
+	failures, err := m.SaveWebhookDeliveryAttempt(ctx, delivery, model.WebhookDeliveryAttempt{AttemptedAt: now, StatusCode: 500})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failures)
+	delivery.AttemptCount, delivery.Status = 2, model.WebhookDeliveryDelivered
+	failures, err = m.SaveWebhookDeliveryAttempt(ctx, delivery, model.WebhookDeliveryAttempt{AttemptedAt: now.Add(time.Minute), StatusCode: 204})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, failures)
+
+	deliveries, err := m.GetWebhookDeliveries(ctx, webhook.ID, 10)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, "delegation-2", deliveries[0].EventID)
+	assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[1].Status)
+	assert.Len(t, deliveries[1].Attempts, 2)
+
+	// A disabled webhook has no due deliveries.
+	assert.NoError(t, m.DisableWebhook(ctx, other.ID, now))
+	due, err = m.GetDueWebhookDeliveries(ctx, now.Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, "delegation-2", due[0].EventID)
+
+	assert.NoError(t, m.DeleteWebhook(ctx, webhook.ID))
+	assert.True(t, errors.Is(m.DeleteWebhook(ctx, webhook.ID), sql.ErrNoRows))
+	_, err = m.GetWebhook(ctx, webhook.ID)
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+	webhooks, err := m.GetWebhooks(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.True(t, webhooks[0].IsDisabled())
+
+	// The webhooks are listed per API key.
+	webhooks, err = m.GetWebhooks(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+	webhooks, err = m.GetWebhooks(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+
+	// An enabled webhook has its deliveries due again.
+	assert.NoError(t, m.EnableWebhook(ctx, other.ID))
+	enabled, err := m.GetWebhook(ctx, other.ID)
+	assert.NoError(t, err)
+	assert.False(t, enabled.IsDisabled())
+	assert.Equal(t, 0, enabled.ConsecutiveFailures)
+	due, err = m.GetDueWebhookDeliveries(ctx, now.Add(2*time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	assert.Equal(t, other.ID, due[0].WebhookID)
+	assert.True(t, errors.Is(m.EnableWebhook(ctx, webhook.ID), sql.ErrNoRows))
+}
+
+func Test_Memory_SyncCursor(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+
+	_, err := m.GetSyncCursor(ctx, "webhooks_delegations")
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+	assert.NoError(t, m.SaveSyncCursor(ctx, "webhooks_delegations", 42))
+	position, err := m.GetSyncCursor(ctx, "webhooks_delegations")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), position)
+}
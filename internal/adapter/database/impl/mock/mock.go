@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -33,9 +34,24 @@ func (m *Mock) GetLatestDelegation(ctx context.Context) (*model.Delegation, erro
 	return args.Get(0).(*model.Delegation), args.Error(1)
 }
 
-// GetDelegations returns delegations with pagination and optional year and maxDelegationID filters.
-func (m *Mock) GetDelegations(ctx context.Context, page uint32, limit, year uint16, maxDelegationID uint64) ([]model.Delegation, error) {
-	args := m.Called(ctx, page, limit, year, maxDelegationID)
+// GetDelegationsAfter returns up to limit delegations matching the filter with an id above afterID, by increasing id.
+func (m *Mock) GetDelegationsAfter(ctx context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error) {
+	args := m.Called(ctx, afterID, filter, limit)
+	return args.Get(0).([]model.Delegation), args.Error(1)
+}
+
+// ListenDelegations returns a channel receiving a value every time SaveDelegations commits.
+func (m *Mock) ListenDelegations(ctx context.Context) (<-chan struct{}, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(<-chan struct{}), args.Error(1)
+}
+
+// GetDelegations returns delegations by page or after a cursor, with optional filters, order and maxDelegationID filter.
+func (m *Mock) GetDelegations(ctx context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error) {
+	args := m.Called(ctx, page, limit, filter, maxDelegationID, cursor)
 	return args.Get(0).([]model.Delegation), args.Error(1)
 }
 
@@ -45,15 +61,48 @@ func (m *Mock) GetHighestBlockLevel(ctx context.Context) (uint64, error) {
 	return args.Get(0).(uint64), args.Error(1)
 }
 
-// GetOperations returns operations with pagination and optional filters.
-func (m *Mock) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress) ([]model.Operation, error) {
-	args := m.Called(ctx, fromDate, toDate, page, limit, operationType, wallet, baker)
+// GetOperations returns operations by page or after a cursor, with optional filters.
+func (m *Mock) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error) {
+	args := m.Called(ctx, fromDate, toDate, page, limit, operationType, wallet, baker, cursor)
 	return args.Get(0).([]model.Operation), args.Error(1)
 }
 
-// GetRewards returns rewards for a given wallet and baker within a date range.
-func (m *Mock) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress) ([]model.Reward, error) {
+// StreamDelegations calls fn for every delegation given to Return, then returns its error.
+func (m *Mock) StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error {
+	args := m.Called(ctx, filter)
+	for _, d := range args.Get(0).([]model.Delegation) {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// StreamOperations calls fn for every operation given to Return, then returns its error.
+func (m *Mock) StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error {
+	args := m.Called(ctx, fromDate, toDate, operationType, wallet, baker)
+	for _, o := range args.Get(0).([]model.Operation) {
+		if err := fn(o); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// StreamRewards calls fn for every reward given to Return, then returns its error.
+func (m *Mock) StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error {
 	args := m.Called(ctx, fromDate, toDate, wallet, baker)
+	for _, r := range args.Get(0).([]model.Reward) {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+// GetRewards returns rewards for a given wallet and baker within a date range, by page or after a cursor.
+func (m *Mock) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error) {
+	args := m.Called(ctx, fromDate, toDate, wallet, baker, page, limit, cursor)
 	return args.Get(0).([]model.Reward), args.Error(1)
 }
 
@@ -105,9 +154,87 @@ func (m *Mock) GetActiveDelegators(ctx context.Context) ([]model.WalletAddress,
 	return args.Get(0).([]model.WalletAddress), args.Error(1)
 }
 
-// GetBakerForDelegatorAtCycle returns the baker for a delegator at a specific cycle.
-func (m *Mock) GetBakerForDelegatorAtCycle(ctx context.Context, delegator model.WalletAddress, cycle int) (model.WalletAddress, error) {
-	args := m.Called(ctx, delegator, cycle)
+// GetBakerDelegators returns the delegators of a baker, currently or at a level.
+func (m *Mock) GetBakerDelegators(ctx context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error) {
+	args := m.Called(ctx, baker, asOfLevel, page, limit)
+	return args.Get(0).([]model.CurrentDelegation), args.Error(1)
+}
+
+// GetCycle returns the levels of a cycle.
+func (m *Mock) GetCycle(ctx context.Context, cycle int) (*model.Cycle, error) {
+	args := m.Called(ctx, cycle)
+	return args.Get(0).(*model.Cycle), args.Error(1)
+}
+
+// GetAccount returns an account.
+func (m *Mock) GetAccount(ctx context.Context, address model.WalletAddress) (*model.Account, error) {
+	args := m.Called(ctx, address)
+	return args.Get(0).(*model.Account), args.Error(1)
+}
+
+// GetDelegatorDelegations returns every delegation of a delegator by increasing level.
+func (m *Mock) GetDelegatorDelegations(ctx context.Context, delegator model.WalletAddress) ([]model.Delegation, error) {
+	args := m.Called(ctx, delegator)
+	return args.Get(0).([]model.Delegation), args.Error(1)
+}
+
+// GetTotalRewards returns the sum of the rewards received by a wallet.
+func (m *Mock) GetTotalRewards(ctx context.Context, recipient model.WalletAddress) (model.Mutez, error) {
+	args := m.Called(ctx, recipient)
+	return args.Get(0).(model.Mutez), args.Error(1)
+}
+
+// GetStakingOperationCounts returns the number of staking operations sent by a wallet, per entrypoint.
+func (m *Mock) GetStakingOperationCounts(ctx context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error) {
+	args := m.Called(ctx, wallet)
+	return args.Get(0).([]model.StakingOperationCount), args.Error(1)
+}
+
+// GetBakers returns the staking pools with their activity over a period, by page.
+func (m *Mock) GetBakers(ctx context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error) {
+	args := m.Called(ctx, filter, page, limit)
+	return args.Get(0).([]model.Baker), args.Error(1)
+}
+
+// GetBaker returns a staking pool with its activity over a period.
+func (m *Mock) GetBaker(ctx context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error) {
+	args := m.Called(ctx, address, fromDate, toDate)
+	return args.Get(0).(*model.Baker), args.Error(1)
+}
+
+// GetPrices returns the daily prices of a currency.
+func (m *Mock) GetPrices(ctx context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error) {
+	args := m.Called(ctx, currency, fromDay, toDay)
+	return args.Get(0).([]model.Price), args.Error(1)
+}
+
+// SavePrices saves daily prices.
+func (m *Mock) SavePrices(ctx context.Context, prices []model.Price) error {
+	args := m.Called(ctx, prices)
+	return args.Error(0)
+}
+
+// GetMissingCycles returns the cycles whose levels are not saved.
+func (m *Mock) GetMissingCycles(ctx context.Context, fromCycle, toCycle int) ([]int, error) {
+	args := m.Called(ctx, fromCycle, toCycle)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// SaveCycles saves the levels of cycles.
+func (m *Mock) SaveCycles(ctx context.Context, cycles []model.Cycle) error {
+	args := m.Called(ctx, cycles)
+	return args.Error(0)
+}
+
+// SaveCurrentDelegations upserts the current delegation state of delegators.
+func (m *Mock) SaveCurrentDelegations(ctx context.Context, delegations []model.CurrentDelegation) error {
+	args := m.Called(ctx, delegations)
+	return args.Error(0)
+}
+
+// GetBakerForDelegatorAtLevel returns the baker of a delegator at a level.
+func (m *Mock) GetBakerForDelegatorAtLevel(ctx context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error) {
+	args := m.Called(ctx, delegator, asOfLevel)
 	return args.Get(0).(model.WalletAddress), args.Error(1)
 }
 
@@ -117,6 +244,159 @@ func (m *Mock) SaveLastSyncedRewardCycle(ctx context.Context, cycle int) error {
 	return args.Error(0)
 }
 
+// SaveWebhook saves a new webhook.
+func (m *Mock) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+// GetWebhooks returns the webhooks of an API key.
+func (m *Mock) GetWebhooks(ctx context.Context, apiKeyID int64) ([]model.Webhook, error) {
+	args := m.Called(ctx, apiKeyID)
+	return args.Get(0).([]model.Webhook), args.Error(1)
+}
+
+// GetWebhook returns a webhook.
+func (m *Mock) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Webhook), args.Error(1)
+}
+
+// DeleteWebhook deletes a webhook.
+func (m *Mock) DeleteWebhook(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// DisableWebhook stops the deliveries to a webhook.
+func (m *Mock) DisableWebhook(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// EnableWebhook resumes the deliveries to a webhook.
+func (m *Mock) EnableWebhook(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// SaveWebhookDeliveries queues webhook deliveries.
+func (m *Mock) SaveWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+// GetDueWebhookDeliveries returns the pending deliveries due at now.
+func (m *Mock) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]model.WebhookDelivery), args.Error(1)
+}
+
+// SaveWebhookDeliveryAttempt records a delivery attempt.
+func (m *Mock) SaveWebhookDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error) {
+	args := m.Called(ctx, delivery, attempt)
+	return args.Get(0).(int), args.Error(1)
+}
+
+// GetWebhookDeliveries returns the last deliveries of a webhook.
+func (m *Mock) GetWebhookDeliveries(ctx context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	return args.Get(0).([]model.WebhookDelivery), args.Error(1)
+}
+
+// GetSyncCursor returns the position saved by a reader of the indexed data.
+func (m *Mock) GetSyncCursor(ctx context.Context, source string) (int64, error) {
+	args := m.Called(ctx, source)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// SaveSyncCursor saves the position of a reader of the indexed data.
+func (m *Mock) SaveSyncCursor(ctx context.Context, source string, position int64) error {
+	args := m.Called(ctx, source, position)
+	return args.Error(0)
+}
+
+// SaveAPIKey saves a new API key.
+func (m *Mock) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// GetAPIKeys returns every API key.
+func (m *Mock) GetAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.APIKey), args.Error(1)
+}
+
+// GetAPIKeyByHash returns the API key of a hash.
+func (m *Mock) GetAPIKeyByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIKey), args.Error(1)
+}
+
+// RevokeAPIKey revokes an API key.
+func (m *Mock) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+// IncrementAPIKeyUsage counts a request of a key and returns the requests of its day.
+func (m *Mock) IncrementAPIKeyUsage(ctx context.Context, id int64, at time.Time) (int64, error) {
+	args := m.Called(ctx, id, at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// EnsurePartitions creates the time partitions of the partitioned tables up to the given date.
+func (m *Mock) EnsurePartitions(ctx context.Context, until time.Time) error {
+	args := m.Called(ctx, until)
+	return args.Error(0)
+}
+
+// RefreshDelegationStats recomputes the daily delegation statistics of a date range.
+func (m *Mock) RefreshDelegationStats(ctx context.Context, fromDate, toDate int64) error {
+	args := m.Called(ctx, fromDate, toDate)
+	return args.Error(0)
+}
+
+// RefreshRewardStats recomputes the per-cycle reward statistics of a cycle range.
+func (m *Mock) RefreshRewardStats(ctx context.Context, fromCycle, toCycle int) error {
+	args := m.Called(ctx, fromCycle, toCycle)
+	return args.Error(0)
+}
+
+// GetDailyDelegationStats returns the daily delegation statistics within a date range.
+func (m *Mock) GetDailyDelegationStats(ctx context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error) {
+	args := m.Called(ctx, fromDate, toDate, baker)
+	return args.Get(0).([]model.DailyDelegationStats), args.Error(1)
+}
+
+// GetBakerRewardStats returns the per-cycle rewards of bakers within a cycle range.
+func (m *Mock) GetBakerRewardStats(ctx context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error) {
+	args := m.Called(ctx, fromCycle, toCycle, baker)
+	return args.Get(0).([]model.BakerCycleRewards), args.Error(1)
+}
+
+// GetDelegatorRewardStats returns the per-cycle rewards of delegators within a cycle range.
+func (m *Mock) GetDelegatorRewardStats(ctx context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error) {
+	args := m.Called(ctx, fromCycle, toCycle, delegator)
+	return args.Get(0).([]model.DelegatorCycleRewards), args.Error(1)
+}
+
+// CheckSchemaVersion checks the database schema version.
+func (m *Mock) CheckSchemaVersion(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // Close closes the database connection.
 func (m *Mock) Close() error {
 	args := m.Called()
@@ -53,7 +53,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 		ctx             context.Context
 		page            uint32
 		limit           uint16
-		year            uint16
+		filter          model.DelegationFilter
 		maxDelegationID uint64
 	}
 	tests := []struct {
@@ -67,7 +67,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 			name: "nominal case",
 			mock: func() *Mock {
 				m := New()
-				m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(2025), uint64(0)).
+				m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{Year: 2025}, uint64(0), (*model.Cursor)(nil)).
 					Return([]model.Delegation{{ID: 1}}, nil)
 				return m
 			}(),
@@ -75,7 +75,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 				ctx:             context.Background(),
 				page:            1,
 				limit:           10,
-				year:            2025,
+				filter:          model.DelegationFilter{Year: 2025},
 				maxDelegationID: 0,
 			},
 			want:    []model.Delegation{{ID: 1}},
@@ -85,7 +85,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 			name: "error case",
 			mock: func() *Mock {
 				m := New()
-				m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(2025), uint64(0)).
+				m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{Year: 2025}, uint64(0), (*model.Cursor)(nil)).
 					Return([]model.Delegation(nil), errors.New("get delegations error"))
 				return m
 			}(),
@@ -93,7 +93,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 				ctx:             context.Background(),
 				page:            1,
 				limit:           10,
-				year:            2025,
+				filter:          model.DelegationFilter{Year: 2025},
 				maxDelegationID: 0,
 			},
 			want:    nil,
@@ -103,7 +103,7 @@ func Test_Mock_GetDelegations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := tt.mock
-			got, err := m.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.year, tt.args.maxDelegationID)
+			got, err := m.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.filter, tt.args.maxDelegationID, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetDelegations() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,91 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetAccount returns an account, or sql.ErrNoRows when the address is unknown.
+func (p *psql) GetAccount(ctx context.Context, address model.WalletAddress) (*model.Account, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetAccount")
+	defer cancel()
+
+	var account model.Account
+	query := `
+		SELECT id, address, COALESCE(alias, '') AS alias, type, created_at
+		FROM ` + p.tableAccounts + `
+		WHERE address = $1
+	`
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &account, query, address)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetAccount", err)
+	}
+	return &account, nil
+}
+
+// GetDelegatorDelegations returns every delegation of a delegator by increasing level, read from the (delegator, level) index.
+func (p *psql) GetDelegatorDelegations(ctx context.Context, delegator model.WalletAddress) ([]model.Delegation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDelegatorDelegations")
+	defer cancel()
+
+	var delegations []model.Delegation
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM ` + p.tableDelegations + `
+		WHERE delegator = $1
+		ORDER BY level ASC
+	`
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		delegations = nil
+		return db.SelectContext(ctx, &delegations, query, delegator)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetDelegatorDelegations", err)
+	}
+	return delegations, nil
+}
+
+// GetTotalRewards returns the sum of the rewards received by a wallet.
+func (p *psql) GetTotalRewards(ctx context.Context, recipient model.WalletAddress) (model.Mutez, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetTotalRewards")
+	defer cancel()
+
+	var total model.Mutez
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM ` + p.tableRewards + `
+		WHERE recipient_address = $1
+	`
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &total, query, recipient)
+	})
+	return total, classifyError(ctx, "GetTotalRewards", err)
+}
+
+// GetStakingOperationCounts returns the number of staking operations sent by a wallet, per entrypoint.
+func (p *psql) GetStakingOperationCounts(ctx context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetStakingOperationCounts")
+	defer cancel()
+
+	var counts []model.StakingOperationCount
+	query := `
+		SELECT entrypoint, COUNT(*) AS count
+		FROM ` + p.tableOperations + `
+		WHERE sender_address = $1
+		GROUP BY entrypoint
+		ORDER BY entrypoint
+	`
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		counts = nil
+		return db.SelectContext(ctx, &counts, query, wallet)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetStakingOperationCounts", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,146 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func newAccountsTestAdapter(db *sqlx.DB) *psql {
+	return &psql{
+		db:               db,
+		tableAccounts:    schemaTableAccounts,
+		tableDelegations: schemaTableDelegations,
+		tableRewards:     schemaTableRewards,
+		tableOperations:  schemaTableOperations,
+	}
+}
+
+func Test_psql_GetAccount(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    *model.Account
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`SELECT id, address, COALESCE\(alias, ''\) AS alias, type, created_at\s+FROM app.accounts\s+WHERE address = \$1`).
+					WithArgs("tz1a").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "address", "alias", "type", "created_at"}).
+						AddRow(1, "tz1a", "Alice", "user", createdAt))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    &model.Account{ID: 1, Address: "tz1a", Alias: "Alice", Type: model.AccountTypeUser, CreatedAt: createdAt},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - unknown address",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.accounts").
+					WithArgs("tz1a").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "address", "alias", "type", "created_at"}))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, sql.ErrNoRows, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			got, err := newAccountsTestAdapter(db).GetAccount(context.Background(), "tz1a")
+			tt.wantErr(t, err, "GetAccount()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetDelegatorDelegations(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    []model.Delegation
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM app.delegations\s+WHERE delegator = \$1\s+ORDER BY level ASC`).
+					WithArgs("tz1a").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
+						AddRow(1, "tz1a", "tz1baker", int64(1000), int64(10), int64(100), createdAt).
+						AddRow(2, "tz1a", "", int64(2000), int64(10), int64(200), createdAt))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want: []model.Delegation{
+				{ID: 1, Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 1000, Amount: 10, Level: 100, CreatedAt: createdAt},
+				{ID: 2, Delegator: "tz1a", Timestamp: 2000, Amount: 10, Level: 200, CreatedAt: createdAt},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.delegations").
+					WillReturnError(errors.New("query error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			got, err := newAccountsTestAdapter(db).GetDelegatorDelegations(context.Background(), "tz1a")
+			tt.wantErr(t, err, "GetDelegatorDelegations()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetTotalRewards(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\)\s+FROM app.rewards\s+WHERE recipient_address = \$1`).
+		WithArgs("tz1a").
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("1500000"))
+
+	got, err := newAccountsTestAdapter(sqlx.NewDb(db, "sqlmock")).GetTotalRewards(context.Background(), "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Mutez(1500000), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_psql_GetStakingOperationCounts(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`SELECT entrypoint, COUNT\(\*\) AS count\s+FROM app.staking_operations\s+WHERE sender_address = \$1\s+GROUP BY entrypoint`).
+		WithArgs("tz1a").
+		WillReturnRows(sqlmock.NewRows([]string{"entrypoint", "count"}).
+			AddRow("stake", 3).
+			AddRow("unstake", 1))
+
+	got, err := newAccountsTestAdapter(sqlx.NewDb(db, "sqlmock")).GetStakingOperationCounts(context.Background(), "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.StakingOperationCount{{Entrypoint: "stake", Count: 3}, {Entrypoint: "unstake", Count: 1}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// apiKeyColumns are the columns read for an API key.
+const apiKeyColumns = "id, name, prefix, key_hash, rate_limit, daily_quota, created_at, revoked_at"
+
+// SaveAPIKey saves a new API key, setting its id and creation date.
+func (p *psql) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveAPIKey")
+	defer cancel()
+
+	query := `
+		INSERT INTO ` + p.tableAPIKeys + ` (name, prefix, key_hash, rate_limit, daily_quota)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	row := p.db.QueryRowxContext(ctx, query, key.Name, key.Prefix, key.Hash, key.RateLimit, key.DailyQuota)
+	return classifyError(ctx, "SaveAPIKey", row.Scan(&key.ID, &key.CreatedAt))
+}
+
+// GetAPIKeys returns every API key, revoked ones included, by increasing id.
+func (p *psql) GetAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetAPIKeys")
+	defer cancel()
+
+	var keys []model.APIKey
+	if err := p.db.SelectContext(ctx, &keys, "SELECT "+apiKeyColumns+" FROM "+p.tableAPIKeys+" ORDER BY id"); err != nil {
+		return nil, classifyError(ctx, "GetAPIKeys", err)
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByHash returns the API key of a hash, or sql.ErrNoRows when it is unknown.
+// Keys are read from the primary, so that a revocation applies without replication lag.
+func (p *psql) GetAPIKeyByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetAPIKeyByHash")
+	defer cancel()
+
+	var key model.APIKey
+	if err := p.db.GetContext(ctx, &key, "SELECT "+apiKeyColumns+" FROM "+p.tableAPIKeys+" WHERE key_hash = $1", hash); err != nil {
+		return nil, classifyError(ctx, "GetAPIKeyByHash", err)
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey stops a key from authenticating requests from the given date, a revoked key keeps its first date.
+// It returns sql.ErrNoRows when the key is unknown.
+func (p *psql) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	ctx, cancel := p.withTimeout(ctx, "RevokeAPIKey")
+	defer cancel()
+
+	query := `
+		UPDATE ` + p.tableAPIKeys + `
+		SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE id = $1
+	`
+	result, err := p.db.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return classifyError(ctx, "RevokeAPIKey", err)
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// IncrementAPIKeyUsage counts a request of a key on the UTC day of the given date, and returns the requests of that day.
+func (p *psql) IncrementAPIKeyUsage(ctx context.Context, id int64, at time.Time) (int64, error) {
+	ctx, cancel := p.withTimeout(ctx, "IncrementAPIKeyUsage")
+	defer cancel()
+
+	query := `
+		INSERT INTO ` + p.tableAPIKeyUsage + ` (api_key_id, day, requests)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (api_key_id, day) DO UPDATE SET requests = ` + p.tableAPIKeyUsage + `.requests + 1
+		RETURNING requests
+	`
+	var requests int64
+	err := p.db.QueryRowxContext(ctx, query, id, at.UTC().Format("2006-01-02")).Scan(&requests)
+	return requests, classifyError(ctx, "IncrementAPIKeyUsage", err)
+}
@@ -0,0 +1,108 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// newAPIKeysTestAdapter returns an adapter over a mocked database with the API key tables.
+func newAPIKeysTestAdapter() (*psql, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return &psql{
+		db:               sqlx.NewDb(db, "sqlmock"),
+		tableAPIKeys:     schemaTableAPIKeys,
+		tableAPIKeyUsage: schemaTableAPIKeyUsage,
+	}, mock
+}
+
+func Test_psql_GetAPIKeyByHash(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		rows    *sqlmock.Rows
+		want    *model.APIKey
+		wantErr error
+	}{
+		{
+			name: "Nominal case",
+			rows: sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "rate_limit", "daily_quota", "created_at", "revoked_at"}).
+				AddRow(3, "explorer", "tzd_1a2b3c4d", "hash", 100, 0, createdAt, nil),
+			want: &model.APIKey{ID: 3, Name: "explorer", Prefix: "tzd_1a2b3c4d", Hash: "hash", RateLimit: 100, CreatedAt: createdAt},
+		},
+		{
+			name:    "Error case - unknown hash",
+			rows:    sqlmock.NewRows([]string{"id"}),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newAPIKeysTestAdapter()
+			mock.ExpectQuery(`SELECT id, name, prefix, key_hash, rate_limit, daily_quota, created_at, revoked_at FROM app.api_keys WHERE key_hash = \$1`).
+				WithArgs("hash").
+				WillReturnRows(tt.rows)
+
+			got, err := p.GetAPIKeyByHash(context.Background(), "hash")
+			assert.True(t, errors.Is(err, tt.wantErr), "error = %v", err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_RevokeAPIKey(t *testing.T) {
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		result  sql.Result
+		wantErr error
+	}{
+		{
+			name:   "Nominal case",
+			result: sqlmock.NewResult(0, 1),
+		},
+		{
+			name:    "Error case - unknown key",
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newAPIKeysTestAdapter()
+			mock.ExpectExec(`(?s)UPDATE app.api_keys\s+SET revoked_at = COALESCE\(revoked_at, \$2\)\s+WHERE id = \$1`).
+				WithArgs(int64(3), at).
+				WillReturnResult(tt.result)
+
+			err := p.RevokeAPIKey(context.Background(), 3, at)
+			assert.True(t, errors.Is(err, tt.wantErr), "error = %v", err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_IncrementAPIKeyUsage(t *testing.T) {
+	p, mock := newAPIKeysTestAdapter()
+
+	// The day is the UTC one, not the one of the location of the date.
+	at := time.Date(2024, 1, 2, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600))
+	mock.ExpectQuery(`(?s)INSERT INTO app.api_key_usage \(api_key_id, day, requests\)\s+VALUES \(\$1, \$2, 1\)\s+ON CONFLICT \(api_key_id, day\) DO UPDATE SET requests = app.api_key_usage.requests \+ 1\s+RETURNING requests`).
+		WithArgs(int64(3), "2024-01-01").
+		WillReturnRows(sqlmock.NewRows([]string{"requests"}).AddRow(42))
+
+	requests, err := p.IncrementAPIKeyUsage(context.Background(), 3, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), requests)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
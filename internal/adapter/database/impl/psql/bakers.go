@@ -0,0 +1,143 @@
+package psql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetBakers returns the staking pools with their current delegators and their activity over the period of filter, by page.
+func (p *psql) GetBakers(ctx context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetBakers")
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+
+	query, args := p.bakersQuery(filter)
+	if filter.Search != "" {
+		args = append(args, likePattern(filter.Search))
+		query += " WHERE pool.name ILIKE $" + strconv.Itoa(len(args))
+	}
+
+	args = append(args, limit, (int(page)-1)*int(limit))
+	query += `
+		` + bakerOrderClause(filter) + `
+		LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	var bakers []model.Baker
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		bakers = nil
+		return db.SelectContext(ctx, &bakers, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetBakers", err)
+	}
+
+	return bakers, nil
+}
+
+// GetBaker returns a staking pool with its activity between fromDate and toDate, or sql.ErrNoRows when it is unknown.
+func (p *psql) GetBaker(ctx context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetBaker")
+	defer cancel()
+
+	query, args := p.bakersQuery(model.BakerFilter{FromDate: fromDate, ToDate: toDate})
+	args = append(args, address.String())
+	query += " WHERE pool.address = $" + strconv.Itoa(len(args))
+
+	var baker model.Baker
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &baker, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetBaker", err)
+	}
+
+	return &baker, nil
+}
+
+// bakerOrderClause returns the ORDER BY clause of the baker directory, the address breaking ties.
+func bakerOrderClause(filter model.BakerFilter) string {
+	column := "delegators"
+	switch filter.SortField() {
+	case model.BakerSortDelegatedVolume:
+		column = "delegated_volume"
+	case model.BakerSortRewards:
+		column = "rewards"
+	case model.BakerSortNetInflow:
+		column = "net_inflow"
+	}
+
+	direction := "DESC"
+	if filter.SortOrder() == model.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	return "ORDER BY " + column + " " + direction + ", pool.address"
+}
+
+// bakersQuery returns the query joining the staking pools to their current delegators, to their daily delegation
+// statistics and to the rewards they paid over the period of filter, left open for a WHERE clause.
+func (p *psql) bakersQuery(filter model.BakerFilter) (string, []interface{}) {
+	var (
+		statsConditions   []string
+		rewardsConditions []string
+		args              []interface{}
+	)
+
+	if filter.FromDate > 0 {
+		args = append(args, filter.FromDate)
+		statsConditions = append(statsConditions, "day >= $"+strconv.Itoa(len(args)))
+		rewardsConditions = append(rewardsConditions, "timestamp >= $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.ToDate > 0 {
+		args = append(args, filter.ToDate)
+		statsConditions = append(statsConditions, "day < $"+strconv.Itoa(len(args)))
+		rewardsConditions = append(rewardsConditions, "timestamp < $"+strconv.Itoa(len(args)))
+	}
+
+	query := `
+		SELECT pool.address, pool.name AS alias,
+			COALESCE(cd.delegators, 0) AS delegators,
+			COALESCE(cd.staking_balance, 0) AS staking_balance,
+			COALESCE(ds.delegated_volume, 0) AS delegated_volume,
+			COALESCE(r.rewards, 0) AS rewards,
+			COALESCE(ds.new_delegations, 0) AS new_delegations,
+			COALESCE(ds.undelegations, 0) AS undelegations,
+			COALESCE(ds.new_delegations, 0) - COALESCE(ds.undelegations, 0) AS net_inflow
+		FROM ` + p.tableStakingPool + ` pool
+		LEFT JOIN (
+			SELECT baker, COUNT(*) AS delegators, SUM(balance) AS staking_balance
+			FROM ` + p.tableCurrentDelegations + `
+			WHERE baker <> ''
+			GROUP BY baker
+		) cd ON cd.baker = pool.address
+		LEFT JOIN (
+			SELECT baker, SUM(new_delegations) AS new_delegations, SUM(undelegations) AS undelegations,
+				SUM(delegated_volume) AS delegated_volume
+			FROM ` + p.tableDelegationStats + `
+			` + whereClause(statsConditions) + `
+			GROUP BY baker
+		) ds ON ds.baker = pool.address
+		LEFT JOIN (
+			SELECT source_address, SUM(amount) AS rewards
+			FROM ` + p.tableRewards + `
+			` + whereClause(rewardsConditions) + `
+			GROUP BY source_address
+		) r ON r.source_address = pool.address
+	`
+
+	return query, args
+}
+
+// likePattern returns the LIKE pattern matching the values which contain search, escaping its wildcards.
+func likePattern(search string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(search) + "%"
+}
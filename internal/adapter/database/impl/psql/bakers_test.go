@@ -0,0 +1,119 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+var bakerColumns = []string{"address", "alias", "delegators", "staking_balance", "delegated_volume", "rewards", "new_delegations", "undelegations", "net_inflow"}
+
+func newBakersTestAdapter(db *sqlx.DB) *psql {
+	return &psql{
+		db:                      db,
+		tableStakingPool:        schemaTableStakingPool,
+		tableCurrentDelegations: schemaTableCurrentDelegations,
+		tableDelegationStats:    schemaTableDelegationStats,
+		tableRewards:            schemaTableRewards,
+	}
+}
+
+func Test_psql_GetBakers(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  model.BakerFilter
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    []model.Baker
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`(?s)FROM app.staking_pools pool\s+LEFT JOIN .+FROM app.daily_delegation_stats\s+GROUP BY baker.+ORDER BY delegators DESC, pool.address\s+LIMIT \$1 OFFSET \$2`).
+					WithArgs(uint16(10), 0).
+					WillReturnRows(sqlmock.NewRows(bakerColumns).
+						AddRow("tz1pool", "Pool", 2, 110, 30, 50, 2, 1, 1))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    []model.Baker{{Address: "tz1pool", Alias: "Pool", Delegators: 2, StakingBalance: 110, DelegatedVolume: 30, Rewards: 50, NewDelegations: 2, Undelegations: 1, NetInflow: 1}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "Search, period and sort",
+			filter: model.BakerFilter{Search: "50%_", FromDate: 86400, ToDate: 172800, Sort: model.BakerSortNetInflow, Order: model.SortOrderAsc},
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`(?s)WHERE day >= \$1 AND day < \$2.+WHERE timestamp >= \$1 AND timestamp < \$2.+WHERE pool.name ILIKE \$3\s+ORDER BY net_inflow ASC, pool.address\s+LIMIT \$4 OFFSET \$5`).
+					WithArgs(int64(86400), int64(172800), `%50\%\_%`, uint16(10), 10).
+					WillReturnRows(sqlmock.NewRows(bakerColumns))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			page := uint16(1)
+			if tt.filter.Search != "" {
+				page = 2
+			}
+			got, err := newBakersTestAdapter(db).GetBakers(context.Background(), tt.filter, page, 10)
+			tt.wantErr(t, err, "GetBakers()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetBaker(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    *model.Baker
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`(?s)FROM app.staking_pools pool.+WHERE pool.address = \$2`).
+					WithArgs(int64(86400), "tz1pool").
+					WillReturnRows(sqlmock.NewRows(bakerColumns).
+						AddRow("tz1pool", "Pool", 2, 110, 30, 50, 2, 1, 1))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    &model.Baker{Address: "tz1pool", Alias: "Pool", Delegators: 2, StakingBalance: 110, DelegatedVolume: 30, Rewards: 50, NewDelegations: 2, Undelegations: 1, NetInflow: 1},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - unknown baker",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.staking_pools pool").
+					WithArgs(int64(86400), "tz1pool").
+					WillReturnRows(sqlmock.NewRows(bakerColumns))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, sql.ErrNoRows, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			got, err := newBakersTestAdapter(db).GetBaker(context.Background(), "tz1pool", 86400, 0)
+			tt.wantErr(t, err, "GetBaker()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
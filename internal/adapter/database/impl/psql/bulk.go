@@ -0,0 +1,194 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// BulkMode defines how multi-row writes are sent to PostgreSQL.
+type BulkMode string
+
+const (
+	// BulkModeValues inserts rows with multi-row INSERT ... VALUES statements.
+	BulkModeValues BulkMode = "values"
+
+	// BulkModeCopy streams rows with COPY FROM STDIN into a staging table,
+	// then moves them into the target table with INSERT ... ON CONFLICT.
+	BulkModeCopy BulkMode = "copy"
+)
+
+const (
+	// defaultBatchSize is the number of rows written per statement when none is configured.
+	defaultBatchSize = 1000
+
+	// maxQueryParams is the maximum number of bind parameters accepted by PostgreSQL in one statement.
+	maxQueryParams = 65535
+)
+
+// bulkInsert writes rows into table in a single transaction, batchSize rows per statement.
+// Rows conflicting with existing ones are skipped.
+func (p *psql) bulkInsert(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	return p.bulkInsertNotify(ctx, table, columns, rows, "")
+}
+
+// bulkInsertNotify writes rows like bulkInsert, then notifies channel, when not empty, with the number of rows.
+// PostgreSQL delivers the notification to the listeners once the transaction commits.
+func (p *psql) bulkInsertNotify(ctx context.Context, table string, columns []string, rows [][]interface{}, channel string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if p.bulkMode == BulkModeCopy {
+		err = p.copyRows(ctx, tx, table, columns, rows)
+	} else {
+		err = p.insertRows(ctx, tx, table, columns, rows)
+	}
+
+	if err == nil && channel != "" {
+		if _, err = tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", channel, strconv.Itoa(len(rows))); err != nil {
+			err = fmt.Errorf("error notifying %s: %w", channel, err)
+		}
+	}
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertRows inserts rows with multi-row VALUES statements.
+func (p *psql) insertRows(ctx context.Context, tx *sqlx.Tx, table string, columns []string, rows [][]interface{}) error {
+	batchSize := p.effectiveBatchSize(len(columns))
+
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		query, args := buildMultiRowInsert(table, columns, rows[start:end])
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error inserting rows %d-%d into %s: %w", start, end-1, table, err)
+		}
+	}
+
+	return nil
+}
+
+// copyRows streams rows into a temporary staging table with COPY FROM STDIN, then moves them into table.
+func (p *psql) copyRows(ctx context.Context, tx *sqlx.Tx, table string, columns []string, rows [][]interface{}) error {
+	staging := stagingTableName(table)
+	columnList := strings.Join(columns, ", ")
+
+	createQuery := `CREATE TEMP TABLE ` + staging + ` ON COMMIT DROP AS SELECT ` + columnList + ` FROM ` + table + ` WITH NO DATA`
+	if _, err := tx.ExecContext(ctx, createQuery); err != nil {
+		return fmt.Errorf("error creating staging table for %s: %w", table, err)
+	}
+
+	batchSize := p.effectiveBatchSize(len(columns))
+
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, pq.CopyIn(staging, columns...))
+		if err != nil {
+			return fmt.Errorf("error preparing copy into %s: %w", staging, err)
+		}
+
+		for _, row := range rows[start:end] {
+			if _, err := stmt.ExecContext(ctx, row...); err != nil {
+				_ = stmt.Close()
+				return fmt.Errorf("error copying row into %s: %w", staging, err)
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx); err != nil {
+			_ = stmt.Close()
+			return fmt.Errorf("error flushing copy into %s: %w", staging, err)
+		}
+
+		if err := stmt.Close(); err != nil {
+			return fmt.Errorf("error closing copy into %s: %w", staging, err)
+		}
+	}
+
+	moveQuery := `INSERT INTO ` + table + ` (` + columnList + `) SELECT ` + columnList + ` FROM ` + staging + ` ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, moveQuery); err != nil {
+		return fmt.Errorf("error moving staged rows into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// effectiveBatchSize returns the configured batch size, bounded by the PostgreSQL parameter limit.
+func (p *psql) effectiveBatchSize(columnCount int) int {
+	batchSize := p.batchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	if columnCount > 0 && batchSize*columnCount > maxQueryParams {
+		batchSize = maxQueryParams / columnCount
+	}
+
+	return batchSize
+}
+
+// buildMultiRowInsert builds an INSERT ... VALUES (...), (...) ON CONFLICT DO NOTHING statement.
+func buildMultiRowInsert(table string, columns []string, rows [][]interface{}) (string, []interface{}) {
+	query, args := buildMultiRowValues(table, columns, rows)
+	return query + " ON CONFLICT DO NOTHING", args
+}
+
+// buildMultiRowValues builds an INSERT ... VALUES (...), (...) statement without conflict clause.
+func buildMultiRowValues(table string, columns []string, rows [][]interface{}) (string, []interface{}) {
+	var sb strings.Builder
+	args := make([]interface{}, 0, len(rows)*len(columns))
+
+	sb.WriteString("INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES ")
+
+	argIndex := 1
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := range columns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("$" + strconv.Itoa(argIndex))
+			argIndex++
+		}
+		sb.WriteString(")")
+		args = append(args, row...)
+	}
+
+	return sb.String(), args
+}
+
+// stagingTableName returns the temporary staging table name used for a target table.
+func stagingTableName(table string) string {
+	if idx := strings.LastIndex(table, "."); idx >= 0 {
+		table = table[idx+1:]
+	}
+	return "staging_" + table
+}
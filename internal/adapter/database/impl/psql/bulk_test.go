@@ -0,0 +1,160 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_psql_bulkInsert(t *testing.T) {
+	const tableAccounts = "app.accounts"
+	columns := []string{"address", "alias", "type"}
+	rows := [][]interface{}{
+		{"tz1account1", "alias1", "user"},
+		{"tz1account2", "alias2", "user"},
+		{"tz1account3", "alias3", "delegate"},
+	}
+
+	tests := []struct {
+		name      string
+		batchSize int
+		bulkMode  BulkMode
+		rows      [][]interface{}
+		db        func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "Nominal case - values split in batches",
+			batchSize: 2,
+			bulkMode:  BulkModeValues,
+			rows:      rows,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO "+tableAccounts+" \\(address, alias, type\\) VALUES \\(\\$1, \\$2, \\$3\\), \\(\\$4, \\$5, \\$6\\) ON CONFLICT DO NOTHING").
+					WithArgs("tz1account1", "alias1", "user", "tz1account2", "alias2", "user").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO "+tableAccounts+" \\(address, alias, type\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT DO NOTHING").
+					WithArgs("tz1account3", "alias3", "delegate").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "Nominal case - copy through staging table",
+			batchSize: 10,
+			bulkMode:  BulkModeCopy,
+			rows:      rows[:2],
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("CREATE TEMP TABLE staging_accounts ON COMMIT DROP AS SELECT address, alias, type FROM " + tableAccounts + " WITH NO DATA").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				prep := mock.ExpectPrepare("COPY \"staging_accounts\" \\(\"address\", \"alias\", \"type\"\\) FROM STDIN")
+				prep.ExpectExec().WithArgs("tz1account1", "alias1", "user").WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs("tz1account2", "alias2", "user").WillReturnResult(sqlmock.NewResult(0, 1))
+				prep.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO " + tableAccounts + " \\(address, alias, type\\) SELECT address, alias, type FROM staging_accounts ON CONFLICT DO NOTHING").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "Nominal case - no rows",
+			bulkMode: BulkModeValues,
+			rows:     nil,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "Error case - insert error rolls back",
+			batchSize: 10,
+			bulkMode:  BulkModeValues,
+			rows:      rows[:1],
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO "+tableAccounts).
+					WithArgs("tz1account1", "alias1", "user").
+					WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "Error case - transaction begin error",
+			bulkMode: BulkModeCopy,
+			rows:     rows[:1],
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin().WillReturnError(fmt.Errorf("begin error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:        db,
+				batchSize: tt.batchSize,
+				bulkMode:  tt.bulkMode,
+			}
+			err := p.bulkInsert(context.Background(), tableAccounts, columns, tt.rows)
+			tt.wantErr(t, err, fmt.Sprintf("bulkInsert(%v)", tt.rows))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_effectiveBatchSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		batchSize   int
+		columnCount int
+		want        int
+	}{
+		{
+			name:        "Nominal case",
+			batchSize:   500,
+			columnCount: 5,
+			want:        500,
+		},
+		{
+			name:        "Default batch size",
+			batchSize:   0,
+			columnCount: 5,
+			want:        defaultBatchSize,
+		},
+		{
+			name:        "Bounded by parameter limit",
+			batchSize:   100000,
+			columnCount: 5,
+			want:        maxQueryParams / 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &psql{batchSize: tt.batchSize}
+			assert.Equal(t, tt.want, p.effectiveBatchSize(tt.columnCount))
+		})
+	}
+}
+
+func Test_stagingTableName(t *testing.T) {
+	assert.Equal(t, "staging_delegations", stagingTableName("app.delegations"))
+	assert.Equal(t, "staging_rewards", stagingTableName("rewards"))
+}
@@ -5,16 +5,14 @@ import (
 	"os"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"           // PostgreSQL driver
-	_ "github.com/mattn/go-sqlite3" // SQLite driver (keeping for backward compatibility)
+	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
 // var execCommand = exec.Command
 
 // initConnection initializes the database connection.
 func initConnection(cfg Config) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, string(cfg.Password), cfg.DBName, cfg.SSLMode)
+	dsn := buildDSN(cfg, cfg.Host, cfg.Port)
 
 	if os.Getenv("GO_TESTING") == "1" {
 		return &sqlx.DB{}, nil
@@ -29,5 +27,13 @@ func initConnection(cfg Config) (*sqlx.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	configurePool(db, cfg.Pool)
+
 	return db, nil
 }
+
+// buildDSN returns the connection string to host and port with the credentials and options of cfg.
+func buildDSN(cfg Config, host string, port int) string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		host, port, cfg.User, string(cfg.Password), cfg.DBName, cfg.SSLMode)
+}
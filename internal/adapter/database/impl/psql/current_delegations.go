@@ -0,0 +1,159 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// currentDelegationColumns are the columns written by SaveCurrentDelegations.
+var currentDelegationColumns = []string{"delegator", "baker", "since_level", "balance"}
+
+// SaveCurrentDelegations upserts the current delegation state of delegators, in a single transaction.
+// A state is only replaced by a more recent one, so replaying a batch is harmless.
+func (p *psql) SaveCurrentDelegations(ctx context.Context, delegations []model.CurrentDelegation) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveCurrentDelegations")
+	defer cancel()
+
+	if len(delegations) == 0 {
+		return nil
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return classifyError(ctx, "SaveCurrentDelegations", err)
+	}
+
+	if err = p.upsertCurrentDelegations(ctx, tx, delegations); err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return classifyError(ctx, "SaveCurrentDelegations", err)
+	}
+
+	return classifyError(ctx, "SaveCurrentDelegations", tx.Commit())
+}
+
+// upsertCurrentDelegations upserts delegations with multi-row statements, batchSize rows per statement.
+// Delegators must be unique, PostgreSQL rejects a statement updating the same row twice.
+func (p *psql) upsertCurrentDelegations(ctx context.Context, tx *sqlx.Tx, delegations []model.CurrentDelegation) error {
+	batchSize := p.effectiveBatchSize(len(currentDelegationColumns))
+
+	for start := 0; start < len(delegations); start += batchSize {
+		end := start + batchSize
+		if end > len(delegations) {
+			end = len(delegations)
+		}
+
+		rows := make([][]interface{}, 0, end-start)
+		for _, d := range delegations[start:end] {
+			rows = append(rows, []interface{}{d.Delegator, d.Baker, d.SinceLevel, d.Balance})
+		}
+
+		query, args := buildMultiRowValues(p.tableCurrentDelegations, currentDelegationColumns, rows)
+		query += `
+			ON CONFLICT (delegator) DO UPDATE
+			SET baker = EXCLUDED.baker, since_level = EXCLUDED.since_level, balance = EXCLUDED.balance
+			WHERE ` + p.tableCurrentDelegations + `.since_level < EXCLUDED.since_level
+		`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error upserting current delegations %d-%d: %w", start, end-1, err)
+		}
+	}
+
+	return nil
+}
+
+// GetBakerForDelegatorAtLevel returns the baker of a delegator at the end of asOfLevel, or from its current delegation
+// state when asOfLevel is 0. The baker is empty when the delegator was undelegated, sql.ErrNoRows when it is unknown.
+func (p *psql) GetBakerForDelegatorAtLevel(ctx context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetBakerForDelegatorAtLevel")
+	defer cancel()
+
+	query := `
+		SELECT baker
+		FROM ` + p.tableCurrentDelegations + `
+		WHERE delegator = $1
+	`
+	args := []interface{}{delegator.String()}
+
+	if asOfLevel > 0 {
+		query = `SELECT latest.baker FROM (` + p.latestDelegationAt("$1", "$2") + `) latest`
+		args = append(args, asOfLevel)
+	}
+
+	var baker model.WalletAddress
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &baker, query, args...)
+	})
+	if err != nil {
+		return "", classifyError(ctx, "GetBakerForDelegatorAtLevel", err)
+	}
+	return baker, nil
+}
+
+// GetBakerDelegators returns the delegators delegated to baker, by decreasing balance.
+// With a positive asOfLevel, the delegation set is reconstructed from the history as it was at the end of that level.
+func (p *psql) GetBakerDelegators(ctx context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetBakerDelegators")
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+	offset := (int(page) - 1) * int(limit)
+
+	query := `
+		SELECT delegator, baker, since_level, balance
+		FROM ` + p.tableCurrentDelegations + `
+		WHERE baker = $1
+		ORDER BY balance DESC, delegator
+		LIMIT $2 OFFSET $3
+	`
+	args := []interface{}{baker.String(), limit, offset}
+
+	// At a level, only the delegators that had delegated to baker by then are candidates (delegate and level index),
+	// each one kept when its latest delegation at that level is still to baker (delegator and level index).
+	if asOfLevel > 0 {
+		query = `
+			SELECT candidate.delegator, latest.baker, latest.since_level, latest.balance
+			FROM (
+				SELECT DISTINCT delegator
+				FROM ` + p.tableDelegations + `
+				WHERE delegate = $1 AND level <= $4
+			) candidate
+			CROSS JOIN LATERAL (` + p.latestDelegationAt("candidate.delegator", "$4") + `) latest
+			WHERE latest.baker = $1
+			ORDER BY latest.balance DESC, candidate.delegator
+			LIMIT $2 OFFSET $3
+		`
+		args = append(args, asOfLevel)
+	}
+
+	var delegators []model.CurrentDelegation
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		delegators = nil
+		return db.SelectContext(ctx, &delegators, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetBakerDelegators", err)
+	}
+
+	return delegators, nil
+}
+
+// latestDelegationAt returns the query of the delegation state of delegator at the end of level, its latest delegation
+// up to that level, which the delegator and level index serves. Both arguments are SQL expressions.
+func (p *psql) latestDelegationAt(delegator, level string) string {
+	return `
+		SELECT delegate AS baker, level AS since_level, amount AS balance
+		FROM ` + p.tableDelegations + `
+		WHERE delegator = ` + delegator + ` AND level <= ` + level + `
+		ORDER BY level DESC
+		LIMIT 1
+	`
+}
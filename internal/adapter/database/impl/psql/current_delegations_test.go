@@ -0,0 +1,213 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const tableCurrentDelegations = "app.current_delegations"
+
+func Test_psql_SaveCurrentDelegations(t *testing.T) {
+	delegations := []model.CurrentDelegation{
+		{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 10},
+		{Delegator: "tz1b", Baker: "", SinceLevel: 110, Balance: 20},
+		{Delegator: "tz1c", Baker: "tz1baker", SinceLevel: 120, Balance: 30},
+	}
+
+	tests := []struct {
+		name        string
+		delegations []model.CurrentDelegation
+		db          func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "Nominal case - batches of two rows",
+			delegations: delegations,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO app.current_delegations \(delegator, baker, since_level, balance\) VALUES \(\$1, \$2, \$3, \$4\), \(\$5, \$6, \$7, \$8\)\s+ON CONFLICT \(delegator\) DO UPDATE`).
+					WithArgs("tz1a", "tz1baker", int64(100), int64(10), "tz1b", "", int64(110), int64(20)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`WHERE app.current_delegations.since_level < EXCLUDED.since_level`).
+					WithArgs("tz1c", "tz1baker", int64(120), int64(30)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - no delegation",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:        "Error case - upsert error",
+			delegations: delegations[:1],
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO app.current_delegations").
+					WillReturnError(fmt.Errorf("upsert error"))
+				mock.ExpectRollback()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                      db,
+				batchSize:               2,
+				tableCurrentDelegations: tableCurrentDelegations,
+			}
+			tt.wantErr(t, p.SaveCurrentDelegations(context.Background(), tt.delegations), "SaveCurrentDelegations()")
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetBakerDelegators(t *testing.T) {
+	tests := []struct {
+		name      string
+		asOfLevel int64
+		page      uint16
+		db        func() (*sqlx.DB, sqlmock.Sqlmock)
+		want      []model.CurrentDelegation
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			page: 2,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM app.current_delegations\s+WHERE baker = \$1\s+ORDER BY balance DESC, delegator\s+LIMIT \$2 OFFSET \$3`).
+					WithArgs("tz1baker", uint16(10), 10).
+					WillReturnRows(sqlmock.NewRows([]string{"delegator", "baker", "since_level", "balance"}).
+						AddRow("tz1a", "tz1baker", 100, 10))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    []model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 10}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "Nominal case - as of level",
+			asOfLevel: 1000,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`SELECT DISTINCT delegator\s+FROM app.delegations\s+WHERE delegate = \$1 AND level <= \$4\s+\) candidate\s+`+
+					`CROSS JOIN LATERAL .+ WHERE delegator = candidate.delegator AND level <= \$4\s+ORDER BY level DESC\s+LIMIT 1\s+\) latest\s+WHERE latest.baker = \$1`).
+					WithArgs("tz1baker", uint16(10), 0, int64(1000)).
+					WillReturnRows(sqlmock.NewRows([]string{"delegator", "baker", "since_level", "balance"}).
+						AddRow("tz1a", "tz1baker", 900, 10))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    []model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 900, Balance: 10}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.current_delegations").
+					WithArgs("tz1baker", uint16(10), 0).
+					WillReturnError(fmt.Errorf("query error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                      db,
+				tableCurrentDelegations: tableCurrentDelegations,
+				tableDelegations:        tableDelegations,
+			}
+			got, err := p.GetBakerDelegators(context.Background(), "tz1baker", tt.asOfLevel, tt.page, 10)
+			if !tt.wantErr(t, err, "GetBakerDelegators()") {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetBakerForDelegatorAtLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		asOfLevel int64
+		db        func() (*sqlx.DB, sqlmock.Sqlmock)
+		want      model.WalletAddress
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - current delegation",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`SELECT baker\s+FROM app.current_delegations\s+WHERE delegator = \$1`).
+					WithArgs("tz1a").
+					WillReturnRows(sqlmock.NewRows([]string{"baker"}).AddRow("tz1baker"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    "tz1baker",
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "Nominal case - as of level",
+			asOfLevel: 1000,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`SELECT latest.baker FROM \(\s+SELECT delegate AS baker, .+ FROM app.delegations\s+`+
+					`WHERE delegator = \$1 AND level <= \$2\s+ORDER BY level DESC\s+LIMIT 1\s+\) latest`).
+					WithArgs("tz1a", int64(1000)).
+					WillReturnRows(sqlmock.NewRows([]string{"delegate"}).AddRow("tz1old"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    "tz1old",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.current_delegations").
+					WithArgs("tz1a").
+					WillReturnError(fmt.Errorf("query error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                      db,
+				tableCurrentDelegations: tableCurrentDelegations,
+				tableDelegations:        tableDelegations,
+			}
+			got, err := p.GetBakerForDelegatorAtLevel(context.Background(), "tz1a", tt.asOfLevel)
+			if !tt.wantErr(t, err, "GetBakerForDelegatorAtLevel()") {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
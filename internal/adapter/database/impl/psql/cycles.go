@@ -0,0 +1,70 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SaveCycles saves the levels of cycles, replacing the ones already saved.
+func (p *psql) SaveCycles(ctx context.Context, cycles []model.Cycle) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveCycles")
+	defer cancel()
+
+	if len(cycles) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, 0, len(cycles))
+	for _, c := range cycles {
+		rows = append(rows, []interface{}{c.Index, c.FirstLevel, c.LastLevel})
+	}
+
+	query, args := buildMultiRowValues(p.tableCycles, []string{"cycle", "first_level", "last_level"}, rows)
+	query += `
+		ON CONFLICT (cycle) DO UPDATE
+		SET first_level = EXCLUDED.first_level, last_level = EXCLUDED.last_level
+	`
+	_, err := p.db.ExecContext(ctx, query, args...)
+	return classifyError(ctx, "SaveCycles", err)
+}
+
+// GetCycle returns the levels of a cycle, or sql.ErrNoRows when the cycle is not synced.
+func (p *psql) GetCycle(ctx context.Context, cycle int) (*model.Cycle, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetCycle")
+	defer cancel()
+
+	var c model.Cycle
+	query := `
+		SELECT cycle, first_level, last_level
+		FROM ` + p.tableCycles + `
+		WHERE cycle = $1
+	`
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &c, query, cycle)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetCycle", err)
+	}
+	return &c, nil
+}
+
+// GetMissingCycles returns the cycles from fromCycle to toCycle, both included, whose levels are not saved.
+func (p *psql) GetMissingCycles(ctx context.Context, fromCycle, toCycle int) ([]int, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetMissingCycles")
+	defer cancel()
+
+	var cycles []int
+	query := `
+		SELECT series.cycle
+		FROM generate_series($1::integer, $2::integer) AS series(cycle)
+		WHERE NOT EXISTS (SELECT 1 FROM ` + p.tableCycles + ` c WHERE c.cycle = series.cycle)
+		ORDER BY series.cycle
+	`
+	if err := p.db.SelectContext(ctx, &cycles, query, fromCycle, toCycle); err != nil {
+		return nil, classifyError(ctx, "GetMissingCycles", err)
+	}
+	return cycles, nil
+}
@@ -0,0 +1,163 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const tableCycles = "app.cycles"
+
+func Test_psql_SaveCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		cycles  []model.Cycle
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "Nominal case",
+			cycles: []model.Cycle{{Index: 700, FirstLevel: 901, LastLevel: 1000}},
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec(`INSERT INTO app.cycles \(cycle, first_level, last_level\) VALUES \(\$1, \$2, \$3\)\s+ON CONFLICT \(cycle\) DO UPDATE`).
+					WithArgs(700, int64(901), int64(1000)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - no cycle",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "Error case - insert error",
+			cycles: []model.Cycle{{Index: 700, FirstLevel: 901, LastLevel: 1000}},
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec("INSERT INTO app.cycles").
+					WillReturnError(fmt.Errorf("insert error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:          db,
+				tableCycles: tableCycles,
+			}
+			tt.wantErr(t, p.SaveCycles(context.Background(), tt.cycles), "SaveCycles()")
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    *model.Cycle
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM app.cycles\s+WHERE cycle = \$1`).
+					WithArgs(700).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle", "first_level", "last_level"}).
+						AddRow(700, 901, 1000))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    &model.Cycle{Index: 700, FirstLevel: 901, LastLevel: 1000},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - cycle not synced",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM app.cycles").
+					WithArgs(700).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle", "first_level", "last_level"}))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, sql.ErrNoRows, i...)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:          db,
+				tableCycles: tableCycles,
+			}
+			got, err := p.GetCycle(context.Background(), 700)
+			tt.wantErr(t, err, "GetCycle()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetMissingCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    []int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`FROM generate_series\(\$1::integer, \$2::integer\) AS series\(cycle\)\s+WHERE NOT EXISTS \(SELECT 1 FROM app.cycles c`).
+					WithArgs(1, 700).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(1).AddRow(2))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    []int{1, 2},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("FROM generate_series").
+					WithArgs(1, 700).
+					WillReturnError(fmt.Errorf("query error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:          db,
+				tableCycles: tableCycles,
+			}
+			got, err := p.GetMissingCycles(context.Background(), 1, 700)
+			tt.wantErr(t, err, "GetMissingCycles()")
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
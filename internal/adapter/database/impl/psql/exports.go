@@ -0,0 +1,106 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// StreamDelegations calls fn for every delegation matching the filter, in its order, as the rows are read.
+func (p *psql) StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error {
+	ctx, cancel := p.withExportTimeout(ctx, "StreamDelegations")
+	defer cancel()
+
+	conditions, args := delegationConditions(filter)
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM ` + p.tableDelegations + `
+		` + whereClause(conditions) + `
+		` + delegationOrderClause(filter)
+
+	err := p.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var d model.Delegation
+		if err := rows.StructScan(&d); err != nil {
+			return err
+		}
+		return fn(d)
+	})
+	return classifyError(ctx, "StreamDelegations", err)
+}
+
+// StreamOperations calls fn for every operation matching the filters, by decreasing timestamp, as the rows are read.
+func (p *psql) StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error {
+	ctx, cancel := p.withExportTimeout(ctx, "StreamOperations")
+	defer cancel()
+
+	conditions, args := operationConditions(fromDate, toDate, operationType, wallet, baker)
+	query := `
+		SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status
+		FROM ` + p.tableOperations + `
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC`
+
+	err := p.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var o model.Operation
+		if err := rows.StructScan(&o); err != nil {
+			return err
+		}
+		return fn(o)
+	})
+	return classifyError(ctx, "StreamOperations", err)
+}
+
+// StreamRewards calls fn for every reward matching the filters, by decreasing timestamp, as the rows are read.
+func (p *psql) StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error {
+	ctx, cancel := p.withExportTimeout(ctx, "StreamRewards")
+	defer cancel()
+
+	conditions, args := rewardConditions(fromDate, toDate, wallet, baker)
+	query := `
+		SELECT id, recipient_address, source_address, cycle, amount, timestamp
+		FROM ` + p.tableRewards + `
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC`
+
+	err := p.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var r model.Reward
+		if err := rows.StructScan(&r); err != nil {
+			return err
+		}
+		return fn(r)
+	})
+	return classifyError(ctx, "StreamRewards", err)
+}
+
+// stream runs query on a replica, or on the primary, and calls scan for every row as it is received, so that the
+// memory used does not depend on the number of rows. A replica failing before its first row falls back to the
+// primary, rows already passed to scan are never sent twice.
+func (p *psql) stream(ctx context.Context, query string, args []interface{}, scan func(rows *sqlx.Rows) error) error {
+	scanned := false
+	run := func(db *sqlx.DB) error {
+		rows, err := db.QueryxContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			scanned = true
+			if err := scan(rows); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}
+
+	if r := p.replicas.pick(); r != nil {
+		err := run(r.db)
+		if err == nil || scanned || ctx.Err() != nil {
+			return err
+		}
+		r.healthy.Store(false)
+	}
+	return run(p.db)
+}
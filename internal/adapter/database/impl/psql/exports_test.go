@@ -0,0 +1,107 @@
+package psql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+var rewardColumns = []string{"id", "recipient_address", "source_address", "cycle", "amount", "timestamp"}
+
+func Test_psql_StreamRewards(t *testing.T) {
+	const query = `(?s)FROM app.rewards\s+WHERE timestamp >= \$1 AND recipient_address = \$2 AND source_address = \$3\s+ORDER BY timestamp DESC, id DESC$`
+	args := []driver.Value{int64(86400), "tz1wallet", "tz1baker"}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, p *psql, primary sqlmock.Sqlmock)
+		fnErr   error
+		want    []model.Reward
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			setup: func(t *testing.T, p *psql, primary sqlmock.Sqlmock) {
+				primary.ExpectQuery(query).WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows(rewardColumns).
+						AddRow(2, "tz1wallet", "tz1baker", 701, 20, 172800).
+						AddRow(1, "tz1wallet", "tz1baker", 700, 10, 86400))
+			},
+			want: []model.Reward{
+				{ID: 2, RecipientAddress: "tz1wallet", SourceAddress: "tz1baker", Cycle: 701, Amount: 20, Timestamp: 172800},
+				{ID: 1, RecipientAddress: "tz1wallet", SourceAddress: "tz1baker", Cycle: 700, Amount: 10, Timestamp: 86400},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Replica failing before its first row falls back to the primary",
+			setup: func(t *testing.T, p *psql, primary sqlmock.Sqlmock) {
+				r, replicaMock := newTestReplica(t, "r1", true)
+				p.replicas = &replicaPool{replicas: []*replica{r}}
+				replicaMock.ExpectQuery(query).WithArgs(args...).WillReturnError(errors.New("connection refused"))
+				primary.ExpectQuery(query).WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows(rewardColumns).AddRow(1, "tz1wallet", "tz1baker", 700, 10, 86400))
+			},
+			want:    []model.Reward{{ID: 1, RecipientAddress: "tz1wallet", SourceAddress: "tz1baker", Cycle: 700, Amount: 10, Timestamp: 86400}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - failure of fn stops the stream",
+			setup: func(t *testing.T, p *psql, primary sqlmock.Sqlmock) {
+				primary.ExpectQuery(query).WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows(rewardColumns).
+						AddRow(2, "tz1wallet", "tz1baker", 701, 20, 172800).
+						AddRow(1, "tz1wallet", "tz1baker", 700, 10, 86400))
+			},
+			fnErr:   errors.New("broken pipe"),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			p := &psql{db: sqlx.NewDb(db, "sqlmock"), tableRewards: schemaTableRewards}
+			tt.setup(t, p, mock)
+
+			var got []model.Reward
+			err := p.StreamRewards(context.Background(), 86400, 0, "tz1wallet", "tz1baker", func(r model.Reward) error {
+				if tt.fnErr != nil {
+					return tt.fnErr
+				}
+				got = append(got, r)
+				return nil
+			})
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_StreamDelegations(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	db, mock, _ := sqlmock.New()
+	p := &psql{db: sqlx.NewDb(db, "sqlmock"), tableDelegations: schemaTableDelegations}
+
+	mock.ExpectQuery(`(?s)FROM app.delegations\s+WHERE delegator = \$1\s+ORDER BY amount ASC, id ASC$`).
+		WithArgs("tz1a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
+			AddRow(1, "tz1a", "tz1baker", 86400, 10, 100, createdAt))
+
+	var got []model.Delegation
+	err := p.StreamDelegations(context.Background(), model.DelegationFilter{Delegator: "tz1a", Sort: model.DelegationSortAmount, Order: model.SortOrderAsc}, func(d model.Delegation) error {
+		got = append(got, d)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Delegation{{ID: 1, Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 86400, Amount: 10, Level: 100, CreatedAt: createdAt}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
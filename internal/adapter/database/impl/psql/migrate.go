@@ -0,0 +1,350 @@
+package psql
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationsLockID is the advisory lock key serialising concurrent migration runs.
+const migrationsLockID = 839104711
+
+// sqitchProject is the project name under which the schema used to be deployed with sqitch.
+const sqitchProject = "tezos-delegation-service"
+
+// migration is a versioned schema change read from migrations/<version>_<name>.<up|down>.sql.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// appliedMigration is a row of the schema_migrations table.
+type appliedMigration struct {
+	Version   int       `db:"version"`
+	Name      string    `db:"name"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+// loadMigrations parses the migration files of fsys, sorted by version.
+// Versions must be contiguous from 1 and every migration must have an up and a down file.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*migration)
+	for _, file := range files {
+		base := path.Base(file)
+
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: file name must end with .up.sql or .down.sql", base)
+		}
+
+		name := strings.TrimSuffix(base, "."+direction+".sql")
+		prefix, _, found := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: file name must start with a positive version followed by _", base)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		} else if m.name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.name, name)
+		}
+
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %s: both up and down files are required", m.name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %s: expected version %d, versions must be contiguous", m.name, i+1)
+		}
+	}
+
+	return migrations, nil
+}
+
+// NewMigrator creates a database.Migrator applying the embedded migrations.
+func NewMigrator(cfg Config) (database.Migrator, error) {
+	adapter, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.(*psql), nil
+}
+
+// expectedSchemaVersion returns the version of the last embedded migration.
+func (p *psql) expectedSchemaVersion() int {
+	if len(p.migrations) == 0 {
+		return 0
+	}
+	return p.migrations[len(p.migrations)-1].version
+}
+
+// CheckSchemaVersion returns database.ErrSchemaVersionMismatch unless the last applied migration is the last embedded one.
+func (p *psql) CheckSchemaVersion(ctx context.Context) error {
+	applied, err := p.appliedMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	current := 0
+	if len(applied) > 0 {
+		current = applied[len(applied)-1].Version
+	}
+
+	if expected := p.expectedSchemaVersion(); current != expected {
+		return fmt.Errorf("%w: database is at version %d, code expects version %d", database.ErrSchemaVersionMismatch, current, expected)
+	}
+
+	return nil
+}
+
+// MigrateUp applies all pending migrations, each in its own transaction.
+func (p *psql) MigrateUp(ctx context.Context) ([]database.MigrationStatus, error) {
+	if err := p.ensureMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var done []database.MigrationStatus
+	for _, m := range p.migrations {
+		applied, err := p.runMigration(ctx, m, true)
+		if err != nil {
+			return done, fmt.Errorf("migration %s: %w", m.name, err)
+		}
+		if applied {
+			now := time.Now()
+			done = append(done, database.MigrationStatus{Version: m.version, Name: m.name, AppliedAt: &now})
+		}
+	}
+
+	return done, nil
+}
+
+// MigrateDown rolls back the last steps applied migrations, most recent first.
+func (p *psql) MigrateDown(ctx context.Context, steps int) ([]database.MigrationStatus, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be a positive number")
+	}
+
+	applied, err := p.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]migration, len(p.migrations))
+	for _, m := range p.migrations {
+		byVersion[m.version] = m
+	}
+
+	var done []database.MigrationStatus
+	for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+		m, ok := byVersion[applied[i].Version]
+		if !ok {
+			return done, fmt.Errorf("migration version %d is applied but unknown to this binary", applied[i].Version)
+		}
+
+		reverted, err := p.runMigration(ctx, m, false)
+		if err != nil {
+			return done, fmt.Errorf("migration %s: %w", m.name, err)
+		}
+		if reverted {
+			done = append(done, database.MigrationStatus{Version: m.version, Name: m.name})
+		}
+	}
+
+	return done, nil
+}
+
+// MigrationStatus returns the embedded migrations and the applied ones unknown to this binary, sorted by version.
+func (p *psql) MigrationStatus(ctx context.Context) ([]database.MigrationStatus, error) {
+	applied, err := p.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedAt := make(map[int]appliedMigration, len(applied))
+	for _, a := range applied {
+		appliedAt[a.Version] = a
+	}
+
+	statuses := make([]database.MigrationStatus, 0, len(p.migrations))
+	for _, m := range p.migrations {
+		status := database.MigrationStatus{Version: m.version, Name: m.name}
+		if a, ok := appliedAt[m.version]; ok {
+			at := a.AppliedAt
+			status.AppliedAt = &at
+			delete(appliedAt, m.version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, a := range appliedAt {
+		at := a.AppliedAt
+		statuses = append(statuses, database.MigrationStatus{Version: a.Version, Name: a.Name, AppliedAt: &at})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// runMigration applies (up) or rolls back (down) m in a transaction holding the migrations advisory lock.
+// It reports false without error when another run already did it.
+func (p *psql) runMigration(ctx context.Context, m migration, up bool) (bool, error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	done, err := func() (bool, error) {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationsLockID); err != nil {
+			return false, err
+		}
+
+		var isApplied bool
+		if err := tx.GetContext(ctx, &isApplied, "SELECT EXISTS (SELECT 1 FROM public.schema_migrations WHERE version = $1)", m.version); err != nil {
+			return false, err
+		}
+		if isApplied == up {
+			return false, nil
+		}
+
+		if up {
+			if _, err := tx.ExecContext(ctx, m.up); err != nil {
+				return false, err
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name)
+			return err == nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, m.down); err != nil {
+			return false, err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM public.schema_migrations WHERE version = $1", m.version)
+		return err == nil, err
+	}()
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return false, errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return false, err
+	}
+
+	return done, tx.Commit()
+}
+
+// ensureMigrationsTable creates the schema_migrations table.
+// On a database deployed with sqitch, the changes recorded in its registry are imported as applied migrations.
+func (p *psql) ensureMigrationsTable(ctx context.Context) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS public.schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`
+	if _, err := p.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var count int
+	if err := p.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM public.schema_migrations"); err != nil {
+		return err
+	}
+
+	var hasSqitch bool
+	if err := p.db.GetContext(ctx, &hasSqitch, "SELECT to_regclass('sqitch.changes') IS NOT NULL"); err != nil {
+		return err
+	}
+
+	if count > 0 || !hasSqitch {
+		return nil
+	}
+
+	var changes []string
+	if err := p.db.SelectContext(ctx, &changes, "SELECT change FROM sqitch.changes WHERE project = $1", sqitchProject); err != nil {
+		return fmt.Errorf("failed to read sqitch registry: %w", err)
+	}
+
+	deployed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		deployed[change] = true
+	}
+
+	for _, m := range p.migrations {
+		if !deployed[m.name] {
+			continue
+		}
+		if _, err := p.db.ExecContext(ctx, "INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2) ON CONFLICT DO NOTHING", m.version, m.name); err != nil {
+			return fmt.Errorf("failed to import sqitch change %s: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the applied migrations sorted by version, none if the schema_migrations table does not exist.
+func (p *psql) appliedMigrations(ctx context.Context) ([]appliedMigration, error) {
+	var exists bool
+	if err := p.db.GetContext(ctx, &exists, "SELECT to_regclass('public.schema_migrations') IS NOT NULL"); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var applied []appliedMigration
+	if err := p.db.SelectContext(ctx, &applied, "SELECT version, name, applied_at FROM public.schema_migrations ORDER BY version"); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
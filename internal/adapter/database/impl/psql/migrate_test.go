@@ -0,0 +1,357 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+var testMigrations = []migration{
+	{version: 1, name: "01_first", up: "CREATE TABLE first (id INT)", down: "DROP TABLE first"},
+	{version: 2, name: "02_second", up: "CREATE TABLE second (id INT)", down: "DROP TABLE second"},
+}
+
+func expectMigrationsTableExists(mock sqlmock.Sqlmock, exists bool) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('public.schema_migrations') IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+func expectAppliedMigrations(mock sqlmock.Sqlmock, versions ...int) {
+	expectMigrationsTableExists(mock, true)
+	rows := sqlmock.NewRows([]string{"version", "name", "applied_at"})
+	for _, v := range versions {
+		rows.AddRow(v, testMigrations[v-1].name, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery("SELECT version, name, applied_at FROM public.schema_migrations").WillReturnRows(rows)
+}
+
+func expectMigrationRun(mock sqlmock.Sqlmock, m migration, isApplied bool, statement string) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+		WithArgs(migrationsLockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM public.schema_migrations WHERE version = $1)")).
+		WithArgs(m.version).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(isApplied))
+	if statement != "" {
+		mock.ExpectExec(regexp.QuoteMeta(statement)).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+}
+
+func Test_loadMigrations(t *testing.T) {
+	tests := []struct {
+		name         string
+		fsys         fstest.MapFS
+		wantVersions []int
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			fsys: fstest.MapFS{
+				"migrations/02_b.up.sql":   {Data: []byte("up b")},
+				"migrations/02_b.down.sql": {Data: []byte("down b")},
+				"migrations/01_a.up.sql":   {Data: []byte("up a")},
+				"migrations/01_a.down.sql": {Data: []byte("down a")},
+			},
+			wantVersions: []int{1, 2},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "Error case - missing down file",
+			fsys: fstest.MapFS{
+				"migrations/01_a.up.sql": {Data: []byte("up a")},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error case - version gap",
+			fsys: fstest.MapFS{
+				"migrations/01_a.up.sql":   {Data: []byte("up a")},
+				"migrations/01_a.down.sql": {Data: []byte("down a")},
+				"migrations/03_c.up.sql":   {Data: []byte("up c")},
+				"migrations/03_c.down.sql": {Data: []byte("down c")},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error case - duplicated version",
+			fsys: fstest.MapFS{
+				"migrations/01_a.up.sql":   {Data: []byte("up a")},
+				"migrations/01_a.down.sql": {Data: []byte("down a")},
+				"migrations/01_b.up.sql":   {Data: []byte("up b")},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error case - invalid file name",
+			fsys: fstest.MapFS{
+				"migrations/first.up.sql": {Data: []byte("up")},
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.fsys)
+			tt.wantErr(t, err)
+
+			versions := make([]int, 0, len(got))
+			for _, m := range got {
+				versions = append(versions, m.version)
+			}
+			if tt.wantVersions != nil {
+				assert.Equal(t, tt.wantVersions, versions)
+			}
+		})
+	}
+}
+
+func Test_loadMigrations_embedded(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, migrations) {
+		assert.Equal(t, "01_appschema", migrations[0].name)
+		assert.Equal(t, len(migrations), migrations[len(migrations)-1].version)
+	}
+	for _, m := range migrations {
+		assert.NotContains(t, m.up, "BEGIN;", "%s runs in a transaction opened by the migrator", m.name)
+		assert.NotContains(t, m.down, "COMMIT;", "%s runs in a transaction opened by the migrator", m.name)
+	}
+}
+
+func Test_psql_CheckSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				expectAppliedMigrations(mock, 1, 2)
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - pending migration",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				expectAppliedMigrations(mock, 1)
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, database.ErrSchemaVersionMismatch, i...)
+			},
+		},
+		{
+			name: "Error case - database never migrated",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				expectMigrationsTableExists(mock, false)
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, database.ErrSchemaVersionMismatch, i...)
+			},
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT to_regclass").WillReturnError(errors.New("connection refused"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{db: db, migrations: testMigrations}
+			tt.wantErr(t, p.CheckSchemaVersion(context.Background()))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_MigrateUp(t *testing.T) {
+	tests := []struct {
+		name        string
+		db          func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantApplied []int
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - second migration pending",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM public.schema_migrations")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('sqitch.changes') IS NOT NULL")).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+				expectMigrationRun(mock, testMigrations[0], true, "")
+				mock.ExpectCommit()
+
+				expectMigrationRun(mock, testMigrations[1], false, testMigrations[1].up)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2)")).
+					WithArgs(2, "02_second").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantApplied: []int{2},
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "Nominal case - sqitch registry imported",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM public.schema_migrations")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('sqitch.changes') IS NOT NULL")).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT change FROM sqitch.changes WHERE project = $1")).
+					WithArgs(sqitchProject).
+					WillReturnRows(sqlmock.NewRows([]string{"change"}).AddRow("01_first").AddRow("02_second"))
+				for _, m := range testMigrations {
+					mock.ExpectExec(regexp.QuoteMeta("INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2) ON CONFLICT DO NOTHING")).
+						WithArgs(m.version, m.name).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+
+				for _, m := range testMigrations {
+					expectMigrationRun(mock, m, true, "")
+					mock.ExpectCommit()
+				}
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - migration fails and is rolled back",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS public.schema_migrations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM public.schema_migrations")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT to_regclass('sqitch.changes') IS NOT NULL")).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+				expectMigrationRun(mock, testMigrations[0], false, "")
+				mock.ExpectExec(regexp.QuoteMeta(testMigrations[0].up)).WillReturnError(errors.New("syntax error"))
+				mock.ExpectRollback()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{db: db, migrations: testMigrations}
+			got, err := p.MigrateUp(context.Background())
+			tt.wantErr(t, err)
+
+			var versions []int
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.wantApplied, versions)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_MigrateDown(t *testing.T) {
+	tests := []struct {
+		name         string
+		steps        int
+		db           func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantReverted []int
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "Nominal case",
+			steps: 1,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				expectAppliedMigrations(mock, 1, 2)
+				expectMigrationRun(mock, testMigrations[1], true, testMigrations[1].down)
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM public.schema_migrations WHERE version = $1")).
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantReverted: []int{2},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:  "Nominal case - nothing applied",
+			steps: 3,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				expectMigrationsTableExists(mock, false)
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "Error case - invalid steps",
+			steps: 0,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{db: db, migrations: testMigrations}
+			got, err := p.MigrateDown(context.Background(), tt.steps)
+			tt.wantErr(t, err)
+
+			var versions []int
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.wantReverted, versions)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_MigrationStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	expectAppliedMigrations(mock, 1)
+
+	p := &psql{db: sqlx.NewDb(db, "sqlmock"), migrations: testMigrations}
+	got, err := p.MigrationStatus(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "01_first", got[0].Name)
+		assert.NotNil(t, got[0].AppliedAt)
+		assert.Equal(t, "02_second", got[1].Name)
+		assert.Nil(t, got[1].AppliedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
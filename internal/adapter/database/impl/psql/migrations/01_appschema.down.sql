@@ -0,0 +1,3 @@
+-- 01_appschema: Add schema for delegation service (rollback)
+
+DROP SCHEMA IF EXISTS app CASCADE;
@@ -0,0 +1,4 @@
+-- 01_appschema: Add schema for delegation service
+
+-- Create main application schema
+CREATE SCHEMA IF NOT EXISTS app;
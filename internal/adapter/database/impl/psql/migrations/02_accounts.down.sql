@@ -1,10 +1,8 @@
--- Revert tezos-delegation-service:02_accounts from pg
-
-BEGIN;
+-- 02_accounts: Create accounts table (rollback)
 
 DROP INDEX IF EXISTS app.idx_accounts_address;
 DROP INDEX IF EXISTS app.idx_accounts_type;
 
 DROP TABLE IF EXISTS app.accounts;
 
-COMMIT;
+DROP TYPE IF EXISTS account_type;
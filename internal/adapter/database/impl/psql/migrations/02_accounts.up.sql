@@ -1,7 +1,4 @@
--- Deploy tezos-delegation-service:02_accounts to pg
--- requires: 01_appschema
-
-BEGIN;
+-- 02_accounts: Create accounts table
 
 CREATE TYPE account_type AS ENUM ('wallet', 'contract', 'baker');
 
@@ -15,5 +12,3 @@ CREATE TABLE IF NOT EXISTS app.accounts (
 
 CREATE INDEX IF NOT EXISTS idx_accounts_address ON app.accounts (address);
 CREATE INDEX IF NOT EXISTS idx_accounts_type ON app.accounts (type);
-
-COMMIT;
\ No newline at end of file
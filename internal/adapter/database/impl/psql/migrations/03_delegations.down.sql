@@ -1,6 +1,4 @@
--- Revert tezos-delegation-service:03_delegations to pg
-
-BEGIN;
+-- 03_delegations: Create delegations table (rollback)
 
 DROP INDEX IF EXISTS app.idx_delegations_level;
 DROP INDEX IF EXISTS app.idx_delegations_delegator;
@@ -11,5 +9,3 @@ DROP INDEX IF EXISTS app.idx_delegations_status;
 DROP INDEX IF EXISTS app.idx_delegations_block;
 
 DROP TABLE IF EXISTS app.delegations;
-
-COMMIT;
\ No newline at end of file
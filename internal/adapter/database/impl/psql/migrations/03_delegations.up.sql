@@ -1,7 +1,4 @@
--- Deploy tezos-delegation-service:03_delegations to pg
--- requires: 01_appschema 02_accounts
-
-BEGIN;
+-- 03_delegations: Create delegations table
 
 CREATE TABLE IF NOT EXISTS app.delegations (
     id SERIAL PRIMARY KEY,
@@ -24,5 +21,3 @@ CREATE INDEX IF NOT EXISTS idx_delegations_sender_address ON app.delegations (se
 CREATE INDEX IF NOT EXISTS idx_delegations_delegate_address ON app.delegations (delegate_address);
 CREATE INDEX IF NOT EXISTS idx_delegations_status ON app.delegations (status);
 CREATE INDEX IF NOT EXISTS idx_delegations_block ON app.delegations (block);
-
-COMMIT;
\ No newline at end of file
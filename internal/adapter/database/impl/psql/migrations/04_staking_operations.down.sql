@@ -1,6 +1,4 @@
--- Revert tezos-delegation-service:04_staking_operations to pg
-
-BEGIN;
+-- 04_staking_operations: Create staking operations table (rollback)
 
 DROP INDEX IF EXISTS app.idx_staking_operations_sender_address;
 DROP INDEX IF EXISTS app.idx_staking_operations_contract_address;
@@ -8,5 +6,3 @@ DROP INDEX IF EXISTS app.idx_staking_operations_timestamp;
 DROP INDEX IF EXISTS app.idx_staking_operations_status;
 
 DROP TABLE IF EXISTS app.staking_operations;
-
-COMMIT;
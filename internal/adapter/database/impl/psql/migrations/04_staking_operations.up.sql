@@ -1,7 +1,4 @@
--- Deploy tezos-delegation-service:04_staking_operations to pg
--- requires: 01_appschema 02_accounts 03_delegations
-
-BEGIN;
+-- 04_staking_operations: Create staking operations table
 
 CREATE TABLE IF NOT EXISTS app.staking_operations (
     id SERIAL PRIMARY KEY,
@@ -19,5 +16,3 @@ CREATE INDEX IF NOT EXISTS idx_staking_operations_sender_address ON app.staking_
 CREATE INDEX IF NOT EXISTS idx_staking_operations_contract_address ON app.staking_operations (contract_address);
 CREATE INDEX IF NOT EXISTS idx_staking_operations_timestamp ON app.staking_operations (timestamp DESC);
 CREATE INDEX IF NOT EXISTS idx_staking_operations_status ON app.staking_operations (status);
-
-COMMIT;
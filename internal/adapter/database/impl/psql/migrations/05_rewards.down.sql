@@ -1,6 +1,4 @@
--- Revert tezos-delegation-service:05_rewards to pg
-
-BEGIN;
+-- 05_rewards: Create rewards table (rollback)
 
 DROP INDEX IF EXISTS app.idx_rewards_recipient_address;
 DROP INDEX IF EXISTS app.idx_rewards_source_address;
@@ -8,5 +6,3 @@ DROP INDEX IF EXISTS app.idx_rewards_cycle;
 DROP INDEX IF EXISTS app.idx_rewards_timestamp;
 
 DROP TABLE IF EXISTS app.rewards;
-
-COMMIT;
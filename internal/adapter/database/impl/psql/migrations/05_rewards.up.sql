@@ -1,7 +1,4 @@
--- Deploy tezos-delegation-service:05_rewards to pg
--- requires: 01_appschema 02_accounts 03_delegations 04_staking_operations
-
-BEGIN;
+-- 05_rewards: Create rewards table
 
 CREATE TABLE IF NOT EXISTS app.rewards (
     id SERIAL PRIMARY KEY,
@@ -17,5 +14,3 @@ CREATE INDEX IF NOT EXISTS idx_rewards_recipient_address ON app.rewards (recipie
 CREATE INDEX IF NOT EXISTS idx_rewards_source_address ON app.rewards (source_address);
 CREATE INDEX IF NOT EXISTS idx_rewards_cycle ON app.rewards (cycle);
 CREATE INDEX IF NOT EXISTS idx_rewards_timestamp ON app.rewards (timestamp DESC);
-
-COMMIT;
@@ -1,7 +1,4 @@
--- Deploy tezos-delegation-service:06_staking_pools to pg
--- requires: 01_appschema 02_accounts 03_delegations 04_staking_operations 05_rewards
-
-BEGIN;
+-- 06_staking_pools: Create staking pools table
 
 CREATE TABLE IF NOT EXISTS app.staking_pools (
     id SERIAL PRIMARY KEY,
@@ -13,5 +10,3 @@ CREATE TABLE IF NOT EXISTS app.staking_pools (
 
 CREATE INDEX IF NOT EXISTS idx_staking_pools_address ON app.staking_pools (address);
 CREATE INDEX IF NOT EXISTS idx_staking_pools_token ON app.staking_pools (staking_token);
-
-COMMIT;
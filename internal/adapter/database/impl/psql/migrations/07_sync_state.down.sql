@@ -0,0 +1,3 @@
+-- 07_sync_state: Create sync state table (rollback)
+
+DROP TABLE IF EXISTS app.sync_state;
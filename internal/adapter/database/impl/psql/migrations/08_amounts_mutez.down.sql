@@ -0,0 +1,10 @@
+-- 08_amounts_mutez: Store amounts as integer mutez (rollback)
+
+ALTER TABLE app.delegations
+    ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 1000000.0;
+
+ALTER TABLE app.staking_operations
+    ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 1000000.0;
+
+ALTER TABLE app.rewards
+    ALTER COLUMN amount TYPE DOUBLE PRECISION USING amount / 1000000.0;
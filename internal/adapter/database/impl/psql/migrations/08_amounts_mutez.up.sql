@@ -0,0 +1,12 @@
+-- 08_amounts_mutez: Store amounts as integer mutez
+
+-- Amounts are stored as integer mutez (1 tez = 1,000,000 mutez) to avoid float rounding.
+-- Existing rows were stored in tez, so they are converted once here.
+ALTER TABLE app.delegations
+    ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 1000000)::BIGINT;
+
+ALTER TABLE app.staking_operations
+    ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 1000000)::BIGINT;
+
+ALTER TABLE app.rewards
+    ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 1000000)::BIGINT;
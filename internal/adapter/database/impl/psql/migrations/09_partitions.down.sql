@@ -0,0 +1,53 @@
+-- 09_partitions: Partition delegations and rewards by month (rollback)
+
+-- Delegations
+
+ALTER TABLE app.delegations RENAME TO delegations_partitioned;
+
+CREATE TABLE app.delegations (
+    LIKE app.delegations_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+);
+
+INSERT INTO app.delegations SELECT * FROM app.delegations_partitioned;
+
+ALTER SEQUENCE app.delegations_id_seq OWNED BY app.delegations.id;
+
+-- Dropping the parent table drops all its partitions and indexes
+DROP TABLE app.delegations_partitioned;
+
+ALTER TABLE app.delegations ADD PRIMARY KEY (id);
+ALTER TABLE app.delegations ADD FOREIGN KEY (sender_address) REFERENCES app.accounts(address);
+ALTER TABLE app.delegations ADD FOREIGN KEY (delegate_address) REFERENCES app.accounts(address);
+
+CREATE INDEX IF NOT EXISTS idx_delegations_timestamp ON app.delegations (timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator ON app.delegations (delegator);
+CREATE INDEX IF NOT EXISTS idx_delegations_level ON app.delegations (level);
+CREATE INDEX IF NOT EXISTS idx_delegations_sender_address ON app.delegations (sender_address);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegate_address ON app.delegations (delegate_address);
+CREATE INDEX IF NOT EXISTS idx_delegations_status ON app.delegations (status);
+CREATE INDEX IF NOT EXISTS idx_delegations_block ON app.delegations (block);
+
+-- Rewards
+
+ALTER TABLE app.rewards RENAME TO rewards_partitioned;
+
+CREATE TABLE app.rewards (
+    LIKE app.rewards_partitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+);
+
+INSERT INTO app.rewards SELECT * FROM app.rewards_partitioned;
+
+ALTER SEQUENCE app.rewards_id_seq OWNED BY app.rewards.id;
+
+DROP TABLE app.rewards_partitioned;
+
+ALTER TABLE app.rewards ADD PRIMARY KEY (id);
+ALTER TABLE app.rewards ADD FOREIGN KEY (recipient_address) REFERENCES app.accounts(address);
+ALTER TABLE app.rewards ADD FOREIGN KEY (source_address) REFERENCES app.accounts(address);
+
+CREATE INDEX IF NOT EXISTS idx_rewards_recipient_address ON app.rewards (recipient_address);
+CREATE INDEX IF NOT EXISTS idx_rewards_source_address ON app.rewards (source_address);
+CREATE INDEX IF NOT EXISTS idx_rewards_cycle ON app.rewards (cycle);
+CREATE INDEX IF NOT EXISTS idx_rewards_timestamp ON app.rewards (timestamp DESC);
+
+DROP FUNCTION IF EXISTS app.create_monthly_partitions(TEXT, DATE, DATE);
@@ -0,0 +1,94 @@
+-- 09_partitions: Partition delegations and rewards by month
+
+-- Creates the monthly range partitions of a table partitioned on its BIGINT unix timestamp column,
+-- named <parent>_yYYYYmMM, from from_month to to_month included.
+-- The job creates future partitions ahead of time with the same naming scheme.
+CREATE OR REPLACE FUNCTION app.create_monthly_partitions(parent TEXT, from_month DATE, to_month DATE)
+RETURNS VOID AS $$
+DECLARE
+    partition_month DATE := date_trunc('month', from_month)::DATE;
+BEGIN
+    WHILE partition_month <= to_month LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %s_y%sm%s PARTITION OF %s FOR VALUES FROM (%s) TO (%s)',
+            parent, to_char(partition_month, 'YYYY'), to_char(partition_month, 'MM'), parent,
+            extract(epoch FROM partition_month::TIMESTAMP AT TIME ZONE 'UTC')::BIGINT,
+            extract(epoch FROM (partition_month + INTERVAL '1 month')::TIMESTAMP AT TIME ZONE 'UTC')::BIGINT
+        );
+        partition_month := (partition_month + INTERVAL '1 month')::DATE;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Delegations
+
+ALTER TABLE app.delegations RENAME TO delegations_unpartitioned;
+
+CREATE TABLE app.delegations (
+    LIKE app.delegations_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+) PARTITION BY RANGE (timestamp);
+
+-- Tezos mainnet started in June 2018, partitions are created from there up to three months ahead
+SELECT app.create_monthly_partitions(
+    'app.delegations',
+    LEAST(
+        '2018-06-01'::DATE,
+        (SELECT to_timestamp(MIN(timestamp)) AT TIME ZONE 'UTC' FROM app.delegations_unpartitioned)::DATE
+    ),
+    (date_trunc('month', now() AT TIME ZONE 'UTC') + INTERVAL '3 months')::DATE
+);
+
+CREATE TABLE IF NOT EXISTS app.delegations_default PARTITION OF app.delegations DEFAULT;
+
+INSERT INTO app.delegations SELECT * FROM app.delegations_unpartitioned;
+
+ALTER SEQUENCE app.delegations_id_seq OWNED BY app.delegations.id;
+
+DROP TABLE app.delegations_unpartitioned;
+
+-- The partition key must be part of the primary key
+ALTER TABLE app.delegations ADD PRIMARY KEY (id, timestamp);
+ALTER TABLE app.delegations ADD FOREIGN KEY (sender_address) REFERENCES app.accounts(address);
+ALTER TABLE app.delegations ADD FOREIGN KEY (delegate_address) REFERENCES app.accounts(address);
+
+CREATE INDEX IF NOT EXISTS idx_delegations_timestamp ON app.delegations (timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator ON app.delegations (delegator);
+CREATE INDEX IF NOT EXISTS idx_delegations_level ON app.delegations (level);
+CREATE INDEX IF NOT EXISTS idx_delegations_sender_address ON app.delegations (sender_address);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegate_address ON app.delegations (delegate_address);
+CREATE INDEX IF NOT EXISTS idx_delegations_status ON app.delegations (status);
+CREATE INDEX IF NOT EXISTS idx_delegations_block ON app.delegations (block);
+
+-- Rewards
+
+ALTER TABLE app.rewards RENAME TO rewards_unpartitioned;
+
+CREATE TABLE app.rewards (
+    LIKE app.rewards_unpartitioned INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+) PARTITION BY RANGE (timestamp);
+
+SELECT app.create_monthly_partitions(
+    'app.rewards',
+    LEAST(
+        '2018-06-01'::DATE,
+        (SELECT to_timestamp(MIN(timestamp)) AT TIME ZONE 'UTC' FROM app.rewards_unpartitioned)::DATE
+    ),
+    (date_trunc('month', now() AT TIME ZONE 'UTC') + INTERVAL '3 months')::DATE
+);
+
+CREATE TABLE IF NOT EXISTS app.rewards_default PARTITION OF app.rewards DEFAULT;
+
+INSERT INTO app.rewards SELECT * FROM app.rewards_unpartitioned;
+
+ALTER SEQUENCE app.rewards_id_seq OWNED BY app.rewards.id;
+
+DROP TABLE app.rewards_unpartitioned;
+
+ALTER TABLE app.rewards ADD PRIMARY KEY (id, timestamp);
+ALTER TABLE app.rewards ADD FOREIGN KEY (recipient_address) REFERENCES app.accounts(address);
+ALTER TABLE app.rewards ADD FOREIGN KEY (source_address) REFERENCES app.accounts(address);
+
+CREATE INDEX IF NOT EXISTS idx_rewards_recipient_address ON app.rewards (recipient_address);
+CREATE INDEX IF NOT EXISTS idx_rewards_source_address ON app.rewards (source_address);
+CREATE INDEX IF NOT EXISTS idx_rewards_cycle ON app.rewards (cycle);
+CREATE INDEX IF NOT EXISTS idx_rewards_timestamp ON app.rewards (timestamp DESC);
@@ -0,0 +1,17 @@
+-- 10_reconcile_schema: Align the schema with the columns written and read by the psql adapter (rollback)
+
+ALTER TABLE app.staking_operations
+    ALTER COLUMN timestamp TYPE TIMESTAMP WITH TIME ZONE USING to_timestamp(timestamp);
+
+DROP INDEX IF EXISTS app.uq_rewards_recipient_source_cycle;
+DROP INDEX IF EXISTS app.uq_delegations_delegator_level;
+
+-- Rows saved without these columns must be removed or completed before rolling back.
+ALTER TABLE app.delegations ALTER COLUMN status SET NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN block SET NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN delegate_address SET NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN sender_address SET NOT NULL;
+
+-- The application types are kept so that existing rows can be converted back.
+CREATE TYPE account_type AS ENUM ('wallet', 'contract', 'baker', 'user', 'delegate');
+ALTER TABLE app.accounts ALTER COLUMN type TYPE account_type USING type::account_type;
@@ -0,0 +1,28 @@
+-- 10_reconcile_schema: Align the schema with the columns written and read by the psql adapter
+
+-- Account types are validated by the application (model.AccountType), which uses 'user' and 'delegate'.
+ALTER TABLE app.accounts ALTER COLUMN type TYPE TEXT USING type::TEXT;
+DROP TYPE IF EXISTS account_type;
+
+-- Delegations are saved from the TzKT delegator, delegate, timestamp, amount and level only.
+ALTER TABLE app.delegations ALTER COLUMN sender_address DROP NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN delegate_address DROP NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN block DROP NOT NULL;
+ALTER TABLE app.delegations ALTER COLUMN status DROP NOT NULL;
+
+-- Re-synchronisations used to insert duplicates since ON CONFLICT DO NOTHING had no constraint to match.
+-- Unique constraints of partitioned tables must include the partition key.
+DELETE FROM app.delegations a
+    USING app.delegations b
+    WHERE a.delegator = b.delegator AND a.level = b.level AND a.timestamp = b.timestamp AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_delegations_delegator_level ON app.delegations (delegator, level, timestamp);
+
+DELETE FROM app.rewards a
+    USING app.rewards b
+    WHERE a.recipient_address = b.recipient_address AND a.source_address = b.source_address
+    AND a.cycle = b.cycle AND a.timestamp = b.timestamp AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_rewards_recipient_source_cycle ON app.rewards (recipient_address, source_address, cycle, timestamp);
+
+-- Operation timestamps are unix seconds like every other table.
+ALTER TABLE app.staking_operations
+    ALTER COLUMN timestamp TYPE BIGINT USING extract(epoch FROM timestamp)::BIGINT;
@@ -0,0 +1,5 @@
+-- 11_stats: Create the daily delegation and per-cycle reward aggregates (rollback)
+
+DROP TABLE IF EXISTS app.delegator_cycle_rewards;
+DROP TABLE IF EXISTS app.baker_cycle_rewards;
+DROP TABLE IF EXISTS app.daily_delegation_stats;
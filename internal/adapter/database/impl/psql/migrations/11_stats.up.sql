@@ -0,0 +1,63 @@
+-- 11_stats: Create the daily delegation and per-cycle reward aggregates
+
+-- Days are the unix timestamps of the start of UTC days, amounts are mutez.
+CREATE TABLE IF NOT EXISTS app.daily_delegation_stats (
+    day BIGINT NOT NULL,
+    baker TEXT NOT NULL,
+    new_delegations BIGINT NOT NULL DEFAULT 0,
+    undelegations BIGINT NOT NULL DEFAULT 0,
+    delegated_volume BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, baker)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_delegation_stats_baker ON app.daily_delegation_stats (baker, day);
+
+CREATE TABLE IF NOT EXISTS app.baker_cycle_rewards (
+    cycle BIGINT NOT NULL,
+    baker TEXT NOT NULL,
+    rewards BIGINT NOT NULL DEFAULT 0,
+    delegators BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cycle, baker)
+);
+
+CREATE INDEX IF NOT EXISTS idx_baker_cycle_rewards_baker ON app.baker_cycle_rewards (baker, cycle);
+
+CREATE TABLE IF NOT EXISTS app.delegator_cycle_rewards (
+    cycle BIGINT NOT NULL,
+    delegator TEXT NOT NULL,
+    rewards BIGINT NOT NULL DEFAULT 0,
+    bakers BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cycle, delegator)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegator_cycle_rewards_delegator ON app.delegator_cycle_rewards (delegator, cycle);
+
+-- Initial aggregates of the existing rows, the job then refreshes the days and cycles of every synced batch.
+-- A delegator leaves the baker of its previous delegation when it undelegates or delegates to another baker.
+INSERT INTO app.daily_delegation_stats (day, baker, new_delegations, undelegations, delegated_volume)
+SELECT day, baker, SUM(new_delegations), SUM(undelegations), SUM(delegated_volume)
+FROM (
+    SELECT timestamp - timestamp % 86400 AS day, delegate AS baker,
+        1 AS new_delegations, 0 AS undelegations, amount AS delegated_volume
+    FROM app.delegations
+    WHERE delegate <> ''
+    UNION ALL
+    SELECT day, prev_delegate, 0, 1, 0
+    FROM (
+        SELECT d.timestamp - d.timestamp % 86400 AS day, d.delegate,
+            LAG(d.delegate) OVER (PARTITION BY d.delegator ORDER BY d.level) AS prev_delegate
+        FROM app.delegations d
+    ) moves
+    WHERE prev_delegate <> '' AND prev_delegate <> delegate
+) activity
+GROUP BY day, baker;
+
+INSERT INTO app.baker_cycle_rewards (cycle, baker, rewards, delegators)
+SELECT cycle, source_address, SUM(amount), COUNT(DISTINCT recipient_address)
+FROM app.rewards
+GROUP BY cycle, source_address;
+
+INSERT INTO app.delegator_cycle_rewards (cycle, delegator, rewards, bakers)
+SELECT cycle, recipient_address, SUM(amount), COUNT(DISTINCT source_address)
+FROM app.rewards
+GROUP BY cycle, recipient_address;
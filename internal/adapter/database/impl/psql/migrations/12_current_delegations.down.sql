@@ -0,0 +1,3 @@
+-- 12_current_delegations: Create the current delegation state of every delegator (rollback)
+
+DROP TABLE IF EXISTS app.current_delegations;
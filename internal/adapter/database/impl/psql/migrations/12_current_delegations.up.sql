@@ -0,0 +1,17 @@
+-- 12_current_delegations: Create the current delegation state of every delegator
+
+-- An undelegated delegator keeps its row with an empty baker, balances are mutez.
+CREATE TABLE IF NOT EXISTS app.current_delegations (
+    delegator TEXT PRIMARY KEY,
+    baker TEXT NOT NULL DEFAULT '',
+    since_level BIGINT NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_current_delegations_baker ON app.current_delegations (baker, balance DESC);
+
+-- Initial state from the existing rows, the job then upserts the delegations of every synced batch.
+INSERT INTO app.current_delegations (delegator, baker, since_level, balance)
+SELECT DISTINCT ON (delegator) delegator, delegate, level, amount
+FROM app.delegations
+ORDER BY delegator, level DESC;
@@ -0,0 +1,4 @@
+-- 13_cycles: Create the cycle levels and index the delegation history for point-in-time queries (rollback)
+
+DROP INDEX IF EXISTS app.idx_delegations_delegator_level;
+DROP TABLE IF EXISTS app.cycles;
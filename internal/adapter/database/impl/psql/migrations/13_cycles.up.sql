@@ -0,0 +1,9 @@
+-- 13_cycles: Create the cycle levels and index the delegation history for point-in-time queries
+
+CREATE TABLE IF NOT EXISTS app.cycles (
+    cycle INTEGER PRIMARY KEY,
+    first_level BIGINT NOT NULL,
+    last_level BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator_level ON app.delegations (delegator, level DESC);
@@ -0,0 +1,11 @@
+-- 14_keyset_pagination: Index the (timestamp, id) order of the paginated lists for cursor pagination (rollback)
+
+CREATE INDEX IF NOT EXISTS idx_rewards_timestamp ON app.rewards (timestamp DESC);
+DROP INDEX IF EXISTS app.idx_rewards_timestamp_id;
+DROP INDEX IF EXISTS app.idx_rewards_recipient_source_timestamp_id;
+
+CREATE INDEX IF NOT EXISTS idx_staking_operations_timestamp ON app.staking_operations (timestamp DESC);
+DROP INDEX IF EXISTS app.idx_staking_operations_timestamp_id;
+
+CREATE INDEX IF NOT EXISTS idx_delegations_timestamp ON app.delegations (timestamp DESC);
+DROP INDEX IF EXISTS app.idx_delegations_timestamp_id;
@@ -0,0 +1,13 @@
+-- 14_keyset_pagination: Index the (timestamp, id) order of the paginated lists for cursor pagination
+
+-- Pages after a cursor are read with (timestamp, id) < (cursor timestamp, cursor id) ORDER BY timestamp DESC, id DESC.
+CREATE INDEX IF NOT EXISTS idx_delegations_timestamp_id ON app.delegations (timestamp DESC, id DESC);
+DROP INDEX IF EXISTS app.idx_delegations_timestamp;
+
+CREATE INDEX IF NOT EXISTS idx_staking_operations_timestamp_id ON app.staking_operations (timestamp DESC, id DESC);
+DROP INDEX IF EXISTS app.idx_staking_operations_timestamp;
+
+-- Rewards are always listed for a wallet and a baker.
+CREATE INDEX IF NOT EXISTS idx_rewards_recipient_source_timestamp_id ON app.rewards (recipient_address, source_address, timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_rewards_timestamp_id ON app.rewards (timestamp DESC, id DESC);
+DROP INDEX IF EXISTS app.idx_rewards_timestamp;
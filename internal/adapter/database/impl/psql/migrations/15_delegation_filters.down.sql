@@ -0,0 +1,9 @@
+-- 15_delegation_filters: Index the filters and sort orders of the delegation list (rollback)
+
+DROP INDEX IF EXISTS app.idx_delegations_amount_id;
+CREATE INDEX IF NOT EXISTS idx_delegations_level ON app.delegations (level);
+DROP INDEX IF EXISTS app.idx_delegations_level_id;
+
+DROP INDEX IF EXISTS app.idx_delegations_delegate_timestamp_id;
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator ON app.delegations (delegator);
+DROP INDEX IF EXISTS app.idx_delegations_delegator_timestamp_id;
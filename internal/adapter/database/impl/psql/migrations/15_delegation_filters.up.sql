@@ -0,0 +1,11 @@
+-- 15_delegation_filters: Index the filters and sort orders of the delegation list
+
+-- Delegations of a delegator or of a baker are listed in the default (timestamp DESC, id DESC) order.
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator_timestamp_id ON app.delegations (delegator, timestamp DESC, id DESC);
+DROP INDEX IF EXISTS app.idx_delegations_delegator;
+CREATE INDEX IF NOT EXISTS idx_delegations_delegate_timestamp_id ON app.delegations (delegate, timestamp DESC, id DESC);
+
+-- Level and amount ranges and sorts, read forwards or backwards with id breaking ties.
+CREATE INDEX IF NOT EXISTS idx_delegations_level_id ON app.delegations (level, id);
+DROP INDEX IF EXISTS app.idx_delegations_level;
+CREATE INDEX IF NOT EXISTS idx_delegations_amount_id ON app.delegations (amount, id);
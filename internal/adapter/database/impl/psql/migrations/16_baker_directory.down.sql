@@ -0,0 +1,6 @@
+-- 16_baker_directory: Make staking pools unique per address and index the rewards paid by a baker (rollback)
+
+DROP INDEX IF EXISTS app.idx_rewards_source_timestamp;
+
+CREATE INDEX IF NOT EXISTS idx_staking_pools_address ON app.staking_pools (address);
+DROP INDEX IF EXISTS app.uq_staking_pools_address;
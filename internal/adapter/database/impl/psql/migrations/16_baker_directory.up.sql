@@ -0,0 +1,11 @@
+-- 16_baker_directory: Make staking pools unique per address and index the rewards paid by a baker
+
+-- Staking pools were saved with ON CONFLICT DO NOTHING but without a constraint to match, keep the first row of every address.
+DELETE FROM app.staking_pools a
+    USING app.staking_pools b
+    WHERE a.address = b.address AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_staking_pools_address ON app.staking_pools (address);
+DROP INDEX IF EXISTS app.idx_staking_pools_address;
+
+-- The baker directory sums the rewards paid by every baker over a period.
+CREATE INDEX IF NOT EXISTS idx_rewards_source_timestamp ON app.rewards (source_address, timestamp);
@@ -0,0 +1,3 @@
+-- 17_prices: Create the daily tez prices valuing the rewards of the tax reports (rollback)
+
+DROP TABLE IF EXISTS app.prices;
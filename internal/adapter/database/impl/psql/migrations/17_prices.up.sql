@@ -0,0 +1,8 @@
+-- 17_prices: Create the daily tez prices valuing the rewards of the tax reports
+
+CREATE TABLE IF NOT EXISTS app.prices (
+    day BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    price NUMERIC(24, 10) NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (currency, day)
+);
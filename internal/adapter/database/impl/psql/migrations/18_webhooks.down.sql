@@ -0,0 +1,5 @@
+-- 18_webhooks: Create the webhook subscriptions, their queued deliveries and the delivery attempts (rollback)
+
+DROP TABLE IF EXISTS app.webhook_delivery_attempts;
+DROP TABLE IF EXISTS app.webhook_deliveries;
+DROP TABLE IF EXISTS app.webhooks;
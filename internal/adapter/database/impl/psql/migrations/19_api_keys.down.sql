@@ -0,0 +1,4 @@
+-- 19_api_keys: Create the API keys, stored hashed, and their daily usage (rollback)
+
+DROP TABLE IF EXISTS app.api_key_usage;
+DROP TABLE IF EXISTS app.api_keys;
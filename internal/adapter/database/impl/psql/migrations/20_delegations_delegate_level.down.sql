@@ -0,0 +1,3 @@
+-- 20_delegations_delegate_level: Index the delegation history by baker and level for point-in-time delegator snapshots (rollback)
+
+DROP INDEX IF EXISTS app.idx_delegations_delegate_level;
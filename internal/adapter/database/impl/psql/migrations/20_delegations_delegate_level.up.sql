@@ -0,0 +1,3 @@
+-- 20_delegations_delegate_level: Index the delegation history by baker and level for point-in-time delegator snapshots
+
+CREATE INDEX IF NOT EXISTS idx_delegations_delegate_level ON app.delegations (delegate, level);
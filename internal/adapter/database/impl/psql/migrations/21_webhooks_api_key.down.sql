@@ -0,0 +1,5 @@
+-- 21_webhooks_api_key: Scope the webhooks to the API key which created them (rollback)
+
+DROP INDEX IF EXISTS app.idx_webhooks_api_key;
+
+ALTER TABLE app.webhooks DROP COLUMN IF EXISTS api_key_id;
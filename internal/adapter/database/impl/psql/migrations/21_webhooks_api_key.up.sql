@@ -0,0 +1,5 @@
+-- 21_webhooks_api_key: Scope the webhooks to the API key which created them
+
+ALTER TABLE app.webhooks ADD COLUMN IF NOT EXISTS api_key_id BIGINT REFERENCES app.api_keys (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_api_key ON app.webhooks (api_key_id);
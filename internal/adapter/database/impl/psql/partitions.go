@@ -0,0 +1,53 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// EnsurePartitions creates the monthly partitions of the delegations and rewards tables
+// from the current month up to the month of until, if they do not exist yet.
+func (p *psql) EnsurePartitions(ctx context.Context, until time.Time) error {
+	ctx, cancel := p.withTimeout(ctx, "EnsurePartitions")
+	defer cancel()
+
+	from := monthStart(time.Now())
+	until = monthStart(until)
+
+	for _, table := range []string{p.tableDelegations, p.tableRewards} {
+		if table == "" {
+			continue
+		}
+
+		for month := from; !month.After(until); month = month.AddDate(0, 1, 0) {
+			if _, err := p.db.ExecContext(ctx, buildCreatePartition(table, month)); err != nil {
+				return fmt.Errorf("error creating partition %s: %w", partitionName(table, month), classifyError(ctx, "EnsurePartitions", err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildCreatePartition builds the statement creating the partition of table holding the rows of the given month.
+// Partition bounds are unix timestamps since both tables are partitioned on their BIGINT timestamp column.
+func buildCreatePartition(table string, month time.Time) string {
+	lower := strconv.FormatInt(month.Unix(), 10)
+	upper := strconv.FormatInt(month.AddDate(0, 1, 0).Unix(), 10)
+
+	return `CREATE TABLE IF NOT EXISTS ` + partitionName(table, month) +
+		` PARTITION OF ` + table + ` FOR VALUES FROM (` + lower + `) TO (` + upper + `)`
+}
+
+// partitionName returns the name of the monthly partition of table, e.g. app.delegations_y2025m04.
+func partitionName(table string, month time.Time) string {
+	return fmt.Sprintf("%s_y%04dm%02d", table, month.Year(), int(month.Month()))
+}
+
+// monthStart returns the first instant of the month of t in UTC.
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
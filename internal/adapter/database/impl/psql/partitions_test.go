@@ -0,0 +1,88 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_psql_EnsurePartitions(t *testing.T) {
+	const tableRewards = "app.rewards"
+
+	now := monthStart(time.Now())
+	next := now.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name    string
+		until   time.Time
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "Nominal case",
+			until: next,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				for _, table := range []string{tableDelegations, tableRewards} {
+					for _, month := range []time.Time{now, next} {
+						mock.ExpectExec(regexp.QuoteMeta(buildCreatePartition(table, month))).
+							WillReturnResult(sqlmock.NewResult(0, 0))
+					}
+				}
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "Nominal case - date in the past",
+			until: now.AddDate(0, -2, 0),
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "Error case - creation error",
+			until: now,
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec(regexp.QuoteMeta(buildCreatePartition(tableDelegations, now))).
+					WillReturnError(fmt.Errorf("overlapping partition"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:               db,
+				tableDelegations: tableDelegations,
+				tableRewards:     tableRewards,
+			}
+			tt.wantErr(t, p.EnsurePartitions(context.Background(), tt.until), fmt.Sprintf("EnsurePartitions(%v)", tt.until))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_buildCreatePartition(t *testing.T) {
+	month := time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t,
+		"CREATE TABLE IF NOT EXISTS app.delegations_y2025m12 PARTITION OF app.delegations FOR VALUES FROM (1764547200) TO (1767225600)",
+		buildCreatePartition("app.delegations", month))
+}
+
+func Test_monthStart(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	got := monthStart(time.Date(2025, time.March, 1, 0, 30, 0, 0, cet))
+	assert.Equal(t, time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), got)
+}
@@ -0,0 +1,138 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+// PoolConfig represents the connection pool settings, applied to the primary and to every replica.
+// Zero values keep the database/sql defaults.
+type PoolConfig struct {
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+}
+
+// configurePool applies cfg to the connection pool of db.
+func configurePool(db *sqlx.DB, cfg PoolConfig) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+}
+
+// statementTimeouts holds the default statement timeout, the one of the exports and the ones overridden per adapter method.
+type statementTimeouts struct {
+	fallback  time.Duration
+	export    time.Duration
+	overrides map[string]time.Duration
+}
+
+// newStatementTimeouts indexes the overrides by lower-cased method name, as configuration keys are case-insensitive.
+func newStatementTimeouts(fallback, export time.Duration, overrides map[string]time.Duration) statementTimeouts {
+	st := statementTimeouts{fallback: fallback, export: export, overrides: make(map[string]time.Duration, len(overrides))}
+	for operation, timeout := range overrides {
+		st.overrides[strings.ToLower(operation)] = timeout
+	}
+	return st
+}
+
+// get returns the statement timeout of operation, 0 when there is none.
+func (st statementTimeouts) get(operation string) time.Duration {
+	if timeout, ok := st.overrides[strings.ToLower(operation)]; ok {
+		return timeout
+	}
+	return st.fallback
+}
+
+// getExport returns the statement timeout of the export operation, 0 when there is none.
+// Exports read whole ranges, so they are not bound by the default statement timeout.
+func (st statementTimeouts) getExport(operation string) time.Duration {
+	if timeout, ok := st.overrides[strings.ToLower(operation)]; ok {
+		return timeout
+	}
+	return st.export
+}
+
+// withTimeout bounds ctx by the statement timeout of operation.
+// When the deadline is reached, the driver cancels the running query on the server.
+func (p *psql) withTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	if timeout := p.timeouts.get(operation); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
+
+// withExportTimeout bounds ctx by the export timeout of operation.
+func (p *psql) withExportTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	if timeout := p.timeouts.getExport(operation); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
+
+// classifyError wraps err in a database.QueryError when it is a timeout or an unavailability of the database.
+// Other errors, including sql.ErrNoRows, are returned as is.
+func classifyError(ctx context.Context, operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Drivers report a query canceled on deadline in their own way, the context tells it was a timeout.
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return database.NewQueryError(operation, database.ErrTimeout, err)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "57014" && !errors.Is(ctx.Err(), context.Canceled):
+			// query_canceled: statement_timeout or deadline reached
+			return database.NewQueryError(operation, database.ErrTimeout, err)
+		case pqErr.Code.Class() == "08", pqErr.Code == "53300", pqErr.Code == "57P01", pqErr.Code == "57P03":
+			// connection_exception, too_many_connections, admin_shutdown, cannot_connect_now
+			return database.NewQueryError(operation, database.ErrUnavailable, err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.As(err, &netErr) {
+		return database.NewQueryError(operation, database.ErrUnavailable, err)
+	}
+
+	return err
+}
+
+// PoolStats returns the statistics of the primary connection pool and of every replica.
+func (p *psql) PoolStats() map[string]sql.DBStats {
+	stats := make(map[string]sql.DBStats)
+	if p.db != nil && p.db.DB != nil {
+		stats["primary"] = p.db.Stats()
+	}
+	if p.replicas != nil {
+		for _, r := range p.replicas.replicas {
+			stats["replica "+r.name] = r.db.Stats()
+		}
+	}
+	return stats
+}
@@ -0,0 +1,146 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+func Test_classifyError(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		err             error
+		wantTimeout     bool
+		wantUnavailable bool
+	}{
+		{
+			name: "Nominal case - no error",
+			ctx:  context.Background(),
+		},
+		{
+			name:        "Deadline exceeded",
+			ctx:         context.Background(),
+			err:         context.DeadlineExceeded,
+			wantTimeout: true,
+		},
+		{
+			name:        "Statement timeout",
+			ctx:         context.Background(),
+			err:         &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"},
+			wantTimeout: true,
+		},
+		{
+			name: "Query canceled by the caller",
+			ctx:  canceled,
+			err:  &pq.Error{Code: "57014", Message: "canceling statement due to user request"},
+		},
+		{
+			name:            "Too many connections",
+			ctx:             context.Background(),
+			err:             &pq.Error{Code: "53300"},
+			wantUnavailable: true,
+		},
+		{
+			name:            "Connection failure",
+			ctx:             context.Background(),
+			err:             &pq.Error{Code: "08006"},
+			wantUnavailable: true,
+		},
+		{
+			name:            "Bad connection",
+			ctx:             context.Background(),
+			err:             driver.ErrBadConn,
+			wantUnavailable: true,
+		},
+		{
+			name: "Unique violation",
+			ctx:  context.Background(),
+			err:  &pq.Error{Code: "23505"},
+		},
+		{
+			name: "No rows",
+			ctx:  context.Background(),
+			err:  sql.ErrNoRows,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.ctx, "GetDelegations", tt.err)
+			assert.ErrorIs(t, got, tt.err)
+			assert.Equal(t, tt.wantTimeout, database.IsTimeout(got))
+			assert.Equal(t, tt.wantUnavailable, database.IsUnavailable(got))
+		})
+	}
+}
+
+func Test_statementTimeouts_get(t *testing.T) {
+	st := newStatementTimeouts(5*time.Second, 10*time.Minute, map[string]time.Duration{
+		"GetOperations":   30 * time.Second,
+		"savedelegations": time.Minute,
+		"StreamRewards":   time.Hour,
+	})
+
+	assert.Equal(t, 30*time.Second, st.get("GetOperations"))
+	assert.Equal(t, time.Minute, st.get("SaveDelegations"))
+	assert.Equal(t, 5*time.Second, st.get("GetDelegations"))
+	assert.Equal(t, time.Duration(0), newStatementTimeouts(0, 0, nil).get("GetDelegations"))
+
+	assert.Equal(t, 10*time.Minute, st.getExport("StreamDelegations"))
+	assert.Equal(t, time.Hour, st.getExport("StreamRewards"))
+	assert.Equal(t, time.Duration(0), newStatementTimeouts(5*time.Second, 0, nil).getExport("StreamDelegations"))
+}
+
+func Test_psql_statementTimeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(level), 0) FROM " + tableDelegations)).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(1))
+
+	p := &psql{
+		db:               sqlx.NewDb(db, "sqlmock"),
+		tableDelegations: tableDelegations,
+		timeouts:         newStatementTimeouts(0, 0, map[string]time.Duration{"GetHighestBlockLevel": 10 * time.Millisecond}),
+	}
+
+	_, err = p.GetHighestBlockLevel(context.Background())
+	assert.True(t, database.IsTimeout(err), "error = %v", err)
+
+	var queryErr *database.QueryError
+	if assert.True(t, errors.As(err, &queryErr)) {
+		assert.Equal(t, "GetHighestBlockLevel", queryErr.Operation)
+	}
+}
+
+func Test_configurePool(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	configurePool(sqlxDB, PoolConfig{MaxOpenConns: 20, MaxIdleConns: 5, ConnMaxLifetime: time.Hour})
+
+	p := &psql{db: sqlxDB}
+	assert.Equal(t, 20, p.PoolStats()["primary"].MaxOpenConnections)
+}
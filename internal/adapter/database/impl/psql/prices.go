@@ -0,0 +1,63 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SavePrices saves daily prices, replacing the ones already saved for their day and currency.
+func (p *psql) SavePrices(ctx context.Context, prices []model.Price) error {
+	ctx, cancel := p.withTimeout(ctx, "SavePrices")
+	defer cancel()
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	// A statement cannot update a row twice, the last price of a day and currency wins.
+	latest := make(map[model.Price]int, len(prices))
+	rows := make([][]interface{}, 0, len(prices))
+	for _, price := range prices {
+		key := model.Price{Day: price.Day, Currency: price.Currency}
+		if i, ok := latest[key]; ok {
+			rows[i][2] = price.Price
+			continue
+		}
+		latest[key] = len(rows)
+		rows = append(rows, []interface{}{price.Day, price.Currency.String(), price.Price})
+	}
+
+	query, args := buildMultiRowValues(p.tablePrices, []string{"day", "currency", "price"}, rows)
+	query += `
+		ON CONFLICT (currency, day) DO UPDATE
+		SET price = EXCLUDED.price
+	`
+	_, err := p.db.ExecContext(ctx, query, args...)
+	return classifyError(ctx, "SavePrices", err)
+}
+
+// GetPrices returns the daily prices of a currency for the UTC days between fromDay and toDay, excluded, by increasing day.
+func (p *psql) GetPrices(ctx context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetPrices")
+	defer cancel()
+
+	query := `
+		SELECT day, currency, price::TEXT AS price
+		FROM ` + p.tablePrices + `
+		WHERE currency = $1 AND day >= $2 AND day < $3
+		ORDER BY day
+	`
+
+	var prices []model.Price
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		prices = nil
+		return db.SelectContext(ctx, &prices, query, currency.String(), fromDay, toDay)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetPrices", err)
+	}
+	return prices, nil
+}
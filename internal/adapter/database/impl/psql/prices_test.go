@@ -0,0 +1,80 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_psql_SavePrices(t *testing.T) {
+	tests := []struct {
+		name    string
+		prices  []model.Price
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - the last price of a day wins",
+			prices: []model.Price{
+				{Day: 86400, Currency: "EUR", Price: "0.9"},
+				{Day: 172800, Currency: "EUR", Price: "1"},
+				{Day: 86400, Currency: "EUR", Price: "0.95"},
+			},
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec(`(?s)INSERT INTO app.prices \(day, currency, price\) VALUES \(\$1, \$2, \$3\), \(\$4, \$5, \$6\)\s+ON CONFLICT \(currency, day\) DO UPDATE`).
+					WithArgs(int64(86400), "EUR", "0.95", int64(172800), "EUR", "1").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - nothing to save",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "Error case - database error",
+			prices: []model.Price{{Day: 86400, Currency: "EUR", Price: "0.9"}},
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectExec(`INSERT INTO app.prices`).WillReturnError(errors.New("db error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{db: db, tablePrices: schemaTablePrices}
+			tt.wantErr(t, p.SavePrices(context.Background(), tt.prices))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetPrices(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	p := &psql{db: sqlx.NewDb(db, "sqlmock"), tablePrices: schemaTablePrices}
+
+	mock.ExpectQuery(`(?s)SELECT day, currency, price::TEXT AS price\s+FROM app.prices\s+WHERE currency = \$1 AND day >= \$2 AND day < \$3\s+ORDER BY day`).
+		WithArgs("EUR", int64(0), int64(172800)).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "currency", "price"}).
+			AddRow(86400, "EUR", "0.9500000000"))
+
+	prices, err := p.GetPrices(context.Background(), "EUR", 0, 172800)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Price{{Day: 86400, Currency: "EUR", Price: "0.9500000000"}}, prices)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,12 +3,12 @@ package psql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
-	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/tezos-delegation-service/internal/adapter/database"
 	"github.com/tezos-delegation-service/internal/model"
@@ -16,20 +16,54 @@ import (
 
 // Config represents database configuration.
 type Config struct {
-	Driver           string `mapstructure:"driver"`
-	Host             string `mapstructure:"host"`
-	Port             int    `mapstructure:"port"`
-	User             string `mapstructure:"user"`
-	Password         Secret `mapstructure:"password"`
-	DBName           string `mapstructure:"dbname"`
-	SSLMode          string `mapstructure:"sslmode"`
-	TableDelegations string `mapstructure:"table_delegations"`
-	TableOperations  string `mapstructure:"table_operations"`
-	TableRewards     string `mapstructure:"table_rewards"`
-	TableAccounts    string `mapstructure:"table_accounts"`
-	TableStakingPool string `mapstructure:"table_staking_pool"`
+	Driver    string   `mapstructure:"driver"`
+	Host      string   `mapstructure:"host"`
+	Port      int      `mapstructure:"port"`
+	User      string   `mapstructure:"user"`
+	Password  Secret   `mapstructure:"password"`
+	DBName    string   `mapstructure:"dbname"`
+	SSLMode   string   `mapstructure:"sslmode"`
+	BatchSize int      `mapstructure:"batch_size"`
+	BulkMode  BulkMode `mapstructure:"bulk_mode"`
+
+	// Replicas receive the Get* queries, the primary keeps the writes.
+	Replicas []ReplicaConfig `mapstructure:"replicas"`
+	// MaxReplicaLag is the number of levels a replica may be behind the primary before reads skip it, 0 disables the check.
+	MaxReplicaLag        uint64        `mapstructure:"max_replica_lag"`
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"`
+
+	Pool PoolConfig `mapstructure:"pool"`
+	// StatementTimeout bounds every query, 0 disables it. StatementTimeouts overrides it per adapter method, e.g. GetOperations.
+	StatementTimeout  time.Duration            `mapstructure:"statement_timeout"`
+	StatementTimeouts map[string]time.Duration `mapstructure:"statement_timeouts"`
+	// ExportTimeout bounds the Stream* queries of the exports instead of StatementTimeout, 0 disables it.
+	ExportTimeout time.Duration `mapstructure:"export_timeout"`
 }
 
+// Tables created by the embedded migrations.
+const (
+	schemaTableAccounts    = "app.accounts"
+	schemaTableDelegations = "app.delegations"
+	schemaTableOperations  = "app.staking_operations"
+	schemaTableRewards     = "app.rewards"
+	schemaTableStakingPool = "app.staking_pools"
+
+	schemaTableDelegationStats  = "app.daily_delegation_stats"
+	schemaTableBakerRewards     = "app.baker_cycle_rewards"
+	schemaTableDelegatorRewards = "app.delegator_cycle_rewards"
+
+	schemaTableCurrentDelegations = "app.current_delegations"
+	schemaTableCycles             = "app.cycles"
+	schemaTablePrices             = "app.prices"
+
+	schemaTableWebhooks                = "app.webhooks"
+	schemaTableWebhookDeliveries       = "app.webhook_deliveries"
+	schemaTableWebhookDeliveryAttempts = "app.webhook_delivery_attempts"
+
+	schemaTableAPIKeys     = "app.api_keys"
+	schemaTableAPIKeyUsage = "app.api_key_usage"
+)
+
 type text interface {
 	~[]byte | ~string
 }
@@ -56,16 +90,38 @@ func (s Secret) MarshalText() (text []byte, err error) {
 
 // psql implements DelegationRepository using SQL database.
 type psql struct {
-	db               *sqlx.DB
-	tableDelegations string
-	tableOperations  string
-	tableRewards     string
-	tableAccounts    string
-	tableStakingPool string
+	db                      *sqlx.DB
+	dsn                     string
+	tableDelegations        string
+	tableOperations         string
+	tableRewards            string
+	tableAccounts           string
+	tableStakingPool        string
+	tableDelegationStats    string
+	tableBakerRewards       string
+	tableDelegatorRewards   string
+	tableCurrentDelegations string
+	tableCycles             string
+	tablePrices             string
+	tableWebhooks           string
+	tableWebhookDeliveries  string
+	tableWebhookAttempts    string
+	tableAPIKeys            string
+	tableAPIKeyUsage        string
+	batchSize               int
+	bulkMode                BulkMode
+	migrations              []migration
+	replicas                *replicaPool
+	timeouts                statementTimeouts
 }
 
 // New creates a new SQL delegation repository.
 func New(cfg Config) (database.Adapter, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded migrations: %w", err)
+	}
+
 	db, err := initConnection(cfg)
 	if err != nil {
 		return nil, err
@@ -73,12 +129,39 @@ func New(cfg Config) (database.Adapter, error) {
 		return nil, errors.New("failed to initialize database connection")
 	}
 
+	var replicas *replicaPool
+	if len(cfg.Replicas) > 0 {
+		replicas, err = newReplicaPool(cfg, db, "SELECT COALESCE(MAX(level), 0) FROM "+schemaTableDelegations)
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
 	return &psql{
-		db:               db,
-		tableDelegations: cfg.TableDelegations,
-		tableOperations:  cfg.TableOperations,
-		tableRewards:     cfg.TableRewards,
-		tableStakingPool: cfg.TableStakingPool,
+		db:                      db,
+		dsn:                     buildDSN(cfg, cfg.Host, cfg.Port),
+		replicas:                replicas,
+		tableDelegations:        schemaTableDelegations,
+		tableOperations:         schemaTableOperations,
+		tableRewards:            schemaTableRewards,
+		tableAccounts:           schemaTableAccounts,
+		tableStakingPool:        schemaTableStakingPool,
+		tableDelegationStats:    schemaTableDelegationStats,
+		tableBakerRewards:       schemaTableBakerRewards,
+		tableDelegatorRewards:   schemaTableDelegatorRewards,
+		tableCurrentDelegations: schemaTableCurrentDelegations,
+		tableCycles:             schemaTableCycles,
+		tablePrices:             schemaTablePrices,
+		tableWebhooks:           schemaTableWebhooks,
+		tableWebhookDeliveries:  schemaTableWebhookDeliveries,
+		tableWebhookAttempts:    schemaTableWebhookDeliveryAttempts,
+		tableAPIKeys:            schemaTableAPIKeys,
+		tableAPIKeyUsage:        schemaTableAPIKeyUsage,
+		batchSize:               cfg.BatchSize,
+		bulkMode:                cfg.BulkMode,
+		migrations:              migrations,
+		timeouts:                newStatementTimeouts(cfg.StatementTimeout, cfg.ExportTimeout, cfg.StatementTimeouts),
 	}, nil
 }
 
@@ -89,15 +172,23 @@ func (p *psql) Ping() error {
 
 // GetHighestBlockLevel returns the highest block level in the database.
 func (p *psql) GetHighestBlockLevel(ctx context.Context) (uint64, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetHighestBlockLevel")
+	defer cancel()
+
 	var level uint64
-	err := p.db.GetContext(ctx, &level, "SELECT COALESCE(MAX(level), 0) FROM "+p.tableDelegations)
-	return level, err
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &level, "SELECT COALESCE(MAX(level), 0) FROM "+p.tableDelegations)
+	})
+	return level, classifyError(ctx, "GetHighestBlockLevel", err)
 }
 
-// GetOperations returns delegations with pagination and optional year, operationType, and maxDelegationID filters.
-func (p *psql) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress) ([]model.Operation, error) {
+// GetOperations returns operations with pagination and optional date range, operationType, wallet and baker filters.
+func (p *psql) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetOperations")
+	defer cancel()
+
 	var operations []model.Operation
-	if page < 1 {
+	if page < 1 || cursor != nil {
 		page = 1
 	}
 
@@ -109,108 +200,146 @@ func (p *psql) GetOperations(ctx context.Context, fromDate, toDate int64, page,
 
 	offset := (page - 1) * limit
 
-	var (
-		query       string
-		args        []interface{}
-		whereClause string
-		argIndex    = 1
-	)
+	conditions, args := operationConditions(fromDate, toDate, operationType, wallet, baker)
+	argIndex := len(args) + 1
 
-	if operationType != "" {
-		whereClause = "WHERE o.entrypoint = $" + strconv.Itoa(argIndex)
-		args = append(args, operationType.String())
-		argIndex++
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition(argIndex))
+		args = append(args, cursor.Timestamp, cursor.ID)
+		argIndex += 2
 	}
 
-	if wallet != "" {
-		if whereClause == "" {
-			whereClause = "WHERE "
-		} else {
-			whereClause += " AND "
-		}
-		whereClause += "sender.address = $" + strconv.Itoa(argIndex)
-		args = append(args, wallet.String())
-		argIndex++
+	query := `
+		SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status
+		FROM ` + p.tableOperations + `
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC
+		LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1) + `
+	`
+	args = append(args, limit, offset)
+
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		operations = nil
+		return db.SelectContext(ctx, &operations, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetOperations", err)
 	}
 
-	if baker != "" {
-		if whereClause == "" {
-			whereClause = "WHERE "
-		} else {
-			whereClause += " AND "
-		}
-		whereClause += "contract.address = $" + strconv.Itoa(argIndex)
-		args = append(args, baker.String())
-		argIndex++
+	return operations, nil
+}
+
+// GetRewards returns rewards for a given wallet and baker within a date range, by page or after a cursor.
+func (p *psql) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetRewards")
+	defer cancel()
+
+	var rewards []model.Reward
+
+	if page < 1 || cursor != nil {
+		page = 1
 	}
 
-	query = `
-		SELECT o.id, sender.address as sender_id, contract.address as contract_id, 
-			o.entrypoint, o.amount, o.block, o.timestamp, o.status, o.created_at
-		FROM ` + p.tableOperations + ` o
-		JOIN app.accounts sender ON o.sender_id = sender.id
-		JOIN app.accounts contract ON o.contract_id = contract.id
-		` + whereClause + `
-		ORDER BY o.timestamp DESC
+	if limit == 0 {
+		limit = 50
+	} else if limit > model.MaxRewardsLimit {
+		limit = model.MaxRewardsLimit
+	}
+
+	offset := int(page-1) * int(limit)
+
+	conditions, args := rewardConditions(fromDate, toDate, wallet, baker)
+	argIndex := len(args) + 1
+
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition(argIndex))
+		args = append(args, cursor.Timestamp, cursor.ID)
+		argIndex += 2
+	}
+
+	query := `
+		SELECT id, recipient_address, source_address, cycle, amount, timestamp
+		FROM ` + p.tableRewards + `
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC
 		LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1) + `
 	`
 	args = append(args, limit, offset)
 
-	err := p.db.SelectContext(ctx, &operations, query, args...)
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		rewards = nil
+		return db.SelectContext(ctx, &rewards, query, args...)
+	})
 	if err != nil {
-		return nil, err
+		return nil, classifyError(ctx, "GetRewards", err)
 	}
 
-	return operations, nil
+	return rewards, nil
 }
 
-// GetRewards returns rewards for a given wallet and baker within a date range.
-func (p *psql) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress) ([]model.Reward, error) {
-	var rewards []model.Reward
-	
+// operationConditions returns the conditions of the operation filters, bound to the arguments from $1.
+func operationConditions(fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress) ([]string, []interface{}) {
 	var (
-		query       string
-		args        []interface{}
-		whereClause string
-		argIndex    = 1
+		conditions []string
+		args       []interface{}
 	)
-	
-	// Build where clause for date range
-	whereClause = "WHERE timestamp >= $" + strconv.Itoa(argIndex) + " AND timestamp <= $" + strconv.Itoa(argIndex+1)
-	args = append(args, fromDate, toDate)
-	argIndex += 2
-	
-	// Add wallet filter if provided
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" $"+strconv.Itoa(len(args)))
+	}
+
+	if fromDate > 0 {
+		add("timestamp >=", fromDate)
+	}
+	if toDate > 0 {
+		add("timestamp <", toDate)
+	}
+	if operationType != "" {
+		add("entrypoint =", operationType.String())
+	}
 	if wallet != "" {
-		whereClause += " AND recipient_address = $" + strconv.Itoa(argIndex)
-		args = append(args, wallet.String())
-		argIndex++
+		add("sender_address =", wallet.String())
 	}
-	
-	// Add baker filter if provided
 	if baker != "" {
-		whereClause += " AND source_address = $" + strconv.Itoa(argIndex)
-		args = append(args, baker.String())
-		argIndex++
+		add("contract_address =", baker.String())
 	}
-	
-	query = `
-		SELECT id, recipient_address, source_address, cycle, amount, timestamp
-		FROM ` + p.tableRewards + `
-		` + whereClause + `
-		ORDER BY timestamp DESC
-	`
-	
-	err := p.db.SelectContext(ctx, &rewards, query, args...)
-	if err != nil {
-		return nil, err
+
+	return conditions, args
+}
+
+// rewardConditions returns the conditions of the reward filters, bound to the arguments from $1.
+// Date bounds are only applied when provided, so that the planner can prune the monthly partitions outside them.
+func rewardConditions(fromDate, toDate int64, wallet, baker model.WalletAddress) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" $"+strconv.Itoa(len(args)))
 	}
-	
-	return rewards, nil
+
+	if fromDate > 0 {
+		add("timestamp >=", fromDate)
+	}
+	if toDate > 0 {
+		add("timestamp <", toDate)
+	}
+	if wallet != "" {
+		add("recipient_address =", wallet.String())
+	}
+	if baker != "" {
+		add("source_address =", baker.String())
+	}
+
+	return conditions, args
 }
 
 // GetLatestDelegation returns the latest delegation from the database.
 func (p *psql) GetLatestDelegation(ctx context.Context) (*model.Delegation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetLatestDelegation")
+	defer cancel()
+
 	var delegation model.Delegation
 	query := `
 		SELECT id, delegator, delegate, timestamp, amount, level, created_at
@@ -218,18 +347,23 @@ func (p *psql) GetLatestDelegation(ctx context.Context) (*model.Delegation, erro
 		ORDER BY level DESC
 		LIMIT 1
 	`
-	err := p.db.GetContext(ctx, &delegation, query)
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &delegation, query)
+	})
 	if err != nil {
-		return nil, err
+		return nil, classifyError(ctx, "GetLatestDelegation", err)
 	}
 	return &delegation, nil
 }
 
-// GetDelegations returns delegations with pagination and optional year and maxDelegationID filters.
-func (p *psql) GetDelegations(ctx context.Context, page uint32, limit, year uint16, maxDelegationID uint64) ([]model.Delegation, error) {
+// GetDelegations returns delegations by page or after a cursor, with optional filters, order and maxDelegationID filter.
+func (p *psql) GetDelegations(ctx context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDelegations")
+	defer cancel()
+
 	var delegations []model.Delegation
 
-	if page < 1 {
+	if page < 1 || cursor != nil {
 		page = 1
 	}
 
@@ -241,154 +375,189 @@ func (p *psql) GetDelegations(ctx context.Context, page uint32, limit, year uint
 
 	offset := (page - 1) * uint32(limit)
 
-	var (
-		query       string
-		args        []interface{}
-		whereClause string
-		argIndex    = 1
-	)
-
+	year := filter.Year
 	applyMaxIDFilter := maxDelegationID > 0 && page > 1 && (year == 0 || int(year) == time.Now().Year())
 
-	if year > 0 {
-		startDate := time.Date(int(year), 1, 1, 0, 0, 0, 0, time.UTC).Unix()
-		endDate := time.Date(int(year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
-		whereClause = "WHERE timestamp >= $" + strconv.Itoa(argIndex) + " AND timestamp < $" + strconv.Itoa(argIndex+1)
-		args = append(args, startDate, endDate)
-		argIndex += 2
+	conditions, args := delegationConditions(filter)
+	argIndex := len(args) + 1
 
-		if applyMaxIDFilter {
-			whereClause += " AND id <= $" + strconv.Itoa(argIndex)
-			args = append(args, maxDelegationID)
-			argIndex++
-		}
-	} else {
-		if applyMaxIDFilter {
-			whereClause = "WHERE id <= $" + strconv.Itoa(argIndex)
-			args = append(args, maxDelegationID)
-			argIndex++
-		}
+	if applyMaxIDFilter {
+		conditions = append(conditions, "id <= $"+strconv.Itoa(argIndex))
+		args = append(args, maxDelegationID)
+		argIndex++
+	}
+
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition(argIndex))
+		args = append(args, cursor.Timestamp, cursor.ID)
+		argIndex += 2
 	}
 
-	query = `
+	query := `
 		SELECT id, delegator, delegate, timestamp, amount, level, created_at
 		FROM ` + p.tableDelegations + `
-		` + whereClause + `
-		ORDER BY timestamp DESC
+		` + whereClause(conditions) + `
+		` + delegationOrderClause(filter) + `
 		LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1) + `
 	`
 	args = append(args, limit, offset)
 
-	err := p.db.SelectContext(ctx, &delegations, query, args...)
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		delegations = nil
+		return db.SelectContext(ctx, &delegations, query, args...)
+	})
 	if err != nil {
-		return nil, err
+		return nil, classifyError(ctx, "GetDelegations", err)
 	}
 
 	return delegations, nil
 }
 
+// delegationConditions returns the conditions of the delegation filters, bound to the arguments from $1.
+// Each filter is served by an index of migration 15: delegator and delegate by (address, timestamp DESC, id DESC),
+// levels by (level, id), amounts by (amount, id), and timestamps by (timestamp DESC, id DESC).
+func delegationConditions(filter model.DelegationFilter) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, condition+" $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.Year > 0 {
+		// Year bounds are aligned on the monthly partitions, so that the planner only scans the twelve partitions of the year
+		add("timestamp >=", time.Date(int(filter.Year), 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+		add("timestamp <", time.Date(int(filter.Year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+	}
+	if filter.Delegator != "" {
+		add("delegator =", filter.Delegator)
+	}
+	if filter.Delegate != "" {
+		add("delegate =", filter.Delegate)
+	}
+	switch filter.Kind {
+	case model.DelegationKindDelegation:
+		conditions = append(conditions, "delegate <> ''")
+	case model.DelegationKindUndelegation:
+		conditions = append(conditions, "delegate = ''")
+	}
+	if filter.FromLevel > 0 {
+		add("level >=", filter.FromLevel)
+	}
+	if filter.ToLevel > 0 {
+		add("level <=", filter.ToLevel)
+	}
+	if filter.FromDate > 0 {
+		add("timestamp >=", filter.FromDate)
+	}
+	if filter.ToDate > 0 {
+		add("timestamp <", filter.ToDate)
+	}
+	if filter.MinAmount != nil {
+		add("amount >=", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		add("amount <=", *filter.MaxAmount)
+	}
+
+	return conditions, args
+}
+
+// delegationOrderClause returns the ORDER BY clause of the delegation sort, ties broken by id in the same direction.
+func delegationOrderClause(filter model.DelegationFilter) string {
+	column := "timestamp"
+	switch filter.SortField() {
+	case model.DelegationSortLevel:
+		column = "level"
+	case model.DelegationSortAmount:
+		column = "amount"
+	}
+
+	direction := "DESC"
+	if filter.SortOrder() == model.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	return "ORDER BY " + column + " " + direction + ", id " + direction
+}
+
+// keysetCondition returns the condition selecting the rows after a cursor in decreasing (timestamp, id) order,
+// bound to the arguments argIndex and argIndex+1. It uses the (timestamp DESC, id DESC) indexes instead of an offset.
+func keysetCondition(argIndex int) string {
+	return "(timestamp, id) < ($" + strconv.Itoa(argIndex) + ", $" + strconv.Itoa(argIndex+1) + ")"
+}
+
 // SaveDelegation saves a delegation to the database.
 func (p *psql) SaveDelegation(ctx context.Context, delegation *model.Delegation) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveDelegation")
+	defer cancel()
+
 	query := `
 		INSERT INTO ` + p.tableDelegations + ` (delegator, delegate, timestamp, amount, level)
 		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT DO NOTHING
 	`
 	_, err := p.db.ExecContext(ctx, query, delegation.Delegator, delegation.Delegate, delegation.Timestamp, delegation.Amount, delegation.Level)
-	return err
+	return classifyError(ctx, "SaveDelegation", err)
 }
 
 // SaveAccount saves a single account to the database.
 func (p *psql) SaveAccount(ctx context.Context, accounts model.Account) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveAccount")
+	defer cancel()
+
 	query := `
 		INSERT INTO ` + p.tableAccounts + ` (address, alias, type)
 		VALUES ($1, $2, $3)
 		ON CONFLICT DO NOTHING
 	`
 	_, err := p.db.ExecContext(ctx, query, accounts.Address, accounts.Alias, accounts.Type)
-	return err
+	return classifyError(ctx, "SaveAccount", err)
 }
 
 // SaveAccounts saves multiple accounts to the database.
 func (p *psql) SaveAccounts(ctx context.Context, accounts []model.Account) error {
-	tx, err := p.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	query := `
-		INSERT INTO ` + p.tableAccounts + ` (address, alias, type)
-		VALUES ($1, $2, $3)
-		ON CONFLICT DO NOTHING
-	`
+	ctx, cancel := p.withTimeout(ctx, "SaveAccounts")
+	defer cancel()
 
+	rows := make([][]interface{}, 0, len(accounts))
 	for _, account := range accounts {
-		_, err := tx.ExecContext(ctx, query, account.Address, account.Alias, account.Type)
-		if err != nil {
-			if errRollBack := tx.Rollback(); errRollBack != nil {
-				return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
-			}
-			return err
-		}
+		rows = append(rows, []interface{}{account.Address, account.Alias, account.Type})
 	}
-
-	return tx.Commit()
+	return classifyError(ctx, "SaveAccounts", p.bulkInsert(ctx, p.tableAccounts, []string{"address", "alias", "type"}, rows))
 }
 
 // SaveDelegations saves multiple delegations to the database.
 func (p *psql) SaveDelegations(ctx context.Context, delegations []*model.Delegation) error {
-	tx, err := p.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	query := `
-		INSERT INTO ` + p.tableDelegations + ` (delegator, delegate, timestamp, amount, level)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT DO NOTHING
-	`
+	ctx, cancel := p.withTimeout(ctx, "SaveDelegations")
+	defer cancel()
 
+	rows := make([][]interface{}, 0, len(delegations))
 	for _, delegation := range delegations {
-		_, err := tx.ExecContext(ctx, query, delegation.Delegator, delegation.Delegate, delegation.Timestamp, delegation.Amount, delegation.Level)
-		if err != nil {
-			if errRollBack := tx.Rollback(); errRollBack != nil {
-				return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
-			}
-			return err
-		}
+		rows = append(rows, []interface{}{delegation.Delegator, delegation.Delegate, delegation.Timestamp, delegation.Amount, delegation.Level})
 	}
-
-	return tx.Commit()
+	return classifyError(ctx, "SaveDelegations", p.bulkInsertNotify(ctx, p.tableDelegations, []string{"delegator", "delegate", "timestamp", "amount", "level"}, rows, delegationsChannel))
 }
 
 // SaveStakingPools saves multiple staking pools to the database.
 func (p *psql) SaveStakingPools(ctx context.Context, stakingPools []model.StakingPool) error {
-	tx, err := p.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	query := `
-		INSERT INTO ` + p.tableStakingPool + ` (address, name, staking_token)
-		VALUES ($1, $2, $3)
-		ON CONFLICT DO NOTHING
-	`
+	ctx, cancel := p.withTimeout(ctx, "SaveStakingPools")
+	defer cancel()
 
+	rows := make([][]interface{}, 0, len(stakingPools))
 	for _, stakingPool := range stakingPools {
-		_, err := tx.ExecContext(ctx, query, stakingPool.Address, stakingPool.Name, stakingPool.StakingToken)
-		if err != nil {
-			if errRollBack := tx.Rollback(); errRollBack != nil {
-				return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
-			}
-			return err
-		}
+		rows = append(rows, []interface{}{stakingPool.Address, stakingPool.Name, stakingPool.StakingToken})
 	}
-
-	return tx.Commit()
+	return classifyError(ctx, "SaveStakingPools", p.bulkInsert(ctx, p.tableStakingPool, []string{"address", "name", "staking_token"}, rows))
 }
 
 // GetLastSyncedRewardCycle returns the last synced reward cycle.
 func (p *psql) GetLastSyncedRewardCycle(ctx context.Context) (int, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetLastSyncedRewardCycle")
+	defer cancel()
+
 	var cycle int
 	query := `
 		SELECT COALESCE(last_synced_level, 0) AS cycle
@@ -396,94 +565,70 @@ func (p *psql) GetLastSyncedRewardCycle(ctx context.Context) (int, error) {
 		WHERE source = 'rewards'
 		LIMIT 1
 	`
-	err := p.db.GetContext(ctx, &cycle, query)
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &cycle, query)
+	})
 	if err != nil {
-		return 0, err
+		return 0, classifyError(ctx, "GetLastSyncedRewardCycle", err)
 	}
 	return cycle, nil
 }
 
-// GetActiveDelegators returns a list of active delegators.
+// GetActiveDelegators returns the delegators currently delegated to a baker.
 func (p *psql) GetActiveDelegators(ctx context.Context) ([]model.WalletAddress, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetActiveDelegators")
+	defer cancel()
+
 	var delegators []model.WalletAddress
 	query := `
-		SELECT DISTINCT delegator AS address
-		FROM ` + p.tableDelegations + `
-		WHERE amount > 0
+		SELECT delegator AS address
+		FROM ` + p.tableCurrentDelegations + `
+		WHERE baker <> ''
 		ORDER BY delegator
 	`
-	err := p.db.SelectContext(ctx, &delegators, query)
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		delegators = nil
+		return db.SelectContext(ctx, &delegators, query)
+	})
 	if err != nil {
-		return nil, err
+		return nil, classifyError(ctx, "GetActiveDelegators", err)
 	}
 	return delegators, nil
 }
 
-// GetBakerForDelegatorAtCycle returns the baker for a delegator at a specific cycle.
-func (p *psql) GetBakerForDelegatorAtCycle(ctx context.Context, delegator model.WalletAddress, cycle int) (model.WalletAddress, error) {
-	var baker model.WalletAddress
-	
-	// Converting cycle to timestamp range
-	// In Tezos, each cycle is approximately 2-3 days
-	// This is an approximation, adjust the logic based on actual Tezos protocol
-	cycleStartTime := time.Now().AddDate(0, 0, -cycle*3).Unix() // approximation
-	
-	query := `
-		SELECT delegate
-		FROM ` + p.tableDelegations + `
-		WHERE delegator = $1
-		AND timestamp <= $2
-		ORDER BY timestamp DESC
-		LIMIT 1
-	`
-	err := p.db.GetContext(ctx, &baker, query, delegator.String(), cycleStartTime)
-	if err != nil {
-		return "", err
-	}
-	return baker, nil
-}
-
 // SaveRewards saves multiple rewards to the repository.
 func (p *psql) SaveRewards(ctx context.Context, rewards []model.Reward) error {
-	tx, err := p.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	query := `
-		INSERT INTO ` + p.tableRewards + ` (recipient_address, source_address, cycle, amount, timestamp)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT DO NOTHING
-	`
+	ctx, cancel := p.withTimeout(ctx, "SaveRewards")
+	defer cancel()
 
+	rows := make([][]interface{}, 0, len(rewards))
 	for _, reward := range rewards {
-		_, err := tx.ExecContext(ctx, query, reward.RecipientAddress, reward.SourceAddress, reward.Cycle, reward.Amount, reward.Timestamp)
-		if err != nil {
-			if errRollBack := tx.Rollback(); errRollBack != nil {
-				return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
-			}
-			return err
-		}
+		rows = append(rows, []interface{}{reward.RecipientAddress, reward.SourceAddress, reward.Cycle, reward.Amount, reward.Timestamp})
 	}
-
-	return tx.Commit()
+	return classifyError(ctx, "SaveRewards", p.bulkInsert(ctx, p.tableRewards, []string{"recipient_address", "source_address", "cycle", "amount", "timestamp"}, rows))
 }
 
 // SaveLastSyncedRewardCycle saves the last synced reward cycle.
 func (p *psql) SaveLastSyncedRewardCycle(ctx context.Context, cycle int) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveLastSyncedRewardCycle")
+	defer cancel()
+
 	query := `
 		INSERT INTO app.sync_state (source, last_synced_level, last_synced_timestamp)
 		VALUES ('rewards', $1, CURRENT_TIMESTAMP)
 		ON CONFLICT (source) DO UPDATE
 		SET last_synced_level = $1, last_synced_timestamp = CURRENT_TIMESTAMP
 	`
-	
+
 	_, err := p.db.ExecContext(ctx, query, cycle)
-	return err
+	return classifyError(ctx, "SaveLastSyncedRewardCycle", err)
 }
 
 // Close closes the database connection.
 func (p *psql) Close() error {
+	if err := p.replicas.close(); err != nil {
+		return err
+	}
 	if p.db != nil {
 		return p.db.Close()
 	}
@@ -104,8 +104,9 @@ func Test_psql_GetDelegations(t *testing.T) {
 		ctx             context.Context
 		page            uint32
 		limit           uint16
-		year            uint16
+		filter          model.DelegationFilter
 		maxDelegationID uint64
+		cursor          *model.Cursor
 	}
 	tests := []struct {
 		name    string
@@ -123,11 +124,11 @@ func Test_psql_GetDelegations(t *testing.T) {
 				createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 
 				rows := sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
-					AddRow(1, "delegator1", "delegate1", int64(1672531199), float64(1000), int64(1), createdAt)
+					AddRow(1, "delegator1", "delegate1", int64(1672531199), int64(1000), int64(1), createdAt)
 
 				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
 					tableDelegations+
-					" WHERE id <= \\$1 ORDER BY timestamp DESC LIMIT \\$2 OFFSET \\$3").
+					" WHERE id <= \\$1 ORDER BY timestamp DESC, id DESC LIMIT \\$2 OFFSET \\$3").
 					WithArgs(int64(10), 2, 2).WillReturnRows(rows)
 				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM " + tableDelegations).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
@@ -137,7 +138,6 @@ func Test_psql_GetDelegations(t *testing.T) {
 				ctx:             context.Background(),
 				page:            2,
 				limit:           2,
-				year:            0,
 				maxDelegationID: 10,
 			},
 			want: []model.Delegation{
@@ -163,11 +163,11 @@ func Test_psql_GetDelegations(t *testing.T) {
 				endDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
 
 				rows := sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
-					AddRow(1, "delegator1", "delegate1", int64(1672531199), float64(1000), int64(1), createdAt)
+					AddRow(1, "delegator1", "delegate1", int64(1672531199), int64(1000), int64(1), createdAt)
 
 				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
 					tableDelegations+
-					" WHERE timestamp >= \\$1 AND timestamp < \\$2 ORDER BY timestamp DESC LIMIT \\$3 OFFSET \\$4").
+					" WHERE timestamp >= \\$1 AND timestamp < \\$2 ORDER BY timestamp DESC, id DESC LIMIT \\$3 OFFSET \\$4").
 					WithArgs(startDate, endDate, 2, 2).WillReturnRows(rows)
 				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM "+tableDelegations+
 					" WHERE timestamp >= \\$1 AND timestamp < \\$2").
@@ -179,7 +179,7 @@ func Test_psql_GetDelegations(t *testing.T) {
 				ctx:             context.Background(),
 				page:            2,
 				limit:           2,
-				year:            2023,
+				filter:          model.DelegationFilter{Year: 2023},
 				maxDelegationID: 10,
 			},
 			want: []model.Delegation{
@@ -204,11 +204,11 @@ func Test_psql_GetDelegations(t *testing.T) {
 				createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 
 				rows := sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
-					AddRow(1, "delegator1", "delegate1", int64(1672531199), float64(1000), int64(1), createdAt).
-					AddRow(2, "delegator2", "", int64(1672531200), float64(2000), int64(2), createdAt)
+					AddRow(1, "delegator1", "delegate1", int64(1672531199), int64(1000), int64(1), createdAt).
+					AddRow(2, "delegator2", "", int64(1672531200), int64(2000), int64(2), createdAt)
 
 				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
-					tableDelegations+" ORDER BY timestamp DESC LIMIT \\$1 OFFSET \\$2").
+					tableDelegations+" ORDER BY timestamp DESC, id DESC LIMIT \\$1 OFFSET \\$2").
 					WithArgs(2, 0).WillReturnRows(rows)
 				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM " + tableDelegations).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
@@ -218,7 +218,6 @@ func Test_psql_GetDelegations(t *testing.T) {
 				ctx:             context.Background(),
 				page:            1,
 				limit:           2,
-				year:            0,
 				maxDelegationID: 0,
 			},
 			want: []model.Delegation{
@@ -243,13 +242,70 @@ func Test_psql_GetDelegations(t *testing.T) {
 			want1:   2,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Nominal case - after a cursor, page and maxDelegationID ignored",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
+					tableDelegations+
+					" WHERE \\(timestamp, id\\) < \\(\\$1, \\$2\\) ORDER BY timestamp DESC, id DESC LIMIT \\$3 OFFSET \\$4").
+					WithArgs(int64(1672531200), int64(2), 2, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				ctx:             context.Background(),
+				page:            5,
+				limit:           2,
+				maxDelegationID: 10,
+				cursor:          model.NewCursor(1672531200, 2),
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - filters and sort",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
+					tableDelegations+
+					" WHERE delegator = \\$1 AND delegate <> '' AND level >= \\$2 AND level <= \\$3"+
+					" AND timestamp >= \\$4 AND timestamp < \\$5 AND amount >= \\$6 AND amount <= \\$7"+
+					" ORDER BY level ASC, id ASC LIMIT \\$8 OFFSET \\$9").
+					WithArgs("tz1a", int64(100), int64(200), int64(1000), int64(2000), int64(10), int64(20), 2, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				ctx:   context.Background(),
+				page:  1,
+				limit: 2,
+				filter: func() model.DelegationFilter {
+					minAmount, maxAmount := model.Mutez(10), model.Mutez(20)
+					return model.DelegationFilter{
+						Delegator: "tz1a",
+						FromLevel: 100,
+						ToLevel:   200,
+						FromDate:  1000,
+						ToDate:    2000,
+						MinAmount: &minAmount,
+						MaxAmount: &maxAmount,
+						Kind:      model.DelegationKindDelegation,
+						Sort:      model.DelegationSortLevel,
+						Order:     model.SortOrderAsc,
+					}
+				}(),
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Error case - context canceled",
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM "+
 					tableDelegations+
-					" ORDER BY timestamp DESC LIMIT \\$1 OFFSET \\$2").
+					" ORDER BY timestamp DESC, id DESC LIMIT \\$1 OFFSET \\$2").
 					WithArgs(2, 0).WillReturnError(context.Canceled)
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -261,7 +317,6 @@ func Test_psql_GetDelegations(t *testing.T) {
 				}(),
 				page:            1,
 				limit:           2,
-				year:            0,
 				maxDelegationID: 0,
 			},
 			want:    nil,
@@ -275,13 +330,13 @@ func Test_psql_GetDelegations(t *testing.T) {
 				db:               tt.db,
 				tableDelegations: tableDelegations,
 			}
-			got, err := p.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.year, tt.args.maxDelegationID)
+			got, err := p.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.filter, tt.args.maxDelegationID, tt.args.cursor)
 			if !tt.wantErr(t, err, fmt.Sprintf("GetDelegations(%v, %v, %v, %v, %v)",
-				tt.args.ctx, tt.args.page, tt.args.limit, tt.args.year, tt.args.maxDelegationID)) {
+				tt.args.ctx, tt.args.page, tt.args.limit, tt.args.filter, tt.args.maxDelegationID)) {
 				return
 			}
 			assert.Equalf(t, tt.want, got, "GetDelegations(%v, %v, %v, %v, %v)",
-				tt.args.ctx, tt.args.page, tt.args.limit, tt.args.year, tt.args.maxDelegationID)
+				tt.args.ctx, tt.args.page, tt.args.limit, tt.args.filter, tt.args.maxDelegationID)
 		})
 	}
 }
@@ -364,7 +419,7 @@ func Test_psql_GetLatestDelegation(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 				createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 				rows := sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
-					AddRow(1, "delegator1", "delegate1", int64(1672531199), float64(1000), int64(1), createdAt)
+					AddRow(1, "delegator1", "delegate1", int64(1672531199), int64(1000), int64(1), createdAt)
 				mock.ExpectQuery("SELECT id, delegator, delegate, timestamp, amount, level, created_at FROM " +
 					tableDelegations + " ORDER BY level DESC LIMIT 1").
 					WillReturnRows(rows)
@@ -471,7 +526,7 @@ func Test_psql_SaveDelegation(t *testing.T) {
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectExec("INSERT INTO "+tableDelegations).
-					WithArgs("delegator1", "delegate1", int64(1672531199), float64(1000), int64(1)).
+					WithArgs("delegator1", "delegate1", int64(1672531199), int64(1000), int64(1)).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -562,12 +617,14 @@ func Test_psql_SaveDelegations(t *testing.T) {
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectBegin()
-				mock.ExpectExec("INSERT INTO "+tableDelegations).
-					WithArgs("delegator1", "delegate2", int64(1672531199), float64(1000), int64(1)).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectExec("INSERT INTO "+tableDelegations).
-					WithArgs("delegator2", "delegate3", int64(1672531200), float64(2000), int64(2)).
-					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO "+tableDelegations+" \\(delegator, delegate, timestamp, amount, level\\) "+
+					"VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\), \\(\\$6, \\$7, \\$8, \\$9, \\$10\\) ON CONFLICT DO NOTHING").
+					WithArgs("delegator1", "delegate2", int64(1672531199), int64(1000), int64(1),
+						"delegator2", "delegate3", int64(1672531200), int64(2000), int64(2)).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+				mock.ExpectExec("SELECT pg_notify\\(\\$1, \\$2\\)").
+					WithArgs(delegationsChannel, "2").
+					WillReturnResult(sqlmock.NewResult(0, 0))
 				mock.ExpectCommit()
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -683,8 +740,6 @@ func Test_psql_GetLastSyncedRewardCycle(t *testing.T) {
 }
 
 func Test_psql_GetActiveDelegators(t *testing.T) {
-	const tableDelegations = "app.delegations"
-	
 	tests := []struct {
 		name    string
 		db      *sqlx.DB
@@ -696,7 +751,7 @@ func Test_psql_GetActiveDelegators(t *testing.T) {
 			name: "Nominal case",
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
-				mock.ExpectQuery("SELECT DISTINCT delegator AS address FROM "+tableDelegations+" WHERE amount > 0 ORDER BY delegator").
+				mock.ExpectQuery("SELECT delegator AS address FROM " + tableCurrentDelegations + " WHERE baker <> '' ORDER BY delegator").
 					WillReturnRows(sqlmock.NewRows([]string{"address"}).AddRow("tz1delegator1").AddRow("tz1delegator2"))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -708,7 +763,7 @@ func Test_psql_GetActiveDelegators(t *testing.T) {
 			name: "Error case - query error",
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
-				mock.ExpectQuery("SELECT DISTINCT delegator AS address FROM "+tableDelegations+" WHERE amount > 0 ORDER BY delegator").
+				mock.ExpectQuery("SELECT delegator AS address FROM " + tableCurrentDelegations + " WHERE baker <> '' ORDER BY delegator").
 					WillReturnError(fmt.Errorf("query error"))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -720,8 +775,8 @@ func Test_psql_GetActiveDelegators(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &psql{
-				db:               tt.db,
-				tableDelegations: tableDelegations,
+				db:                      tt.db,
+				tableCurrentDelegations: tableCurrentDelegations,
 			}
 			got, err := p.GetActiveDelegators(tt.ctx)
 			if !tt.wantErr(t, err, fmt.Sprintf("GetActiveDelegators(%v)", tt.ctx)) {
@@ -732,68 +787,248 @@ func Test_psql_GetActiveDelegators(t *testing.T) {
 	}
 }
 
-func Test_psql_GetBakerForDelegatorAtCycle(t *testing.T) {
-	const tableDelegations = "app.delegations"
-	
+func Test_psql_GetRewards(t *testing.T) {
+	const tableRewards = "app.rewards"
+
+	type args struct {
+		fromDate int64
+		toDate   int64
+		wallet   model.WalletAddress
+		baker    model.WalletAddress
+		page     uint32
+		cursor   *model.Cursor
+	}
 	tests := []struct {
-		name      string
-		db        *sqlx.DB
-		ctx       context.Context
-		delegator model.WalletAddress
-		cycle     int
-		want      model.WalletAddress
-		wantErr   assert.ErrorAssertionFunc
+		name    string
+		db      *sqlx.DB
+		args    args
+		want    []model.Reward
+		wantErr assert.ErrorAssertionFunc
 	}{
 		{
-			name: "Nominal case",
+			name: "Nominal case - date range and filters",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				rows := sqlmock.NewRows([]string{"id", "recipient_address", "source_address", "cycle", "amount", "timestamp"}).
+					AddRow(1, "tz1delegator", "tz1baker", 10, int64(5500000), int64(1672531199))
+				mock.ExpectQuery("SELECT id, recipient_address, source_address, cycle, amount, timestamp FROM "+tableRewards+
+					" WHERE timestamp >= \\$1 AND timestamp < \\$2 AND recipient_address = \\$3 AND source_address = \\$4 ORDER BY timestamp DESC, id DESC LIMIT \\$5 OFFSET \\$6").
+					WithArgs(int64(1672531000), int64(1672532000), "tz1delegator", "tz1baker", uint16(50), 0).
+					WillReturnRows(rows)
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				fromDate: 1672531000,
+				toDate:   1672532000,
+				wallet:   "tz1delegator",
+				baker:    "tz1baker",
+			},
+			want: []model.Reward{
+				{
+					ID:               1,
+					RecipientAddress: "tz1delegator",
+					SourceAddress:    "tz1baker",
+					Cycle:            10,
+					Amount:           5500000,
+					Timestamp:        1672531199,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - no date bounds",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, recipient_address, source_address, cycle, amount, timestamp FROM "+tableRewards+
+					" WHERE recipient_address = \\$1 ORDER BY timestamp DESC, id DESC LIMIT \\$2 OFFSET \\$3").
+					WithArgs("tz1delegator", uint16(50), 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "recipient_address", "source_address", "cycle", "amount", "timestamp"}))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				wallet: "tz1delegator",
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - page beyond the uint16 offsets",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, recipient_address, source_address, cycle, amount, timestamp FROM "+tableRewards+
+					" WHERE recipient_address = \\$1 ORDER BY timestamp DESC, id DESC LIMIT \\$2 OFFSET \\$3").
+					WithArgs("tz1delegator", uint16(50), 3499950).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "recipient_address", "source_address", "cycle", "amount", "timestamp"}))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				wallet: "tz1delegator",
+				page:   70000,
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - after a cursor",
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
-				// Nous ne testons pas exactement l'approximation du timestamp, mais la requête elle-même
-				mock.ExpectQuery("SELECT delegate FROM "+tableDelegations+" WHERE delegator = \\$1 AND timestamp <= \\$2 ORDER BY timestamp DESC LIMIT 1").
-					WithArgs("tz1delegator1", mock.AnyArg()).
-					WillReturnRows(sqlmock.NewRows([]string{"delegate"}).AddRow("tz1baker1"))
+				mock.ExpectQuery("SELECT id, recipient_address, source_address, cycle, amount, timestamp FROM "+tableRewards+
+					" WHERE recipient_address = \\$1 AND \\(timestamp, id\\) < \\(\\$2, \\$3\\) ORDER BY timestamp DESC, id DESC LIMIT \\$4 OFFSET \\$5").
+					WithArgs("tz1delegator", int64(1672531199), int64(7), uint16(50), 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "recipient_address", "source_address", "cycle", "amount", "timestamp"}))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
-			ctx:       context.Background(),
-			delegator: "tz1delegator1",
-			cycle:     10,
-			want:      "tz1baker1",
-			wantErr:   assert.NoError,
+			args: args{
+				wallet: "tz1delegator",
+				cursor: model.NewCursor(1672531199, 7),
+			},
+			want:    nil,
+			wantErr: assert.NoError,
 		},
 		{
 			name: "Error case - query error",
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
-				mock.ExpectQuery("SELECT delegate FROM "+tableDelegations+" WHERE delegator = \\$1 AND timestamp <= \\$2 ORDER BY timestamp DESC LIMIT 1").
-					WithArgs("tz1delegator1", mock.AnyArg()).
+				mock.ExpectQuery("SELECT id, recipient_address, source_address, cycle, amount, timestamp FROM " + tableRewards).
 					WillReturnError(fmt.Errorf("query error"))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
-			ctx:       context.Background(),
-			delegator: "tz1delegator1",
-			cycle:     10,
-			want:      "",
-			wantErr:   assert.Error,
+			wantErr: assert.Error,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &psql{
-				db:               tt.db,
-				tableDelegations: tableDelegations,
+				db:           tt.db,
+				tableRewards: tableRewards,
 			}
-			got, err := p.GetBakerForDelegatorAtCycle(tt.ctx, tt.delegator, tt.cycle)
-			if !tt.wantErr(t, err, fmt.Sprintf("GetBakerForDelegatorAtCycle(%v, %v, %v)", tt.ctx, tt.delegator, tt.cycle)) {
+			got, err := p.GetRewards(context.Background(), tt.args.fromDate, tt.args.toDate, tt.args.wallet, tt.args.baker, tt.args.page, 50, tt.args.cursor)
+			if !tt.wantErr(t, err, fmt.Sprintf("GetRewards(%v)", tt.args)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "GetBakerForDelegatorAtCycle(%v, %v, %v)", tt.ctx, tt.delegator, tt.cycle)
+			assert.Equalf(t, tt.want, got, "GetRewards(%v)", tt.args)
+		})
+	}
+}
+
+func Test_psql_GetOperations(t *testing.T) {
+	const tableOperations = "app.staking_operations"
+	columns := []string{"id", "sender_address", "contract_address", "entrypoint", "amount", "block", "timestamp", "status"}
+
+	type args struct {
+		fromDate      int64
+		toDate        int64
+		page          uint16
+		limit         uint16
+		operationType model.OperationType
+		wallet        model.WalletAddress
+		baker         model.WalletAddress
+		cursor        *model.Cursor
+	}
+	tests := []struct {
+		name    string
+		db      *sqlx.DB
+		args    args
+		want    []model.Operation
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - all filters",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "tz1sender", "KT1pool", "stake", int64(1000000), "BLock1", int64(1672531199), "applied")
+				mock.ExpectQuery("SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status FROM "+tableOperations+
+					" WHERE timestamp >= \\$1 AND timestamp < \\$2 AND entrypoint = \\$3 AND sender_address = \\$4 AND contract_address = \\$5"+
+					" ORDER BY timestamp DESC, id DESC LIMIT \\$6 OFFSET \\$7").
+					WithArgs(int64(1672531000), int64(1672532000), "stake", "tz1sender", "KT1pool", uint16(10), uint16(10)).
+					WillReturnRows(rows)
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				fromDate:      1672531000,
+				toDate:        1672532000,
+				page:          2,
+				limit:         10,
+				operationType: "stake",
+				wallet:        "tz1sender",
+				baker:         "KT1pool",
+			},
+			want: []model.Operation{
+				{
+					ID:              1,
+					SenderAddress:   "tz1sender",
+					ContractAddress: "KT1pool",
+					Entrypoint:      "stake",
+					Amount:          1000000,
+					Block:           "BLock1",
+					Timestamp:       1672531199,
+					Status:          "applied",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - no filter and default limit",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status FROM "+tableOperations+
+					" ORDER BY timestamp DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+					WithArgs(uint16(50), uint16(0)).
+					WillReturnRows(sqlmock.NewRows(columns))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Nominal case - after a cursor",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status FROM "+tableOperations+
+					" WHERE sender_address = \\$1 AND \\(timestamp, id\\) < \\(\\$2, \\$3\\) ORDER BY timestamp DESC, id DESC LIMIT \\$4 OFFSET \\$5").
+					WithArgs("tz1sender", int64(1672531199), int64(1), uint16(10), uint16(0)).
+					WillReturnRows(sqlmock.NewRows(columns))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			args: args{
+				page:   3,
+				limit:  10,
+				wallet: "tz1sender",
+				cursor: model.NewCursor(1672531199, 1),
+			},
+			want:    nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() *sqlx.DB {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT id, sender_address, contract_address").
+					WillReturnError(fmt.Errorf("query error"))
+				return sqlx.NewDb(db, "sqlmock")
+			}(),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &psql{
+				db:              tt.db,
+				tableOperations: tableOperations,
+			}
+			got, err := p.GetOperations(context.Background(), tt.args.fromDate, tt.args.toDate, tt.args.page, tt.args.limit, tt.args.operationType, tt.args.wallet, tt.args.baker, tt.args.cursor)
+			if !tt.wantErr(t, err, fmt.Sprintf("GetOperations(%v)", tt.args)) {
+				return
+			}
+			assert.Equalf(t, tt.want, got, "GetOperations(%v)", tt.args)
 		})
 	}
 }
 
 func Test_psql_SaveRewards(t *testing.T) {
 	const tableRewards = "app.rewards"
-	
+
 	type args struct {
 		ctx     context.Context
 		rewards []model.Reward
@@ -810,19 +1045,17 @@ func Test_psql_SaveRewards(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO "+tableRewards).
-					WithArgs("tz1delegator1", "tz1baker1", 10, 5.5, int64(1672531199)).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectExec("INSERT INTO "+tableRewards).
-					WithArgs("tz1delegator2", "tz1baker2", 10, 3.3, int64(1672531200)).
-					WillReturnResult(sqlmock.NewResult(2, 1))
+					WithArgs("tz1delegator1", "tz1baker1", 10, int64(5500000), int64(1672531199),
+						"tz1delegator2", "tz1baker2", 10, int64(3300000), int64(1672531200)).
+					WillReturnResult(sqlmock.NewResult(2, 2))
 				mock.ExpectCommit()
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
 			args: args{
 				ctx: context.Background(),
 				rewards: []model.Reward{
-					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5.5, Timestamp: 1672531199},
-					{RecipientAddress: "tz1delegator2", SourceAddress: "tz1baker2", Cycle: 10, Amount: 3.3, Timestamp: 1672531200},
+					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5500000, Timestamp: 1672531199},
+					{RecipientAddress: "tz1delegator2", SourceAddress: "tz1baker2", Cycle: 10, Amount: 3300000, Timestamp: 1672531200},
 				},
 			},
 			wantErr: assert.NoError,
@@ -837,7 +1070,7 @@ func Test_psql_SaveRewards(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				rewards: []model.Reward{
-					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5.5, Timestamp: 1672531199},
+					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5500000, Timestamp: 1672531199},
 				},
 			},
 			wantErr: assert.Error,
@@ -848,7 +1081,7 @@ func Test_psql_SaveRewards(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO "+tableRewards).
-					WithArgs("tz1delegator1", "tz1baker1", 10, 5.5, int64(1672531199)).
+					WithArgs("tz1delegator1", "tz1baker1", 10, int64(5500000), int64(1672531199)).
 					WillReturnError(fmt.Errorf("insert error"))
 				mock.ExpectRollback()
 				return sqlx.NewDb(db, "sqlmock")
@@ -856,7 +1089,7 @@ func Test_psql_SaveRewards(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				rewards: []model.Reward{
-					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5.5, Timestamp: 1672531199},
+					{RecipientAddress: "tz1delegator1", SourceAddress: "tz1baker1", Cycle: 10, Amount: 5500000, Timestamp: 1672531199},
 				},
 			},
 			wantErr: assert.Error,
@@ -865,7 +1098,7 @@ func Test_psql_SaveRewards(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &psql{
-				db:          tt.db,
+				db:           tt.db,
 				tableRewards: tableRewards,
 			}
 			tt.wantErr(t, p.SaveRewards(tt.args.ctx, tt.args.rewards),
@@ -887,7 +1120,7 @@ func Test_psql_SaveLastSyncedRewardCycle(t *testing.T) {
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectExec("INSERT INTO app.sync_state").
-					WithArgs("rewards", 10, mock.AnyArg()).
+					WithArgs(10).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -900,7 +1133,7 @@ func Test_psql_SaveLastSyncedRewardCycle(t *testing.T) {
 			db: func() *sqlx.DB {
 				db, mock, _ := sqlmock.New()
 				mock.ExpectExec("INSERT INTO app.sync_state").
-					WithArgs("rewards", 10, mock.AnyArg()).
+					WithArgs(10).
 					WillReturnError(fmt.Errorf("insert error"))
 				return sqlx.NewDb(db, "sqlmock")
 			}(),
@@ -0,0 +1,194 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+// defaultReplicaCheckInterval is used when no replica check interval is configured.
+const defaultReplicaCheckInterval = 10 * time.Second
+
+// ReplicaConfig represents a read replica. The other connection settings are those of the primary.
+type ReplicaConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
+// replica is a read replica connection and its last known health.
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// replicaPool distributes reads over the healthy replicas in round-robin.
+type replicaPool struct {
+	primary    *sqlx.DB
+	replicas   []*replica
+	next       atomic.Uint32
+	maxLag     uint64
+	levelQuery string
+	stop       chan struct{}
+	wg         sync.WaitGroup
+}
+
+// newReplicaPool opens the replica connections, checks them once and starts checking them every interval.
+// A replica unreachable at startup is only marked unhealthy, reads then go to the primary.
+func newReplicaPool(cfg Config, primary *sqlx.DB, levelQuery string) (*replicaPool, error) {
+	rp := &replicaPool{
+		primary:    primary,
+		maxLag:     cfg.MaxReplicaLag,
+		levelQuery: levelQuery,
+		stop:       make(chan struct{}),
+	}
+
+	for _, r := range cfg.Replicas {
+		db, err := sqlx.Open(cfg.Driver, buildDSN(cfg, r.Host, r.Port))
+		if err != nil {
+			rp.close()
+			return nil, fmt.Errorf("failed to open replica %s:%d: %w", r.Host, r.Port, err)
+		}
+		configurePool(db, cfg.Pool)
+		rp.replicas = append(rp.replicas, &replica{name: fmt.Sprintf("%s:%d", r.Host, r.Port), db: db})
+	}
+
+	interval := cfg.ReplicaCheckInterval
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
+	rp.check(ctx)
+	cancel()
+
+	rp.wg.Add(1)
+	go rp.run(interval)
+
+	return rp, nil
+}
+
+// pick returns the next healthy replica, or nil when there is none.
+func (rp *replicaPool) pick() *replica {
+	if rp == nil || len(rp.replicas) == 0 {
+		return nil
+	}
+
+	healthy := 0
+	for _, r := range rp.replicas {
+		if r.healthy.Load() {
+			healthy++
+		}
+	}
+	if healthy == 0 {
+		return nil
+	}
+
+	n := int(rp.next.Add(1) % uint32(healthy))
+	for _, r := range rp.replicas {
+		if !r.healthy.Load() {
+			continue
+		}
+		if n == 0 {
+			return r
+		}
+		n--
+	}
+	// A replica turned unhealthy in the meantime.
+	return nil
+}
+
+// run checks the replicas every interval until the pool is closed.
+func (rp *replicaPool) run(interval time.Duration) {
+	defer rp.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rp.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			rp.check(ctx)
+			cancel()
+		}
+	}
+}
+
+// check updates the health of every replica.
+// When a max lag is set, a replica whose indexed level is more than maxLag behind the primary is unhealthy.
+func (rp *replicaPool) check(ctx context.Context) {
+	var primaryLevel uint64
+	if rp.maxLag > 0 {
+		if err := rp.primary.GetContext(ctx, &primaryLevel, rp.levelQuery); err != nil {
+			// Without the primary level the lag is unknown, replicas are only pinged.
+			primaryLevel = 0
+		}
+	}
+
+	for _, r := range rp.replicas {
+		r.healthy.Store(rp.isHealthy(ctx, r, primaryLevel))
+	}
+}
+
+// isHealthy pings r and compares its indexed level with primaryLevel.
+func (rp *replicaPool) isHealthy(ctx context.Context, r *replica, primaryLevel uint64) bool {
+	if err := r.db.PingContext(ctx); err != nil {
+		return false
+	}
+
+	if rp.maxLag == 0 || primaryLevel == 0 {
+		return true
+	}
+
+	var level uint64
+	if err := r.db.GetContext(ctx, &level, rp.levelQuery); err != nil {
+		return false
+	}
+	return level+rp.maxLag >= primaryLevel
+}
+
+// close stops the health checks and closes the replica connections.
+func (rp *replicaPool) close() error {
+	if rp == nil {
+		return nil
+	}
+
+	select {
+	case <-rp.stop:
+	default:
+		close(rp.stop)
+	}
+	rp.wg.Wait()
+
+	var errs []error
+	for _, r := range rp.replicas {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("replica %s: %w", r.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// read runs query on a healthy replica, or on the primary when there is none.
+// A replica that cannot be reached is marked unhealthy and the query is run on the primary. Other errors, such as
+// an empty result, an invalid query or a canceled request, are the query's own and are returned as is.
+func (p *psql) read(ctx context.Context, query func(db *sqlx.DB) error) error {
+	if r := p.replicas.pick(); r != nil {
+		err := query(r.db)
+		if err == nil || ctx.Err() != nil || !database.IsUnavailable(classifyError(ctx, "read", err)) {
+			return err
+		}
+		r.healthy.Store(false)
+	}
+	return query(p.db)
+}
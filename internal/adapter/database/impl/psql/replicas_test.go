@@ -0,0 +1,255 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLevelQuery = "SELECT COALESCE(MAX(level), 0) FROM app.delegations"
+
+func newTestReplica(t *testing.T, name string, healthy bool) (*replica, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+
+	r := &replica{name: name, db: sqlx.NewDb(db, "sqlmock")}
+	r.healthy.Store(healthy)
+	return r, mock
+}
+
+func Test_replicaPool_pick(t *testing.T) {
+	r1, _ := newTestReplica(t, "r1", true)
+	r2, _ := newTestReplica(t, "r2", false)
+	r3, _ := newTestReplica(t, "r3", true)
+
+	tests := []struct {
+		name      string
+		pool      *replicaPool
+		wantNames []string
+	}{
+		{
+			name:      "Nominal case - round-robin over healthy replicas",
+			pool:      &replicaPool{replicas: []*replica{r1, r2, r3}},
+			wantNames: []string{"r3", "r1", "r3", "r1"},
+		},
+		{
+			name:      "No healthy replica",
+			pool:      &replicaPool{replicas: []*replica{r2}},
+			wantNames: []string{"", ""},
+		},
+		{
+			name:      "No replica pool",
+			pool:      nil,
+			wantNames: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for range tt.wantNames {
+				name := ""
+				if r := tt.pool.pick(); r != nil {
+					name = r.name
+				}
+				names = append(names, name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func Test_replicaPool_check(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxLag      uint64
+		setup       func(primary, replica sqlmock.Sqlmock)
+		wantHealthy bool
+	}{
+		{
+			name: "Nominal case - reachable replica without lag check",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectPing()
+			},
+			wantHealthy: true,
+		},
+		{
+			name:   "Nominal case - replica within max lag",
+			maxLag: 10,
+			setup: func(primary, replica sqlmock.Sqlmock) {
+				primary.ExpectQuery(regexp.QuoteMeta(testLevelQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(1000))
+				replica.ExpectPing()
+				replica.ExpectQuery(regexp.QuoteMeta(testLevelQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(995))
+			},
+			wantHealthy: true,
+		},
+		{
+			name:   "Error case - replica lagging",
+			maxLag: 10,
+			setup: func(primary, replica sqlmock.Sqlmock) {
+				primary.ExpectQuery(regexp.QuoteMeta(testLevelQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(1000))
+				replica.ExpectPing()
+				replica.ExpectQuery(regexp.QuoteMeta(testLevelQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(900))
+			},
+			wantHealthy: false,
+		},
+		{
+			name: "Error case - replica unreachable",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectPing().WillReturnError(errors.New("connection refused"))
+			},
+			wantHealthy: false,
+		},
+		{
+			name:   "Primary level unknown - replica only pinged",
+			maxLag: 10,
+			setup: func(primary, replica sqlmock.Sqlmock) {
+				primary.ExpectQuery(regexp.QuoteMeta(testLevelQuery)).WillReturnError(errors.New("timeout"))
+				replica.ExpectPing()
+			},
+			wantHealthy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryDB, primaryMock, _ := sqlmock.New()
+			r, replicaMock := newTestReplica(t, "r1", !tt.wantHealthy)
+			tt.setup(primaryMock, replicaMock)
+
+			pool := &replicaPool{
+				primary:    sqlx.NewDb(primaryDB, "sqlmock"),
+				replicas:   []*replica{r},
+				maxLag:     tt.maxLag,
+				levelQuery: testLevelQuery,
+			}
+			pool.check(context.Background())
+
+			assert.Equal(t, tt.wantHealthy, r.healthy.Load())
+			assert.NoError(t, primaryMock.ExpectationsWereMet())
+			assert.NoError(t, replicaMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_read(t *testing.T) {
+	query := "SELECT COALESCE(MAX(level), 0) FROM " + tableDelegations
+
+	tests := []struct {
+		name         string
+		setup        func(primary, replica sqlmock.Sqlmock)
+		wantLevel    uint64
+		wantHealthy  bool
+		wantErr      assert.ErrorAssertionFunc
+		noReplicaSet bool
+	}{
+		{
+			name: "Nominal case - read from replica",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(42))
+			},
+			wantLevel:   42,
+			wantHealthy: true,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "Replica failure falls back to the primary",
+			setup: func(primary, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pq.Error{Code: "08006"})
+				primary.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(43))
+			},
+			wantLevel:   43,
+			wantHealthy: false,
+			wantErr:     assert.NoError,
+		},
+		{
+			name: "No rows is not a replica failure",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(sql.ErrNoRows)
+			},
+			wantHealthy: true,
+			wantErr:     assert.Error,
+		},
+		{
+			name: "Query error is not a replica failure",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pq.Error{Code: "42601"})
+			},
+			wantHealthy: true,
+			wantErr:     assert.Error,
+		},
+		{
+			name: "Canceled query is not a replica failure",
+			setup: func(_, replica sqlmock.Sqlmock) {
+				replica.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(context.Canceled)
+			},
+			wantHealthy: true,
+			wantErr:     assert.Error,
+		},
+		{
+			name: "Nominal case - without replicas reads go to the primary",
+			setup: func(primary, _ sqlmock.Sqlmock) {
+				primary.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnRows(sqlmock.NewRows([]string{"level"}).AddRow(44))
+			},
+			wantLevel:    44,
+			wantErr:      assert.NoError,
+			noReplicaSet: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryDB, primaryMock, _ := sqlmock.New()
+			r, replicaMock := newTestReplica(t, "r1", true)
+			tt.setup(primaryMock, replicaMock)
+
+			p := &psql{
+				db:               sqlx.NewDb(primaryDB, "sqlmock"),
+				tableDelegations: tableDelegations,
+			}
+			if !tt.noReplicaSet {
+				p.replicas = &replicaPool{replicas: []*replica{r}}
+			}
+
+			got, err := p.GetHighestBlockLevel(context.Background())
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantLevel, got)
+			if !tt.noReplicaSet {
+				assert.Equal(t, tt.wantHealthy, r.healthy.Load())
+			}
+			assert.NoError(t, primaryMock.ExpectationsWereMet())
+			assert.NoError(t, replicaMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_replicaPool_close(t *testing.T) {
+	r, mock := newTestReplica(t, "r1", true)
+	mock.ExpectClose()
+
+	pool := &replicaPool{replicas: []*replica{r}, stop: make(chan struct{})}
+	pool.wg.Add(1)
+	go pool.run(defaultReplicaCheckInterval)
+
+	assert.NoError(t, pool.close())
+	assert.NoError(t, pool.close(), "closing twice is a no-op for the health checks")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	var nilPool *replicaPool
+	assert.NoError(t, nilPool.close())
+}
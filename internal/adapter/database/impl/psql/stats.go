@@ -0,0 +1,230 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// RefreshDelegationStats recomputes the daily delegation statistics of the UTC days between fromDate and toDate, included.
+func (p *psql) RefreshDelegationStats(ctx context.Context, fromDate, toDate int64) error {
+	ctx, cancel := p.withTimeout(ctx, "RefreshDelegationStats")
+	defer cancel()
+
+	fromDay, toDay := model.DayStart(fromDate), model.DayStart(toDate)
+
+	// A delegator leaves the baker of its previous delegation when it undelegates or delegates to another baker.
+	insert := `
+		INSERT INTO ` + p.tableDelegationStats + ` (day, baker, new_delegations, undelegations, delegated_volume)
+		SELECT day, baker, SUM(new_delegations), SUM(undelegations), SUM(delegated_volume)
+		FROM (
+			SELECT timestamp - timestamp % 86400 AS day, delegate AS baker,
+				1 AS new_delegations, 0 AS undelegations, amount AS delegated_volume
+			FROM ` + p.tableDelegations + `
+			WHERE timestamp >= $1 AND timestamp < $2 AND delegate <> ''
+			UNION ALL
+			SELECT day, prev_delegate, 0, 1, 0
+			FROM (
+				SELECT d.timestamp - d.timestamp % 86400 AS day, d.delegate,
+					(SELECT prev.delegate FROM ` + p.tableDelegations + ` prev
+					WHERE prev.delegator = d.delegator AND prev.level < d.level
+					ORDER BY prev.level DESC LIMIT 1) AS prev_delegate
+				FROM ` + p.tableDelegations + ` d
+				WHERE d.timestamp >= $1 AND d.timestamp < $2
+			) moves
+			WHERE prev_delegate <> '' AND prev_delegate <> delegate
+		) activity
+		GROUP BY day, baker
+	`
+
+	err := p.refresh(ctx,
+		"DELETE FROM "+p.tableDelegationStats+" WHERE day >= $1 AND day < $2", insert,
+		fromDay, toDay+model.SecondsPerDay)
+	return classifyError(ctx, "RefreshDelegationStats", err)
+}
+
+// RefreshRewardStats recomputes the per-cycle reward statistics of the cycles between fromCycle and toCycle, included.
+func (p *psql) RefreshRewardStats(ctx context.Context, fromCycle, toCycle int) error {
+	ctx, cancel := p.withTimeout(ctx, "RefreshRewardStats")
+	defer cancel()
+
+	err := p.refresh(ctx,
+		"DELETE FROM "+p.tableBakerRewards+" WHERE cycle >= $1 AND cycle <= $2", `
+		INSERT INTO `+p.tableBakerRewards+` (cycle, baker, rewards, delegators)
+		SELECT cycle, source_address, SUM(amount), COUNT(DISTINCT recipient_address)
+		FROM `+p.tableRewards+`
+		WHERE cycle >= $1 AND cycle <= $2
+		GROUP BY cycle, source_address
+	`, fromCycle, toCycle)
+	if err != nil {
+		return classifyError(ctx, "RefreshRewardStats", err)
+	}
+
+	err = p.refresh(ctx,
+		"DELETE FROM "+p.tableDelegatorRewards+" WHERE cycle >= $1 AND cycle <= $2", `
+		INSERT INTO `+p.tableDelegatorRewards+` (cycle, delegator, rewards, bakers)
+		SELECT cycle, recipient_address, SUM(amount), COUNT(DISTINCT source_address)
+		FROM `+p.tableRewards+`
+		WHERE cycle >= $1 AND cycle <= $2
+		GROUP BY cycle, recipient_address
+	`, fromCycle, toCycle)
+	return classifyError(ctx, "RefreshRewardStats", err)
+}
+
+// refresh replaces the aggregates selected by del with the ones computed by insert, in a transaction.
+func (p *psql) refresh(ctx context.Context, del, insert string, args ...interface{}) error {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, del, args...); err == nil {
+		_, err = tx.ExecContext(ctx, insert, args...)
+	}
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDailyDelegationStats returns the daily delegation statistics within a date range, optionally of a baker.
+func (p *psql) GetDailyDelegationStats(ctx context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDailyDelegationStats")
+	defer cancel()
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if fromDate > 0 {
+		args = append(args, model.DayStart(fromDate))
+		conditions = append(conditions, "day >= $"+strconv.Itoa(len(args)))
+	}
+
+	if toDate > 0 {
+		args = append(args, toDate)
+		conditions = append(conditions, "day <= $"+strconv.Itoa(len(args)))
+	}
+
+	if baker != "" {
+		args = append(args, baker.String())
+		conditions = append(conditions, "baker = $"+strconv.Itoa(len(args)))
+	}
+
+	query := `
+		SELECT day, baker, new_delegations, undelegations, delegated_volume
+		FROM ` + p.tableDelegationStats + `
+		` + whereClause(conditions) + `
+		ORDER BY day, baker
+	`
+
+	var stats []model.DailyDelegationStats
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		stats = nil
+		return db.SelectContext(ctx, &stats, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetDailyDelegationStats", err)
+	}
+
+	return stats, nil
+}
+
+// GetBakerRewardStats returns the per-cycle rewards of bakers within a cycle range, optionally of a baker.
+func (p *psql) GetBakerRewardStats(ctx context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetBakerRewardStats")
+	defer cancel()
+
+	conditions, args := cycleConditions(fromCycle, toCycle)
+	if baker != "" {
+		args = append(args, baker.String())
+		conditions = append(conditions, "baker = $"+strconv.Itoa(len(args)))
+	}
+
+	query := `
+		SELECT cycle, baker, rewards, delegators
+		FROM ` + p.tableBakerRewards + `
+		` + whereClause(conditions) + `
+		ORDER BY cycle, baker
+	`
+
+	var stats []model.BakerCycleRewards
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		stats = nil
+		return db.SelectContext(ctx, &stats, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetBakerRewardStats", err)
+	}
+
+	return stats, nil
+}
+
+// GetDelegatorRewardStats returns the per-cycle rewards of delegators within a cycle range, optionally of a delegator.
+func (p *psql) GetDelegatorRewardStats(ctx context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDelegatorRewardStats")
+	defer cancel()
+
+	conditions, args := cycleConditions(fromCycle, toCycle)
+	if delegator != "" {
+		args = append(args, delegator.String())
+		conditions = append(conditions, "delegator = $"+strconv.Itoa(len(args)))
+	}
+
+	query := `
+		SELECT cycle, delegator, rewards, bakers
+		FROM ` + p.tableDelegatorRewards + `
+		` + whereClause(conditions) + `
+		ORDER BY cycle, delegator
+	`
+
+	var stats []model.DelegatorCycleRewards
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		stats = nil
+		return db.SelectContext(ctx, &stats, query, args...)
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetDelegatorRewardStats", err)
+	}
+
+	return stats, nil
+}
+
+// cycleConditions returns the conditions and arguments of a cycle range, where 0 leaves a bound open.
+func cycleConditions(fromCycle, toCycle int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if fromCycle > 0 {
+		args = append(args, fromCycle)
+		conditions = append(conditions, "cycle >= $"+strconv.Itoa(len(args)))
+	}
+
+	if toCycle > 0 {
+		args = append(args, toCycle)
+		conditions = append(conditions, "cycle <= $"+strconv.Itoa(len(args)))
+	}
+
+	return conditions, args
+}
+
+// whereClause joins conditions into a WHERE clause, empty when there is none.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
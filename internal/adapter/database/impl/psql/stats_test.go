@@ -0,0 +1,213 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_psql_RefreshDelegationStats(t *testing.T) {
+	const tableDelegationStats = "app.daily_delegation_stats"
+
+	// 2025-03-01T10:00:00Z and 2025-03-02T23:00:00Z
+	const from, to = int64(1740823200), int64(1740956400)
+	const fromDay, toDayEnd = int64(1740787200), int64(1740960000)
+
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM "+tableDelegationStats).
+					WithArgs(fromDay, toDayEnd).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO "+tableDelegationStats).
+					WithArgs(fromDay, toDayEnd).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - insert error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM " + tableDelegationStats).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO " + tableDelegationStats).
+					WillReturnError(fmt.Errorf("insert error"))
+				mock.ExpectRollback()
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                   db,
+				tableDelegations:     tableDelegations,
+				tableDelegationStats: tableDelegationStats,
+			}
+			tt.wantErr(t, p.RefreshDelegationStats(context.Background(), from, to), "RefreshDelegationStats()")
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_RefreshRewardStats(t *testing.T) {
+	const tableBakerRewards = "app.baker_cycle_rewards"
+	const tableDelegatorRewards = "app.delegator_cycle_rewards"
+
+	tests := []struct {
+		name    string
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				for _, table := range []string{tableBakerRewards, tableDelegatorRewards} {
+					mock.ExpectBegin()
+					mock.ExpectExec("DELETE FROM "+table).
+						WithArgs(700, 701).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectExec("INSERT INTO "+table).
+						WithArgs(700, 701).
+						WillReturnResult(sqlmock.NewResult(0, 4))
+					mock.ExpectCommit()
+				}
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - begin error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectBegin().WillReturnError(fmt.Errorf("begin error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                    db,
+				tableRewards:          "app.rewards",
+				tableBakerRewards:     tableBakerRewards,
+				tableDelegatorRewards: tableDelegatorRewards,
+			}
+			tt.wantErr(t, p.RefreshRewardStats(context.Background(), 700, 701), "RefreshRewardStats()")
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetDailyDelegationStats(t *testing.T) {
+	const tableDelegationStats = "app.daily_delegation_stats"
+
+	tests := []struct {
+		name    string
+		from    int64
+		to      int64
+		baker   model.WalletAddress
+		db      func() (*sqlx.DB, sqlmock.Sqlmock)
+		want    []model.DailyDelegationStats
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "Nominal case",
+			from:  1740823200,
+			to:    1740956400,
+			baker: "tz1baker",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery(`WHERE day >= \$1 AND day <= \$2 AND baker = \$3`).
+					WithArgs(int64(1740787200), int64(1740956400), "tz1baker").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "baker", "new_delegations", "undelegations", "delegated_volume"}).
+						AddRow(1740787200, "tz1baker", 2, 1, 1500000))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			want:    []model.DailyDelegationStats{{Day: 1740787200, Baker: "tz1baker", NewDelegations: 2, Undelegations: 1, DelegatedVolume: 1500000}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - query error",
+			db: func() (*sqlx.DB, sqlmock.Sqlmock) {
+				db, mock, _ := sqlmock.New()
+				mock.ExpectQuery("SELECT day, baker").
+					WithoutArgs().
+					WillReturnError(fmt.Errorf("query error"))
+				return sqlx.NewDb(db, "sqlmock"), mock
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := tt.db()
+			p := &psql{
+				db:                   db,
+				tableDelegationStats: tableDelegationStats,
+			}
+			got, err := p.GetDailyDelegationStats(context.Background(), tt.from, tt.to, tt.baker)
+			if !tt.wantErr(t, err, "GetDailyDelegationStats()") {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_GetBakerRewardStats(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`WHERE cycle >= \$1 AND cycle <= \$2 AND baker = \$3`).
+		WithArgs(700, 710, "tz1baker").
+		WillReturnRows(sqlmock.NewRows([]string{"cycle", "baker", "rewards", "delegators"}).
+			AddRow(700, "tz1baker", 2000000, 3))
+
+	p := &psql{
+		db:                sqlx.NewDb(db, "sqlmock"),
+		tableBakerRewards: "app.baker_cycle_rewards",
+	}
+	got, err := p.GetBakerRewardStats(context.Background(), 700, 710, "tz1baker")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.BakerCycleRewards{{Cycle: 700, Baker: "tz1baker", Rewards: 2000000, Delegators: 3}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_psql_GetDelegatorRewardStats(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(`FROM app.delegator_cycle_rewards\s+WHERE delegator = \$1`).
+		WithArgs("tz1delegator").
+		WillReturnRows(sqlmock.NewRows([]string{"cycle", "delegator", "rewards", "bakers"}).
+			AddRow(701, "tz1delegator", 250000, 1))
+
+	p := &psql{
+		db:                    sqlx.NewDb(db, "sqlmock"),
+		tableDelegatorRewards: "app.delegator_cycle_rewards",
+	}
+	got, err := p.GetDelegatorRewardStats(context.Background(), 0, 0, "tz1delegator")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.DelegatorCycleRewards{{Cycle: 701, Delegator: "tz1delegator", Rewards: 250000, Bakers: 1}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
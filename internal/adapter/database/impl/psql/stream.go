@@ -0,0 +1,81 @@
+package psql
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// delegationsChannel is the notification channel SaveDelegations notifies when its transaction commits.
+const delegationsChannel = "app_delegations"
+
+const (
+	listenerMinReconnect = time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// GetDelegationsAfter returns up to limit delegations matching the filter with an id above afterID, by increasing id.
+// It reads the primary: notifications are sent on its commits, which replicas may not have replayed yet.
+func (p *psql) GetDelegationsAfter(ctx context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDelegationsAfter")
+	defer cancel()
+
+	conditions, args := delegationConditions(filter)
+	args = append(args, afterID)
+	conditions = append(conditions, "id > $"+strconv.Itoa(len(args)))
+	args = append(args, limit)
+
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM ` + p.tableDelegations + `
+		` + whereClause(conditions) + `
+		ORDER BY id ASC
+		LIMIT $` + strconv.Itoa(len(args))
+
+	var delegations []model.Delegation
+	if err := p.db.SelectContext(ctx, &delegations, query, args...); err != nil {
+		return nil, classifyError(ctx, "GetDelegationsAfter", err)
+	}
+	return delegations, nil
+}
+
+// ListenDelegations listens to the notifications of SaveDelegations on a dedicated connection until ctx is done.
+// The channel also receives a value after every reconnection, since notifications may have been missed meanwhile.
+func (p *psql) ListenDelegations(ctx context.Context) (<-chan struct{}, error) {
+	listener := pq.NewListener(p.dsn, listenerMinReconnect, listenerMaxReconnect, nil)
+	signals := make(chan struct{}, 1)
+
+	go func() {
+		defer close(signals)
+		defer func() { _ = listener.Close() }()
+
+		// Listen blocks until the connection is established, which must not delay the caller.
+		listening := make(chan error, 1)
+		go func() { listening <- listener.Listen(delegationsChannel) }()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-listening:
+				if err != nil {
+					return
+				}
+			case _, ok := <-listener.Notify:
+				if !ok {
+					return
+				}
+				select {
+				case signals <- struct{}{}:
+				default: // a signal is already pending, the reader fetches every new row at once
+				}
+			}
+		}
+	}()
+
+	return signals, nil
+}
@@ -0,0 +1,58 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_psql_GetDelegationsAfter(t *testing.T) {
+	const query = `(?s)FROM app.delegations\s+WHERE delegate = \$1 AND id > \$2\s+ORDER BY id ASC\s+LIMIT \$3$`
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, p *psql, primary sqlmock.Sqlmock)
+		want    []model.Delegation
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - read from the primary despite replicas",
+			setup: func(t *testing.T, p *psql, primary sqlmock.Sqlmock) {
+				r, _ := newTestReplica(t, "r1", true)
+				p.replicas = &replicaPool{replicas: []*replica{r}}
+				primary.ExpectQuery(query).WithArgs("tz1baker", int64(41), uint16(500)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "delegator", "delegate", "timestamp", "amount", "level", "created_at"}).
+						AddRow(42, "tz1a", "tz1baker", 86400, 10, 100, createdAt))
+			},
+			want:    []model.Delegation{{ID: 42, Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 86400, Amount: 10, Level: 100, CreatedAt: createdAt}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Error case - database error",
+			setup: func(t *testing.T, p *psql, primary sqlmock.Sqlmock) {
+				primary.ExpectQuery(query).WithArgs("tz1baker", int64(41), uint16(500)).WillReturnError(errors.New("connection refused"))
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			p := &psql{db: sqlx.NewDb(db, "sqlmock"), tableDelegations: schemaTableDelegations}
+			tt.setup(t, p, mock)
+
+			got, err := p.GetDelegationsAfter(context.Background(), 41, model.DelegationFilter{Delegate: "tz1baker"}, 500)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// webhookDeliveryColumns are the columns written when queuing deliveries.
+var webhookDeliveryColumns = []string{"webhook_id", "event_id", "event_type", "payload", "next_attempt_at"}
+
+// webhookColumns are the columns read into a webhookRow, the webhooks created before their API key have none.
+const webhookColumns = "id, url, secret, event_types, baker, delegator, description, created_at, disabled_at, consecutive_failures, COALESCE(api_key_id, 0) AS api_key_id"
+
+// webhookRow is a webhook as stored, with its event types in an array column.
+type webhookRow struct {
+	model.Webhook
+	EventTypes pq.StringArray `db:"event_types"`
+}
+
+// toWebhook returns the webhook of a row.
+func (r webhookRow) toWebhook() model.Webhook {
+	webhook := r.Webhook
+	webhook.EventTypes = make([]model.WebhookEventType, 0, len(r.EventTypes))
+	for _, t := range r.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, model.WebhookEventType(t))
+	}
+	return webhook
+}
+
+// SaveWebhook saves a new webhook, setting its id and creation date.
+func (p *psql) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveWebhook")
+	defer cancel()
+
+	eventTypes := make(pq.StringArray, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	query := `
+		INSERT INTO ` + p.tableWebhooks + ` (url, secret, event_types, baker, delegator, description, api_key_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0))
+		RETURNING id, created_at
+	`
+	row := p.db.QueryRowxContext(ctx, query, webhook.URL, webhook.Secret, eventTypes,
+		webhook.Baker.String(), webhook.Delegator.String(), webhook.Description, webhook.APIKeyID)
+	return classifyError(ctx, "SaveWebhook", row.Scan(&webhook.ID, &webhook.CreatedAt))
+}
+
+// GetWebhooks returns the webhooks of an API key, or every webhook when apiKeyID is 0, disabled ones included,
+// by increasing id. The webhooks are read from the primary, which the delivery worker relies on to stop delivering
+// deleted ones.
+func (p *psql) GetWebhooks(ctx context.Context, apiKeyID int64) ([]model.Webhook, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetWebhooks")
+	defer cancel()
+
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM ` + p.tableWebhooks + `
+		WHERE $1 = 0 OR api_key_id = $1
+		ORDER BY id
+	`
+
+	var rows []webhookRow
+	if err := p.db.SelectContext(ctx, &rows, query, apiKeyID); err != nil {
+		return nil, classifyError(ctx, "GetWebhooks", err)
+	}
+
+	webhooks := make([]model.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, row.toWebhook())
+	}
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook, or sql.ErrNoRows when it is unknown.
+func (p *psql) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetWebhook")
+	defer cancel()
+
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM ` + p.tableWebhooks + `
+		WHERE id = $1
+	`
+
+	var row webhookRow
+	if err := p.db.GetContext(ctx, &row, query, id); err != nil {
+		return nil, classifyError(ctx, "GetWebhook", err)
+	}
+	webhook := row.toWebhook()
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes a webhook, its deliveries and their attempts are deleted in cascade.
+func (p *psql) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, cancel := p.withTimeout(ctx, "DeleteWebhook")
+	defer cancel()
+
+	result, err := p.db.ExecContext(ctx, "DELETE FROM "+p.tableWebhooks+" WHERE id = $1", id)
+	if err != nil {
+		return classifyError(ctx, "DeleteWebhook", err)
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DisableWebhook stops the deliveries to a webhook from the given date, a disabled webhook keeps its first date.
+func (p *psql) DisableWebhook(ctx context.Context, id int64, at time.Time) error {
+	ctx, cancel := p.withTimeout(ctx, "DisableWebhook")
+	defer cancel()
+
+	query := `
+		UPDATE ` + p.tableWebhooks + `
+		SET disabled_at = COALESCE(disabled_at, $2)
+		WHERE id = $1
+	`
+	_, err := p.db.ExecContext(ctx, query, id, at)
+	return classifyError(ctx, "DisableWebhook", err)
+}
+
+// EnableWebhook resumes the deliveries to a disabled webhook and resets its consecutive failures, or returns
+// sql.ErrNoRows when it is unknown.
+func (p *psql) EnableWebhook(ctx context.Context, id int64) error {
+	ctx, cancel := p.withTimeout(ctx, "EnableWebhook")
+	defer cancel()
+
+	query := `
+		UPDATE ` + p.tableWebhooks + `
+		SET disabled_at = NULL, consecutive_failures = 0
+		WHERE id = $1
+	`
+	result, err := p.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return classifyError(ctx, "EnableWebhook", err)
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SaveWebhookDeliveries queues deliveries in a single transaction, skipping the events already queued for their webhook.
+func (p *psql) SaveWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveWebhookDeliveries")
+	defer cancel()
+
+	rows := make([][]interface{}, 0, len(deliveries))
+	for _, d := range deliveries {
+		rows = append(rows, []interface{}{d.WebhookID, d.EventID, d.EventType.String(), string(d.Payload), d.NextAttemptAt})
+	}
+	return classifyError(ctx, "SaveWebhookDeliveries", p.bulkInsert(ctx, p.tableWebhookDeliveries, webhookDeliveryColumns, rows))
+}
+
+// GetDueWebhookDeliveries returns up to limit pending deliveries of enabled webhooks due at now, by next attempt date.
+func (p *psql) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetDueWebhookDeliveries")
+	defer cancel()
+
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempt_count,
+			d.next_attempt_at, d.last_error, d.created_at
+		FROM ` + p.tableWebhookDeliveries + ` d
+		JOIN ` + p.tableWebhooks + ` w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND w.disabled_at IS NULL
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT $2
+	`
+
+	var deliveries []model.WebhookDelivery
+	if err := p.db.SelectContext(ctx, &deliveries, query, now, limit); err != nil {
+		return nil, classifyError(ctx, "GetDueWebhookDeliveries", err)
+	}
+	return deliveries, nil
+}
+
+// SaveWebhookDeliveryAttempt records an attempt with the resulting state of its delivery in a single transaction,
+// and returns the consecutive failures of its webhook, reset by a successful attempt.
+func (p *psql) SaveWebhookDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error) {
+	ctx, cancel := p.withTimeout(ctx, "SaveWebhookDeliveryAttempt")
+	defer cancel()
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, classifyError(ctx, "SaveWebhookDeliveryAttempt", err)
+	}
+
+	var failures int
+	err = func() error {
+		attemptQuery := `
+			INSERT INTO ` + p.tableWebhookAttempts + ` (delivery_id, attempted_at, status_code, error, duration_ms)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		if _, err := tx.ExecContext(ctx, attemptQuery, delivery.ID, attempt.AttemptedAt, attempt.StatusCode, attempt.Error, attempt.DurationMs); err != nil {
+			return err
+		}
+
+		deliveryQuery := `
+			UPDATE ` + p.tableWebhookDeliveries + `
+			SET status = $2, attempt_count = $3, next_attempt_at = $4, last_error = $5
+			WHERE id = $1
+		`
+		if _, err := tx.ExecContext(ctx, deliveryQuery, delivery.ID, string(delivery.Status), delivery.AttemptCount, delivery.NextAttemptAt, delivery.LastError); err != nil {
+			return err
+		}
+
+		webhookQuery := `
+			UPDATE ` + p.tableWebhooks + `
+			SET consecutive_failures = CASE WHEN $2 THEN 0 ELSE consecutive_failures + 1 END
+			WHERE id = $1
+			RETURNING consecutive_failures
+		`
+		err := tx.GetContext(ctx, &failures, webhookQuery, delivery.WebhookID, attempt.Succeeded())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // deleted while delivering
+		}
+		return err
+	}()
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return 0, errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return 0, classifyError(ctx, "SaveWebhookDeliveryAttempt", err)
+	}
+	return failures, classifyError(ctx, "SaveWebhookDeliveryAttempt", tx.Commit())
+}
+
+// GetWebhookDeliveries returns the last limit deliveries of a webhook with their attempts, latest first.
+func (p *psql) GetWebhookDeliveries(ctx context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetWebhookDeliveries")
+	defer cancel()
+
+	deliveriesQuery := `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempt_count, next_attempt_at, last_error, created_at
+		FROM ` + p.tableWebhookDeliveries + `
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+	attemptsQuery := `
+		SELECT delivery_id, attempted_at, status_code, error, duration_ms
+		FROM ` + p.tableWebhookAttempts + `
+		WHERE delivery_id = ANY($1)
+		ORDER BY attempted_at, id
+	`
+
+	var deliveries []model.WebhookDelivery
+	err := p.read(ctx, func(db *sqlx.DB) error {
+		deliveries = nil
+		if err := db.SelectContext(ctx, &deliveries, deliveriesQuery, webhookID, limit); err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		index := make(map[int64]int, len(deliveries))
+		for i, d := range deliveries {
+			ids = append(ids, d.ID)
+			index[d.ID] = i
+		}
+
+		var attempts []model.WebhookDeliveryAttempt
+		if err := db.SelectContext(ctx, &attempts, attemptsQuery, pq.Array(ids)); err != nil {
+			return err
+		}
+		for _, a := range attempts {
+			i := index[a.DeliveryID]
+			deliveries[i].Attempts = append(deliveries[i].Attempts, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(ctx, "GetWebhookDeliveries", err)
+	}
+	return deliveries, nil
+}
+
+// GetSyncCursor returns the position saved by a reader of the indexed data, or sql.ErrNoRows when none was saved.
+func (p *psql) GetSyncCursor(ctx context.Context, source string) (int64, error) {
+	ctx, cancel := p.withTimeout(ctx, "GetSyncCursor")
+	defer cancel()
+
+	var position int64
+	query := `SELECT last_synced_level FROM app.sync_state WHERE source = $1`
+	if err := p.db.GetContext(ctx, &position, query, source); err != nil {
+		return 0, classifyError(ctx, "GetSyncCursor", err)
+	}
+	return position, nil
+}
+
+// SaveSyncCursor saves the position of a reader of the indexed data.
+func (p *psql) SaveSyncCursor(ctx context.Context, source string, position int64) error {
+	ctx, cancel := p.withTimeout(ctx, "SaveSyncCursor")
+	defer cancel()
+
+	query := `
+		INSERT INTO app.sync_state (source, last_synced_level, last_synced_timestamp)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (source) DO UPDATE
+		SET last_synced_level = $2, last_synced_timestamp = CURRENT_TIMESTAMP
+	`
+	_, err := p.db.ExecContext(ctx, query, source, position)
+	return classifyError(ctx, "SaveSyncCursor", err)
+}
@@ -0,0 +1,206 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// newWebhooksTestAdapter returns an adapter over a mocked database with the webhook tables.
+func newWebhooksTestAdapter() (*psql, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return &psql{
+		db:                     sqlx.NewDb(db, "sqlmock"),
+		tableWebhooks:          schemaTableWebhooks,
+		tableWebhookDeliveries: schemaTableWebhookDeliveries,
+		tableWebhookAttempts:   schemaTableWebhookDeliveryAttempts,
+	}, mock
+}
+
+func Test_psql_SaveWebhook(t *testing.T) {
+	p, mock := newWebhooksTestAdapter()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`(?s)INSERT INTO app.webhooks \(url, secret, event_types, baker, delegator, description, api_key_id\)\s+VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, NULLIF\(\$7, 0\)\)\s+RETURNING id, created_at`).
+		WithArgs("https://example.com/hook", "whsec_a", pq.StringArray{"delegation", "rewards"}, "tz1baker", "", "treasury", int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, createdAt))
+
+	webhook := &model.Webhook{
+		URL:         "https://example.com/hook",
+		Secret:      "whsec_a",
+		EventTypes:  []model.WebhookEventType{model.WebhookEventDelegation, model.WebhookEventRewards},
+		Baker:       "tz1baker",
+		Description: "treasury",
+		APIKeyID:    3,
+	}
+	assert.NoError(t, p.SaveWebhook(context.Background(), webhook))
+	assert.Equal(t, int64(7), webhook.ID)
+	assert.Equal(t, createdAt, webhook.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_psql_GetWebhooks(t *testing.T) {
+	p, mock := newWebhooksTestAdapter()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	disabledAt := createdAt.Add(time.Hour)
+
+	mock.ExpectQuery(`(?s)SELECT id, url, secret, event_types, baker, delegator, description, created_at, disabled_at, consecutive_failures, COALESCE\(api_key_id, 0\) AS api_key_id\s+FROM app.webhooks\s+WHERE \$1 = 0 OR api_key_id = \$1\s+ORDER BY id`).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "event_types", "baker", "delegator", "description", "created_at", "disabled_at", "consecutive_failures", "api_key_id"}).
+			AddRow(1, "https://example.com/hook", "whsec_a", "{delegation,undelegation}", "tz1baker", "", "", createdAt, nil, 0, 3).
+			AddRow(2, "https://example.com/other", "whsec_b", "{}", "", "tz1a", "", createdAt, disabledAt, 20, 3))
+
+	webhooks, err := p.GetWebhooks(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Webhook{
+		{ID: 1, URL: "https://example.com/hook", Secret: "whsec_a", EventTypes: []model.WebhookEventType{model.WebhookEventDelegation, model.WebhookEventUndelegation}, Baker: "tz1baker", CreatedAt: createdAt, APIKeyID: 3},
+		{ID: 2, URL: "https://example.com/other", Secret: "whsec_b", EventTypes: []model.WebhookEventType{}, Delegator: "tz1a", CreatedAt: createdAt, DisabledAt: &disabledAt, ConsecutiveFailures: 20, APIKeyID: 3},
+	}, webhooks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_psql_DeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		err     error
+		wantErr func(t *testing.T, err error)
+	}{
+		{
+			name:    "Nominal case",
+			result:  sqlmock.NewResult(0, 1),
+			wantErr: func(t *testing.T, err error) { assert.NoError(t, err) },
+		},
+		{
+			name:    "Error case - unknown webhook",
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: func(t *testing.T, err error) { assert.True(t, errors.Is(err, sql.ErrNoRows), "error = %v", err) },
+		},
+		{
+			name:    "Error case - database error",
+			err:     errors.New("db error"),
+			wantErr: func(t *testing.T, err error) { assert.EqualError(t, err, "db error") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newWebhooksTestAdapter()
+			exec := mock.ExpectExec(`DELETE FROM app.webhooks WHERE id = \$1`).WithArgs(int64(7))
+			if tt.err != nil {
+				exec.WillReturnError(tt.err)
+			} else {
+				exec.WillReturnResult(tt.result)
+			}
+
+			tt.wantErr(t, p.DeleteWebhook(context.Background(), 7))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_EnableWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  sql.Result
+		err     error
+		wantErr func(t *testing.T, err error)
+	}{
+		{
+			name:    "Nominal case",
+			result:  sqlmock.NewResult(0, 1),
+			wantErr: func(t *testing.T, err error) { assert.NoError(t, err) },
+		},
+		{
+			name:    "Error case - unknown webhook",
+			result:  sqlmock.NewResult(0, 0),
+			wantErr: func(t *testing.T, err error) { assert.True(t, errors.Is(err, sql.ErrNoRows), "error = %v", err) },
+		},
+		{
+			name:    "Error case - database error",
+			err:     errors.New("db error"),
+			wantErr: func(t *testing.T, err error) { assert.EqualError(t, err, "db error") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newWebhooksTestAdapter()
+			exec := mock.ExpectExec(`(?s)UPDATE app.webhooks\s+SET disabled_at = NULL, consecutive_failures = 0\s+WHERE id = \$1`).WithArgs(int64(7))
+			if tt.err != nil {
+				exec.WillReturnError(tt.err)
+			} else {
+				exec.WillReturnResult(tt.result)
+			}
+
+			tt.wantErr(t, p.EnableWebhook(context.Background(), 7))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_psql_SaveWebhookDeliveryAttempt(t *testing.T) {
+	attemptedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	delivery := model.WebhookDelivery{
+		ID:            3,
+		WebhookID:     7,
+		Status:        model.WebhookDeliveryPending,
+		AttemptCount:  2,
+		NextAttemptAt: attemptedAt.Add(time.Minute),
+		LastError:     "unexpected status 500",
+	}
+	attempt := model.WebhookDeliveryAttempt{AttemptedAt: attemptedAt, StatusCode: 500, DurationMs: 12}
+
+	tests := []struct {
+		name         string
+		db           func(mock sqlmock.Sqlmock)
+		wantFailures int
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Nominal case - failures counted in the transaction",
+			db: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO app.webhook_delivery_attempts \(delivery_id, attempted_at, status_code, error, duration_ms\)`).
+					WithArgs(int64(3), attemptedAt, 500, "", int64(12)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`(?s)UPDATE app.webhook_deliveries\s+SET status = \$2, attempt_count = \$3, next_attempt_at = \$4, last_error = \$5\s+WHERE id = \$1`).
+					WithArgs(int64(3), "pending", 2, attemptedAt.Add(time.Minute), "unexpected status 500").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`(?s)UPDATE app.webhooks\s+SET consecutive_failures = CASE WHEN \$2 THEN 0 ELSE consecutive_failures \+ 1 END`).
+					WithArgs(int64(7), false).
+					WillReturnRows(sqlmock.NewRows([]string{"consecutive_failures"}).AddRow(4))
+				mock.ExpectCommit()
+			},
+			wantFailures: 4,
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "Error case - rolled back",
+			db: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO app.webhook_delivery_attempts`).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, mock := newWebhooksTestAdapter()
+			tt.db(mock)
+
+			failures, err := p.SaveWebhookDeliveryAttempt(context.Background(), delivery, attempt)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantFailures, failures)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
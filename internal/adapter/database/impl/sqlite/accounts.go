@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetAccount returns an account, or sql.ErrNoRows when the address is unknown.
+func (s *sqlite) GetAccount(ctx context.Context, address model.WalletAddress) (*model.Account, error) {
+	var account model.Account
+	query := `
+		SELECT id, address, alias, type, created_at
+		FROM accounts
+		WHERE address = ?
+	`
+	if err := s.db.GetContext(ctx, &account, query, address); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// GetDelegatorDelegations returns every delegation of a delegator by increasing level.
+func (s *sqlite) GetDelegatorDelegations(ctx context.Context, delegator model.WalletAddress) ([]model.Delegation, error) {
+	var delegations []model.Delegation
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM delegations
+		WHERE delegator = ?
+		ORDER BY level ASC
+	`
+	if err := s.db.SelectContext(ctx, &delegations, query, delegator); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// GetTotalRewards returns the sum of the rewards received by a wallet.
+func (s *sqlite) GetTotalRewards(ctx context.Context, recipient model.WalletAddress) (model.Mutez, error) {
+	var total model.Mutez
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM rewards
+		WHERE recipient_address = ?
+	`
+	err := s.db.GetContext(ctx, &total, query, recipient)
+	return total, err
+}
+
+// GetStakingOperationCounts returns the number of staking operations sent by a wallet, per entrypoint.
+func (s *sqlite) GetStakingOperationCounts(ctx context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error) {
+	var counts []model.StakingOperationCount
+	query := `
+		SELECT entrypoint, COUNT(*) AS count
+		FROM operations
+		WHERE sender_address = ?
+		GROUP BY entrypoint
+		ORDER BY entrypoint
+	`
+	if err := s.db.SelectContext(ctx, &counts, query, wallet); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
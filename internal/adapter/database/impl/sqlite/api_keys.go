@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// apiKeyColumns are the columns read for an API key.
+const apiKeyColumns = "id, name, prefix, key_hash, rate_limit, daily_quota, created_at, revoked_at"
+
+// SaveAPIKey saves a new API key, setting its id and creation date.
+func (s *sqlite) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	createdAt := time.Now().UTC()
+
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, rate_limit, daily_quota, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := s.db.ExecContext(ctx, query, key.Name, key.Prefix, key.Hash, key.RateLimit, key.DailyQuota, createdAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	key.ID, key.CreatedAt = id, createdAt
+	return nil
+}
+
+// GetAPIKeys returns every API key, revoked ones included, by increasing id.
+func (s *sqlite) GetAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	var keys []model.APIKey
+	if err := s.db.SelectContext(ctx, &keys, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id"); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByHash returns the API key of a hash, or sql.ErrNoRows when it is unknown.
+func (s *sqlite) GetAPIKeyByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := s.db.GetContext(ctx, &key, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = ?", hash); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey stops a key from authenticating requests from the given date, a revoked key keeps its first date.
+// It returns sql.ErrNoRows when the key is unknown.
+func (s *sqlite) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	result, err := s.db.ExecContext(ctx, "UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?", at.UTC(), id)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// IncrementAPIKeyUsage counts a request of a key on the UTC day of the given date, and returns the requests of that day.
+func (s *sqlite) IncrementAPIKeyUsage(ctx context.Context, id int64, at time.Time) (int64, error) {
+	query := `
+		INSERT INTO api_key_usage (api_key_id, day, requests)
+		VALUES (?, ?, 1)
+		ON CONFLICT (api_key_id, day) DO UPDATE SET requests = requests + 1
+		RETURNING requests
+	`
+	var requests int64
+	err := s.db.QueryRowxContext(ctx, query, id, at.UTC().Format("2006-01-02")).Scan(&requests)
+	return requests, err
+}
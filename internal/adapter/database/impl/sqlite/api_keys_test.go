@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_sqlite_APIKeys(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	key := &model.APIKey{Name: "explorer", Prefix: "tzd_1a2b3c4d", Hash: model.HashAPIKey("tzd_1a2b3c4d"), RateLimit: 10}
+	assert.NoError(t, s.SaveAPIKey(ctx, key))
+	assert.Equal(t, int64(1), key.ID)
+
+	got, err := s.GetAPIKeyByHash(ctx, key.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, "explorer", got.Name)
+	_, err = s.GetAPIKeyByHash(ctx, "unknown")
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+
+	// Requests are counted per UTC day.
+	for i, want := range []int64{1, 2} {
+		requests, err := s.IncrementAPIKeyUsage(ctx, key.ID, now)
+		assert.NoError(t, err, i)
+		assert.Equal(t, want, requests)
+	}
+	requests, err := s.IncrementAPIKeyUsage(ctx, key.ID, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), requests)
+
+	// A revoked key keeps its first revocation date.
+	assert.NoError(t, s.RevokeAPIKey(ctx, key.ID, now))
+	assert.NoError(t, s.RevokeAPIKey(ctx, key.ID, now.Add(time.Hour)))
+	assert.True(t, errors.Is(s.RevokeAPIKey(ctx, 2, now), sql.ErrNoRows))
+
+	keys, err := s.GetAPIKeys(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, now, *keys[0].RevokedAt)
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetBakers returns the staking pools with their current delegators and their activity over the period of filter, by page.
+func (s *sqlite) GetBakers(ctx context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	query, args := bakersQuery(filter)
+	if filter.Search != "" {
+		query += ` WHERE pool.name LIKE ? ESCAPE '\'`
+		args = append(args, likePattern(filter.Search))
+	}
+
+	query += `
+		` + bakerOrderClause(filter) + `
+		LIMIT ? OFFSET ?`
+	args = append(args, limit, (int(page)-1)*int(limit))
+
+	var bakers []model.Baker
+	if err := s.db.SelectContext(ctx, &bakers, query, args...); err != nil {
+		return nil, err
+	}
+	return bakers, nil
+}
+
+// GetBaker returns a staking pool with its activity between fromDate and toDate, or sql.ErrNoRows when it is unknown.
+func (s *sqlite) GetBaker(ctx context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error) {
+	query, args := bakersQuery(model.BakerFilter{FromDate: fromDate, ToDate: toDate})
+	query += " WHERE pool.address = ?"
+	args = append(args, address.String())
+
+	var baker model.Baker
+	if err := s.db.GetContext(ctx, &baker, query, args...); err != nil {
+		return nil, err
+	}
+	return &baker, nil
+}
+
+// bakerOrderClause returns the ORDER BY clause of the baker directory, the address breaking ties.
+func bakerOrderClause(filter model.BakerFilter) string {
+	column := "delegators"
+	switch filter.SortField() {
+	case model.BakerSortDelegatedVolume:
+		column = "delegated_volume"
+	case model.BakerSortRewards:
+		column = "rewards"
+	case model.BakerSortNetInflow:
+		column = "net_inflow"
+	}
+
+	direction := "DESC"
+	if filter.SortOrder() == model.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	return "ORDER BY " + column + " " + direction + ", pool.address"
+}
+
+// bakersQuery returns the query joining the staking pools to their current delegators, to their daily delegation
+// statistics and to the rewards they paid over the period of filter, left open for a WHERE clause.
+func bakersQuery(filter model.BakerFilter) (string, []interface{}) {
+	var (
+		statsConditions   []string
+		rewardsConditions []string
+		statsArgs         []interface{}
+		rewardsArgs       []interface{}
+	)
+
+	if filter.FromDate > 0 {
+		statsConditions = append(statsConditions, "day >= ?")
+		rewardsConditions = append(rewardsConditions, "timestamp >= ?")
+		statsArgs = append(statsArgs, filter.FromDate)
+		rewardsArgs = append(rewardsArgs, filter.FromDate)
+	}
+
+	if filter.ToDate > 0 {
+		statsConditions = append(statsConditions, "day < ?")
+		rewardsConditions = append(rewardsConditions, "timestamp < ?")
+		statsArgs = append(statsArgs, filter.ToDate)
+		rewardsArgs = append(rewardsArgs, filter.ToDate)
+	}
+
+	query := `
+		SELECT pool.address, pool.name AS alias,
+			COALESCE(cd.delegators, 0) AS delegators,
+			COALESCE(cd.staking_balance, 0) AS staking_balance,
+			COALESCE(ds.delegated_volume, 0) AS delegated_volume,
+			COALESCE(r.rewards, 0) AS rewards,
+			COALESCE(ds.new_delegations, 0) AS new_delegations,
+			COALESCE(ds.undelegations, 0) AS undelegations,
+			COALESCE(ds.new_delegations, 0) - COALESCE(ds.undelegations, 0) AS net_inflow
+		FROM staking_pools pool
+		LEFT JOIN (
+			SELECT baker, COUNT(*) AS delegators, SUM(balance) AS staking_balance
+			FROM current_delegations
+			WHERE baker <> ''
+			GROUP BY baker
+		) cd ON cd.baker = pool.address
+		LEFT JOIN (
+			SELECT baker, SUM(new_delegations) AS new_delegations, SUM(undelegations) AS undelegations,
+				SUM(delegated_volume) AS delegated_volume
+			FROM daily_delegation_stats
+			` + whereClause(statsConditions) + `
+			GROUP BY baker
+		) ds ON ds.baker = pool.address
+		LEFT JOIN (
+			SELECT source_address, SUM(amount) AS rewards
+			FROM rewards
+			` + whereClause(rewardsConditions) + `
+			GROUP BY source_address
+		) r ON r.source_address = pool.address
+	`
+
+	return query, append(statsArgs, rewardsArgs...)
+}
+
+// likePattern returns the LIKE pattern matching the values which contain search, escaping its wildcards.
+func likePattern(search string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(search) + "%"
+}
@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SaveCurrentDelegations upserts the current delegation state of delegators, in a single transaction.
+// A state is only replaced by a more recent one, so replaying a batch is harmless.
+func (s *sqlite) SaveCurrentDelegations(ctx context.Context, delegations []model.CurrentDelegation) error {
+	if len(delegations) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, 0, len(delegations))
+	for _, d := range delegations {
+		rows = append(rows, []interface{}{d.Delegator, d.Baker, d.SinceLevel, d.Balance})
+	}
+
+	query := `
+		INSERT INTO current_delegations (delegator, baker, since_level, balance)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (delegator) DO UPDATE
+		SET baker = excluded.baker, since_level = excluded.since_level, balance = excluded.balance
+		WHERE current_delegations.since_level < excluded.since_level
+	`
+	return s.execRows(ctx, "current_delegations", query, rows)
+}
+
+// GetBakerForDelegatorAtLevel returns the baker of a delegator at the end of asOfLevel, or from its current delegation
+// state when asOfLevel is 0. The baker is empty when the delegator was undelegated, sql.ErrNoRows when it is unknown.
+func (s *sqlite) GetBakerForDelegatorAtLevel(ctx context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error) {
+	query := `SELECT baker FROM current_delegations WHERE delegator = ?1`
+	args := []interface{}{delegator.String()}
+
+	if asOfLevel > 0 {
+		query = `SELECT baker FROM (` + delegationsAt("?2") + `) snapshot WHERE rank = 1 AND delegator = ?1`
+		args = append(args, asOfLevel)
+	}
+
+	var baker model.WalletAddress
+	if err := s.db.GetContext(ctx, &baker, query, args...); err != nil {
+		return "", err
+	}
+	return baker, nil
+}
+
+// GetBakerDelegators returns the delegators delegated to baker, by decreasing balance.
+// With a positive asOfLevel, the delegation set is reconstructed from the history as it was at the end of that level.
+func (s *sqlite) GetBakerDelegators(ctx context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error) {
+	if page < 1 {
+		page = 1
+	}
+	offset := (int(page) - 1) * int(limit)
+
+	query := `
+		SELECT delegator, baker, since_level, balance
+		FROM current_delegations
+		WHERE baker = ?1
+		ORDER BY balance DESC, delegator
+		LIMIT ?2 OFFSET ?3
+	`
+	args := []interface{}{baker.String(), limit, offset}
+
+	if asOfLevel > 0 {
+		query = `
+			SELECT delegator, baker, since_level, balance
+			FROM (` + delegationsAt("?4") + `) snapshot
+			WHERE rank = 1 AND baker = ?1
+			ORDER BY balance DESC, delegator
+			LIMIT ?2 OFFSET ?3
+		`
+		args = append(args, asOfLevel)
+	}
+
+	var delegators []model.CurrentDelegation
+	if err := s.db.SelectContext(ctx, &delegators, query, args...); err != nil {
+		return nil, err
+	}
+	return delegators, nil
+}
+
+// delegationsAt returns the query of the delegations up to level, ranked from the latest one of each delegator, whose
+// rank 1 is the delegation state of the delegator at the end of level. The level is an SQL expression.
+func delegationsAt(level string) string {
+	return `
+		SELECT delegator, delegate AS baker, level AS since_level, amount AS balance,
+			ROW_NUMBER() OVER (PARTITION BY delegator ORDER BY level DESC) AS rank
+		FROM delegations
+		WHERE level <= ` + level + `
+	`
+}
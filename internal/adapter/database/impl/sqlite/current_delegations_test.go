@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_sqlite_CurrentDelegations(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveCurrentDelegations(ctx, []model.CurrentDelegation{
+		{Delegator: "tz1a", Baker: "tz1baker1", SinceLevel: 100, Balance: 10},
+		{Delegator: "tz1b", Baker: "tz1baker1", SinceLevel: 110, Balance: 30},
+		{Delegator: "tz1c", Baker: "tz1baker1", SinceLevel: 120, Balance: 20},
+		{Delegator: "tz1d", Baker: "tz1baker2", SinceLevel: 130, Balance: 40},
+	}))
+	assert.NoError(t, s.SaveCurrentDelegations(ctx, []model.CurrentDelegation{
+		// tz1a moves to another baker, tz1c undelegates.
+		{Delegator: "tz1a", Baker: "tz1baker2", SinceLevel: 200, Balance: 15},
+		{Delegator: "tz1c", Baker: "", SinceLevel: 210, Balance: 20},
+		// Older than the saved state of tz1b, ignored.
+		{Delegator: "tz1b", Baker: "tz1baker2", SinceLevel: 50, Balance: 1},
+	}))
+
+	delegators, err := s.GetActiveDelegators(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WalletAddress{"tz1a", "tz1b", "tz1d"}, delegators)
+
+	got, err := s.GetBakerDelegators(ctx, "tz1baker2", 0, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CurrentDelegation{
+		{Delegator: "tz1d", Baker: "tz1baker2", SinceLevel: 130, Balance: 40},
+		{Delegator: "tz1a", Baker: "tz1baker2", SinceLevel: 200, Balance: 15},
+	}, got)
+
+	got, err = s.GetBakerDelegators(ctx, "tz1baker2", 0, 2, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, model.WalletAddress("tz1a"), got[0].Delegator)
+	}
+
+	got, err = s.GetBakerDelegators(ctx, "tz1baker1", 0, 1, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, model.WalletAddress("tz1b"), got[0].Delegator)
+	}
+}
+
+func Test_sqlite_GetBakerDelegators_AsOfLevel(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveDelegations(ctx, []*model.Delegation{
+		{ID: 1, Delegator: "tz1a", Delegate: "tz1baker1", Amount: 10, Level: 100, Timestamp: 1000},
+		{ID: 2, Delegator: "tz1b", Delegate: "tz1baker1", Amount: 30, Level: 110, Timestamp: 1100},
+		{ID: 3, Delegator: "tz1a", Delegate: "tz1baker2", Amount: 15, Level: 200, Timestamp: 2000},
+		{ID: 4, Delegator: "tz1b", Delegate: "", Amount: 30, Level: 210, Timestamp: 2100},
+	}))
+
+	got, err := s.GetBakerDelegators(ctx, "tz1baker1", 150, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CurrentDelegation{
+		{Delegator: "tz1b", Baker: "tz1baker1", SinceLevel: 110, Balance: 30},
+		{Delegator: "tz1a", Baker: "tz1baker1", SinceLevel: 100, Balance: 10},
+	}, got)
+
+	got, err = s.GetBakerDelegators(ctx, "tz1baker1", 200, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CurrentDelegation{
+		{Delegator: "tz1b", Baker: "tz1baker1", SinceLevel: 110, Balance: 30},
+	}, got)
+
+	got, err = s.GetBakerDelegators(ctx, "tz1baker1", 210, 1, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func Test_sqlite_GetBakerForDelegatorAtLevel(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveDelegations(ctx, []*model.Delegation{
+		{ID: 1, Delegator: "tz1a", Delegate: "tz1baker1", Amount: 10, Level: 100, Timestamp: 1000},
+		{ID: 2, Delegator: "tz1a", Delegate: "tz1baker2", Amount: 15, Level: 200, Timestamp: 2000},
+	}))
+	assert.NoError(t, s.SaveCurrentDelegations(ctx, []model.CurrentDelegation{
+		{Delegator: "tz1a", Baker: "tz1baker2", SinceLevel: 200, Balance: 15},
+	}))
+
+	baker, err := s.GetBakerForDelegatorAtLevel(ctx, "tz1a", 150)
+	assert.NoError(t, err)
+	assert.Equal(t, model.WalletAddress("tz1baker1"), baker)
+
+	baker, err = s.GetBakerForDelegatorAtLevel(ctx, "tz1a", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, model.WalletAddress("tz1baker2"), baker)
+
+	_, err = s.GetBakerForDelegatorAtLevel(ctx, "tz1a", 50)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_sqlite_Cycles(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	_, err := s.GetCycle(ctx, 700)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.NoError(t, s.SaveCycles(ctx, []model.Cycle{{Index: 700, FirstLevel: 901, LastLevel: 990}}))
+	assert.NoError(t, s.SaveCycles(ctx, []model.Cycle{{Index: 700, FirstLevel: 901, LastLevel: 1000}}))
+
+	got, err := s.GetCycle(ctx, 700)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.Cycle{Index: 700, FirstLevel: 901, LastLevel: 1000}, got)
+
+	missing, err := s.GetMissingCycles(ctx, 698, 701)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{698, 699, 701}, missing)
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SaveCycles saves the levels of cycles, replacing the ones already saved.
+func (s *sqlite) SaveCycles(ctx context.Context, cycles []model.Cycle) error {
+	if len(cycles) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, 0, len(cycles))
+	for _, c := range cycles {
+		rows = append(rows, []interface{}{c.Index, c.FirstLevel, c.LastLevel})
+	}
+
+	query := `
+		INSERT INTO cycles (cycle, first_level, last_level)
+		VALUES (?, ?, ?)
+		ON CONFLICT (cycle) DO UPDATE
+		SET first_level = excluded.first_level, last_level = excluded.last_level
+	`
+	return s.execRows(ctx, "cycles", query, rows)
+}
+
+// GetCycle returns the levels of a cycle, or sql.ErrNoRows when the cycle is not synced.
+func (s *sqlite) GetCycle(ctx context.Context, cycle int) (*model.Cycle, error) {
+	var c model.Cycle
+	query := `
+		SELECT cycle, first_level, last_level
+		FROM cycles
+		WHERE cycle = ?
+	`
+	if err := s.db.GetContext(ctx, &c, query, cycle); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetMissingCycles returns the cycles from fromCycle to toCycle, both included, whose levels are not saved.
+func (s *sqlite) GetMissingCycles(ctx context.Context, fromCycle, toCycle int) ([]int, error) {
+	var cycles []int
+	query := `
+		WITH RECURSIVE series(cycle) AS (
+			SELECT ? WHERE ? <= ?
+			UNION ALL
+			SELECT cycle + 1 FROM series WHERE cycle < ?
+		)
+		SELECT series.cycle
+		FROM series
+		WHERE NOT EXISTS (SELECT 1 FROM cycles c WHERE c.cycle = series.cycle)
+		ORDER BY series.cycle
+	`
+	if err := s.db.SelectContext(ctx, &cycles, query, fromCycle, fromCycle, toCycle, toCycle); err != nil {
+		return nil, err
+	}
+	return cycles, nil
+}
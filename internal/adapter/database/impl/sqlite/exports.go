@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// StreamDelegations calls fn for every delegation matching the filter, in its order, as the rows are read.
+func (s *sqlite) StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error {
+	conditions, args := delegationConditions(filter)
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM delegations
+		` + whereClause(conditions) + `
+		` + delegationOrderClause(filter)
+
+	return s.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var d model.Delegation
+		if err := rows.StructScan(&d); err != nil {
+			return err
+		}
+		return fn(d)
+	})
+}
+
+// StreamOperations calls fn for every operation matching the filters, by decreasing timestamp, as the rows are read.
+func (s *sqlite) StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error {
+	conditions, args := operationConditions(fromDate, toDate, operationType, wallet, baker)
+	query := `
+		SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status
+		FROM operations
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC`
+
+	return s.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var o model.Operation
+		if err := rows.StructScan(&o); err != nil {
+			return err
+		}
+		return fn(o)
+	})
+}
+
+// StreamRewards calls fn for every reward matching the filters, by decreasing timestamp, as the rows are read.
+func (s *sqlite) StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error {
+	conditions, args := rewardConditions(fromDate, toDate, wallet, baker)
+	query := `
+		SELECT id, recipient_address, source_address, cycle, amount, timestamp
+		FROM rewards
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC`
+
+	return s.stream(ctx, query, args, func(rows *sqlx.Rows) error {
+		var r model.Reward
+		if err := rows.StructScan(&r); err != nil {
+			return err
+		}
+		return fn(r)
+	})
+}
+
+// stream runs query and calls scan for every row as it is read.
+func (s *sqlite) stream(ctx context.Context, query string, args []interface{}, scan func(rows *sqlx.Rows) error) error {
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
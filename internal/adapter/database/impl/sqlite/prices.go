@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// SavePrices saves daily prices, replacing the ones already saved for their day and currency.
+func (s *sqlite) SavePrices(ctx context.Context, prices []model.Price) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	rows := make([][]interface{}, 0, len(prices))
+	for _, p := range prices {
+		rows = append(rows, []interface{}{p.Day, p.Currency.String(), p.Price})
+	}
+
+	query := `
+		INSERT INTO prices (day, currency, price)
+		VALUES (?, ?, ?)
+		ON CONFLICT (currency, day) DO UPDATE
+		SET price = excluded.price
+	`
+	return s.execRows(ctx, "prices", query, rows)
+}
+
+// GetPrices returns the daily prices of a currency for the UTC days between fromDay and toDay, excluded, by increasing day.
+func (s *sqlite) GetPrices(ctx context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error) {
+	query := `
+		SELECT day, currency, price
+		FROM prices
+		WHERE currency = ? AND day >= ? AND day < ?
+		ORDER BY day
+	`
+
+	var prices []model.Price
+	if err := s.db.SelectContext(ctx, &prices, query, currency.String(), fromDay, toDay); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
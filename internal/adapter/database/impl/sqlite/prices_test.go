@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_sqlite_Prices(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SavePrices(ctx, []model.Price{
+		{Day: model.SecondsPerDay, Currency: "EUR", Price: "0.9"},
+		{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.95"},
+		{Day: 3 * model.SecondsPerDay, Currency: "EUR", Price: "1.1"},
+		{Day: 2 * model.SecondsPerDay, Currency: "USD", Price: "1.05"},
+	}))
+	// A new import replaces the price of a day.
+	assert.NoError(t, s.SavePrices(ctx, []model.Price{{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.12345678901"}}))
+
+	prices, err := s.GetPrices(ctx, "EUR", 2*model.SecondsPerDay, 4*model.SecondsPerDay)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Price{
+		{Day: 2 * model.SecondsPerDay, Currency: "EUR", Price: "0.12345678901"},
+		{Day: 3 * model.SecondsPerDay, Currency: "EUR", Price: "1.1"},
+	}, prices)
+
+	prices, err = s.GetPrices(ctx, "CHF", 0, 4*model.SecondsPerDay)
+	assert.NoError(t, err)
+	assert.Empty(t, prices)
+}
@@ -0,0 +1,183 @@
+-- SQLite schema of the tezos-delegation-service, applied at startup by the sqlite adapter.
+-- Amounts are integer mutez and timestamps are unix seconds, as in the PostgreSQL schema.
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    address TEXT NOT NULL UNIQUE,
+    alias TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS delegations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    delegator TEXT NOT NULL,
+    delegate TEXT NOT NULL DEFAULT '',
+    timestamp INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (delegator, level)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegations_timestamp_id ON delegations (timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator_timestamp_id ON delegations (delegator, timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_delegations_delegate_timestamp_id ON delegations (delegate, timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_delegations_level_id ON delegations (level, id);
+CREATE INDEX IF NOT EXISTS idx_delegations_amount_id ON delegations (amount, id);
+
+CREATE TABLE IF NOT EXISTS operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    sender_address TEXT NOT NULL,
+    contract_address TEXT NOT NULL,
+    entrypoint TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    block TEXT NOT NULL,
+    timestamp INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_timestamp_id ON operations (timestamp DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS rewards (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    recipient_address TEXT NOT NULL,
+    source_address TEXT NOT NULL,
+    cycle INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    timestamp INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (recipient_address, source_address, cycle)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rewards_timestamp_id ON rewards (timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_rewards_recipient_source_timestamp_id ON rewards (recipient_address, source_address, timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_rewards_source_timestamp ON rewards (source_address, timestamp);
+
+CREATE TABLE IF NOT EXISTS staking_pools (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    address TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL DEFAULT '',
+    staking_token TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS sync_state (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL UNIQUE,
+    last_synced_level INTEGER NOT NULL,
+    last_synced_timestamp DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Aggregates refreshed by the job after each synced batch, days are the unix timestamps of the start of UTC days.
+CREATE TABLE IF NOT EXISTS daily_delegation_stats (
+    day INTEGER NOT NULL,
+    baker TEXT NOT NULL,
+    new_delegations INTEGER NOT NULL DEFAULT 0,
+    undelegations INTEGER NOT NULL DEFAULT 0,
+    delegated_volume INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, baker)
+);
+
+CREATE TABLE IF NOT EXISTS baker_cycle_rewards (
+    cycle INTEGER NOT NULL,
+    baker TEXT NOT NULL,
+    rewards INTEGER NOT NULL DEFAULT 0,
+    delegators INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (cycle, baker)
+);
+
+CREATE TABLE IF NOT EXISTS delegator_cycle_rewards (
+    cycle INTEGER NOT NULL,
+    delegator TEXT NOT NULL,
+    rewards INTEGER NOT NULL DEFAULT 0,
+    bakers INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (cycle, delegator)
+);
+
+-- Current delegation state of every delegator, an undelegated delegator keeps its row with an empty baker.
+CREATE TABLE IF NOT EXISTS current_delegations (
+    delegator TEXT PRIMARY KEY,
+    baker TEXT NOT NULL DEFAULT '',
+    since_level INTEGER NOT NULL,
+    balance INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_current_delegations_baker ON current_delegations (baker, balance DESC);
+
+CREATE TABLE IF NOT EXISTS cycles (
+    cycle INTEGER PRIMARY KEY,
+    first_level INTEGER NOT NULL,
+    last_level INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegations_delegator_level ON delegations (delegator, level DESC);
+
+-- Prices are exact decimal strings, SQLite would round a NUMERIC to a float.
+CREATE TABLE IF NOT EXISTS prices (
+    day INTEGER NOT NULL,
+    currency TEXT NOT NULL,
+    price TEXT NOT NULL,
+    PRIMARY KEY (currency, day)
+);
+
+-- Event types are a comma-separated list, empty for every type.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT NOT NULL DEFAULT '',
+    baker TEXT NOT NULL DEFAULT '',
+    delegator TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    disabled_at DATETIME,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    api_key_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id INTEGER NOT NULL,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    delivery_id INTEGER NOT NULL,
+    attempted_at DATETIME NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts (delivery_id);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    rate_limit INTEGER NOT NULL DEFAULT 0,
+    daily_quota INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    revoked_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id INTEGER NOT NULL,
+    day TEXT NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key_id, day)
+);
@@ -0,0 +1,494 @@
+package sqlite
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+//go:embed schema.sql
+var schema string
+
+// Config represents the SQLite database configuration.
+type Config struct {
+	// Path is the database file path, or ":memory:" for a database living as long as the process.
+	Path string `mapstructure:"path"`
+}
+
+// sqlite implements database.Adapter on top of a local SQLite database.
+type sqlite struct {
+	db *sqlx.DB
+}
+
+// New opens the SQLite database and applies the embedded schema.
+func New(cfg Config) (database.Adapter, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("SQLite database path is required")
+	}
+
+	db, err := sqlx.Connect("sqlite3", cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+
+	// SQLite allows a single writer, and each connection to ":memory:" opens its own database.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to apply SQLite schema: %w", err)
+	}
+
+	return &sqlite{db: db}, nil
+}
+
+// Ping checks the database connection.
+func (s *sqlite) Ping() error {
+	return s.db.Ping()
+}
+
+// GetDelegations returns delegations by page or after a cursor, with optional filters, order and maxDelegationID filter.
+func (s *sqlite) GetDelegations(ctx context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error) {
+	var delegations []model.Delegation
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > 200 {
+		limit = 200
+	}
+
+	offset := (page - 1) * uint32(limit)
+
+	year := filter.Year
+	conditions, args := delegationConditions(filter)
+
+	if maxDelegationID > 0 && page > 1 && (year == 0 || int(year) == time.Now().Year()) {
+		conditions = append(conditions, "id <= ?")
+		args = append(args, maxDelegationID)
+	}
+
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition)
+		args = append(args, cursor.Timestamp, cursor.ID)
+	}
+
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM delegations
+		` + whereClause(conditions) + `
+		` + delegationOrderClause(filter) + `
+		LIMIT ? OFFSET ?
+	`
+	args = append(args, limit, offset)
+
+	if err := s.db.SelectContext(ctx, &delegations, query, args...); err != nil {
+		return nil, err
+	}
+
+	return delegations, nil
+}
+
+// delegationConditions returns the conditions of the delegation filters.
+func delegationConditions(filter model.DelegationFilter) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		conditions = append(conditions, condition+" ?")
+		args = append(args, arg)
+	}
+
+	if filter.Year > 0 {
+		add("timestamp >=", time.Date(int(filter.Year), 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+		add("timestamp <", time.Date(int(filter.Year)+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+	}
+	if filter.Delegator != "" {
+		add("delegator =", filter.Delegator)
+	}
+	if filter.Delegate != "" {
+		add("delegate =", filter.Delegate)
+	}
+	switch filter.Kind {
+	case model.DelegationKindDelegation:
+		conditions = append(conditions, "delegate <> ''")
+	case model.DelegationKindUndelegation:
+		conditions = append(conditions, "delegate = ''")
+	}
+	if filter.FromLevel > 0 {
+		add("level >=", filter.FromLevel)
+	}
+	if filter.ToLevel > 0 {
+		add("level <=", filter.ToLevel)
+	}
+	if filter.FromDate > 0 {
+		add("timestamp >=", filter.FromDate)
+	}
+	if filter.ToDate > 0 {
+		add("timestamp <", filter.ToDate)
+	}
+	if filter.MinAmount != nil {
+		add("amount >=", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		add("amount <=", *filter.MaxAmount)
+	}
+
+	return conditions, args
+}
+
+// delegationOrderClause returns the ORDER BY clause of the delegation sort, ties broken by id in the same direction.
+func delegationOrderClause(filter model.DelegationFilter) string {
+	column := "timestamp"
+	switch filter.SortField() {
+	case model.DelegationSortLevel:
+		column = "level"
+	case model.DelegationSortAmount:
+		column = "amount"
+	}
+
+	direction := "DESC"
+	if filter.SortOrder() == model.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	return "ORDER BY " + column + " " + direction + ", id " + direction
+}
+
+// GetLatestDelegation returns the latest delegation from the database.
+func (s *sqlite) GetLatestDelegation(ctx context.Context) (*model.Delegation, error) {
+	var delegation model.Delegation
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM delegations
+		ORDER BY level DESC
+		LIMIT 1
+	`
+	if err := s.db.GetContext(ctx, &delegation, query); err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
+
+// GetHighestBlockLevel returns the highest block level in the database.
+func (s *sqlite) GetHighestBlockLevel(ctx context.Context) (uint64, error) {
+	var level uint64
+	err := s.db.GetContext(ctx, &level, "SELECT COALESCE(MAX(level), 0) FROM delegations")
+	return level, err
+}
+
+// GetOperations returns operations with pagination and optional operationType, wallet and baker filters.
+func (s *sqlite) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error) {
+	var operations []model.Operation
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > 200 {
+		limit = 200
+	}
+
+	offset := (page - 1) * limit
+
+	conditions, args := operationConditions(fromDate, toDate, operationType, wallet, baker)
+
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition)
+		args = append(args, cursor.Timestamp, cursor.ID)
+	}
+
+	query := `
+		SELECT id, sender_address, contract_address, entrypoint, amount, block, timestamp, status
+		FROM operations
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	args = append(args, limit, offset)
+
+	if err := s.db.SelectContext(ctx, &operations, query, args...); err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+// GetRewards returns rewards for a given wallet and baker within a date range, by page or after a cursor.
+func (s *sqlite) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error) {
+	var rewards []model.Reward
+
+	if page < 1 || cursor != nil {
+		page = 1
+	}
+
+	if limit == 0 {
+		limit = 50
+	} else if limit > model.MaxRewardsLimit {
+		limit = model.MaxRewardsLimit
+	}
+
+	offset := int(page-1) * int(limit)
+
+	conditions, args := rewardConditions(fromDate, toDate, wallet, baker)
+
+	if cursor != nil {
+		conditions = append(conditions, keysetCondition)
+		args = append(args, cursor.Timestamp, cursor.ID)
+	}
+
+	query := `
+		SELECT id, recipient_address, source_address, cycle, amount, timestamp
+		FROM rewards
+		` + whereClause(conditions) + `
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	args = append(args, limit, offset)
+
+	if err := s.db.SelectContext(ctx, &rewards, query, args...); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+// operationConditions returns the conditions of the operation filters.
+func operationConditions(fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		conditions = append(conditions, condition+" ?")
+		args = append(args, arg)
+	}
+
+	if fromDate > 0 {
+		add("timestamp >=", fromDate)
+	}
+	if toDate > 0 {
+		add("timestamp <", toDate)
+	}
+	if operationType != "" {
+		add("entrypoint =", operationType.String())
+	}
+	if wallet != "" {
+		add("sender_address =", wallet.String())
+	}
+	if baker != "" {
+		add("contract_address =", baker.String())
+	}
+
+	return conditions, args
+}
+
+// rewardConditions returns the conditions of the reward filters.
+func rewardConditions(fromDate, toDate int64, wallet, baker model.WalletAddress) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	add := func(condition string, arg interface{}) {
+		conditions = append(conditions, condition+" ?")
+		args = append(args, arg)
+	}
+
+	if fromDate > 0 {
+		add("timestamp >=", fromDate)
+	}
+	if toDate > 0 {
+		add("timestamp <", toDate)
+	}
+	if wallet != "" {
+		add("recipient_address =", wallet.String())
+	}
+	if baker != "" {
+		add("source_address =", baker.String())
+	}
+
+	return conditions, args
+}
+
+// GetLastSyncedRewardCycle returns the last synced reward cycle.
+func (s *sqlite) GetLastSyncedRewardCycle(ctx context.Context) (int, error) {
+	var cycle int
+	query := `
+		SELECT COALESCE(last_synced_level, 0) AS cycle
+		FROM sync_state
+		WHERE source = 'rewards'
+		LIMIT 1
+	`
+	if err := s.db.GetContext(ctx, &cycle, query); err != nil {
+		return 0, err
+	}
+	return cycle, nil
+}
+
+// GetActiveDelegators returns the delegators currently delegated to a baker.
+func (s *sqlite) GetActiveDelegators(ctx context.Context) ([]model.WalletAddress, error) {
+	var delegators []model.WalletAddress
+	query := `
+		SELECT delegator AS address
+		FROM current_delegations
+		WHERE baker <> ''
+		ORDER BY delegator
+	`
+	if err := s.db.SelectContext(ctx, &delegators, query); err != nil {
+		return nil, err
+	}
+	return delegators, nil
+}
+
+// SaveAccount saves a single account to the database.
+func (s *sqlite) SaveAccount(ctx context.Context, account model.Account) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR IGNORE INTO accounts (address, alias, type) VALUES (?, ?, ?)`,
+		account.Address, account.Alias, account.Type)
+	return err
+}
+
+// SaveAccounts saves multiple accounts to the database.
+func (s *sqlite) SaveAccounts(ctx context.Context, accounts []model.Account) error {
+	rows := make([][]interface{}, 0, len(accounts))
+	for _, account := range accounts {
+		rows = append(rows, []interface{}{account.Address, account.Alias, account.Type})
+	}
+	return s.insertRows(ctx, "accounts", []string{"address", "alias", "type"}, rows)
+}
+
+// SaveDelegation saves a delegation to the database.
+func (s *sqlite) SaveDelegation(ctx context.Context, delegation *model.Delegation) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR IGNORE INTO delegations (delegator, delegate, timestamp, amount, level) VALUES (?, ?, ?, ?, ?)`,
+		delegation.Delegator, delegation.Delegate, delegation.Timestamp, delegation.Amount, delegation.Level)
+	return err
+}
+
+// SaveDelegations saves multiple delegations to the database.
+func (s *sqlite) SaveDelegations(ctx context.Context, delegations []*model.Delegation) error {
+	rows := make([][]interface{}, 0, len(delegations))
+	for _, delegation := range delegations {
+		rows = append(rows, []interface{}{delegation.Delegator, delegation.Delegate, delegation.Timestamp, delegation.Amount, delegation.Level})
+	}
+	return s.insertRows(ctx, "delegations", []string{"delegator", "delegate", "timestamp", "amount", "level"}, rows)
+}
+
+// SaveStakingPools saves multiple staking pools to the database.
+func (s *sqlite) SaveStakingPools(ctx context.Context, stakingPools []model.StakingPool) error {
+	rows := make([][]interface{}, 0, len(stakingPools))
+	for _, stakingPool := range stakingPools {
+		rows = append(rows, []interface{}{stakingPool.Address, stakingPool.Name, stakingPool.StakingToken})
+	}
+	return s.insertRows(ctx, "staking_pools", []string{"address", "name", "staking_token"}, rows)
+}
+
+// SaveRewards saves multiple rewards to the database.
+func (s *sqlite) SaveRewards(ctx context.Context, rewards []model.Reward) error {
+	rows := make([][]interface{}, 0, len(rewards))
+	for _, reward := range rewards {
+		rows = append(rows, []interface{}{reward.RecipientAddress, reward.SourceAddress, reward.Cycle, reward.Amount, reward.Timestamp})
+	}
+	return s.insertRows(ctx, "rewards", []string{"recipient_address", "source_address", "cycle", "amount", "timestamp"}, rows)
+}
+
+// SaveLastSyncedRewardCycle saves the last synced reward cycle.
+func (s *sqlite) SaveLastSyncedRewardCycle(ctx context.Context, cycle int) error {
+	query := `
+		INSERT INTO sync_state (source, last_synced_level, last_synced_timestamp)
+		VALUES ('rewards', ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (source) DO UPDATE
+		SET last_synced_level = excluded.last_synced_level, last_synced_timestamp = CURRENT_TIMESTAMP
+	`
+	_, err := s.db.ExecContext(ctx, query, cycle)
+	return err
+}
+
+// EnsurePartitions does nothing, SQLite tables are not partitioned.
+func (s *sqlite) EnsurePartitions(_ context.Context, _ time.Time) error {
+	return nil
+}
+
+// CheckSchemaVersion always succeeds, the embedded schema is applied when the adapter is created.
+func (s *sqlite) CheckSchemaVersion(_ context.Context) error {
+	return nil
+}
+
+// Close closes the database connection.
+func (s *sqlite) Close() error {
+	if s.db != nil {
+		return s.db.Close()
+	}
+	return nil
+}
+
+// insertRows inserts rows into table in a single transaction with one prepared statement.
+// Rows conflicting with existing ones are skipped.
+func (s *sqlite) insertRows(ctx context.Context, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	query := `INSERT OR IGNORE INTO ` + table + ` (` + strings.Join(columns, ", ") + `) VALUES (` + placeholders + `)`
+
+	return s.execRows(ctx, table, query, rows)
+}
+
+// execRows executes the prepared query once per row of table, in a single transaction.
+func (s *sqlite) execRows(ctx context.Context, table, query string, rows [][]interface{}) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = func() error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = stmt.Close()
+		}()
+
+		for _, row := range rows {
+			if _, err := stmt.ExecContext(ctx, row...); err != nil {
+				return fmt.Errorf("error inserting row into %s: %w", table, err)
+			}
+		}
+		return nil
+	}()
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// keysetCondition selects the rows after a cursor in decreasing (timestamp, id) order, bound to the cursor timestamp and id.
+const keysetCondition = "(timestamp, id) < (?, ?)"
+
+// whereClause joins conditions into a WHERE clause, or returns an empty string when there is none.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
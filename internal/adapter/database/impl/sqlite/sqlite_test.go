@@ -0,0 +1,332 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func newTestAdapter(t *testing.T) *sqlite {
+	t.Helper()
+
+	adapter, err := New(Config{Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = adapter.Close()
+	})
+
+	return adapter.(*sqlite)
+}
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Nominal case",
+			cfg:     Config{Path: ":memory:"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Error case - empty path",
+			cfg:     Config{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - unreachable path",
+			cfg:     Config{Path: "/nonexistent/dir/tezos.db"},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.cfg)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.NoError(t, got.Ping())
+				assert.NoError(t, got.Close())
+			}
+		})
+	}
+}
+
+func Test_sqlite_SaveDelegations_GetDelegations(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	ts2024 := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Unix()
+	ts2025 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	err := s.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1baker", Timestamp: ts2024, Amount: 1250000, Level: 100},
+		{Delegator: "tz1b", Delegate: "tz1baker", Timestamp: ts2025, Amount: 42, Level: 200},
+		// Duplicate (delegator, level) is skipped.
+		{Delegator: "tz1a", Delegate: "tz1other", Timestamp: ts2024, Amount: 1, Level: 100},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, s.SaveDelegation(ctx, &model.Delegation{Delegator: "tz1c", Timestamp: ts2025 + 1, Amount: 0, Level: 300}))
+
+	tests := []struct {
+		name           string
+		page           uint32
+		limit          uint16
+		filter         model.DelegationFilter
+		cursor         *model.Cursor
+		wantDelegators []model.WalletAddress
+	}{
+		{
+			name:           "Nominal case",
+			wantDelegators: []model.WalletAddress{"tz1c", "tz1b", "tz1a"},
+		},
+		{
+			name:           "Filter by year",
+			filter:         model.DelegationFilter{Year: 2024},
+			wantDelegators: []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:           "Second page",
+			page:           2,
+			limit:          2,
+			wantDelegators: []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:           "Filter by delegate and level range",
+			filter:         model.DelegationFilter{Delegate: "tz1baker", FromLevel: 150, ToLevel: 250},
+			wantDelegators: []model.WalletAddress{"tz1b"},
+		},
+		{
+			name:           "Filter by undelegation kind",
+			filter:         model.DelegationFilter{Kind: model.DelegationKindUndelegation},
+			wantDelegators: []model.WalletAddress{"tz1c"},
+		},
+		{
+			name:           "Sort by decreasing amount",
+			filter:         model.DelegationFilter{Sort: model.DelegationSortAmount},
+			wantDelegators: []model.WalletAddress{"tz1a", "tz1b", "tz1c"},
+		},
+		{
+			name:           "After a cursor",
+			limit:          2,
+			cursor:         model.NewCursor(ts2025, 2),
+			wantDelegators: []model.WalletAddress{"tz1a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetDelegations(ctx, tt.page, tt.limit, tt.filter, 0, tt.cursor)
+			assert.NoError(t, err)
+
+			delegators := make([]model.WalletAddress, 0, len(got))
+			for _, d := range got {
+				delegators = append(delegators, d.Delegator)
+			}
+			assert.Equal(t, tt.wantDelegators, delegators)
+		})
+	}
+
+	latest, err := s.GetLatestDelegation(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(300), latest.Level)
+	}
+
+	level, err := s.GetHighestBlockLevel(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300), level)
+
+	// The active delegators are those of the current delegation state, which the sync saves along with the delegations.
+	all, err := s.GetDelegations(ctx, 1, 50, model.DelegationFilter{}, 0, nil)
+	assert.NoError(t, err)
+	saved := make([]*model.Delegation, 0, len(all))
+	for i := range all {
+		saved = append(saved, &all[i])
+	}
+	assert.NoError(t, s.SaveCurrentDelegations(ctx, model.CurrentDelegationsFromDelegations(saved)))
+
+	delegators, err := s.GetActiveDelegators(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.WalletAddress{"tz1a", "tz1b"}, delegators)
+}
+
+func Test_sqlite_GetRewards(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 1, Amount: 10, Timestamp: 1000},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1other", Cycle: 2, Amount: 20, Timestamp: 2000},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1baker", Cycle: 2, Amount: 30, Timestamp: 3000},
+	}))
+
+	tests := []struct {
+		name       string
+		fromDate   int64
+		toDate     int64
+		wallet     model.WalletAddress
+		baker      model.WalletAddress
+		wantAmount []model.Mutez
+	}{
+		{
+			name:       "Nominal case",
+			wantAmount: []model.Mutez{30, 20, 10},
+		},
+		{
+			name:       "Filter by wallet and baker",
+			wallet:     "tz1a",
+			baker:      "tz1baker",
+			wantAmount: []model.Mutez{10},
+		},
+		{
+			name:       "Filter by date range",
+			fromDate:   1500,
+			toDate:     2500,
+			wantAmount: []model.Mutez{20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetRewards(ctx, tt.fromDate, tt.toDate, tt.wallet, tt.baker, 1, 50, nil)
+			assert.NoError(t, err)
+
+			amounts := make([]model.Mutez, 0, len(got))
+			for _, r := range got {
+				amounts = append(amounts, r.Amount)
+			}
+			assert.Equal(t, tt.wantAmount, amounts)
+		})
+	}
+}
+
+func Test_sqlite_GetOperations(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	_, err := s.db.Exec(`INSERT INTO operations (sender_address, contract_address, entrypoint, amount, block, timestamp, status)
+		VALUES ('tz1a', 'KT1pool', 'stake', 100, 'B1', 1000, 'applied'),
+		       ('tz1b', 'KT1pool', 'unstake', 200, 'B2', 2000, 'applied')`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		operationType model.OperationType
+		wallet        model.WalletAddress
+		wantSenders   []model.WalletAddress
+	}{
+		{
+			name:        "Nominal case",
+			wantSenders: []model.WalletAddress{"tz1b", "tz1a"},
+		},
+		{
+			name:          "Filter by operation type",
+			operationType: model.OperationType("stake"),
+			wantSenders:   []model.WalletAddress{"tz1a"},
+		},
+		{
+			name:        "Filter by wallet",
+			wallet:      "tz1b",
+			wantSenders: []model.WalletAddress{"tz1b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetOperations(ctx, 0, 0, 1, 50, tt.operationType, tt.wallet, "", nil)
+			assert.NoError(t, err)
+
+			senders := make([]model.WalletAddress, 0, len(got))
+			for _, o := range got {
+				senders = append(senders, o.SenderAddress)
+			}
+			assert.Equal(t, tt.wantSenders, senders)
+		})
+	}
+}
+
+func Test_sqlite_LastSyncedRewardCycle(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	_, err := s.GetLastSyncedRewardCycle(ctx)
+	assert.Error(t, err, "no sync state saved yet")
+
+	assert.NoError(t, s.SaveLastSyncedRewardCycle(ctx, 700))
+	assert.NoError(t, s.SaveLastSyncedRewardCycle(ctx, 701))
+
+	cycle, err := s.GetLastSyncedRewardCycle(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 701, cycle)
+}
+
+func Test_sqlite_SaveAccounts_SaveStakingPools(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveAccount(ctx, model.Account{Address: "tz1a", Type: "user"}))
+	assert.NoError(t, s.SaveAccounts(ctx, []model.Account{
+		{Address: "tz1a", Type: "user"},
+		{Address: "tz1baker", Alias: "Baker", Type: "delegate"},
+	}))
+	assert.NoError(t, s.SaveStakingPools(ctx, []model.StakingPool{{Address: "KT1pool", Name: "Pool", StakingToken: "XTZ"}}))
+	assert.NoError(t, s.SaveAccounts(ctx, nil))
+
+	var accounts int
+	assert.NoError(t, s.db.Get(&accounts, "SELECT COUNT(*) FROM accounts"))
+	assert.Equal(t, 2, accounts)
+
+	var pools int
+	assert.NoError(t, s.db.Get(&pools, "SELECT COUNT(*) FROM staking_pools"))
+	assert.Equal(t, 1, pools)
+
+	assert.NoError(t, s.EnsurePartitions(ctx, time.Now()))
+}
+
+func Test_sqlite_AccountProfile(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveAccounts(ctx, []model.Account{{Address: "tz1a", Alias: "Alice", Type: model.AccountTypeUser}}))
+	assert.NoError(t, s.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1new", Timestamp: 2000, Amount: 10, Level: 200},
+		{Delegator: "tz1a", Delegate: "tz1old", Timestamp: 1000, Amount: 10, Level: 100},
+		{Delegator: "tz1b", Delegate: "tz1old", Timestamp: 1000, Amount: 10, Level: 100},
+	}))
+	assert.NoError(t, s.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1old", Cycle: 1, Amount: 10, Timestamp: 1000},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1new", Cycle: 2, Amount: 20, Timestamp: 2000},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1old", Cycle: 1, Amount: 30, Timestamp: 1000},
+	}))
+	_, err := s.db.Exec(`INSERT INTO operations (sender_address, contract_address, entrypoint, amount, block, timestamp, status)
+		VALUES ('tz1a', 'KT1pool', 'stake', 100, 'B1', 1000, 'applied'),
+		       ('tz1a', 'KT1pool', 'stake', 100, 'B2', 2000, 'applied'),
+		       ('tz1a', 'KT1pool', 'unstake', 100, 'B3', 3000, 'applied')`)
+	assert.NoError(t, err)
+
+	account, err := s.GetAccount(ctx, "tz1a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Alice", account.Alias)
+	}
+	_, err = s.GetAccount(ctx, "tz1unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	delegations, err := s.GetDelegatorDelegations(ctx, "tz1a")
+	assert.NoError(t, err)
+	if assert.Len(t, delegations, 2) {
+		assert.Equal(t, int64(100), delegations[0].Level)
+		assert.Equal(t, model.WalletAddress("tz1new"), delegations[1].Delegate)
+	}
+
+	total, err := s.GetTotalRewards(ctx, "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, model.Mutez(30), total)
+
+	counts, err := s.GetStakingOperationCounts(ctx, "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.StakingOperationCount{{Entrypoint: "stake", Count: 2}, {Entrypoint: "unstake", Count: 1}}, counts)
+}
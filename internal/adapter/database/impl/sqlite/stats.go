@@ -0,0 +1,184 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// RefreshDelegationStats recomputes the daily delegation statistics of the UTC days between fromDate and toDate, included.
+func (s *sqlite) RefreshDelegationStats(ctx context.Context, fromDate, toDate int64) error {
+	// A delegator leaves the baker of its previous delegation when it undelegates or delegates to another baker.
+	insert := `
+		INSERT INTO daily_delegation_stats (day, baker, new_delegations, undelegations, delegated_volume)
+		SELECT day, baker, SUM(new_delegations), SUM(undelegations), SUM(delegated_volume)
+		FROM (
+			SELECT timestamp - timestamp % 86400 AS day, delegate AS baker,
+				1 AS new_delegations, 0 AS undelegations, amount AS delegated_volume
+			FROM delegations
+			WHERE timestamp >= ?1 AND timestamp < ?2 AND delegate <> ''
+			UNION ALL
+			SELECT day, prev_delegate, 0, 1, 0
+			FROM (
+				SELECT d.timestamp - d.timestamp % 86400 AS day, d.delegate,
+					(SELECT prev.delegate FROM delegations prev
+					WHERE prev.delegator = d.delegator AND prev.level < d.level
+					ORDER BY prev.level DESC LIMIT 1) AS prev_delegate
+				FROM delegations d
+				WHERE d.timestamp >= ?1 AND d.timestamp < ?2
+			)
+			WHERE prev_delegate <> '' AND prev_delegate <> delegate
+		)
+		GROUP BY day, baker
+	`
+
+	return s.refresh(ctx, "DELETE FROM daily_delegation_stats WHERE day >= ?1 AND day < ?2", insert,
+		model.DayStart(fromDate), model.DayStart(toDate)+model.SecondsPerDay)
+}
+
+// RefreshRewardStats recomputes the per-cycle reward statistics of the cycles between fromCycle and toCycle, included.
+func (s *sqlite) RefreshRewardStats(ctx context.Context, fromCycle, toCycle int) error {
+	err := s.refresh(ctx, "DELETE FROM baker_cycle_rewards WHERE cycle >= ?1 AND cycle <= ?2", `
+		INSERT INTO baker_cycle_rewards (cycle, baker, rewards, delegators)
+		SELECT cycle, source_address, SUM(amount), COUNT(DISTINCT recipient_address)
+		FROM rewards
+		WHERE cycle >= ?1 AND cycle <= ?2
+		GROUP BY cycle, source_address
+	`, fromCycle, toCycle)
+	if err != nil {
+		return err
+	}
+
+	return s.refresh(ctx, "DELETE FROM delegator_cycle_rewards WHERE cycle >= ?1 AND cycle <= ?2", `
+		INSERT INTO delegator_cycle_rewards (cycle, delegator, rewards, bakers)
+		SELECT cycle, recipient_address, SUM(amount), COUNT(DISTINCT source_address)
+		FROM rewards
+		WHERE cycle >= ?1 AND cycle <= ?2
+		GROUP BY cycle, recipient_address
+	`, fromCycle, toCycle)
+}
+
+// refresh replaces the aggregates selected by del with the ones computed by insert, in a transaction.
+func (s *sqlite) refresh(ctx context.Context, del, insert string, args ...interface{}) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, del, args...); err == nil {
+		_, err = tx.ExecContext(ctx, insert, args...)
+	}
+
+	if err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDailyDelegationStats returns the daily delegation statistics within a date range, optionally of a baker.
+func (s *sqlite) GetDailyDelegationStats(ctx context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if fromDate > 0 {
+		conditions = append(conditions, "day >= ?")
+		args = append(args, model.DayStart(fromDate))
+	}
+
+	if toDate > 0 {
+		conditions = append(conditions, "day <= ?")
+		args = append(args, toDate)
+	}
+
+	if baker != "" {
+		conditions = append(conditions, "baker = ?")
+		args = append(args, baker.String())
+	}
+
+	query := `
+		SELECT day, baker, new_delegations, undelegations, delegated_volume
+		FROM daily_delegation_stats
+		` + whereClause(conditions) + `
+		ORDER BY day, baker
+	`
+
+	var stats []model.DailyDelegationStats
+	if err := s.db.SelectContext(ctx, &stats, query, args...); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetBakerRewardStats returns the per-cycle rewards of bakers within a cycle range, optionally of a baker.
+func (s *sqlite) GetBakerRewardStats(ctx context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error) {
+	conditions, args := cycleConditions(fromCycle, toCycle)
+	if baker != "" {
+		conditions = append(conditions, "baker = ?")
+		args = append(args, baker.String())
+	}
+
+	query := `
+		SELECT cycle, baker, rewards, delegators
+		FROM baker_cycle_rewards
+		` + whereClause(conditions) + `
+		ORDER BY cycle, baker
+	`
+
+	var stats []model.BakerCycleRewards
+	if err := s.db.SelectContext(ctx, &stats, query, args...); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetDelegatorRewardStats returns the per-cycle rewards of delegators within a cycle range, optionally of a delegator.
+func (s *sqlite) GetDelegatorRewardStats(ctx context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error) {
+	conditions, args := cycleConditions(fromCycle, toCycle)
+	if delegator != "" {
+		conditions = append(conditions, "delegator = ?")
+		args = append(args, delegator.String())
+	}
+
+	query := `
+		SELECT cycle, delegator, rewards, bakers
+		FROM delegator_cycle_rewards
+		` + whereClause(conditions) + `
+		ORDER BY cycle, delegator
+	`
+
+	var stats []model.DelegatorCycleRewards
+	if err := s.db.SelectContext(ctx, &stats, query, args...); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// cycleConditions returns the conditions and arguments of a cycle range, where 0 leaves a bound open.
+func cycleConditions(fromCycle, toCycle int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if fromCycle > 0 {
+		conditions = append(conditions, "cycle >= ?")
+		args = append(args, fromCycle)
+	}
+
+	if toCycle > 0 {
+		conditions = append(conditions, "cycle <= ?")
+		args = append(args, toCycle)
+	}
+
+	return conditions, args
+}
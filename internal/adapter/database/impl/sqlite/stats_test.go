@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_sqlite_DelegationStats(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	// 2025-03-01T00:00:00Z
+	const day1 = int64(1740787200)
+	const day2 = day1 + model.SecondsPerDay
+
+	assert.NoError(t, s.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1baker1", Amount: 100, Timestamp: day1 + 60, Level: 1},
+		{Delegator: "tz1a", Delegate: "tz1baker2", Amount: 200, Timestamp: day2 + 60, Level: 2},
+		{Delegator: "tz1c", Delegate: "tz1baker1", Amount: 50, Timestamp: day2 + 120, Level: 3},
+		{Delegator: "tz1c", Delegate: "", Amount: 50, Timestamp: day2 + 180, Level: 4},
+	}))
+
+	// Refreshing twice must not count the delegations twice.
+	assert.NoError(t, s.RefreshDelegationStats(ctx, day1+60, day2+180))
+	assert.NoError(t, s.RefreshDelegationStats(ctx, day2, day2))
+
+	got, err := s.GetDailyDelegationStats(ctx, day1, day2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.DailyDelegationStats{
+		{Day: day1, Baker: "tz1baker1", NewDelegations: 1, DelegatedVolume: 100},
+		{Day: day2, Baker: "tz1baker1", NewDelegations: 1, Undelegations: 2, DelegatedVolume: 50},
+		{Day: day2, Baker: "tz1baker2", NewDelegations: 1, DelegatedVolume: 200},
+	}, got)
+
+	got, err = s.GetDailyDelegationStats(ctx, day2, 0, "tz1baker2")
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func Test_sqlite_RewardStats(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker1", Cycle: 700, Amount: 10, Timestamp: 1000},
+		{RecipientAddress: "tz1c", SourceAddress: "tz1baker1", Cycle: 700, Amount: 20, Timestamp: 1000},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker2", Cycle: 700, Amount: 5, Timestamp: 1000},
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker2", Cycle: 701, Amount: 7, Timestamp: 2000},
+	}))
+	assert.NoError(t, s.RefreshRewardStats(ctx, 700, 701))
+
+	bakers, err := s.GetBakerRewardStats(ctx, 700, 700, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.BakerCycleRewards{
+		{Cycle: 700, Baker: "tz1baker1", Rewards: 30, Delegators: 2},
+		{Cycle: 700, Baker: "tz1baker2", Rewards: 5, Delegators: 1},
+	}, bakers)
+
+	delegators, err := s.GetDelegatorRewardStats(ctx, 0, 0, "tz1a")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.DelegatorCycleRewards{
+		{Cycle: 700, Delegator: "tz1a", Rewards: 15, Bakers: 2},
+		{Cycle: 701, Delegator: "tz1a", Rewards: 7, Bakers: 1},
+	}, delegators)
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// GetDelegationsAfter returns up to limit delegations matching the filter with an id above afterID, by increasing id.
+func (s *sqlite) GetDelegationsAfter(ctx context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error) {
+	conditions, args := delegationConditions(filter)
+	conditions = append(conditions, "id > ?")
+	args = append(args, afterID, limit)
+
+	query := `
+		SELECT id, delegator, delegate, timestamp, amount, level, created_at
+		FROM delegations
+		` + whereClause(conditions) + `
+		ORDER BY id ASC
+		LIMIT ?`
+
+	var delegations []model.Delegation
+	if err := s.db.SelectContext(ctx, &delegations, query, args...); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// ListenDelegations is not supported: SQLite cannot notify the other processes sharing its file.
+func (s *sqlite) ListenDelegations(_ context.Context) (<-chan struct{}, error) {
+	return nil, database.ErrListenUnsupported
+}
@@ -0,0 +1,33 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_sqlite_GetDelegationsAfter(t *testing.T) {
+	ctx := context.Background()
+	s := newTestAdapter(t)
+
+	assert.NoError(t, s.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1pool1", Timestamp: model.SecondsPerDay, Level: 10},
+		{Delegator: "tz1b", Delegate: "", Timestamp: model.SecondsPerDay, Level: 10},
+		{Delegator: "tz1c", Delegate: "", Timestamp: model.SecondsPerDay, Level: 11},
+	}))
+
+	delegations, err := s.GetDelegationsAfter(ctx, 1, model.DelegationFilter{Kind: model.DelegationKindUndelegation}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, delegations, 1) {
+		assert.Equal(t, int64(2), delegations[0].ID)
+		assert.Equal(t, model.WalletAddress("tz1b"), delegations[0].Delegator)
+	}
+
+	_, err = s.ListenDelegations(ctx)
+	assert.True(t, errors.Is(err, database.ErrListenUnsupported))
+}
@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// webhookColumns are the columns read into a webhookRow.
+const webhookColumns = "id, url, secret, event_types, baker, delegator, description, created_at, disabled_at, consecutive_failures, api_key_id"
+
+// webhookRow is a webhook as stored, with its event types in a comma-separated column.
+type webhookRow struct {
+	model.Webhook
+	EventTypes string `db:"event_types"`
+}
+
+// toWebhook returns the webhook of a row.
+func (r webhookRow) toWebhook() model.Webhook {
+	webhook := r.Webhook
+	webhook.EventTypes = []model.WebhookEventType{}
+	if r.EventTypes != "" {
+		for _, t := range strings.Split(r.EventTypes, ",") {
+			webhook.EventTypes = append(webhook.EventTypes, model.WebhookEventType(t))
+		}
+	}
+	return webhook
+}
+
+// SaveWebhook saves a new webhook, setting its id and creation date.
+func (s *sqlite) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+	createdAt := time.Now().UTC()
+
+	query := `
+		INSERT INTO webhooks (url, secret, event_types, baker, delegator, description, created_at, api_key_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := s.db.ExecContext(ctx, query, webhook.URL, webhook.Secret, strings.Join(eventTypes, ","),
+		webhook.Baker.String(), webhook.Delegator.String(), webhook.Description, createdAt, webhook.APIKeyID)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	webhook.ID, webhook.CreatedAt = id, createdAt
+	return nil
+}
+
+// GetWebhooks returns the webhooks of an API key, or every webhook when apiKeyID is 0, disabled ones included,
+// by increasing id.
+func (s *sqlite) GetWebhooks(ctx context.Context, apiKeyID int64) ([]model.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE ? = 0 OR api_key_id = ?
+		ORDER BY id
+	`
+
+	var rows []webhookRow
+	if err := s.db.SelectContext(ctx, &rows, query, apiKeyID, apiKeyID); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]model.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, row.toWebhook())
+	}
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook, or sql.ErrNoRows when it is unknown.
+func (s *sqlite) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE id = ?
+	`
+
+	var row webhookRow
+	if err := s.db.GetContext(ctx, &row, query, id); err != nil {
+		return nil, err
+	}
+	webhook := row.toWebhook()
+	return &webhook, nil
+}
+
+// DeleteWebhook deletes a webhook with its deliveries and their attempts, or returns sql.ErrNoRows when it is unknown.
+func (s *sqlite) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.inTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+		if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+			return sql.ErrNoRows
+		}
+
+		query := `
+			DELETE FROM webhook_delivery_attempts
+			WHERE delivery_id IN (SELECT id FROM webhook_deliveries WHERE webhook_id = ?)
+		`
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE webhook_id = ?", id)
+		return err
+	})
+}
+
+// DisableWebhook stops the deliveries to a webhook from the given date, a disabled webhook keeps its first date.
+func (s *sqlite) DisableWebhook(ctx context.Context, id int64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE webhooks SET disabled_at = COALESCE(disabled_at, ?) WHERE id = ?", at.UTC(), id)
+	return err
+}
+
+// EnableWebhook resumes the deliveries to a disabled webhook and resets its consecutive failures, or returns
+// sql.ErrNoRows when it is unknown.
+func (s *sqlite) EnableWebhook(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, "UPDATE webhooks SET disabled_at = NULL, consecutive_failures = 0 WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err == nil && updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// SaveWebhookDeliveries queues deliveries, skipping the events already queued for their webhook.
+func (s *sqlite) SaveWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	createdAt := time.Now().UTC()
+	rows := make([][]interface{}, 0, len(deliveries))
+	for _, d := range deliveries {
+		rows = append(rows, []interface{}{d.WebhookID, d.EventID, d.EventType.String(), []byte(d.Payload), d.NextAttemptAt.UTC(), createdAt})
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+	return s.execRows(ctx, "webhook_deliveries", query, rows)
+}
+
+// GetDueWebhookDeliveries returns up to limit pending deliveries of enabled webhooks due at now, by next attempt date.
+func (s *sqlite) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempt_count,
+			d.next_attempt_at, d.last_error, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= ? AND w.disabled_at IS NULL
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT ?
+	`
+
+	var deliveries []model.WebhookDelivery
+	if err := s.db.SelectContext(ctx, &deliveries, query, now.UTC(), limit); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// SaveWebhookDeliveryAttempt records an attempt with the resulting state of its delivery in a single transaction,
+// and returns the consecutive failures of its webhook, reset by a successful attempt.
+func (s *sqlite) SaveWebhookDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error) {
+	var failures int
+	err := s.inTx(ctx, func(tx *sqlx.Tx) error {
+		attemptQuery := `
+			INSERT INTO webhook_delivery_attempts (delivery_id, attempted_at, status_code, error, duration_ms)
+			VALUES (?, ?, ?, ?, ?)
+		`
+		if _, err := tx.ExecContext(ctx, attemptQuery, delivery.ID, attempt.AttemptedAt.UTC(), attempt.StatusCode, attempt.Error, attempt.DurationMs); err != nil {
+			return err
+		}
+
+		deliveryQuery := `
+			UPDATE webhook_deliveries
+			SET status = ?, attempt_count = ?, next_attempt_at = ?, last_error = ?
+			WHERE id = ?
+		`
+		if _, err := tx.ExecContext(ctx, deliveryQuery, string(delivery.Status), delivery.AttemptCount, delivery.NextAttemptAt.UTC(), delivery.LastError, delivery.ID); err != nil {
+			return err
+		}
+
+		webhookQuery := `
+			UPDATE webhooks
+			SET consecutive_failures = CASE WHEN ? THEN 0 ELSE consecutive_failures + 1 END
+			WHERE id = ?
+			RETURNING consecutive_failures
+		`
+		err := tx.GetContext(ctx, &failures, webhookQuery, attempt.Succeeded(), delivery.WebhookID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // deleted while delivering
+		}
+		return err
+	})
+	return failures, err
+}
+
+// GetWebhookDeliveries returns the last limit deliveries of a webhook with their attempts, latest first.
+func (s *sqlite) GetWebhookDeliveries(ctx context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error) {
+	query := `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempt_count, next_attempt_at, last_error, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`
+
+	var deliveries []model.WebhookDelivery
+	if err := s.db.SelectContext(ctx, &deliveries, query, webhookID, limit); err != nil || len(deliveries) == 0 {
+		return deliveries, err
+	}
+
+	ids := make([]interface{}, 0, len(deliveries))
+	index := make(map[int64]int, len(deliveries))
+	for i, d := range deliveries {
+		ids = append(ids, d.ID)
+		index[d.ID] = i
+	}
+
+	attemptsQuery := `
+		SELECT delivery_id, attempted_at, status_code, error, duration_ms
+		FROM webhook_delivery_attempts
+		WHERE delivery_id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)
+		ORDER BY attempted_at, id
+	`
+	var attempts []model.WebhookDeliveryAttempt
+	if err := s.db.SelectContext(ctx, &attempts, attemptsQuery, ids...); err != nil {
+		return nil, err
+	}
+	for _, a := range attempts {
+		i := index[a.DeliveryID]
+		deliveries[i].Attempts = append(deliveries[i].Attempts, a)
+	}
+	return deliveries, nil
+}
+
+// GetSyncCursor returns the position saved by a reader of the indexed data, or sql.ErrNoRows when none was saved.
+func (s *sqlite) GetSyncCursor(ctx context.Context, source string) (int64, error) {
+	var position int64
+	if err := s.db.GetContext(ctx, &position, "SELECT last_synced_level FROM sync_state WHERE source = ?", source); err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// SaveSyncCursor saves the position of a reader of the indexed data.
+func (s *sqlite) SaveSyncCursor(ctx context.Context, source string, position int64) error {
+	query := `
+		INSERT INTO sync_state (source, last_synced_level, last_synced_timestamp)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (source) DO UPDATE
+		SET last_synced_level = excluded.last_synced_level, last_synced_timestamp = CURRENT_TIMESTAMP
+	`
+	_, err := s.db.ExecContext(ctx, query, source, position)
+	return err
+}
+
+// inTx runs fn in a transaction, committed when fn succeeds and rolled back otherwise.
+func (s *sqlite) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		if errRollBack := tx.Rollback(); errRollBack != nil {
+			return errors.New("query execution error: " + err.Error() + ", rollback error: " + errRollBack.Error())
+		}
+		return err
+	}
+	return tx.Commit()
+}
@@ -2,17 +2,48 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/tezos-delegation-service/internal/model"
 )
 
+// ErrSchemaVersionMismatch is returned when the database schema version differs from the one expected by the code.
+var ErrSchemaVersionMismatch = errors.New("database schema version mismatch")
+
+// ErrListenUnsupported is returned by ListenDelegations when the repository cannot notify other processes of its writes.
+var ErrListenUnsupported = errors.New("database notifications unsupported")
+
+// PoolStatsProvider is implemented by the adapters backed by connection pools.
+type PoolStatsProvider interface {
+	// PoolStats returns the statistics of every connection pool, keyed by pool name.
+	PoolStats() map[string]sql.DBStats
+}
+
 // Adapter defines the interface for delegation repository operations
 type Adapter interface {
 	// Ping checks the connection to the database.
 	Ping() error
 
-	// GetDelegations returns delegations with pagination and optional year and maxDelegationID filters.
-	GetDelegations(ctx context.Context, page uint32, limit, year uint16, maxDelegationID uint64) ([]model.Delegation, error)
+	// GetDelegations returns delegations by page or after a cursor, with optional filters, order and maxDelegationID filter.
+	GetDelegations(ctx context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error)
+
+	// StreamDelegations calls fn for every delegation matching the filter, in its order, as they are read from the repository.
+	StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error
+
+	// StreamOperations calls fn for every operation matching the filters, by decreasing timestamp, as they are read from the repository.
+	StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error
+
+	// StreamRewards calls fn for every reward matching the filters, by decreasing timestamp, as they are read from the repository.
+	StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error
+
+	// GetDelegationsAfter returns up to limit delegations matching the filter with an id above afterID, by increasing id.
+	GetDelegationsAfter(ctx context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error)
+
+	// ListenDelegations returns a channel receiving a value every time SaveDelegations commits, closed once ctx is done.
+	// It returns ErrListenUnsupported when the repository cannot be listened to, its readers must poll it instead.
+	ListenDelegations(ctx context.Context) (<-chan struct{}, error)
 
 	// GetLatestDelegation returns the latest delegation from the repository.
 	GetLatestDelegation(ctx context.Context) (*model.Delegation, error)
@@ -20,20 +51,53 @@ type Adapter interface {
 	// GetHighestBlockLevel returns the highest block level in the repository.
 	GetHighestBlockLevel(ctx context.Context) (uint64, error)
 
-	// GetOperations returns operations with pagination and optional filters.
-	GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress) ([]model.Operation, error)
+	// GetOperations returns operations by page or after a cursor, with optional filters.
+	// The dates are unix seconds, fromDate included and toDate excluded, where 0 leaves a bound open.
+	GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error)
 
-	// GetRewards returns rewards for a given wallet and baker within a date range.
-	GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress) ([]model.Reward, error)
+	// GetRewards returns rewards for a given wallet and baker within a date range, by page or after a cursor.
+	// The dates are unix seconds, fromDate included and toDate excluded, where 0 leaves a bound open.
+	GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error)
 
 	// GetLastSyncedRewardCycle returns the last synced reward cycle.
 	GetLastSyncedRewardCycle(ctx context.Context) (int, error)
 
-	// GetActiveDelegators returns a list of active delegators.
+	// GetActiveDelegators returns the delegators currently delegated to a baker.
 	GetActiveDelegators(ctx context.Context) ([]model.WalletAddress, error)
 
-	// GetBakerForDelegatorAtCycle returns the baker for a delegator at a specific cycle.
-	GetBakerForDelegatorAtCycle(ctx context.Context, delegator model.WalletAddress, cycle int) (model.WalletAddress, error)
+	// GetBakerDelegators returns the delegators of a baker by decreasing balance, currently or at the end of asOfLevel when positive.
+	GetBakerDelegators(ctx context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error)
+
+	// GetCycle returns the levels of a cycle, or sql.ErrNoRows when the cycle is not synced.
+	GetCycle(ctx context.Context, cycle int) (*model.Cycle, error)
+
+	// GetMissingCycles returns the cycles from fromCycle to toCycle, both included, whose levels are not saved.
+	GetMissingCycles(ctx context.Context, fromCycle, toCycle int) ([]int, error)
+
+	// GetAccount returns an account, or sql.ErrNoRows when the address is unknown.
+	GetAccount(ctx context.Context, address model.WalletAddress) (*model.Account, error)
+
+	// GetDelegatorDelegations returns every delegation of a delegator by increasing level.
+	GetDelegatorDelegations(ctx context.Context, delegator model.WalletAddress) ([]model.Delegation, error)
+
+	// GetTotalRewards returns the sum of the rewards received by a wallet.
+	GetTotalRewards(ctx context.Context, recipient model.WalletAddress) (model.Mutez, error)
+
+	// GetStakingOperationCounts returns the number of staking operations sent by a wallet, per entrypoint.
+	GetStakingOperationCounts(ctx context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error)
+
+	// GetBakers returns the staking pools with their current delegators and their activity over the period of filter, by page.
+	GetBakers(ctx context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error)
+
+	// GetBaker returns a staking pool with its activity between fromDate and toDate, or sql.ErrNoRows when it is unknown.
+	GetBaker(ctx context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error)
+
+	// GetPrices returns the daily prices of a currency for the UTC days between fromDay and toDay, excluded, by increasing day.
+	GetPrices(ctx context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error)
+
+	// GetBakerForDelegatorAtLevel returns the baker of a delegator at the end of asOfLevel, or from its current delegation
+	// state when asOfLevel is 0. The baker is empty when the delegator was undelegated, sql.ErrNoRows when it is unknown.
+	GetBakerForDelegatorAtLevel(ctx context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error)
 
 	// SaveAccount saves an account to the repository.
 	SaveAccount(ctx context.Context, account model.Account) error
@@ -50,12 +114,118 @@ type Adapter interface {
 	// SaveDelegations saves multiple delegations to the repository.
 	SaveDelegations(ctx context.Context, delegations []*model.Delegation) error
 
+	// SaveCurrentDelegations upserts the current delegation state of delegators, keeping the most recent one.
+	SaveCurrentDelegations(ctx context.Context, delegations []model.CurrentDelegation) error
+
+	// SaveCycles saves the levels of cycles, replacing the ones already saved.
+	SaveCycles(ctx context.Context, cycles []model.Cycle) error
+
+	// SavePrices saves daily prices, replacing the ones already saved for their day and currency.
+	SavePrices(ctx context.Context, prices []model.Price) error
+
 	// SaveRewards saves multiple rewards to the repository.
 	SaveRewards(ctx context.Context, rewards []model.Reward) error
 
 	// SaveLastSyncedRewardCycle saves the last synced reward cycle.
 	SaveLastSyncedRewardCycle(ctx context.Context, cycle int) error
 
+	// RefreshDelegationStats recomputes the daily delegation statistics of the UTC days between fromDate and toDate, included.
+	RefreshDelegationStats(ctx context.Context, fromDate, toDate int64) error
+
+	// RefreshRewardStats recomputes the per-cycle reward statistics of the cycles between fromCycle and toCycle, included.
+	RefreshRewardStats(ctx context.Context, fromCycle, toCycle int) error
+
+	// GetDailyDelegationStats returns the daily delegation statistics within a date range, optionally of a baker.
+	GetDailyDelegationStats(ctx context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error)
+
+	// GetBakerRewardStats returns the per-cycle rewards of bakers within a cycle range, optionally of a baker.
+	GetBakerRewardStats(ctx context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error)
+
+	// GetDelegatorRewardStats returns the per-cycle rewards of delegators within a cycle range, optionally of a delegator.
+	GetDelegatorRewardStats(ctx context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error)
+
+	// SaveWebhook saves a new webhook, setting its id and creation date.
+	SaveWebhook(ctx context.Context, webhook *model.Webhook) error
+
+	// GetWebhooks returns the webhooks of an API key, or every webhook when apiKeyID is 0, disabled ones included,
+	// by increasing id.
+	GetWebhooks(ctx context.Context, apiKeyID int64) ([]model.Webhook, error)
+
+	// GetWebhook returns a webhook, or sql.ErrNoRows when it is unknown.
+	GetWebhook(ctx context.Context, id int64) (*model.Webhook, error)
+
+	// DeleteWebhook deletes a webhook with its deliveries, or returns sql.ErrNoRows when it is unknown.
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	// DisableWebhook stops the deliveries to a webhook from the given date.
+	DisableWebhook(ctx context.Context, id int64, at time.Time) error
+
+	// EnableWebhook resumes the deliveries to a disabled webhook and resets its consecutive failures, or returns
+	// sql.ErrNoRows when it is unknown.
+	EnableWebhook(ctx context.Context, id int64) error
+
+	// SaveWebhookDeliveries queues deliveries, skipping the events already queued for their webhook.
+	SaveWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+
+	// GetDueWebhookDeliveries returns up to limit pending deliveries of enabled webhooks due at now, by next attempt date.
+	GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error)
+
+	// SaveWebhookDeliveryAttempt records an attempt with the resulting state of its delivery, and counts the consecutive
+	// failures of its webhook, reset by a successful attempt. It returns the consecutive failures of the webhook.
+	SaveWebhookDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error)
+
+	// GetWebhookDeliveries returns the last limit deliveries of a webhook with their attempts, latest first.
+	GetWebhookDeliveries(ctx context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error)
+
+	// GetSyncCursor returns the position saved by a reader of the indexed data, or sql.ErrNoRows when none was saved.
+	GetSyncCursor(ctx context.Context, source string) (int64, error)
+
+	// SaveSyncCursor saves the position of a reader of the indexed data.
+	SaveSyncCursor(ctx context.Context, source string, position int64) error
+
+	// SaveAPIKey saves a new API key, setting its id and creation date.
+	SaveAPIKey(ctx context.Context, key *model.APIKey) error
+
+	// GetAPIKeys returns every API key, revoked ones included, by increasing id.
+	GetAPIKeys(ctx context.Context) ([]model.APIKey, error)
+
+	// GetAPIKeyByHash returns the API key of a hash, or sql.ErrNoRows when it is unknown.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*model.APIKey, error)
+
+	// RevokeAPIKey stops a key from authenticating requests from the given date, or returns sql.ErrNoRows when it is unknown.
+	RevokeAPIKey(ctx context.Context, id int64, at time.Time) error
+
+	// IncrementAPIKeyUsage counts a request of a key on the UTC day of the given date, and returns the requests of that day.
+	IncrementAPIKeyUsage(ctx context.Context, id int64, at time.Time) (int64, error)
+
+	// EnsurePartitions creates the time partitions of the partitioned tables up to the given date.
+	EnsurePartitions(ctx context.Context, until time.Time) error
+
+	// CheckSchemaVersion returns ErrSchemaVersionMismatch if the schema version is not the one expected by the code.
+	CheckSchemaVersion(ctx context.Context) error
+
+	// Close closes the database connection.
+	Close() error
+}
+
+// MigrationStatus describes a schema migration and when it was applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator defines the interface for applying and rolling back schema migrations.
+type Migrator interface {
+	// MigrateUp applies all pending migrations and returns the ones applied.
+	MigrateUp(ctx context.Context) ([]MigrationStatus, error)
+
+	// MigrateDown rolls back the last steps applied migrations and returns the ones rolled back.
+	MigrateDown(ctx context.Context, steps int) ([]MigrationStatus, error)
+
+	// MigrationStatus returns every known migration with its applied date, nil when pending.
+	MigrationStatus(ctx context.Context) ([]MigrationStatus, error)
+
 	// Close closes the database connection.
 	Close() error
 }
@@ -0,0 +1,35 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+)
+
+// poolStatsInterval is the interval between two records of the connection pool statistics.
+const poolStatsInterval = 15 * time.Second
+
+// reportPoolStats records the connection pool statistics of provider every interval until the wrapper is closed.
+func (w *TelemetryWrapper) reportPoolStats(provider database.PoolStatsProvider, interval time.Duration) {
+	defer close(w.statsDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	w.recordPoolStats(provider)
+	for {
+		select {
+		case <-w.stopStats:
+			return
+		case <-ticker.C:
+			w.recordPoolStats(provider)
+		}
+	}
+}
+
+// recordPoolStats records the current statistics of every connection pool of provider.
+func (w *TelemetryWrapper) recordPoolStats(provider database.PoolStatsProvider) {
+	for pool, stats := range provider.PoolStats() {
+		w.metrics.RecordDBPoolStats(pool, stats)
+	}
+}
@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	databasemock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsmemory "github.com/tezos-delegation-service/internal/adapter/metrics/impl/memory"
+)
+
+// pooledAdapter is a database adapter exposing connection pool statistics.
+type pooledAdapter struct {
+	*databasemock.Mock
+	stats map[string]sql.DBStats
+}
+
+func (a *pooledAdapter) PoolStats() map[string]sql.DBStats {
+	return a.stats
+}
+
+func Test_TelemetryWrapper_reportPoolStats(t *testing.T) {
+	db := &pooledAdapter{
+		Mock: databasemock.New(),
+		stats: map[string]sql.DBStats{
+			"primary":           {OpenConnections: 4, InUse: 1},
+			"replica db-2:5432": {OpenConnections: 2},
+		},
+	}
+	db.On("Close").Return(nil)
+	metricsClient := metricsmemory.New()
+
+	w := New(db, "psql", metricsClient).(*TelemetryWrapper)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(metricsClient.DBPoolStats, db.stats) {
+		t.Errorf("DBPoolStats = %v, want %v", metricsClient.DBPoolStats, db.stats)
+	}
+}
@@ -11,9 +11,11 @@ import (
 
 // TelemetryWrapper is a wrapper for a database adapter that records telemetry metrics.
 type TelemetryWrapper struct {
-	metrics  metrics.Adapter
-	db       database.Adapter
-	implType string
+	metrics   metrics.Adapter
+	db        database.Adapter
+	implType  string
+	stopStats chan struct{}
+	statsDone chan struct{}
 }
 
 // New creates a new TelemetryWrapper for a given database adapter.
@@ -21,11 +23,19 @@ func New(db database.Adapter, implType string, metrics metrics.Adapter) database
 	if db == nil {
 		return nil
 	}
-	return &TelemetryWrapper{
+	w := &TelemetryWrapper{
 		metrics:  metrics,
 		db:       db,
 		implType: implType,
 	}
+
+	if provider, ok := db.(database.PoolStatsProvider); ok && metrics != nil {
+		w.stopStats = make(chan struct{})
+		w.statsDone = make(chan struct{})
+		go w.reportPoolStats(provider, poolStatsInterval)
+	}
+
+	return w
 }
 
 // Ping checks the database connection and records metrics.
@@ -54,10 +64,36 @@ func (w *TelemetryWrapper) GetLatestDelegation(ctx context.Context) (*model.Dele
 	return delegation, err
 }
 
+// GetDelegationsAfter retrieves the delegations saved after an id and records metrics.
+func (w *TelemetryWrapper) GetDelegationsAfter(ctx context.Context, afterID int64, filter model.DelegationFilter, limit uint16) ([]model.Delegation, error) {
+	startTime := time.Now()
+	delegations, err := w.db.GetDelegationsAfter(ctx, afterID, filter, limit)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetDelegationsAfter", w.implType, duration, err)
+	}
+
+	return delegations, err
+}
+
+// ListenDelegations starts listening to the saved delegations and records metrics.
+func (w *TelemetryWrapper) ListenDelegations(ctx context.Context) (<-chan struct{}, error) {
+	startTime := time.Now()
+	signals, err := w.db.ListenDelegations(ctx)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("ListenDelegations", w.implType, duration, err)
+	}
+
+	return signals, err
+}
+
 // GetDelegations retrieves delegations with pagination and records metrics.
-func (w *TelemetryWrapper) GetDelegations(ctx context.Context, page uint32, limit, year uint16, maxDelegationID uint64) ([]model.Delegation, error) {
+func (w *TelemetryWrapper) GetDelegations(ctx context.Context, page uint32, limit uint16, filter model.DelegationFilter, maxDelegationID uint64, cursor *model.Cursor) ([]model.Delegation, error) {
 	startTime := time.Now()
-	delegations, err := w.db.GetDelegations(ctx, page, limit, year, maxDelegationID)
+	delegations, err := w.db.GetDelegations(ctx, page, limit, filter, maxDelegationID, cursor)
 	duration := time.Since(startTime)
 
 	if w.metrics != nil {
@@ -68,9 +104,9 @@ func (w *TelemetryWrapper) GetDelegations(ctx context.Context, page uint32, limi
 }
 
 // GetOperations retrieves operations with pagination and records metrics.
-func (w *TelemetryWrapper) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress) ([]model.Operation, error) {
+func (w *TelemetryWrapper) GetOperations(ctx context.Context, fromDate, toDate int64, page, limit uint16, operationType model.OperationType, wallet, baker model.WalletAddress, cursor *model.Cursor) ([]model.Operation, error) {
 	startTime := time.Now()
-	delegations, err := w.db.GetOperations(ctx, fromDate, toDate, page, limit, operationType, wallet, baker)
+	delegations, err := w.db.GetOperations(ctx, fromDate, toDate, page, limit, operationType, wallet, baker, cursor)
 	duration := time.Since(startTime)
 
 	if w.metrics != nil {
@@ -81,9 +117,9 @@ func (w *TelemetryWrapper) GetOperations(ctx context.Context, fromDate, toDate i
 }
 
 // GetRewards retrieves rewards for a given wallet and baker within a date range and records metrics.
-func (w *TelemetryWrapper) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress) ([]model.Reward, error) {
+func (w *TelemetryWrapper) GetRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, page uint32, limit uint16, cursor *model.Cursor) ([]model.Reward, error) {
 	startTime := time.Now()
-	rewards, err := w.db.GetRewards(ctx, fromDate, toDate, wallet, baker)
+	rewards, err := w.db.GetRewards(ctx, fromDate, toDate, wallet, baker, page, limit, cursor)
 	duration := time.Since(startTime)
 
 	if w.metrics != nil {
@@ -93,6 +129,45 @@ func (w *TelemetryWrapper) GetRewards(ctx context.Context, fromDate, toDate int6
 	return rewards, err
 }
 
+// StreamDelegations streams the delegations matching a filter and records metrics over the whole stream.
+func (w *TelemetryWrapper) StreamDelegations(ctx context.Context, filter model.DelegationFilter, fn func(model.Delegation) error) error {
+	startTime := time.Now()
+	err := w.db.StreamDelegations(ctx, filter, fn)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("StreamDelegations", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// StreamOperations streams the operations matching the filters and records metrics over the whole stream.
+func (w *TelemetryWrapper) StreamOperations(ctx context.Context, fromDate, toDate int64, operationType model.OperationType, wallet, baker model.WalletAddress, fn func(model.Operation) error) error {
+	startTime := time.Now()
+	err := w.db.StreamOperations(ctx, fromDate, toDate, operationType, wallet, baker, fn)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("StreamOperations", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// StreamRewards streams the rewards matching the filters and records metrics over the whole stream.
+func (w *TelemetryWrapper) StreamRewards(ctx context.Context, fromDate, toDate int64, wallet, baker model.WalletAddress, fn func(model.Reward) error) error {
+	startTime := time.Now()
+	err := w.db.StreamRewards(ctx, fromDate, toDate, wallet, baker, fn)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("StreamRewards", w.implType, duration, err)
+	}
+
+	return err
+}
+
 // GetHighestBlockLevel retrieves the highest block level and records metrics.
 func (w *TelemetryWrapper) GetHighestBlockLevel(ctx context.Context) (uint64, error) {
 	startTime := time.Now()
@@ -106,6 +181,214 @@ func (w *TelemetryWrapper) GetHighestBlockLevel(ctx context.Context) (uint64, er
 	return level, err
 }
 
+// GetLastSyncedRewardCycle retrieves the last synced reward cycle and records metrics.
+func (w *TelemetryWrapper) GetLastSyncedRewardCycle(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	cycle, err := w.db.GetLastSyncedRewardCycle(ctx)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetLastSyncedRewardCycle", w.implType, duration, err)
+	}
+
+	return cycle, err
+}
+
+// GetActiveDelegators retrieves the active delegators and records metrics.
+func (w *TelemetryWrapper) GetActiveDelegators(ctx context.Context) ([]model.WalletAddress, error) {
+	startTime := time.Now()
+	delegators, err := w.db.GetActiveDelegators(ctx)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetActiveDelegators", w.implType, duration, err)
+	}
+
+	return delegators, err
+}
+
+// GetBakerDelegators retrieves the delegators of a baker, currently or at a level, and records metrics.
+func (w *TelemetryWrapper) GetBakerDelegators(ctx context.Context, baker model.WalletAddress, asOfLevel int64, page, limit uint16) ([]model.CurrentDelegation, error) {
+	startTime := time.Now()
+	delegators, err := w.db.GetBakerDelegators(ctx, baker, asOfLevel, page, limit)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetBakerDelegators", w.implType, duration, err)
+	}
+
+	return delegators, err
+}
+
+// GetCycle retrieves the levels of a cycle and records metrics.
+func (w *TelemetryWrapper) GetCycle(ctx context.Context, cycle int) (*model.Cycle, error) {
+	startTime := time.Now()
+	c, err := w.db.GetCycle(ctx, cycle)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetCycle", w.implType, duration, err)
+	}
+
+	return c, err
+}
+
+// GetMissingCycles retrieves the cycles whose levels are not saved and records metrics.
+func (w *TelemetryWrapper) GetMissingCycles(ctx context.Context, fromCycle, toCycle int) ([]int, error) {
+	startTime := time.Now()
+	cycles, err := w.db.GetMissingCycles(ctx, fromCycle, toCycle)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetMissingCycles", w.implType, duration, err)
+	}
+
+	return cycles, err
+}
+
+// GetAccount retrieves an account and records metrics.
+func (w *TelemetryWrapper) GetAccount(ctx context.Context, address model.WalletAddress) (*model.Account, error) {
+	startTime := time.Now()
+	account, err := w.db.GetAccount(ctx, address)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetAccount", w.implType, duration, err)
+	}
+
+	return account, err
+}
+
+// GetDelegatorDelegations retrieves every delegation of a delegator and records metrics.
+func (w *TelemetryWrapper) GetDelegatorDelegations(ctx context.Context, delegator model.WalletAddress) ([]model.Delegation, error) {
+	startTime := time.Now()
+	delegations, err := w.db.GetDelegatorDelegations(ctx, delegator)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetDelegatorDelegations", w.implType, duration, err)
+	}
+
+	return delegations, err
+}
+
+// GetTotalRewards retrieves the sum of the rewards received by a wallet and records metrics.
+func (w *TelemetryWrapper) GetTotalRewards(ctx context.Context, recipient model.WalletAddress) (model.Mutez, error) {
+	startTime := time.Now()
+	total, err := w.db.GetTotalRewards(ctx, recipient)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetTotalRewards", w.implType, duration, err)
+	}
+
+	return total, err
+}
+
+// GetStakingOperationCounts retrieves the number of staking operations of a wallet per entrypoint and records metrics.
+func (w *TelemetryWrapper) GetStakingOperationCounts(ctx context.Context, wallet model.WalletAddress) ([]model.StakingOperationCount, error) {
+	startTime := time.Now()
+	counts, err := w.db.GetStakingOperationCounts(ctx, wallet)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetStakingOperationCounts", w.implType, duration, err)
+	}
+
+	return counts, err
+}
+
+// GetBakers retrieves the staking pools with their activity over a period and records metrics.
+func (w *TelemetryWrapper) GetBakers(ctx context.Context, filter model.BakerFilter, page, limit uint16) ([]model.Baker, error) {
+	startTime := time.Now()
+	bakers, err := w.db.GetBakers(ctx, filter, page, limit)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetBakers", w.implType, duration, err)
+	}
+
+	return bakers, err
+}
+
+// GetBaker retrieves a staking pool with its activity over a period and records metrics.
+func (w *TelemetryWrapper) GetBaker(ctx context.Context, address model.WalletAddress, fromDate, toDate int64) (*model.Baker, error) {
+	startTime := time.Now()
+	baker, err := w.db.GetBaker(ctx, address, fromDate, toDate)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetBaker", w.implType, duration, err)
+	}
+
+	return baker, err
+}
+
+// GetPrices retrieves the daily prices of a currency and records metrics.
+func (w *TelemetryWrapper) GetPrices(ctx context.Context, currency model.Currency, fromDay, toDay int64) ([]model.Price, error) {
+	startTime := time.Now()
+	prices, err := w.db.GetPrices(ctx, currency, fromDay, toDay)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetPrices", w.implType, duration, err)
+	}
+
+	return prices, err
+}
+
+// SavePrices saves daily prices and records metrics.
+func (w *TelemetryWrapper) SavePrices(ctx context.Context, prices []model.Price) error {
+	startTime := time.Now()
+	err := w.db.SavePrices(ctx, prices)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SavePrices", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveCycles saves the levels of cycles and records metrics.
+func (w *TelemetryWrapper) SaveCycles(ctx context.Context, cycles []model.Cycle) error {
+	startTime := time.Now()
+	err := w.db.SaveCycles(ctx, cycles)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveCycles", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveCurrentDelegations upserts the current delegation state of delegators and records metrics.
+func (w *TelemetryWrapper) SaveCurrentDelegations(ctx context.Context, delegations []model.CurrentDelegation) error {
+	startTime := time.Now()
+	err := w.db.SaveCurrentDelegations(ctx, delegations)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveCurrentDelegations", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// GetBakerForDelegatorAtLevel retrieves the baker of a delegator at a given level and records metrics.
+func (w *TelemetryWrapper) GetBakerForDelegatorAtLevel(ctx context.Context, delegator model.WalletAddress, asOfLevel int64) (model.WalletAddress, error) {
+	startTime := time.Now()
+	baker, err := w.db.GetBakerForDelegatorAtLevel(ctx, delegator, asOfLevel)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetBakerForDelegatorAtLevel", w.implType, duration, err)
+	}
+
+	return baker, err
+}
+
 // SaveDelegation saves a single delegation and records metrics.
 func (w *TelemetryWrapper) SaveDelegation(ctx context.Context, delegation *model.Delegation) error {
 	startTime := time.Now()
@@ -156,11 +439,11 @@ func (w *TelemetryWrapper) SaveDelegations(ctx context.Context, delegations []*m
 
 		// Record business metrics
 		if err == nil {
-			amount := 0.0
+			var amount model.Mutez
 			for _, d := range delegations {
 				amount += d.Amount
 			}
-			w.metrics.RecordDelegationsSync("repository", len(delegations), amount)
+			w.metrics.RecordDelegationsSync("repository", len(delegations), amount.TezFloat())
 		}
 	}
 
@@ -180,8 +463,352 @@ func (w *TelemetryWrapper) SaveStakingPools(ctx context.Context, stakingPools []
 	return err
 }
 
+// SaveRewards saves multiple rewards and records metrics.
+func (w *TelemetryWrapper) SaveRewards(ctx context.Context, rewards []model.Reward) error {
+	startTime := time.Now()
+	err := w.db.SaveRewards(ctx, rewards)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveRewards", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveLastSyncedRewardCycle saves the last synced reward cycle and records metrics.
+func (w *TelemetryWrapper) SaveLastSyncedRewardCycle(ctx context.Context, cycle int) error {
+	startTime := time.Now()
+	err := w.db.SaveLastSyncedRewardCycle(ctx, cycle)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveLastSyncedRewardCycle", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveWebhook saves a new webhook and records metrics.
+func (w *TelemetryWrapper) SaveWebhook(ctx context.Context, webhook *model.Webhook) error {
+	startTime := time.Now()
+	err := w.db.SaveWebhook(ctx, webhook)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveWebhook", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// GetWebhooks retrieves the webhooks of an API key and records metrics.
+func (w *TelemetryWrapper) GetWebhooks(ctx context.Context, apiKeyID int64) ([]model.Webhook, error) {
+	startTime := time.Now()
+	webhooks, err := w.db.GetWebhooks(ctx, apiKeyID)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetWebhooks", w.implType, duration, err)
+	}
+
+	return webhooks, err
+}
+
+// GetWebhook retrieves a webhook and records metrics.
+func (w *TelemetryWrapper) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	startTime := time.Now()
+	webhook, err := w.db.GetWebhook(ctx, id)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetWebhook", w.implType, duration, err)
+	}
+
+	return webhook, err
+}
+
+// DeleteWebhook deletes a webhook and records metrics.
+func (w *TelemetryWrapper) DeleteWebhook(ctx context.Context, id int64) error {
+	startTime := time.Now()
+	err := w.db.DeleteWebhook(ctx, id)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("DeleteWebhook", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// DisableWebhook disables a webhook and records metrics.
+func (w *TelemetryWrapper) DisableWebhook(ctx context.Context, id int64, at time.Time) error {
+	startTime := time.Now()
+	err := w.db.DisableWebhook(ctx, id, at)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("DisableWebhook", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// EnableWebhook enables a webhook and records metrics.
+func (w *TelemetryWrapper) EnableWebhook(ctx context.Context, id int64) error {
+	startTime := time.Now()
+	err := w.db.EnableWebhook(ctx, id)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("EnableWebhook", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveWebhookDeliveries queues webhook deliveries and records metrics.
+func (w *TelemetryWrapper) SaveWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	startTime := time.Now()
+	err := w.db.SaveWebhookDeliveries(ctx, deliveries)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveWebhookDeliveries", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// GetDueWebhookDeliveries retrieves the due webhook deliveries and records metrics.
+func (w *TelemetryWrapper) GetDueWebhookDeliveries(ctx context.Context, now time.Time, limit uint16) ([]model.WebhookDelivery, error) {
+	startTime := time.Now()
+	deliveries, err := w.db.GetDueWebhookDeliveries(ctx, now, limit)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetDueWebhookDeliveries", w.implType, duration, err)
+	}
+
+	return deliveries, err
+}
+
+// SaveWebhookDeliveryAttempt records a webhook delivery attempt and records metrics.
+func (w *TelemetryWrapper) SaveWebhookDeliveryAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) (int, error) {
+	startTime := time.Now()
+	failures, err := w.db.SaveWebhookDeliveryAttempt(ctx, delivery, attempt)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveWebhookDeliveryAttempt", w.implType, duration, err)
+	}
+
+	return failures, err
+}
+
+// GetWebhookDeliveries retrieves the deliveries of a webhook and records metrics.
+func (w *TelemetryWrapper) GetWebhookDeliveries(ctx context.Context, webhookID int64, limit uint16) ([]model.WebhookDelivery, error) {
+	startTime := time.Now()
+	deliveries, err := w.db.GetWebhookDeliveries(ctx, webhookID, limit)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetWebhookDeliveries", w.implType, duration, err)
+	}
+
+	return deliveries, err
+}
+
+// GetSyncCursor retrieves a sync cursor and records metrics.
+func (w *TelemetryWrapper) GetSyncCursor(ctx context.Context, source string) (int64, error) {
+	startTime := time.Now()
+	position, err := w.db.GetSyncCursor(ctx, source)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetSyncCursor", w.implType, duration, err)
+	}
+
+	return position, err
+}
+
+// SaveSyncCursor saves a sync cursor and records metrics.
+func (w *TelemetryWrapper) SaveSyncCursor(ctx context.Context, source string, position int64) error {
+	startTime := time.Now()
+	err := w.db.SaveSyncCursor(ctx, source, position)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveSyncCursor", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// SaveAPIKey saves an API key and records metrics.
+func (w *TelemetryWrapper) SaveAPIKey(ctx context.Context, key *model.APIKey) error {
+	startTime := time.Now()
+	err := w.db.SaveAPIKey(ctx, key)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("SaveAPIKey", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// GetAPIKeys returns the API keys and records metrics.
+func (w *TelemetryWrapper) GetAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	startTime := time.Now()
+	keys, err := w.db.GetAPIKeys(ctx)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetAPIKeys", w.implType, duration, err)
+	}
+
+	return keys, err
+}
+
+// GetAPIKeyByHash returns the API key of a hash and records metrics.
+func (w *TelemetryWrapper) GetAPIKeyByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	startTime := time.Now()
+	key, err := w.db.GetAPIKeyByHash(ctx, hash)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetAPIKeyByHash", w.implType, duration, err)
+	}
+
+	return key, err
+}
+
+// RevokeAPIKey revokes an API key and records metrics.
+func (w *TelemetryWrapper) RevokeAPIKey(ctx context.Context, id int64, at time.Time) error {
+	startTime := time.Now()
+	err := w.db.RevokeAPIKey(ctx, id, at)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("RevokeAPIKey", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// IncrementAPIKeyUsage counts a request of an API key and records metrics.
+func (w *TelemetryWrapper) IncrementAPIKeyUsage(ctx context.Context, id int64, at time.Time) (int64, error) {
+	startTime := time.Now()
+	requests, err := w.db.IncrementAPIKeyUsage(ctx, id, at)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("IncrementAPIKeyUsage", w.implType, duration, err)
+	}
+
+	return requests, err
+}
+
+// EnsurePartitions creates the time partitions up to the given date and records metrics.
+func (w *TelemetryWrapper) EnsurePartitions(ctx context.Context, until time.Time) error {
+	startTime := time.Now()
+	err := w.db.EnsurePartitions(ctx, until)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("EnsurePartitions", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// RefreshDelegationStats recomputes the daily delegation statistics and records metrics.
+func (w *TelemetryWrapper) RefreshDelegationStats(ctx context.Context, fromDate, toDate int64) error {
+	startTime := time.Now()
+	err := w.db.RefreshDelegationStats(ctx, fromDate, toDate)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("RefreshDelegationStats", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// RefreshRewardStats recomputes the per-cycle reward statistics and records metrics.
+func (w *TelemetryWrapper) RefreshRewardStats(ctx context.Context, fromCycle, toCycle int) error {
+	startTime := time.Now()
+	err := w.db.RefreshRewardStats(ctx, fromCycle, toCycle)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("RefreshRewardStats", w.implType, duration, err)
+	}
+
+	return err
+}
+
+// GetDailyDelegationStats retrieves the daily delegation statistics and records metrics.
+func (w *TelemetryWrapper) GetDailyDelegationStats(ctx context.Context, fromDate, toDate int64, baker model.WalletAddress) ([]model.DailyDelegationStats, error) {
+	startTime := time.Now()
+	stats, err := w.db.GetDailyDelegationStats(ctx, fromDate, toDate, baker)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetDailyDelegationStats", w.implType, duration, err)
+	}
+
+	return stats, err
+}
+
+// GetBakerRewardStats retrieves the per-cycle rewards of bakers and records metrics.
+func (w *TelemetryWrapper) GetBakerRewardStats(ctx context.Context, fromCycle, toCycle int, baker model.WalletAddress) ([]model.BakerCycleRewards, error) {
+	startTime := time.Now()
+	stats, err := w.db.GetBakerRewardStats(ctx, fromCycle, toCycle, baker)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetBakerRewardStats", w.implType, duration, err)
+	}
+
+	return stats, err
+}
+
+// GetDelegatorRewardStats retrieves the per-cycle rewards of delegators and records metrics.
+func (w *TelemetryWrapper) GetDelegatorRewardStats(ctx context.Context, fromCycle, toCycle int, delegator model.WalletAddress) ([]model.DelegatorCycleRewards, error) {
+	startTime := time.Now()
+	stats, err := w.db.GetDelegatorRewardStats(ctx, fromCycle, toCycle, delegator)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("GetDelegatorRewardStats", w.implType, duration, err)
+	}
+
+	return stats, err
+}
+
+// CheckSchemaVersion checks the schema version and records metrics.
+func (w *TelemetryWrapper) CheckSchemaVersion(ctx context.Context) error {
+	startTime := time.Now()
+	err := w.db.CheckSchemaVersion(ctx)
+	duration := time.Since(startTime)
+
+	if w.metrics != nil {
+		w.metrics.RecordRepositoryOperation("CheckSchemaVersion", w.implType, duration, err)
+	}
+
+	return err
+}
+
 // Close closes the repository and records metrics.
 func (w *TelemetryWrapper) Close() error {
+	if w.stopStats != nil {
+		close(w.stopStats)
+		<-w.statsDone
+		w.stopStats = nil
+	}
+
 	startTime := time.Now()
 	err := w.db.Close()
 	duration := time.Since(startTime)
@@ -136,7 +136,7 @@ func Test_TelemetryWrapper_GetDelegations(t *testing.T) {
 		ctx             context.Context
 		page            uint32
 		limit           uint16
-		year            uint16
+		filter          model.DelegationFilter
 		maxDelegationID uint64
 	}
 	tests := []struct {
@@ -152,20 +152,20 @@ func Test_TelemetryWrapper_GetDelegations(t *testing.T) {
 				metrics: metricsmemory.New(),
 				db: func() database.Adapter {
 					m := databasemock.New()
-					m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(2025), uint64(0)).
+					m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{Year: 2025}, uint64(0), (*model.Cursor)(nil)).
 						Return([]model.Delegation{
 							{Amount: 100},
 							{Amount: 200},
-						}, 2, nil)
+						}, nil)
 					return m
 				}(),
 				implType: "api",
 			},
 			args: args{
-				ctx:   context.TODO(),
-				page:  1,
-				limit: 10,
-				year:  2025,
+				ctx:    context.TODO(),
+				page:   1,
+				limit:  10,
+				filter: model.DelegationFilter{Year: 2025},
 			},
 			want: []model.Delegation{
 				{Amount: 100},
@@ -179,17 +179,17 @@ func Test_TelemetryWrapper_GetDelegations(t *testing.T) {
 				metrics: metricsmemory.New(),
 				db: func() database.Adapter {
 					m := databasemock.New()
-					m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(2025), uint64(0)).
-						Return([]model.Delegation{}, 0, errors.New("db error"))
+					m.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{Year: 2025}, uint64(0), (*model.Cursor)(nil)).
+						Return([]model.Delegation{}, errors.New("db error"))
 					return m
 				}(),
 				implType: "api",
 			},
 			args: args{
-				ctx:   context.TODO(),
-				page:  1,
-				limit: 10,
-				year:  2025,
+				ctx:    context.TODO(),
+				page:   1,
+				limit:  10,
+				filter: model.DelegationFilter{Year: 2025},
 			},
 			want:    []model.Delegation{},
 			wantErr: true,
@@ -202,7 +202,7 @@ func Test_TelemetryWrapper_GetDelegations(t *testing.T) {
 				db:       tt.fields.db,
 				implType: tt.fields.implType,
 			}
-			got, err := w.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.year, tt.args.maxDelegationID)
+			got, err := w.GetDelegations(tt.args.ctx, tt.args.page, tt.args.limit, tt.args.filter, tt.args.maxDelegationID, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetDelegations() error = %v, wantErr %v", err, tt.wantErr)
 				return
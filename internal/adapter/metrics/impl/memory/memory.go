@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -16,6 +17,21 @@ type Metrics struct {
 	DelegationsTotal          int
 	DelegationsAmount         float64
 	DelegationsFetched        int
+	DBPoolStats               map[string]sql.DBStats
+	APIKeyRequests            map[APIKeyRequest]int
+	CacheRequests             map[CacheRequest]int
+}
+
+// APIKeyRequest identifies the requests of an API key with an outcome.
+type APIKeyRequest struct {
+	APIKey  string
+	Outcome string
+}
+
+// CacheRequest identifies the lookups of a query cache with a result.
+type CacheRequest struct {
+	Cache  string
+	Result string
 }
 
 // New creates a new memory metrics client.
@@ -62,3 +78,27 @@ func (m *Metrics) RecordDelegationsSync(syncType string, count int, amount float
 func (m *Metrics) RecordDelegationsFetched(count int) {
 	m.DelegationsFetched += count
 }
+
+// RecordDBPoolStats keeps the last statistics of the database connection pool.
+func (m *Metrics) RecordDBPoolStats(pool string, stats sql.DBStats) {
+	if m.DBPoolStats == nil {
+		m.DBPoolStats = make(map[string]sql.DBStats)
+	}
+	m.DBPoolStats[pool] = stats
+}
+
+// RecordAPIKeyRequest counts a request of an API key by outcome.
+func (m *Metrics) RecordAPIKeyRequest(apiKey, outcome string) {
+	if m.APIKeyRequests == nil {
+		m.APIKeyRequests = make(map[APIKeyRequest]int)
+	}
+	m.APIKeyRequests[APIKeyRequest{APIKey: apiKey, Outcome: outcome}]++
+}
+
+// RecordCacheRequest counts a lookup of a query cache by result.
+func (m *Metrics) RecordCacheRequest(cache, result string) {
+	if m.CacheRequests == nil {
+		m.CacheRequests = make(map[CacheRequest]int)
+	}
+	m.CacheRequests[CacheRequest{Cache: cache, Result: result}]++
+}
@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"database/sql"
 	"errors"
 	"reflect"
 	"testing"
@@ -513,3 +514,44 @@ func TestNew(t *testing.T) {
 		t.Errorf("New() = %v, want %v", got, want)
 	}
 }
+
+func TestMetrics_RecordDBPoolStats(t *testing.T) {
+	m := New()
+	m.RecordDBPoolStats("primary", sql.DBStats{OpenConnections: 3, InUse: 1, Idle: 2})
+	m.RecordDBPoolStats("primary", sql.DBStats{OpenConnections: 4, InUse: 4})
+
+	want := map[string]sql.DBStats{"primary": {OpenConnections: 4, InUse: 4}}
+	if !reflect.DeepEqual(m.DBPoolStats, want) {
+		t.Errorf("DBPoolStats = %v, want %v", m.DBPoolStats, want)
+	}
+}
+
+func TestMetrics_RecordAPIKeyRequest(t *testing.T) {
+	m := New()
+	m.RecordAPIKeyRequest("tzd_1a2b3c4d", "allowed")
+	m.RecordAPIKeyRequest("tzd_1a2b3c4d", "allowed")
+	m.RecordAPIKeyRequest("anonymous", "rate_limited")
+
+	want := map[APIKeyRequest]int{
+		{APIKey: "tzd_1a2b3c4d", Outcome: "allowed"}:   2,
+		{APIKey: "anonymous", Outcome: "rate_limited"}: 1,
+	}
+	if !reflect.DeepEqual(m.APIKeyRequests, want) {
+		t.Errorf("APIKeyRequests = %v, want %v", m.APIKeyRequests, want)
+	}
+}
+
+func TestMetrics_RecordCacheRequest(t *testing.T) {
+	m := New()
+	m.RecordCacheRequest("GetDelegations", "hit")
+	m.RecordCacheRequest("GetDelegations", "hit")
+	m.RecordCacheRequest("GetRewards", "miss")
+
+	want := map[CacheRequest]int{
+		{Cache: "GetDelegations", Result: "hit"}: 2,
+		{Cache: "GetRewards", Result: "miss"}:    1,
+	}
+	if !reflect.DeepEqual(m.CacheRequests, want) {
+		t.Errorf("CacheRequests = %v, want %v", m.CacheRequests, want)
+	}
+}
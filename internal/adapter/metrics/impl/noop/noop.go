@@ -1,6 +1,7 @@
 package noop
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -32,3 +33,12 @@ func (m *Metrics) RecordDelegationsSync(syncType string, count int, amount float
 
 // RecordDelegationsFetched is a no-op implementation.
 func (m *Metrics) RecordDelegationsFetched(count int) {}
+
+// RecordDBPoolStats is a no-op implementation.
+func (m *Metrics) RecordDBPoolStats(pool string, stats sql.DBStats) {}
+
+// RecordAPIKeyRequest is a no-op implementation.
+func (m *Metrics) RecordAPIKeyRequest(apiKey, outcome string) {}
+
+// RecordCacheRequest is a no-op implementation.
+func (m *Metrics) RecordCacheRequest(cache, result string) {}
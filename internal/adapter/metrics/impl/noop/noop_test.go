@@ -1,6 +1,7 @@
 package noop
 
 import (
+	"database/sql"
 	"errors"
 	"reflect"
 	"testing"
@@ -305,3 +306,18 @@ func TestNew(t *testing.T) {
 		t.Errorf("New() = %v, want %v", got, want)
 	}
 }
+
+func TestMetrics_RecordDBPoolStats(t *testing.T) {
+	m := &Metrics{}
+	m.RecordDBPoolStats("primary", sql.DBStats{OpenConnections: 3})
+}
+
+func TestMetrics_RecordAPIKeyRequest(t *testing.T) {
+	m := &Metrics{}
+	m.RecordAPIKeyRequest("anonymous", "allowed")
+}
+
+func TestMetrics_RecordCacheRequest(t *testing.T) {
+	m := &Metrics{}
+	m.RecordCacheRequest("GetDelegations", "hit")
+}
@@ -1,6 +1,7 @@
 package prometheus
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,12 +14,22 @@ type Metrics struct {
 	APIRequestsTotal   *prometheus.CounterVec
 	APIRequestDuration *prometheus.HistogramVec
 	APIResponseSize    *prometheus.HistogramVec
+	APIKeyRequests     *prometheus.CounterVec
+	CacheRequests      *prometheus.CounterVec
 
 	// Repository Metrics
 	RepositoryOperationsTotal   *prometheus.CounterVec
 	RepositoryOperationDuration *prometheus.HistogramVec
 	RepositoryErrors            *prometheus.CounterVec
 
+	// Database Connection Pool Metrics
+	DBPoolOpenConnections *prometheus.GaugeVec
+	DBPoolInUse           *prometheus.GaugeVec
+	DBPoolIdle            *prometheus.GaugeVec
+	DBPoolMaxOpen         *prometheus.GaugeVec
+	DBPoolWaitCount       *prometheus.GaugeVec
+	DBPoolWaitDuration    *prometheus.GaugeVec
+
 	// Service Metrics
 	ServiceOperationsTotal   *prometheus.CounterVec
 	ServiceOperationDuration *prometheus.HistogramVec
@@ -63,6 +74,20 @@ func New() *Metrics {
 			},
 			[]string{"method", "path"},
 		),
+		APIKeyRequests: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "tezos_delegation_api_key_requests_total",
+				Help: "Total number of API requests per API key prefix, or anonymous, and outcome",
+			},
+			[]string{"api_key", "outcome"},
+		),
+		CacheRequests: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "tezos_delegation_cache_requests_total",
+				Help: "Total number of query cache lookups per cache and result",
+			},
+			[]string{"cache", "result"},
+		),
 
 		// Repository Metrics
 		RepositoryOperationsTotal: promauto.NewCounterVec(
@@ -88,6 +113,50 @@ func New() *Metrics {
 			[]string{"operation", "repository_type", "error_type"},
 		),
 
+		// Database Connection Pool Metrics
+		DBPoolOpenConnections: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_open_connections",
+				Help: "Number of established connections to the database, in use and idle",
+			},
+			[]string{"pool"},
+		),
+		DBPoolInUse: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_in_use_connections",
+				Help: "Number of database connections currently in use",
+			},
+			[]string{"pool"},
+		),
+		DBPoolIdle: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_idle_connections",
+				Help: "Number of idle database connections",
+			},
+			[]string{"pool"},
+		),
+		DBPoolMaxOpen: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_max_open_connections",
+				Help: "Maximum number of open connections to the database, 0 for unlimited",
+			},
+			[]string{"pool"},
+		),
+		DBPoolWaitCount: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_wait_count",
+				Help: "Total number of connections waited for",
+			},
+			[]string{"pool"},
+		),
+		DBPoolWaitDuration: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "tezos_delegation_db_pool_wait_duration_seconds",
+				Help: "Total time blocked waiting for a new connection, in seconds",
+			},
+			[]string{"pool"},
+		),
+
 		// Service Metrics
 		ServiceOperationsTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
@@ -175,6 +244,16 @@ func (m *Metrics) RecordAPIRequest(method, path, status string, duration time.Du
 	m.APIResponseSize.WithLabelValues(method, path).Observe(float64(responseSize))
 }
 
+// RecordAPIKeyRequest counts a request of an API key by outcome: allowed, rate_limited or quota_exceeded.
+func (m *Metrics) RecordAPIKeyRequest(apiKey, outcome string) {
+	m.APIKeyRequests.WithLabelValues(apiKey, outcome).Inc()
+}
+
+// RecordCacheRequest counts a lookup of a query cache by result: hit, miss or shared.
+func (m *Metrics) RecordCacheRequest(cache, result string) {
+	m.CacheRequests.WithLabelValues(cache, result).Inc()
+}
+
 // RecordRepositoryOperation records metrics for a repository operation.
 func (m *Metrics) RecordRepositoryOperation(operation, repoType string, duration time.Duration, err error) {
 	m.RepositoryOperationsTotal.WithLabelValues(operation, repoType).Inc()
@@ -185,6 +264,16 @@ func (m *Metrics) RecordRepositoryOperation(operation, repoType string, duration
 	}
 }
 
+// RecordDBPoolStats records the statistics of a database connection pool.
+func (m *Metrics) RecordDBPoolStats(pool string, stats sql.DBStats) {
+	m.DBPoolOpenConnections.WithLabelValues(pool).Set(float64(stats.OpenConnections))
+	m.DBPoolInUse.WithLabelValues(pool).Set(float64(stats.InUse))
+	m.DBPoolIdle.WithLabelValues(pool).Set(float64(stats.Idle))
+	m.DBPoolMaxOpen.WithLabelValues(pool).Set(float64(stats.MaxOpenConnections))
+	m.DBPoolWaitCount.WithLabelValues(pool).Set(float64(stats.WaitCount))
+	m.DBPoolWaitDuration.WithLabelValues(pool).Set(stats.WaitDuration.Seconds())
+}
+
 // RecordServiceOperation records metrics for a service operation.
 func (m *Metrics) RecordServiceOperation(operation, serviceType string, duration time.Duration, err error) {
 	m.ServiceOperationsTotal.WithLabelValues(operation, serviceType).Inc()
@@ -1,6 +1,7 @@
 package prometheus
 
 import (
+	"database/sql"
 	"errors"
 	"strings"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func Test_Metrics_RecordAPIRequest(t *testing.T) {
@@ -324,6 +326,80 @@ func Test_Metrics_RecordTZKTAPIRequest(t *testing.T) {
 	}
 }
 
+func Test_Metrics_RecordDBPoolStats(t *testing.T) {
+	newGaugeVec := func(name string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name}, []string{"pool"})
+	}
+	m := &Metrics{
+		DBPoolOpenConnections: newGaugeVec("test_db_pool_open_connections"),
+		DBPoolInUse:           newGaugeVec("test_db_pool_in_use_connections"),
+		DBPoolIdle:            newGaugeVec("test_db_pool_idle_connections"),
+		DBPoolMaxOpen:         newGaugeVec("test_db_pool_max_open_connections"),
+		DBPoolWaitCount:       newGaugeVec("test_db_pool_wait_count"),
+		DBPoolWaitDuration:    newGaugeVec("test_db_pool_wait_duration_seconds"),
+	}
+
+	m.RecordDBPoolStats("primary", sql.DBStats{OpenConnections: 5, InUse: 3, Idle: 2, MaxOpenConnections: 20, WaitCount: 7, WaitDuration: 1500 * time.Millisecond})
+
+	tests := []struct {
+		name  string
+		gauge *prometheus.GaugeVec
+		want  float64
+	}{
+		{name: "open connections", gauge: m.DBPoolOpenConnections, want: 5},
+		{name: "in use", gauge: m.DBPoolInUse, want: 3},
+		{name: "idle", gauge: m.DBPoolIdle, want: 2},
+		{name: "max open", gauge: m.DBPoolMaxOpen, want: 20},
+		{name: "wait count", gauge: m.DBPoolWaitCount, want: 7},
+		{name: "wait duration", gauge: m.DBPoolWaitDuration, want: 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var metric dto.Metric
+			if err := tt.gauge.WithLabelValues("primary").Write(&metric); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if got := metric.GetGauge().GetValue(); got != tt.want {
+				t.Errorf("gauge = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Metrics_RecordAPIKeyRequest(t *testing.T) {
+	m := &Metrics{
+		APIKeyRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_api_key_requests_total"}, []string{"api_key", "outcome"}),
+	}
+	m.RecordAPIKeyRequest("tzd_1a2b3c4d", "allowed")
+	m.RecordAPIKeyRequest("tzd_1a2b3c4d", "allowed")
+	m.RecordAPIKeyRequest("tzd_1a2b3c4d", "quota_exceeded")
+
+	var metric dto.Metric
+	if err := m.APIKeyRequests.WithLabelValues("tzd_1a2b3c4d", "allowed").Write(&metric); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := metric.GetCounter().GetValue(); got != 2 {
+		t.Errorf("counter = %v, want 2", got)
+	}
+}
+
+func Test_Metrics_RecordCacheRequest(t *testing.T) {
+	m := &Metrics{
+		CacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_cache_requests_total"}, []string{"cache", "result"}),
+	}
+	m.RecordCacheRequest("GetDelegations", "hit")
+	m.RecordCacheRequest("GetDelegations", "hit")
+	m.RecordCacheRequest("GetDelegations", "miss")
+
+	var metric dto.Metric
+	if err := m.CacheRequests.WithLabelValues("GetDelegations", "hit").Write(&metric); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := metric.GetCounter().GetValue(); got != 2 {
+		t.Errorf("counter = %v, want 2", got)
+	}
+}
+
 func Test_New(t *testing.T) {
 	defaultRegisterer := prometheus.DefaultRegisterer
 	defaultRegistry := prometheus.DefaultGatherer
@@ -340,6 +416,8 @@ func Test_New(t *testing.T) {
 
 	t.Run("verification of names and labels", func(t *testing.T) {
 		assertCounterVecConfig(t, got.APIRequestsTotal, "tezos_delegation_api_requests_total", []string{"method", "path", "status"})
+		assertCounterVecConfig(t, got.APIKeyRequests, "tezos_delegation_api_key_requests_total", []string{"api_key", "outcome"})
+		assertCounterVecConfig(t, got.CacheRequests, "tezos_delegation_cache_requests_total", []string{"cache", "result"})
 
 		assertCounterVecConfig(t, got.RepositoryOperationsTotal, "tezos_delegation_repository_operations_total", []string{"operation", "repository_type"})
 		assertCounterVecConfig(t, got.ServiceOperationsTotal, "tezos_delegation_service_operations_total", []string{"operation", "service_type"})
@@ -1,6 +1,9 @@
 package metrics
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 // Adapter defines the interface for metrics collection.
 type Adapter interface {
@@ -10,4 +13,7 @@ type Adapter interface {
 	RecordTZKTAPIRequest(endpoint string, duration time.Duration, success bool)
 	RecordDelegationsSync(syncType string, count int, amount float64)
 	RecordDelegationsFetched(count int)
+	RecordDBPoolStats(pool string, stats sql.DBStats)
+	RecordAPIKeyRequest(apiKey, outcome string)
+	RecordCacheRequest(cache, result string)
 }
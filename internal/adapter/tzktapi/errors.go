@@ -0,0 +1,6 @@
+package tzktapi
+
+import "github.com/tezos-delegation-service/internal/apperror"
+
+// ErrUnavailable is returned when the TzKT API cannot be reached or answers an unexpected status.
+var ErrUnavailable = apperror.New(apperror.KindUnavailable, "upstream_unavailable", "TzKT API unavailable")
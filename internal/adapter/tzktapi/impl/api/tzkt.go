@@ -55,7 +55,7 @@ func (a *Adapter) FetchDelegations(ctx context.Context, limit uint16, offset int
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching delegations: %w", err)
+		return nil, unavailable(fmt.Errorf("error fetching delegations: %w", err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -64,7 +64,7 @@ func (a *Adapter) FetchDelegations(ctx context.Context, limit uint16, offset int
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, unavailable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	var delegations model.TzktDelegationResponse
@@ -91,7 +91,7 @@ func (a *Adapter) FetchDelegationsFromLevel(ctx context.Context, level uint64, l
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching delegations from level: %w", err)
+		return nil, unavailable(fmt.Errorf("error fetching delegations from level: %w", err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -100,7 +100,7 @@ func (a *Adapter) FetchDelegationsFromLevel(ctx context.Context, level uint64, l
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, unavailable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	var delegations model.TzktDelegationResponse
@@ -116,7 +116,7 @@ func (a *Adapter) FetchOperationsFromTezos(blockID string) ([]model.Operation, e
 	url := fmt.Sprintf("%s/chains/main/blocks/%s/operations", a.apiURL, blockID)
 	resp, err := http.Get(url)
 	if err != nil {
-		return nil, err
+		return nil, unavailable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -136,7 +136,7 @@ func (a *Adapter) FetchRewardsForBaker(blockID, bakerAddress string) (model.Rewa
 	url := fmt.Sprintf("%s/chains/main/blocks/%s/context/delegates/%s", a.apiURL, blockID, bakerAddress)
 	resp, err := http.Get(url)
 	if err != nil {
-		return model.Reward{}, err
+		return model.Reward{}, unavailable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -161,7 +161,7 @@ func (a *Adapter) GetCurrentCycle(ctx context.Context) (int, error) {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("error fetching current cycle: %w", err)
+		return 0, unavailable(fmt.Errorf("error fetching current cycle: %w", err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -170,7 +170,7 @@ func (a *Adapter) GetCurrentCycle(ctx context.Context) (int, error) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return 0, unavailable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	var head struct {
@@ -183,6 +183,36 @@ func (a *Adapter) GetCurrentCycle(ctx context.Context) (int, error) {
 	return head.Cycle, nil
 }
 
+// FetchCycle returns the first and last levels of a cycle from the TzKT API.
+func (a *Adapter) FetchCycle(ctx context.Context, cycle int) (model.Cycle, error) {
+	url := fmt.Sprintf("%s/v1/cycles/%d", a.apiURL, cycle)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return model.Cycle{}, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return model.Cycle{}, unavailable(fmt.Errorf("error fetching cycle %d: %w", cycle, err))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			a.logger.Errorf("error closing response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.Cycle{}, unavailable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	var c model.Cycle
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return model.Cycle{}, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return c, nil
+}
+
 // FetchRewardsForCycle fetches rewards for a specific delegator and baker in a given cycle.
 func (a *Adapter) FetchRewardsForCycle(ctx context.Context, delegator model.WalletAddress, baker model.WalletAddress, cycle int) ([]model.Reward, error) {
 	// TzKT API endpoint for rewards
@@ -194,7 +224,7 @@ func (a *Adapter) FetchRewardsForCycle(ctx context.Context, delegator model.Wall
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching rewards: %w", err)
+		return nil, unavailable(fmt.Errorf("error fetching rewards: %w", err))
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -203,12 +233,12 @@ func (a *Adapter) FetchRewardsForCycle(ctx context.Context, delegator model.Wall
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, unavailable(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	// TzKT API response for rewards
 	var rewardsResponse struct {
-		RewardsShare float64 `json:"rewardsShare"`
+		RewardsShare int64 `json:"rewardsShare"`
 		Baker        struct {
 			Address string `json:"address"`
 		} `json:"baker"`
@@ -230,7 +260,7 @@ func (a *Adapter) FetchRewardsForCycle(ctx context.Context, delegator model.Wall
 		RecipientAddress: delegator,
 		SourceAddress:    model.WalletAddress(rewardsResponse.Baker.Address),
 		Cycle:            rewardsResponse.Cycle,
-		Amount:           rewardsResponse.RewardsShare,
+		Amount:           model.Mutez(rewardsResponse.RewardsShare),
 		Timestamp:        rewardsResponse.Timestamp,
 		TimestampTime:    time.Unix(rewardsResponse.Timestamp, 0).Format(time.RFC3339),
 	}
@@ -240,10 +270,10 @@ func (a *Adapter) FetchRewardsForCycle(ctx context.Context, delegator model.Wall
 
 // FetchWalletInfo fetches wallet information from the Tezos node.
 func (a *Adapter) FetchWalletInfo(blockID, walletAddress string) (model.WalletInfo, error) {
-	url := fmt.Sprintf("%s/chains/main/blocks/%s/context/contracts/%s", blockID, walletAddress)
+	url := fmt.Sprintf("%s/chains/main/blocks/%s/context/contracts/%s", a.apiURL, blockID, walletAddress)
 	resp, err := http.Get(url)
 	if err != nil {
-		return model.WalletInfo{}, err
+		return model.WalletInfo{}, unavailable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -306,7 +336,7 @@ func (a *Adapter) fetchDelegationOperations(ctx context.Context, filter tzktapi.
 	}
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, unavailable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -373,7 +403,7 @@ func (a *Adapter) fetchTransactionOperations(ctx context.Context, filter tzktapi
 	}
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, unavailable(err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -386,7 +416,7 @@ func (a *Adapter) fetchTransactionOperations(ctx context.Context, filter tzktapi
 		Sender     struct{ Address string } `json:"sender"`
 		Target     struct{ Address string } `json:"target"`
 		Entrypoint string                   `json:"entrypoint"`
-		Amount     float64                  `json:"amount"`
+		Amount     int64                    `json:"amount"`
 		Timestamp  time.Time                `json:"timestamp"`
 		Status     string                   `json:"status"`
 	}
@@ -401,7 +431,7 @@ func (a *Adapter) fetchTransactionOperations(ctx context.Context, filter tzktapi
 			Entrypoint: t.Entrypoint,
 			Wallet:     model.WalletAddress(t.Sender.Address),
 			Baker:      model.WalletAddress(t.Target.Address),
-			Amount:     t.Amount / 1_000_000, // µꜩ → ꜩ
+			Amount:     model.Mutez(t.Amount),
 			Timestamp:  t.Timestamp,
 			Status:     t.Status,
 		})
@@ -409,3 +439,8 @@ func (a *Adapter) fetchTransactionOperations(ctx context.Context, filter tzktapi
 
 	return ops, nil
 }
+
+// unavailable classifies a failed call to the TzKT API as tzktapi.ErrUnavailable.
+func unavailable(err error) error {
+	return fmt.Errorf("%w: %w", tzktapi.ErrUnavailable, err)
+}
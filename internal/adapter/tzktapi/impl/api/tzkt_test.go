@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"reflect"
@@ -122,6 +123,9 @@ func Test_Adapter_FetchDelegations(t *testing.T) {
 				t.Errorf("FetchDelegations() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr && !errors.Is(err, tzktapi.ErrUnavailable) {
+				t.Errorf("FetchDelegations() error = %v, want tzktapi.ErrUnavailable", err)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("FetchDelegations() got = %v, want %v", got, tt.want)
 			}
@@ -330,6 +334,66 @@ func Test_Adapter_GetCurrentCycle(t *testing.T) {
 	}
 }
 
+func Test_Adapter_FetchCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *http.Client
+		want    model.Cycle
+		wantErr bool
+	}{
+		{
+			name: "Nominal case",
+			client: httpClientMock(func(req *http.Request) *http.Response {
+				if req.URL.Path != "/v1/cycles/700" {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"index": 700, "firstLevel": 5160961, "lastLevel": 5185536}`)),
+				}
+			}),
+			want: model.Cycle{Index: 700, FirstLevel: 5160961, LastLevel: 5185536},
+		},
+		{
+			name: "Error case - API error",
+			client: httpClientMock(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader(``)),
+				}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Error case - invalid JSON",
+			client: httpClientMock(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{invalid`)),
+				}
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Adapter{
+				apiURL: "http://example.com",
+				client: tt.client,
+				logger: logrus.NewEntry(logrus.New()),
+			}
+			got, err := a.FetchCycle(context.Background(), 700)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FetchCycle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FetchCycle() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_Adapter_FetchRewardsForCycle(t *testing.T) {
 	type fields struct {
 		apiURL string
@@ -358,7 +422,7 @@ func Test_Adapter_FetchRewardsForCycle(t *testing.T) {
 					return &http.Response{
 						StatusCode: http.StatusOK,
 						Body: io.NopCloser(strings.NewReader(`{
-							"rewardsShare": 5.5,
+							"rewardsShare": 5500000,
 							"baker": {"address": "tz1baker1"},
 							"cycle": 10,
 							"timestamp": 1672531199
@@ -379,7 +443,7 @@ func Test_Adapter_FetchRewardsForCycle(t *testing.T) {
 					RecipientAddress: "tz1delegator1",
 					SourceAddress:    "tz1baker1",
 					Cycle:            10,
-					Amount:           5.5,
+					Amount:           5500000,
 					Timestamp:        1672531199,
 					TimestampTime:    time.Unix(1672531199, 0).Format(time.RFC3339),
 				},
@@ -27,7 +27,7 @@ func (m *Mock) FetchDelegations(ctx context.Context, limit uint16, offset int) (
 
 // FetchDelegationsFromLevel fetches delegations from a specific level.
 func (m *Mock) FetchDelegationsFromLevel(ctx context.Context, level uint64, limit uint8) (model.TzktDelegationResponse, error) {
-	args := m.Called(ctx, level)
+	args := m.Called(ctx, level, limit)
 	return args.Get(0).(model.TzktDelegationResponse), args.Error(1)
 }
 
@@ -61,6 +61,12 @@ func (m *Mock) GetCurrentCycle(ctx context.Context) (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+// FetchCycle fetches the first and last levels of a cycle from the TzKT API.
+func (m *Mock) FetchCycle(ctx context.Context, cycle int) (model.Cycle, error) {
+	args := m.Called(ctx, cycle)
+	return args.Get(0).(model.Cycle), args.Error(1)
+}
+
 // FetchRewardsForCycle fetches rewards for a specific delegator and baker in a given cycle.
 func (m *Mock) FetchRewardsForCycle(ctx context.Context, delegator model.WalletAddress, baker model.WalletAddress, cycle int) ([]model.Reward, error) {
 	args := m.Called(ctx, delegator, baker, cycle)
@@ -69,7 +69,7 @@ func Test_Mock_FetchDelegations(t *testing.T) {
 			name: "Nominal case",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegations", mock.Anything, 10, 0).
+				m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 					Return(stubTZKTDelegationResponse, nil)
 				return m
 			}(),
@@ -85,7 +85,7 @@ func Test_Mock_FetchDelegations(t *testing.T) {
 			name: "Error case - API error",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegations", mock.Anything, 10, 0).
+				m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 					Return(model.TzktDelegationResponse{}, errors.New("API error"))
 				return m
 			}(),
@@ -101,7 +101,7 @@ func Test_Mock_FetchDelegations(t *testing.T) {
 			name: "Error case - Invalid response",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegations", mock.Anything, 10, 0).
+				m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 					Return(model.TzktDelegationResponse{}, errors.New("invalid response"))
 				return m
 			}(),
@@ -146,7 +146,7 @@ func Test_Mock_FetchDelegationsFromLevel(t *testing.T) {
 			name: "Nominal case",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(100)).
 					Return(stubTZKTDelegationResponse, nil)
 				return m
 			}(),
@@ -162,7 +162,7 @@ func Test_Mock_FetchDelegationsFromLevel(t *testing.T) {
 			name: "Error case - API error",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(100)).
 					Return(model.TzktDelegationResponse{}, errors.New("API error"))
 				return m
 			}(),
@@ -178,7 +178,7 @@ func Test_Mock_FetchDelegationsFromLevel(t *testing.T) {
 			name: "Error case - Invalid response",
 			mock: func() *Mock {
 				m := New()
-				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+				m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(100)).
 					Return(model.TzktDelegationResponse{}, errors.New("invalid response"))
 				return m
 			}(),
@@ -29,6 +29,9 @@ type Adapter interface {
 	// GetCurrentCycle gets the current cycle from the TzKT API.
 	GetCurrentCycle(ctx context.Context) (int, error)
 
+	// FetchCycle fetches the first and last levels of a cycle from the TzKT API.
+	FetchCycle(ctx context.Context, cycle int) (model.Cycle, error)
+
 	// FetchRewardsForCycle fetches rewards for a specific delegator and baker in a given cycle.
 	FetchRewardsForCycle(ctx context.Context, delegator model.WalletAddress, baker model.WalletAddress, cycle int) ([]model.Reward, error)
 }
@@ -52,3 +52,101 @@ func (w *TelemetryWrapper) FetchDelegationsFromLevel(ctx context.Context, level
 
 	return result, err
 }
+
+// FetchOperationsFromTezos fetches operations from the Tezos node with telemetry.
+func (w *TelemetryWrapper) FetchOperationsFromTezos(blockID string) ([]model.Operation, error) {
+	startTime := time.Now()
+	endpoint := "operations_from_tezos"
+
+	result, err := w.adapter.FetchOperationsFromTezos(blockID)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// FetchRewardsForBaker fetches rewards for a baker with telemetry.
+func (w *TelemetryWrapper) FetchRewardsForBaker(blockID, bakerAddress string) (model.Reward, error) {
+	startTime := time.Now()
+	endpoint := "rewards_for_baker"
+
+	result, err := w.adapter.FetchRewardsForBaker(blockID, bakerAddress)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// FetchWalletInfo fetches wallet information with telemetry.
+func (w *TelemetryWrapper) FetchWalletInfo(blockID, walletAddress string) (model.WalletInfo, error) {
+	startTime := time.Now()
+	endpoint := "wallet_info"
+
+	result, err := w.adapter.FetchWalletInfo(blockID, walletAddress)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// FetchStakingOperations fetches staking operations with telemetry.
+func (w *TelemetryWrapper) FetchStakingOperations(ctx context.Context, filter tzktapi.OperationFilter) ([]model.StakingOperation, error) {
+	startTime := time.Now()
+	endpoint := "staking_operations"
+
+	result, err := w.adapter.FetchStakingOperations(ctx, filter)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// GetCurrentCycle gets the current cycle with telemetry.
+func (w *TelemetryWrapper) GetCurrentCycle(ctx context.Context) (int, error) {
+	startTime := time.Now()
+	endpoint := "current_cycle"
+
+	result, err := w.adapter.GetCurrentCycle(ctx)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// FetchCycle fetches the levels of a cycle with telemetry.
+func (w *TelemetryWrapper) FetchCycle(ctx context.Context, cycle int) (model.Cycle, error) {
+	startTime := time.Now()
+	endpoint := "cycle"
+
+	result, err := w.adapter.FetchCycle(ctx, cycle)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
+
+// FetchRewardsForCycle fetches rewards for a delegator in a cycle with telemetry.
+func (w *TelemetryWrapper) FetchRewardsForCycle(ctx context.Context, delegator model.WalletAddress, baker model.WalletAddress, cycle int) ([]model.Reward, error) {
+	startTime := time.Now()
+	endpoint := "rewards_for_cycle"
+
+	result, err := w.adapter.FetchRewardsForCycle(ctx, delegator, baker, cycle)
+
+	if w.metrics != nil {
+		w.metrics.RecordTZKTAPIRequest(endpoint, time.Since(startTime), err == nil)
+	}
+
+	return result, err
+}
@@ -128,7 +128,7 @@ func Test_TelemetryWrapper_FetchDelegations(t *testing.T) {
 	}
 	type args struct {
 		ctx    context.Context
-		limit  int
+		limit  uint16
 		offset int
 	}
 	tests := []struct {
@@ -143,7 +143,7 @@ func Test_TelemetryWrapper_FetchDelegations(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegations", mock.Anything, 10, 0).
+					m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 						Return(stubTZKTDelegationResponse, nil)
 					return m
 				}(),
@@ -163,7 +163,7 @@ func Test_TelemetryWrapper_FetchDelegations(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegations", mock.Anything, 10, 0).
+					m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 						Return(model.TzktDelegationResponse{}, errors.New("adapter error"))
 					return m
 				}(),
@@ -183,7 +183,7 @@ func Test_TelemetryWrapper_FetchDelegations(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegations", mock.Anything, 10, 0).
+					m.On("FetchDelegations", mock.Anything, uint16(10), 0).
 						Return(model.TzktDelegationResponse{}, context.Canceled)
 					return m
 				}(),
@@ -244,7 +244,7 @@ func Test_TelemetryWrapper_FetchDelegationsFromLevel(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(10)).
 						Return(stubTZKTDelegationResponse, nil)
 					return m
 				}(),
@@ -263,7 +263,7 @@ func Test_TelemetryWrapper_FetchDelegationsFromLevel(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(10)).
 						Return(model.TzktDelegationResponse{}, errors.New("adapter error"))
 					return m
 				}(),
@@ -282,7 +282,7 @@ func Test_TelemetryWrapper_FetchDelegationsFromLevel(t *testing.T) {
 			fields: fields{
 				adapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(10)).
 						Return(model.TzktDelegationResponse{}, context.Canceled)
 					return m
 				}(),
@@ -308,7 +308,7 @@ func Test_TelemetryWrapper_FetchDelegationsFromLevel(t *testing.T) {
 				implType: tt.fields.implType,
 				metrics:  tt.fields.metrics,
 			}
-			got, err := w.FetchDelegationsFromLevel(tt.args.ctx, tt.args.level)
+			got, err := w.FetchDelegationsFromLevel(tt.args.ctx, tt.args.level, 10)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FetchDelegationsFromLevel() error = %v, wantErr %v", err, tt.wantErr)
 				return
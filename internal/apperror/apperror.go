@@ -0,0 +1,94 @@
+package apperror
+
+import "errors"
+
+// Kind classifies an error by how its clients can handle it.
+type Kind string
+
+const (
+	// KindValidation is a request with invalid parameters or body.
+	KindValidation Kind = "validation"
+	// KindUnauthorized is a request without valid credentials.
+	KindUnauthorized Kind = "unauthorized"
+	// KindForbidden is a request whose credentials do not grant access.
+	KindForbidden Kind = "forbidden"
+	// KindNotFound is a request for a resource which does not exist.
+	KindNotFound Kind = "not_found"
+	// KindRateLimited is a request over a rate limit or quota.
+	KindRateLimited Kind = "rate_limited"
+	// KindUnavailable is a request a dependency, such as the database or the TzKT API, cannot serve for now.
+	KindUnavailable Kind = "unavailable"
+	// KindTimeout is a request a dependency did not serve in time.
+	KindTimeout Kind = "timeout"
+	// KindInternal is any other failure.
+	KindInternal Kind = "internal"
+)
+
+// Codes shared by the errors which are not defined by a use case or an adapter.
+const (
+	// CodeInvalidRequest is the code of the requests with invalid parameters or body.
+	CodeInvalidRequest = "invalid_request"
+	// CodeInternal is the code of the untyped errors.
+	CodeInternal = "internal_error"
+)
+
+// Error is an error of a Kind with a stable, machine-readable code. Its message is written for the clients, while
+// its cause, if any, is only logged.
+type Error struct {
+	// Kind classifies the error.
+	Kind Kind
+	// Code identifies the error, e.g. baker_not_found, and does not change across releases.
+	Code string
+	// Message describes the error to the clients.
+	Message string
+	// Err is the cause of the error.
+	Err error
+}
+
+// New returns an error of kind with a code and a message.
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap returns an error of kind with a code and a message, caused by err.
+func Wrap(err error, kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap allows errors.Is and errors.As to match the cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As returns the first *Error in the chain of err.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of the first *Error in the chain of err, KindInternal for untyped errors.
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// CodeOf returns the code of the first *Error in the chain of err, CodeInternal for untyped errors.
+func CodeOf(err error) string {
+	if e, ok := As(err); ok {
+		return e.Code
+	}
+	return CodeInternal
+}
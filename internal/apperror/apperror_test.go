@@ -0,0 +1,110 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Error_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{
+			name: "Nominal case",
+			err:  New(KindNotFound, "baker_not_found", "baker not found"),
+			want: "baker not found",
+		},
+		{
+			name: "Nominal case - with cause",
+			err:  Wrap(errors.New("connection refused"), KindUnavailable, "database_unavailable", "database unavailable"),
+			want: "database unavailable: connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
+
+func Test_Error_Unwrap(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("get bakers: %w", Wrap(cause, KindUnavailable, "database_unavailable", "database unavailable"))
+
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, New(KindInternal, CodeInternal, "internal error").Unwrap())
+}
+
+func Test_As(t *testing.T) {
+	sentinel := New(KindNotFound, "baker_not_found", "baker not found")
+	tests := []struct {
+		name   string
+		err    error
+		want   *Error
+		wantOK bool
+	}{
+		{
+			name:   "Nominal case",
+			err:    sentinel,
+			want:   sentinel,
+			wantOK: true,
+		},
+		{
+			name:   "Nominal case - wrapped",
+			err:    fmt.Errorf("%w: tz1", sentinel),
+			want:   sentinel,
+			wantOK: true,
+		},
+		{
+			name:   "Error case - untyped",
+			err:    errors.New("boom"),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := As(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_KindOf_CodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind Kind
+		wantCode string
+	}{
+		{
+			name:     "Nominal case",
+			err:      New(KindValidation, "invalid_cursor", "invalid cursor"),
+			wantKind: KindValidation,
+			wantCode: "invalid_cursor",
+		},
+		{
+			name:     "Nominal case - outermost typed error",
+			err:      Wrap(New(KindTimeout, "database_timeout", "database query timed out"), KindUnavailable, "upstream_unavailable", "TzKT API unavailable"),
+			wantKind: KindUnavailable,
+			wantCode: "upstream_unavailable",
+		},
+		{
+			name:     "Error case - untyped",
+			err:      errors.New("boom"),
+			wantKind: KindInternal,
+			wantCode: CodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantKind, KindOf(tt.err))
+			assert.Equal(t, tt.wantCode, CodeOf(tt.err))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// StakingOperationCount is the number of staking operations of a wallet with an entrypoint.
+type StakingOperationCount struct {
+	Entrypoint string `db:"entrypoint" json:"entrypoint"`
+	Count      int64  `db:"count" json:"count"`
+}
+
+// BakerChange represents a delegator moving to a baker, or leaving its baker when Baker is empty.
+type BakerChange struct {
+	Baker         WalletAddress `json:"baker"`
+	Level         int64         `json:"level"`
+	TimestampTime string        `json:"timestamp"`
+}
+
+// AccountProfile is the response format of a single address.
+type AccountProfile struct {
+	Address            WalletAddress    `json:"address"`
+	Alias              string           `json:"alias,omitempty"`
+	Type               AccountType      `json:"type"`
+	FirstSeenLevel     int64            `json:"first_seen_level,omitempty"`
+	LastActiveLevel    int64            `json:"last_active_level,omitempty"`
+	CurrentBaker       WalletAddress    `json:"current_baker,omitempty"`
+	DelegationTimeline []BakerChange    `json:"delegation_timeline"`
+	TotalRewards       Mutez            `json:"total_rewards"`
+	TotalRewardsTez    string           `json:"total_rewards_tez"`
+	StakingOperations  map[string]int64 `json:"staking_operations"`
+}
+
+// BakerChangesFromDelegations returns the baker changes of delegations sorted by increasing level,
+// skipping the delegations which keep the baker of the previous one.
+func BakerChangesFromDelegations(delegations []Delegation) []BakerChange {
+	changes := make([]BakerChange, 0, len(delegations))
+	for i, d := range delegations {
+		if i > 0 && d.Delegate == delegations[i-1].Delegate {
+			continue
+		}
+		changes = append(changes, BakerChange{
+			Baker:         d.Delegate,
+			Level:         d.Level,
+			TimestampTime: time.Unix(d.Timestamp, 0).UTC().Format(time.RFC3339),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BakerChangesFromDelegations(t *testing.T) {
+	tests := []struct {
+		name        string
+		delegations []Delegation
+		want        []BakerChange
+	}{
+		{
+			name: "Nominal case",
+			delegations: []Delegation{
+				{Delegate: "tz1old", Level: 100, Timestamp: 0},
+				{Delegate: "tz1old", Level: 150, Timestamp: 60},
+				{Delegate: "tz1new", Level: 200, Timestamp: 120},
+				{Delegate: "", Level: 300, Timestamp: 180},
+			},
+			want: []BakerChange{
+				{Baker: "tz1old", Level: 100, TimestampTime: "1970-01-01T00:00:00Z"},
+				{Baker: "tz1new", Level: 200, TimestampTime: "1970-01-01T00:02:00Z"},
+				{Baker: "", Level: 300, TimestampTime: "1970-01-01T00:03:00Z"},
+			},
+		},
+		{
+			name: "No delegation",
+			want: []BakerChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BakerChangesFromDelegations(tt.delegations))
+		})
+	}
+}
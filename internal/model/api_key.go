@@ -0,0 +1,49 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// APIKey is a key authenticating the API requests of a client, with its own rate limit and daily quota.
+// Only the SHA-256 hash of the key is stored, its prefix identifies it in listings and metrics.
+type APIKey struct {
+	ID     int64  `json:"id" db:"id"`
+	Name   string `json:"name" db:"name"`
+	Prefix string `json:"prefix" db:"prefix"`
+	Hash   string `json:"-" db:"key_hash"`
+	// RateLimit is the number of requests per minute, 0 for the configured default.
+	RateLimit int `json:"rate_limit" db:"rate_limit"`
+	// DailyQuota is the number of requests per UTC day, 0 for the configured default.
+	DailyQuota int        `json:"daily_quota" db:"daily_quota"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// IsRevoked reports whether the key no longer authenticates requests.
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// APIKeyPrefix prefixes the API keys, so that they are recognized when leaked, and told apart from the other bearer
+// tokens such as the admin token.
+const APIKeyPrefix = "tzd_"
+
+// HashAPIKey returns the hash under which a key is stored.
+// Keys are random, so a fast unsalted hash is enough to make a leaked table unusable.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreatedAPIKey is the response of a key creation, the only one exposing the key itself.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeysResponse represents the API response of the key listing.
+type APIKeysResponse struct {
+	APIKeys []APIKey `json:"data"`
+}
@@ -0,0 +1,104 @@
+package model
+
+import "strings"
+
+// BakerSort is a field the baker directory can be sorted by.
+type BakerSort string
+
+const (
+	// BakerSortDelegators sorts bakers by their current number of delegators.
+	BakerSortDelegators BakerSort = "delegators"
+	// BakerSortDelegatedVolume sorts bakers by the volume delegated to them over the period.
+	BakerSortDelegatedVolume BakerSort = "delegated_volume"
+	// BakerSortRewards sorts bakers by the rewards they distributed over the period.
+	BakerSortRewards BakerSort = "rewards"
+	// BakerSortNetInflow sorts bakers by their new delegations minus their undelegations over the period.
+	BakerSortNetInflow BakerSort = "net_inflow"
+)
+
+// String returns the string representation of the BakerSort.
+func (s BakerSort) String() string {
+	return string(s)
+}
+
+// IsValid checks if the BakerSort is a known sort field.
+func (s BakerSort) IsValid() bool {
+	switch s {
+	case BakerSortDelegators, BakerSortDelegatedVolume, BakerSortRewards, BakerSortNetInflow:
+		return true
+	}
+	return false
+}
+
+// BakerFilter holds the search, period and order of the baker directory.
+// The period, from FromDate included to ToDate excluded in unix seconds, bounds the delegation activity and the
+// rewards, where 0 leaves a bound open. Bakers are sorted by decreasing delegators by default.
+type BakerFilter struct {
+	Search   string
+	FromDate int64
+	ToDate   int64
+	Sort     BakerSort
+	Order    SortOrder
+}
+
+// SortField returns the sort field, delegators by default.
+func (f BakerFilter) SortField() BakerSort {
+	if f.Sort == "" {
+		return BakerSortDelegators
+	}
+	return f.Sort
+}
+
+// SortOrder returns the sort order, descending by default.
+func (f BakerFilter) SortOrder() SortOrder {
+	if f.Order == "" {
+		return SortOrderDesc
+	}
+	return f.Order
+}
+
+// MatchSearch reports whether the alias of a baker contains the search, ignoring case.
+func (f BakerFilter) MatchSearch(alias string) bool {
+	return f.Search == "" || strings.Contains(strings.ToLower(alias), strings.ToLower(f.Search))
+}
+
+// InPeriod reports whether timestamp is within the period of the filter.
+func (f BakerFilter) InPeriod(timestamp int64) bool {
+	return (f.FromDate <= 0 || timestamp >= f.FromDate) && (f.ToDate <= 0 || timestamp < f.ToDate)
+}
+
+// Baker represents a staking pool with its current delegators and its activity over a period.
+// NetInflow is the number of new delegations minus the number of delegators who left the baker.
+type Baker struct {
+	Address            WalletAddress `db:"address" json:"address"`
+	Alias              string        `db:"alias" json:"alias,omitempty"`
+	Delegators         int64         `db:"delegators" json:"delegators"`
+	StakingBalance     Mutez         `db:"staking_balance" json:"staking_balance"`
+	StakingBalanceTez  string        `db:"-" json:"staking_balance_tez"`
+	DelegatedVolume    Mutez         `db:"delegated_volume" json:"delegated_volume"`
+	DelegatedVolumeTez string        `db:"-" json:"delegated_volume_tez"`
+	Rewards            Mutez         `db:"rewards" json:"rewards"`
+	RewardsTez         string        `db:"-" json:"rewards_tez"`
+	NewDelegations     int64         `db:"new_delegations" json:"new_delegations"`
+	Undelegations      int64         `db:"undelegations" json:"undelegations"`
+	NetInflow          int64         `db:"net_inflow" json:"net_inflow"`
+}
+
+// SetTez sets the tez representations of the amounts of the baker.
+func (b *Baker) SetTez() {
+	b.StakingBalanceTez = b.StakingBalance.Tez()
+	b.DelegatedVolumeTez = b.DelegatedVolume.Tez()
+	b.RewardsTez = b.Rewards.Tez()
+}
+
+// BakersResponse is the response format of the baker directory.
+type BakersResponse struct {
+	Bakers     []Baker        `json:"data"`
+	Pagination PaginationInfo `json:"-"`
+}
+
+// BakerResponse is the response format of a baker with its per-cycle history.
+type BakerResponse struct {
+	Baker
+	History []BakerCycleRewards `json:"history"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BakerFilter(t *testing.T) {
+	assert.Equal(t, BakerSortDelegators, BakerFilter{}.SortField())
+	assert.Equal(t, SortOrderDesc, BakerFilter{}.SortOrder())
+	assert.Equal(t, SortOrderAsc, BakerFilter{Order: SortOrderAsc}.SortOrder())
+
+	assert.True(t, BakerFilter{}.MatchSearch("Alpha Baker"))
+	assert.True(t, BakerFilter{Search: "alpha"}.MatchSearch("Alpha Baker"))
+	assert.False(t, BakerFilter{Search: "beta"}.MatchSearch("Alpha Baker"))
+
+	period := BakerFilter{FromDate: 100, ToDate: 200}
+	assert.True(t, period.InPeriod(100))
+	assert.False(t, period.InPeriod(200))
+	assert.False(t, period.InPeriod(99))
+	assert.True(t, BakerFilter{}.InPeriod(0))
+
+	assert.True(t, BakerSortNetInflow.IsValid())
+	assert.False(t, BakerSort("alias").IsValid())
+}
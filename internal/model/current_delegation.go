@@ -0,0 +1,46 @@
+package model
+
+// CurrentDelegation represents the current delegation state of a delegator.
+// An undelegated delegator keeps its row with an empty Baker, so that older delegations never overwrite it.
+type CurrentDelegation struct {
+	Delegator  WalletAddress `db:"delegator" json:"delegator"`
+	Baker      WalletAddress `db:"baker" json:"baker"`
+	SinceLevel int64         `db:"since_level" json:"since_level"`
+	Balance    Mutez         `db:"balance" json:"balance"`
+	BalanceTez string        `db:"-" json:"balance_tez"`
+}
+
+// CurrentDelegationsFromDelegations returns the current delegation state set by the latest delegation of every delegator.
+func CurrentDelegationsFromDelegations(delegations []*Delegation) []CurrentDelegation {
+	latest := make(map[WalletAddress]int, len(delegations))
+	current := make([]CurrentDelegation, 0, len(delegations))
+
+	for _, d := range delegations {
+		state := CurrentDelegation{
+			Delegator:  d.Delegator,
+			Baker:      d.Delegate,
+			SinceLevel: d.Level,
+			Balance:    d.Amount,
+		}
+
+		i, ok := latest[d.Delegator]
+		if !ok {
+			latest[d.Delegator] = len(current)
+			current = append(current, state)
+			continue
+		}
+		if d.Level > current[i].SinceLevel {
+			current[i] = state
+		}
+	}
+
+	return current
+}
+
+// BakerDelegatorsResponse is the response format of the delegators of a baker, currently or at a level.
+type BakerDelegatorsResponse struct {
+	Delegators []CurrentDelegation `json:"data"`
+	AsOfLevel  int64               `json:"as_of_level,omitempty"`
+	AsOfCycle  int                 `json:"as_of_cycle,omitempty"`
+	Pagination PaginationInfo      `json:"-"`
+}
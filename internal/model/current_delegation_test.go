@@ -0,0 +1,32 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CurrentDelegationsFromDelegations(t *testing.T) {
+	got := CurrentDelegationsFromDelegations([]*Delegation{
+		{Delegator: "tz1a", Delegate: "tz1baker1", Amount: 10, Level: 100},
+		{Delegator: "tz1b", Delegate: "tz1baker1", Amount: 20, Level: 101},
+		{Delegator: "tz1a", Delegate: "", Amount: 10, Level: 300},
+		{Delegator: "tz1a", Delegate: "tz1baker2", Amount: 15, Level: 200},
+	})
+
+	assert.Equal(t, []CurrentDelegation{
+		{Delegator: "tz1a", Baker: "", SinceLevel: 300, Balance: 10},
+		{Delegator: "tz1b", Baker: "tz1baker1", SinceLevel: 101, Balance: 20},
+	}, got)
+}
+
+func Test_BakerDelegatorsResponse_JSON(t *testing.T) {
+	jsonData, err := json.Marshal(BakerDelegatorsResponse{
+		Delegators: []CurrentDelegation{},
+		AsOfLevel:  100,
+		Pagination: PaginationInfo{CurrentPage: 2, PerPage: 10},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data":[],"as_of_level":100}`, string(jsonData))
+}
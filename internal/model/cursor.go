@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/tezos-delegation-service/internal/apperror"
+)
+
+// ErrInvalidCursor is returned when a cursor token cannot be decoded.
+var ErrInvalidCursor = apperror.New(apperror.KindValidation, "invalid_cursor", "invalid cursor")
+
+// Cursor is the position of a row in a list ordered by decreasing timestamp and id.
+// Pages following a cursor start with the row right after it, so they stay stable when rows are added.
+type Cursor struct {
+	Timestamp int64
+	ID        int64
+}
+
+// NewCursor returns the cursor of the row with the given timestamp and id.
+func NewCursor(timestamp, id int64) *Cursor {
+	return &Cursor{Timestamp: timestamp, ID: id}
+}
+
+// Encode returns the cursor as an opaque URL safe token.
+func (c Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Timestamp, 10) + ":" + strconv.FormatInt(c.ID, 10)))
+}
+
+// After reports whether a row with the given timestamp and id comes after the cursor in decreasing order.
+func (c Cursor) After(timestamp, id int64) bool {
+	return timestamp < c.Timestamp || (timestamp == c.Timestamp && id < c.ID)
+}
+
+// ParseCursor decodes a token returned by Cursor.Encode.
+func ParseCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	timestampStr, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil || timestamp < 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return NewCursor(timestamp, id), nil
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    *Cursor
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Nominal case",
+			token:   Cursor{Timestamp: 1740787200, ID: 42}.Encode(),
+			want:    &Cursor{Timestamp: 1740787200, ID: 42},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Error case - not base64",
+			token:   "not a cursor!",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - missing id",
+			token:   "MTc0MDc4NzIwMA",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - negative id",
+			token:   Cursor{Timestamp: 1740787200, ID: -1}.Encode(),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCursor(tt.token)
+			if !tt.wantErr(t, err, "ParseCursor()") {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Cursor_After(t *testing.T) {
+	c := Cursor{Timestamp: 100, ID: 10}
+
+	assert.True(t, c.After(99, 50))
+	assert.True(t, c.After(100, 9))
+	assert.False(t, c.After(100, 10))
+	assert.False(t, c.After(101, 1))
+}
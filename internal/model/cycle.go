@@ -0,0 +1,8 @@
+package model
+
+// Cycle represents the block levels of a Tezos cycle.
+type Cycle struct {
+	Index      int   `db:"cycle" json:"index"`
+	FirstLevel int64 `db:"first_level" json:"firstLevel"`
+	LastLevel  int64 `db:"last_level" json:"lastLevel"`
+}
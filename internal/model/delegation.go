@@ -9,7 +9,8 @@ type Delegation struct {
 	Delegate      WalletAddress `db:"delegate" json:"delegate"`
 	Timestamp     int64         `db:"timestamp" json:"-"`
 	TimestampTime string        `db:"-" json:"timestamp"`
-	Amount        float64       `db:"amount" json:"amount"`
+	Amount        Mutez         `db:"amount" json:"amount"`
+	AmountTez     string        `db:"-" json:"amount_tez"`
 	Level         int64         `db:"level" json:"level"`
 	CreatedAt     time.Time     `db:"created_at" json:"-"`
 }
@@ -27,6 +28,134 @@ type PaginationInfo struct {
 // DelegationsResponse is the response format for the API.
 type DelegationsResponse struct {
 	Delegations     []Delegation   `json:"data"`
-	Pagination      PaginationInfo `json:"-,omitempty"`
+	NextCursor      string         `json:"next_cursor,omitempty"`
+	Pagination      PaginationInfo `json:"-"`
 	MaxDelegationID int64          `json:"-"`
 }
+
+// DelegationKind represents the kind of delegation event.
+type DelegationKind string
+
+var (
+	// DelegationKindDelegation represents a delegation to a baker.
+	DelegationKindDelegation DelegationKind = "delegation"
+	// DelegationKindUndelegation represents a delegator leaving its baker, saved with an empty delegate.
+	DelegationKindUndelegation DelegationKind = "undelegation"
+)
+
+// String returns the string representation of the delegation kind.
+func (k DelegationKind) String() string {
+	return string(k)
+}
+
+// IsValid checks if the delegation kind is valid.
+func (k DelegationKind) IsValid() bool {
+	return k == DelegationKindDelegation || k == DelegationKindUndelegation
+}
+
+// DelegationSort represents the field delegations are sorted by.
+type DelegationSort string
+
+var (
+	// DelegationSortTimestamp sorts delegations by timestamp.
+	DelegationSortTimestamp DelegationSort = "timestamp"
+	// DelegationSortLevel sorts delegations by block level.
+	DelegationSortLevel DelegationSort = "level"
+	// DelegationSortAmount sorts delegations by amount.
+	DelegationSortAmount DelegationSort = "amount"
+)
+
+// String returns the string representation of the delegation sort.
+func (s DelegationSort) String() string {
+	return string(s)
+}
+
+// IsValid checks if the delegation sort is valid.
+func (s DelegationSort) IsValid() bool {
+	switch s {
+	case DelegationSortTimestamp, DelegationSortLevel, DelegationSortAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortOrder represents the direction of a sort.
+type SortOrder string
+
+var (
+	// SortOrderAsc sorts in increasing order.
+	SortOrderAsc SortOrder = "asc"
+	// SortOrderDesc sorts in decreasing order.
+	SortOrderDesc SortOrder = "desc"
+)
+
+// IsValid checks if the sort order is valid.
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
+
+// DelegationFilter holds the optional filters and the order of a delegation list, zero values disable a filter.
+// Level bounds and amount bounds are inclusive, FromDate is inclusive and ToDate exclusive, like the Year bounds.
+type DelegationFilter struct {
+	Year      uint16
+	Delegator WalletAddress
+	Delegate  WalletAddress
+	FromLevel int64
+	ToLevel   int64
+	FromDate  int64
+	ToDate    int64
+	MinAmount *Mutez
+	MaxAmount *Mutez
+	Kind      DelegationKind
+	Sort      DelegationSort
+	Order     SortOrder
+}
+
+// SortField returns the sort field, timestamp by default.
+func (f DelegationFilter) SortField() DelegationSort {
+	if f.Sort == "" {
+		return DelegationSortTimestamp
+	}
+	return f.Sort
+}
+
+// SortOrder returns the sort order, decreasing by default.
+func (f DelegationFilter) SortOrder() SortOrder {
+	if f.Order == "" {
+		return SortOrderDesc
+	}
+	return f.Order
+}
+
+// IsDefaultOrder reports whether delegations are listed by decreasing timestamp, the only order cursors paginate.
+func (f DelegationFilter) IsDefaultOrder() bool {
+	return f.SortField() == DelegationSortTimestamp && f.SortOrder() == SortOrderDesc
+}
+
+// Match reports whether a delegation passes the filters, the year excepted.
+func (f DelegationFilter) Match(d Delegation) bool {
+	switch {
+	case f.Delegator != "" && d.Delegator != f.Delegator:
+		return false
+	case f.Delegate != "" && d.Delegate != f.Delegate:
+		return false
+	case f.FromLevel > 0 && d.Level < f.FromLevel:
+		return false
+	case f.ToLevel > 0 && d.Level > f.ToLevel:
+		return false
+	case f.FromDate > 0 && d.Timestamp < f.FromDate:
+		return false
+	case f.ToDate > 0 && d.Timestamp >= f.ToDate:
+		return false
+	case f.MinAmount != nil && d.Amount < *f.MinAmount:
+		return false
+	case f.MaxAmount != nil && d.Amount > *f.MaxAmount:
+		return false
+	case f.Kind == DelegationKindDelegation && d.Delegate == "":
+		return false
+	case f.Kind == DelegationKindUndelegation && d.Delegate != "":
+		return false
+	}
+	return true
+}
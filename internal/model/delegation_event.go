@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// DelegationEvent is a delegation saved by the indexer, as pushed to the subscribers of the live stream.
+// ID is the id of the delegation, which a subscriber sends back to resume the stream after it.
+type DelegationEvent struct {
+	ID         int64          `json:"id"`
+	Kind       DelegationKind `json:"kind"`
+	Delegation Delegation     `json:"delegation"`
+}
+
+// NewDelegationEvent returns the event of a saved delegation, with its formatted timestamp and amount.
+func NewDelegationEvent(d Delegation) DelegationEvent {
+	kind := DelegationKindDelegation
+	if d.Delegate == "" {
+		kind = DelegationKindUndelegation
+	}
+
+	d.TimestampTime = time.Unix(d.Timestamp, 0).UTC().Format(time.RFC3339)
+	d.AmountTez = d.Amount.Tez()
+	return DelegationEvent{ID: d.ID, Kind: kind, Delegation: d}
+}
@@ -14,15 +14,15 @@ func Test_Delegation(t *testing.T) {
 		ID:        1,
 		Delegator: "tz1abc",
 		Timestamp: now,
-		Amount:    100.0,
+		Amount:    100,
 		Level:     12345,
 		CreatedAt: time.Now(),
 	}
 
 	assert.Equal(t, int64(1), delegation.ID)
-	assert.Equal(t, "tz1abc", delegation.Delegator)
+	assert.Equal(t, WalletAddress("tz1abc"), delegation.Delegator)
 	assert.Equal(t, now, delegation.Timestamp)
-	assert.Equal(t, 100.0, delegation.Amount)
+	assert.Equal(t, Mutez(100), delegation.Amount)
 	assert.Equal(t, int64(12345), delegation.Level)
 }
 
@@ -33,7 +33,7 @@ func Test_DelegationJSON(t *testing.T) {
 		Delegator: "tz1abc",
 		Delegate:  "tz1def",
 		Timestamp: now,
-		Amount:    100.0,
+		Amount:    100,
 		Level:     12345,
 		CreatedAt: time.Now(),
 	}
@@ -47,7 +47,7 @@ func Test_DelegationJSON(t *testing.T) {
 
 	assert.Equal(t, "tz1abc", jsonMap["delegator"])
 	assert.Equal(t, "tz1def", jsonMap["delegate"])
-	assert.Equal(t, 100.0, jsonMap["amount"])
+	assert.Equal(t, "100", jsonMap["amount"])
 	assert.Equal(t, float64(12345), jsonMap["level"])
 
 	_, hasID := jsonMap["id"]
@@ -63,7 +63,7 @@ func Test_DelegationResponse(t *testing.T) {
 			ID:        1,
 			Delegator: "tz1abc",
 			Timestamp: now,
-			Amount:    100.0,
+			Amount:    100,
 			Level:     12345,
 			CreatedAt: time.Now(),
 		},
@@ -71,7 +71,7 @@ func Test_DelegationResponse(t *testing.T) {
 			ID:        2,
 			Delegator: "tz1def",
 			Timestamp: now - 100,
-			Amount:    200.0,
+			Amount:    200,
 			Level:     12346,
 			CreatedAt: time.Now(),
 		},
@@ -84,24 +84,26 @@ func Test_DelegationResponse(t *testing.T) {
 		HasNextPage: false,
 	}
 
-	response := DelegationResponse{
-		Data:       delegations,
-		Pagination: pagination,
+	response := DelegationsResponse{
+		Delegations: delegations,
+		Pagination:  pagination,
 	}
 
-	assert.Equal(t, delegations, response.Data)
+	assert.Equal(t, delegations, response.Delegations)
 	assert.Equal(t, pagination, response.Pagination)
 
 	jsonData, err := json.Marshal(response)
 	assert.NoError(t, err)
 
-	var parsedResponse DelegationResponse
+	var parsedResponse DelegationsResponse
 	err = json.Unmarshal(jsonData, &parsedResponse)
 	assert.NoError(t, err)
 
-	assert.Equal(t, len(delegations), len(parsedResponse.Data))
-	assert.Equal(t, delegations[0].Delegator, parsedResponse.Data[0].Delegator)
-	assert.Equal(t, pagination.CurrentPage, parsedResponse.Pagination.CurrentPage)
+	assert.Equal(t, len(delegations), len(parsedResponse.Delegations))
+	assert.Equal(t, delegations[0].Delegator, parsedResponse.Delegations[0].Delegator)
+	// The pagination is sent in headers, not in the body.
+	assert.NotContains(t, string(jsonData), `"-"`)
+	assert.Equal(t, PaginationInfo{}, parsedResponse.Pagination)
 }
 
 func Test_PaginationInfo(t *testing.T) {
@@ -135,3 +137,72 @@ func Test_PaginationInfo(t *testing.T) {
 	assert.Equal(t, float64(1), jsonMap["prev_page"])
 	assert.Equal(t, float64(3), jsonMap["next_page"])
 }
+
+func Test_DelegationFilter_Match(t *testing.T) {
+	minAmount := Mutez(100)
+	maxAmount := Mutez(0)
+	delegation := Delegation{Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 1000, Amount: 150, Level: 10}
+
+	tests := []struct {
+		name   string
+		filter DelegationFilter
+		d      Delegation
+		want   bool
+	}{
+		{
+			name: "Nominal case - no filter",
+			d:    delegation,
+			want: true,
+		},
+		{
+			name:   "Every filter matching",
+			filter: DelegationFilter{Delegator: "tz1a", Delegate: "tz1baker", FromLevel: 10, ToLevel: 10, FromDate: 1000, ToDate: 1001, MinAmount: &minAmount, Kind: DelegationKindDelegation},
+			d:      delegation,
+			want:   true,
+		},
+		{
+			name:   "Other delegator",
+			filter: DelegationFilter{Delegator: "tz1b"},
+			d:      delegation,
+		},
+		{
+			name:   "Level above the range",
+			filter: DelegationFilter{ToLevel: 9},
+			d:      delegation,
+		},
+		{
+			name:   "ToDate is exclusive",
+			filter: DelegationFilter{ToDate: 1000},
+			d:      delegation,
+		},
+		{
+			name:   "Amount above the maximum",
+			filter: DelegationFilter{MaxAmount: &maxAmount},
+			d:      delegation,
+		},
+		{
+			name:   "Undelegation only",
+			filter: DelegationFilter{Kind: DelegationKindUndelegation},
+			d:      delegation,
+		},
+		{
+			name:   "Undelegation matching",
+			filter: DelegationFilter{Kind: DelegationKindUndelegation, MaxAmount: &maxAmount},
+			d:      Delegation{Delegator: "tz1a"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.d))
+		})
+	}
+}
+
+func Test_DelegationFilter_IsDefaultOrder(t *testing.T) {
+	assert.True(t, DelegationFilter{}.IsDefaultOrder())
+	assert.True(t, DelegationFilter{Sort: DelegationSortTimestamp, Order: SortOrderDesc}.IsDefaultOrder())
+	assert.False(t, DelegationFilter{Order: SortOrderAsc}.IsDefaultOrder())
+	assert.False(t, DelegationFilter{Sort: DelegationSortAmount}.IsDefaultOrder())
+	assert.Equal(t, DelegationSortLevel, DelegationFilter{Sort: DelegationSortLevel}.SortField())
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Mutez represents an amount of tez in its smallest unit (1 tez = 1,000,000 mutez).
+type Mutez int64
+
+// MutezPerTez is the number of mutez in one tez.
+const MutezPerTez Mutez = 1_000_000
+
+// tezDecimals is the number of decimals of a tez amount.
+const tezDecimals = 6
+
+// tezDigits matches the integer and decimal parts of a tez amount.
+var tezDigits = regexp.MustCompile(`^[0-9]+$`)
+
+// Int64 returns the amount in mutez as an int64.
+func (m Mutez) Int64() int64 {
+	return int64(m)
+}
+
+// String returns the amount in mutez as a base 10 integer string.
+func (m Mutez) String() string {
+	return strconv.FormatInt(int64(m), 10)
+}
+
+// Tez returns the exact amount in tez as a decimal string with 6 decimals (e.g. "1.250000").
+func (m Mutez) Tez() string {
+	sign := ""
+	abs := uint64(m)
+	if m < 0 {
+		sign = "-"
+		abs = uint64(-m)
+	}
+	return fmt.Sprintf("%s%d.%06d", sign, abs/uint64(MutezPerTez), abs%uint64(MutezPerTez))
+}
+
+// TezFloat returns the amount in tez as a float64.
+// It is lossy and must only be used for display or metrics, never for accounting.
+func (m Mutez) TezFloat() float64 {
+	return float64(m) / float64(MutezPerTez)
+}
+
+// MarshalJSON encodes the amount as a JSON string of mutez so that it is not rounded by JSON clients.
+func (m Mutez) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes an amount of mutez given either as a JSON string or a JSON integer.
+func (m *Mutez) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*m = 0
+		return nil
+	}
+
+	parsed, err := ParseMutez(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ParseMutez parses a base 10 integer string of mutez.
+func ParseMutez(s string) (Mutez, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid mutez amount %q: %w", s, err)
+	}
+	return Mutez(v), nil
+}
+
+// ParseTez parses a decimal tez string (e.g. "1.25") into mutez without going through float64.
+func ParseTez(s string) (Mutez, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty tez amount")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if intPart == "" && hasFrac {
+		intPart = "0"
+	}
+	if !tezDigits.MatchString(intPart) || (hasFrac && !tezDigits.MatchString(fracPart)) {
+		return 0, fmt.Errorf("invalid tez amount %q: not a decimal number", s)
+	}
+	if len(fracPart) > tezDecimals {
+		return 0, fmt.Errorf("invalid tez amount %q: more than %d decimals", s, tezDecimals)
+	}
+	fracPart += strings.Repeat("0", tezDecimals-len(fracPart))
+
+	whole, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tez amount %q: %w", s, err)
+	}
+	frac, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tez amount %q: %w", s, err)
+	}
+
+	if whole > (1<<63-1-frac)/int64(MutezPerTez) {
+		return 0, fmt.Errorf("invalid tez amount %q: out of range", s)
+	}
+
+	amount := Mutez(whole)*MutezPerTez + Mutez(frac)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
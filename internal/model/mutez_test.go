@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mutez_Tez(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Mutez
+		want string
+	}{
+		{
+			name: "Nominal case",
+			m:    1250000,
+			want: "1.250000",
+		},
+		{
+			name: "Less than one tez",
+			m:    42,
+			want: "0.000042",
+		},
+		{
+			name: "Zero",
+			m:    0,
+			want: "0.000000",
+		},
+		{
+			name: "Negative amount",
+			m:    -1500001,
+			want: "-1.500001",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.m.Tez())
+		})
+	}
+}
+
+func Test_ParseTez(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Mutez
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Nominal case",
+			s:       "1.25",
+			want:    1250000,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Integer amount",
+			s:       "3",
+			want:    3000000,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Smallest unit",
+			s:       "0.000001",
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Negative amount",
+			s:       "-0.5",
+			want:    -500000,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Explicit positive sign",
+			s:       "+1.5",
+			want:    1500000,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Error case - too many decimals",
+			s:       "0.0000001",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - not a number",
+			s:       "abc",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - empty",
+			s:       "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - sign in the decimals",
+			s:       "1.-5",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - double sign",
+			s:       "--1",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - mixed signs",
+			s:       "+-1",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - sign only",
+			s:       "-",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "Error case - empty decimals",
+			s:       "1.",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTez(tt.s)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Mutez_JSON(t *testing.T) {
+	type payload struct {
+		Amount Mutez `json:"amount"`
+	}
+
+	jsonData, err := json.Marshal(payload{Amount: 9007199254740993})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"9007199254740993"}`, string(jsonData))
+
+	var fromString payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":"125896"}`), &fromString))
+	assert.Equal(t, Mutez(125896), fromString.Amount)
+
+	var fromNumber payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":125896}`), &fromNumber))
+	assert.Equal(t, Mutez(125896), fromNumber.Amount)
+
+	var invalid payload
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":"1.5"}`), &invalid))
+}
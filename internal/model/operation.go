@@ -37,7 +37,8 @@ type Operation struct {
 	SenderAddress   WalletAddress `db:"sender_address" json:"sender_address"`
 	ContractAddress WalletAddress `db:"contract_address" json:"contract_address"`
 	Entrypoint      string        `db:"entrypoint" json:"entrypoint"`
-	Amount          float64       `db:"amount" json:"amount"`
+	Amount          Mutez         `db:"amount" json:"amount"`
+	AmountTez       string        `db:"-" json:"amount_tez"`
 	Block           string        `db:"block" json:"block"`
 	Timestamp       int64         `db:"timestamp" json:"-"`
 	TimestampTime   string        `db:"-" json:"timestamp"`
@@ -48,5 +49,6 @@ type Operation struct {
 // OperationsResponse is the response format for the API.
 type OperationsResponse struct {
 	Operations []Operation    `json:"data"`
-	Pagination PaginationInfo `json:"-,omitempty"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"-"`
 }
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Currency is an ISO 4217 fiat currency code, such as EUR or USD.
+type Currency string
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsValid checks if the currency is made of three uppercase letters.
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Price is the price of one tez in a fiat currency on a UTC day.
+// The price is an exact decimal string, so that the fiat values of the reports are not rounded by floats.
+type Price struct {
+	Day      int64    `db:"day" json:"day"`
+	Currency Currency `db:"currency" json:"currency"`
+	Price    string   `db:"price" json:"price"`
+}
+
+// priceFormat matches the non-negative decimal prices.
+var priceFormat = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ParsePrices reads daily prices from a CSV with a header line naming a date column (YYYY-MM-DD) and a price column.
+// An optional currency column sets the currency of every line, which is currency otherwise.
+func ParsePrices(r io.Reader, currency Currency) ([]Price, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("empty price file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{"date": -1, "price": -1, "currency": -1}
+	for i, name := range header {
+		if _, ok := columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+	}
+	if columns["date"] < 0 || columns["price"] < 0 {
+		return nil, errors.New("price file header must name a date and a price column")
+	}
+	if columns["currency"] < 0 && !currency.IsValid() {
+		return nil, fmt.Errorf("invalid currency: %q", currency)
+	}
+
+	var prices []Price
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return prices, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		day, err := time.Parse("2006-01-02", strings.TrimSpace(record[columns["date"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q, use YYYY-MM-DD", line, record[columns["date"]])
+		}
+
+		price := strings.TrimSpace(record[columns["price"]])
+		if !priceFormat.MatchString(price) {
+			return nil, fmt.Errorf("line %d: invalid price %q", line, price)
+		}
+
+		lineCurrency := currency
+		if columns["currency"] >= 0 {
+			lineCurrency = Currency(strings.ToUpper(strings.TrimSpace(record[columns["currency"]])))
+		}
+		if !lineCurrency.IsValid() {
+			return nil, fmt.Errorf("line %d: invalid currency %q", line, lineCurrency)
+		}
+
+		prices = append(prices, Price{Day: day.Unix(), Currency: lineCurrency, Price: price})
+	}
+}
+
+// Rat returns the price as an exact rational number, or nil when it is not a decimal.
+func (p Price) Rat() *big.Rat {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(p.Price))
+	if !ok {
+		return nil
+	}
+	return r
+}
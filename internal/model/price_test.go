@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Currency_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		want     bool
+	}{
+		{name: "Nominal case", currency: "EUR", want: true},
+		{name: "Lowercase", currency: "eur", want: false},
+		{name: "Too long", currency: "EURO", want: false},
+		{name: "Empty", currency: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.currency.IsValid())
+		})
+	}
+}
+
+func Test_ParsePrices(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		currency Currency
+		want     []Price
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "Nominal case - currency of the command",
+			csv:      "date,price\n2024-01-01,0.9512\n2024-01-02, 1\n",
+			currency: "EUR",
+			want: []Price{
+				{Day: 1704067200, Currency: "EUR", Price: "0.9512"},
+				{Day: 1704153600, Currency: "EUR", Price: "1"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Nominal case - currency column",
+			csv:     "Currency,Date,Price,Source\nusd,2024-01-01,1.05,exchange\n",
+			want:    []Price{{Day: 1704067200, Currency: "USD", Price: "1.05"}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "Error case - missing price column",
+			csv:      "date,close\n2024-01-01,1\n",
+			currency: "EUR",
+			wantErr:  assert.Error,
+		},
+		{
+			name:    "Error case - missing currency",
+			csv:     "date,price\n2024-01-01,1\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:     "Error case - invalid date",
+			csv:      "date,price\n01/01/2024,1\n",
+			currency: "EUR",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "Error case - negative price",
+			csv:      "date,price\n2024-01-01,-1\n",
+			currency: "EUR",
+			wantErr:  assert.Error,
+		},
+		{
+			name:     "Error case - empty file",
+			currency: "EUR",
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrices(strings.NewReader(tt.csv), tt.currency)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
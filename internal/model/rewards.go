@@ -1,17 +1,23 @@
 package model
 
+// MaxRewardsLimit is the maximum number of rewards per page.
+const MaxRewardsLimit = 100
+
 // Reward represents a Tezos reward.
 type Reward struct {
 	ID               int64         `db:"id" json:"id"`
 	RecipientAddress WalletAddress `db:"recipient_address" json:"recipient_address"`
 	SourceAddress    WalletAddress `db:"source_address" json:"source_address"`
 	Cycle            int           `db:"cycle" json:"cycle"`
-	Amount           float64       `db:"amount" json:"amount"`
+	Amount           Mutez         `db:"amount" json:"amount"`
+	AmountTez        string        `db:"-" json:"amount_tez"`
 	Timestamp        int64         `db:"timestamp" json:"-"`
 	TimestampTime    string        `db:"-" json:"timestamp"`
 }
 
 // RewardsResponse is the response format for the API.
 type RewardsResponse struct {
-	Rewards []Reward `json:"rewards"`
+	Rewards    []Reward       `json:"rewards"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Pagination PaginationInfo `json:"-"`
 }
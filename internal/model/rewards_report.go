@@ -0,0 +1,195 @@
+package model
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RewardsReport lists the rewards received by a wallet over a fiscal year, with their value in a fiat currency.
+// Values are rounded to the cent per reward, the total value is the sum of the rounded values.
+type RewardsReport struct {
+	Wallet         WalletAddress  `json:"wallet"`
+	Year           int            `json:"year"`
+	Currency       Currency       `json:"currency"`
+	Rewards        []ReportReward `json:"rewards"`
+	TotalAmount    Mutez          `json:"total_amount"`
+	TotalAmountTez string         `json:"total_amount_tez"`
+	TotalValue     string         `json:"total_value"`
+	MissingPrices  int            `json:"missing_prices"`
+}
+
+// ReportReward is a reward of a report, valued at the price of its day.
+// Price and Value are empty when no price of the currency is known on or before the day of the reward.
+type ReportReward struct {
+	Cycle     int           `json:"cycle"`
+	Date      string        `json:"date"`
+	Baker     WalletAddress `json:"baker"`
+	Amount    Mutez         `json:"amount"`
+	AmountTez string        `json:"amount_tez"`
+	Price     string        `json:"price,omitempty"`
+	Value     string        `json:"value,omitempty"`
+}
+
+// NewRewardsReport values the rewards of wallet in year at the latest price known on or before their day and sums them.
+func NewRewardsReport(wallet WalletAddress, year int, currency Currency, rewards []Reward, prices []Price) *RewardsReport {
+	rewards = append([]Reward(nil), rewards...)
+	sort.Slice(rewards, func(i, j int) bool {
+		if rewards[i].Timestamp != rewards[j].Timestamp {
+			return rewards[i].Timestamp < rewards[j].Timestamp
+		}
+		return rewards[i].ID < rewards[j].ID
+	})
+
+	prices = append([]Price(nil), prices...)
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Day < prices[j].Day })
+
+	report := &RewardsReport{
+		Wallet:   wallet,
+		Year:     year,
+		Currency: currency,
+		Rewards:  make([]ReportReward, 0, len(rewards)),
+	}
+
+	totalCents := new(big.Int)
+	for _, r := range rewards {
+		line := ReportReward{
+			Cycle:     r.Cycle,
+			Date:      time.Unix(r.Timestamp, 0).UTC().Format("2006-01-02"),
+			Baker:     r.SourceAddress,
+			Amount:    r.Amount,
+			AmountTez: r.Amount.Tez(),
+		}
+		report.TotalAmount += r.Amount
+
+		day := DayStart(r.Timestamp)
+		i := sort.Search(len(prices), func(i int) bool { return prices[i].Day > day })
+		var price *big.Rat
+		if i > 0 {
+			price = prices[i-1].Rat()
+		}
+
+		if price == nil {
+			report.MissingPrices++
+		} else {
+			cents := valueCents(r.Amount, price)
+			totalCents.Add(totalCents, cents)
+			line.Price = formatPrice(prices[i-1].Price)
+			line.Value = formatCents(cents)
+		}
+
+		report.Rewards = append(report.Rewards, line)
+	}
+
+	report.TotalAmountTez = report.TotalAmount.Tez()
+	report.TotalValue = formatCents(totalCents)
+	return report
+}
+
+// valueCents returns the value in cents of amount at price, rounded half away from zero.
+func valueCents(amount Mutez, price *big.Rat) *big.Int {
+	// amount / 1e6 tez * price * 100 cents
+	value := new(big.Rat).Mul(big.NewRat(int64(amount), int64(MutezPerTez)/100), price)
+
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+	cents := new(big.Int).Quo(new(big.Int).Add(new(big.Int).Lsh(num, 1), den), new(big.Int).Lsh(den, 1))
+	if value.Sign() < 0 {
+		cents.Neg(cents)
+	}
+	return cents
+}
+
+// formatCents returns an amount of cents as a decimal string with 2 decimals (e.g. "12.05").
+func formatCents(cents *big.Int) string {
+	sign := ""
+	abs := new(big.Int).Set(cents)
+	if cents.Sign() < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+	units, rest := new(big.Int).QuoRem(abs, big.NewInt(100), new(big.Int))
+	return fmt.Sprintf("%s%s.%02d", sign, units.String(), rest.Int64())
+}
+
+// formatPrice returns a decimal price without the trailing zeros of its decimals, which databases pad to their scale.
+func formatPrice(price string) string {
+	price = strings.TrimSpace(price)
+	if !strings.Contains(price, ".") {
+		return price
+	}
+	return strings.TrimSuffix(strings.TrimRight(price, "0"), ".")
+}
+
+// reportColumns is the header line of the CSV reports.
+var reportColumns = []string{"cycle", "date", "baker", "amount", "amount_tez", "price", "value"}
+
+// WriteCSV writes the rewards of the report as CSV lines, followed by a total line.
+func (r *RewardsReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(reportColumns); err != nil {
+		return err
+	}
+	for _, line := range r.Rewards {
+		record := []string{strconv.Itoa(line.Cycle), line.Date, line.Baker.String(), line.Amount.String(), line.AmountTez, line.Price, line.Value}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write([]string{"total", "", "", r.TotalAmount.String(), r.TotalAmountTez, "", r.TotalValue}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// reportTemplate renders a report as a standalone page meant to be printed.
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Staking rewards {{.Year}} - {{.Wallet}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #111; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; }
+th, td { border-bottom: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+tfoot td { font-weight: bold; border-top: 2px solid #111; }
+.note { font-size: 0.85em; color: #555; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Staking rewards {{.Year}}</h1>
+<p>Wallet: <code>{{.Wallet}}</code><br>Currency: {{.Currency}}</p>
+<table>
+<thead>
+<tr><th>Cycle</th><th>Date (UTC)</th><th>Baker</th><th class="num">Amount (XTZ)</th><th class="num">Price ({{.Currency}})</th><th class="num">Value ({{.Currency}})</th></tr>
+</thead>
+<tbody>
+{{- range .Rewards}}
+<tr><td>{{.Cycle}}</td><td>{{.Date}}</td><td><code>{{.Baker}}</code></td><td class="num">{{.AmountTez}}</td><td class="num">{{.Price}}</td><td class="num">{{.Value}}</td></tr>
+{{- end}}
+</tbody>
+<tfoot>
+<tr><td colspan="3">Total</td><td class="num">{{.TotalAmountTez}}</td><td></td><td class="num">{{.TotalValue}}</td></tr>
+</tfoot>
+</table>
+{{- if .MissingPrices}}
+<p class="note">{{.MissingPrices}} reward(s) have no {{.Currency}} price on or before their day and are not valued.</p>
+{{- end}}
+<p class="note">Values are rounded to the cent per reward, at the latest daily price known on or before the day of the reward.</p>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a printable HTML page.
+func (r *RewardsReport) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRewardsReport(t *testing.T) {
+	rewards := []Reward{
+		{ID: 3, SourceAddress: "tz1baker", Cycle: 702, Amount: 1_000_000, Timestamp: 1704326400 + 3600}, // 2024-01-04
+		{ID: 1, SourceAddress: "tz1baker", Cycle: 700, Amount: 1_234_567, Timestamp: 1704067200 + 60},   // 2024-01-01
+		{ID: 2, SourceAddress: "tz1baker", Cycle: 701, Amount: 500_000, Timestamp: 1704153600},          // 2024-01-02
+	}
+	prices := []Price{
+		{Day: 1704153600, Currency: "EUR", Price: "0.50000000"}, // 2024-01-02
+		{Day: 1704240000, Currency: "EUR", Price: "0.333"},      // 2024-01-03
+	}
+
+	report := NewRewardsReport("tz1wallet", 2024, "EUR", rewards, prices)
+
+	assert.Equal(t, &RewardsReport{
+		Wallet:   "tz1wallet",
+		Year:     2024,
+		Currency: "EUR",
+		Rewards: []ReportReward{
+			{Cycle: 700, Date: "2024-01-01", Baker: "tz1baker", Amount: 1_234_567, AmountTez: "1.234567"},
+			{Cycle: 701, Date: "2024-01-02", Baker: "tz1baker", Amount: 500_000, AmountTez: "0.500000", Price: "0.5", Value: "0.25"},
+			// The latest price before the day values the reward, 0.333 EUR is rounded to the cent.
+			{Cycle: 702, Date: "2024-01-04", Baker: "tz1baker", Amount: 1_000_000, AmountTez: "1.000000", Price: "0.333", Value: "0.33"},
+		},
+		TotalAmount:    2_734_567,
+		TotalAmountTez: "2.734567",
+		TotalValue:     "0.58",
+		MissingPrices:  1,
+	}, report)
+}
+
+func Test_valueCents(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Mutez
+		price  string
+		want   string
+	}{
+		{name: "Nominal case", amount: 2_000_000, price: "1.2345", want: "2.47"},
+		{name: "Half rounds up", amount: 5_000, price: "1", want: "0.01"},
+		{name: "Below half rounds down", amount: 4_999, price: "1", want: "0.00"},
+		{name: "Large amount", amount: 1_000_000_000_000, price: "0.75", want: "750000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatCents(valueCents(tt.amount, Price{Price: tt.price}.Rat())))
+		})
+	}
+}
+
+func Test_RewardsReport_WriteCSV(t *testing.T) {
+	report := NewRewardsReport("tz1wallet", 2024, "EUR",
+		[]Reward{{SourceAddress: "tz1baker", Cycle: 700, Amount: 2_000_000, Timestamp: 1704067200}},
+		[]Price{{Day: 1704067200, Currency: "EUR", Price: "1.5"}})
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buf))
+	assert.Equal(t, "cycle,date,baker,amount,amount_tez,price,value\n"+
+		"700,2024-01-01,tz1baker,2000000,2.000000,1.5,3.00\n"+
+		"total,,,2000000,2.000000,,3.00\n", buf.String())
+}
+
+func Test_RewardsReport_WriteHTML(t *testing.T) {
+	report := NewRewardsReport("tz1<wallet>", 2024, "EUR",
+		[]Reward{{SourceAddress: "tz1baker", Cycle: 700, Amount: 2_000_000, Timestamp: 1704067200}}, nil)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteHTML(&buf))
+	assert.Contains(t, buf.String(), "<h1>Staking rewards 2024</h1>")
+	assert.Contains(t, buf.String(), "tz1&lt;wallet&gt;")
+	assert.Contains(t, buf.String(), "1 reward(s) have no EUR price")
+}
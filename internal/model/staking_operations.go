@@ -7,7 +7,7 @@ type StakingOperation struct {
 	Hash       string        `json:"hash"`
 	Type       OperationType `json:"type"`
 	Entrypoint string        `json:"entrypoint,omitempty"`
-	Amount     float64       `json:"amount"`
+	Amount     Mutez         `json:"amount"`
 	Wallet     WalletAddress `json:"wallet"`
 	Baker      WalletAddress `json:"baker,omitempty"`
 	Timestamp  time.Time     `json:"timestamp"`
@@ -0,0 +1,54 @@
+package model
+
+// SecondsPerDay is the length of the days of the daily statistics, which are UTC days.
+const SecondsPerDay = 86400
+
+// DayStart returns the unix timestamp of the start of the UTC day of timestamp.
+func DayStart(timestamp int64) int64 {
+	return timestamp - timestamp%SecondsPerDay
+}
+
+// DailyDelegationStats represents the delegation activity of a baker on a day.
+// Undelegations count the delegators leaving the baker, to no baker or to another one.
+type DailyDelegationStats struct {
+	Day                int64         `db:"day" json:"-"`
+	DayDate            string        `db:"-" json:"day"`
+	Baker              WalletAddress `db:"baker" json:"baker"`
+	NewDelegations     int64         `db:"new_delegations" json:"new_delegations"`
+	Undelegations      int64         `db:"undelegations" json:"undelegations"`
+	DelegatedVolume    Mutez         `db:"delegated_volume" json:"delegated_volume"`
+	DelegatedVolumeTez string        `db:"-" json:"delegated_volume_tez"`
+}
+
+// BakerCycleRewards represents the rewards paid by a baker in a cycle.
+type BakerCycleRewards struct {
+	Cycle      int           `db:"cycle" json:"cycle"`
+	Baker      WalletAddress `db:"baker" json:"baker"`
+	Rewards    Mutez         `db:"rewards" json:"rewards"`
+	RewardsTez string        `db:"-" json:"rewards_tez"`
+	Delegators int64         `db:"delegators" json:"delegators"`
+}
+
+// DelegatorCycleRewards represents the rewards received by a delegator in a cycle.
+type DelegatorCycleRewards struct {
+	Cycle      int           `db:"cycle" json:"cycle"`
+	Delegator  WalletAddress `db:"delegator" json:"delegator"`
+	Rewards    Mutez         `db:"rewards" json:"rewards"`
+	RewardsTez string        `db:"-" json:"rewards_tez"`
+	Bakers     int64         `db:"bakers" json:"bakers"`
+}
+
+// DelegationStatsResponse is the response format of the daily delegation statistics.
+type DelegationStatsResponse struct {
+	Stats []DailyDelegationStats `json:"data"`
+}
+
+// BakerRewardStatsResponse is the response format of the per-cycle rewards of bakers.
+type BakerRewardStatsResponse struct {
+	Stats []BakerCycleRewards `json:"data"`
+}
+
+// DelegatorRewardStatsResponse is the response format of the per-cycle rewards of delegators.
+type DelegatorRewardStatsResponse struct {
+	Stats []DelegatorCycleRewards `json:"data"`
+}
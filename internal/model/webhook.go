@@ -0,0 +1,167 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventType represents the type of the events delivered to the webhooks.
+type WebhookEventType string
+
+var (
+	// WebhookEventDelegation is sent when a delegation to a baker is indexed.
+	WebhookEventDelegation WebhookEventType = "delegation"
+	// WebhookEventUndelegation is sent when a delegator leaving its baker is indexed.
+	WebhookEventUndelegation WebhookEventType = "undelegation"
+	// WebhookEventRewards is sent when the rewards of a cycle are indexed.
+	WebhookEventRewards WebhookEventType = "rewards"
+)
+
+// WebhookEventTypes lists every webhook event type.
+var WebhookEventTypes = []WebhookEventType{WebhookEventDelegation, WebhookEventUndelegation, WebhookEventRewards}
+
+// String returns the string representation of the webhook event type.
+func (t WebhookEventType) String() string {
+	return string(t)
+}
+
+// IsValid checks if the webhook event type is valid.
+func (t WebhookEventType) IsValid() bool {
+	switch t {
+	case WebhookEventDelegation, WebhookEventUndelegation, WebhookEventRewards:
+		return true
+	default:
+		return false
+	}
+}
+
+// Webhook is an endpoint notified of the indexed events matching its filters.
+// An empty EventTypes subscribes to every event type, an empty Baker or Delegator does not filter on it.
+type Webhook struct {
+	ID          int64              `db:"id" json:"id"`
+	URL         string             `db:"url" json:"url"`
+	Secret      string             `db:"secret" json:"-"`
+	EventTypes  []WebhookEventType `db:"-" json:"event_types"`
+	Baker       WalletAddress      `db:"baker" json:"baker,omitempty"`
+	Delegator   WalletAddress      `db:"delegator" json:"delegator,omitempty"`
+	Description string             `db:"description" json:"description,omitempty"`
+	CreatedAt   time.Time          `db:"created_at" json:"created_at"`
+	// DisabledAt is set once the endpoint failed too many consecutive deliveries, no event is delivered to it anymore.
+	DisabledAt          *time.Time `db:"disabled_at" json:"disabled_at,omitempty"`
+	ConsecutiveFailures int        `db:"consecutive_failures" json:"consecutive_failures"`
+	// APIKeyID is the API key which created the webhook, the only one managing it.
+	APIKeyID int64 `db:"api_key_id" json:"-"`
+}
+
+// CreatedWebhook is a new webhook with its signing secret, which is only returned once.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhooksResponse is the response format of the webhook list.
+type WebhooksResponse struct {
+	Webhooks []Webhook `json:"data"`
+}
+
+// Subscribes reports whether the webhook subscribes to the event type.
+func (w Webhook) Subscribes(eventType WebhookEventType) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDisabled reports whether the webhook was disabled after repeated failures.
+func (w Webhook) IsDisabled() bool {
+	return w.DisabledAt != nil
+}
+
+// MatchDelegation reports whether the webhook subscribes to the event of a delegation, returning its event type.
+func (w Webhook) MatchDelegation(d Delegation) (WebhookEventType, bool) {
+	eventType := WebhookEventDelegation
+	if d.Delegate == "" {
+		eventType = WebhookEventUndelegation
+	}
+
+	if !w.Subscribes(eventType) {
+		return eventType, false
+	}
+	if w.Delegator != "" && w.Delegator != d.Delegator {
+		return eventType, false
+	}
+	// An undelegation carries no baker, a baker filter cannot match it.
+	if w.Baker != "" && w.Baker != d.Delegate {
+		return eventType, false
+	}
+	return eventType, true
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+var (
+	// WebhookDeliveryPending is a delivery waiting for its next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered is a delivery acknowledged by the endpoint with a 2xx status.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryFailed is a delivery given up after its last attempt.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event queued for a webhook, attempted until delivered or given up.
+// EventID identifies the event, an event is queued once per webhook.
+type WebhookDelivery struct {
+	ID            int64                    `db:"id" json:"id"`
+	WebhookID     int64                    `db:"webhook_id" json:"webhook_id"`
+	EventID       string                   `db:"event_id" json:"event_id"`
+	EventType     WebhookEventType         `db:"event_type" json:"event_type"`
+	Payload       json.RawMessage          `db:"payload" json:"payload"`
+	Status        WebhookDeliveryStatus    `db:"status" json:"status"`
+	AttemptCount  int                      `db:"attempt_count" json:"attempt_count"`
+	NextAttemptAt time.Time                `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string                   `db:"last_error" json:"last_error,omitempty"`
+	CreatedAt     time.Time                `db:"created_at" json:"created_at"`
+	Attempts      []WebhookDeliveryAttempt `db:"-" json:"attempts,omitempty"`
+}
+
+// WebhookDeliveryAttempt records a POST of a delivery to its webhook.
+// StatusCode is 0 when no response was received, Error tells why the attempt failed.
+type WebhookDeliveryAttempt struct {
+	DeliveryID  int64     `db:"delivery_id" json:"-"`
+	AttemptedAt time.Time `db:"attempted_at" json:"attempted_at"`
+	StatusCode  int       `db:"status_code" json:"status_code,omitempty"`
+	Error       string    `db:"error" json:"error,omitempty"`
+	DurationMs  int64     `db:"duration_ms" json:"duration_ms"`
+}
+
+// Succeeded reports whether the endpoint acknowledged the attempt.
+func (a WebhookDeliveryAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// WebhookDeliveriesResponse is the response format of the deliveries of a webhook.
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"data"`
+}
+
+// WebhookEvent is the JSON body POSTed to the webhooks.
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// RewardsIndexedEvent is the data of a rewards event: the rewards of a cycle paid by the bakers and received by
+// the delegators of the webhook filters. Bakers lists every baker of the cycle when the webhook has no filter.
+type RewardsIndexedEvent struct {
+	Cycle      int                     `json:"cycle"`
+	Bakers     []BakerCycleRewards     `json:"bakers,omitempty"`
+	Delegators []DelegatorCycleRewards `json:"delegators,omitempty"`
+}
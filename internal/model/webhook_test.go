@@ -0,0 +1,85 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Webhook_MatchDelegation(t *testing.T) {
+	tests := []struct {
+		name          string
+		webhook       Webhook
+		delegation    Delegation
+		wantEventType WebhookEventType
+		wantMatch     bool
+	}{
+		{
+			name:          "Nominal case - every event type",
+			webhook:       Webhook{},
+			delegation:    Delegation{Delegator: "tz1a", Delegate: "tz1baker"},
+			wantEventType: WebhookEventDelegation,
+			wantMatch:     true,
+		},
+		{
+			name:          "Nominal case - delegation to the baker of the filter",
+			webhook:       Webhook{EventTypes: []WebhookEventType{WebhookEventDelegation}, Baker: "tz1baker"},
+			delegation:    Delegation{Delegator: "tz1a", Delegate: "tz1baker"},
+			wantEventType: WebhookEventDelegation,
+			wantMatch:     true,
+		},
+		{
+			name:          "Nominal case - undelegation of the delegator of the filter",
+			webhook:       Webhook{EventTypes: []WebhookEventType{WebhookEventUndelegation}, Delegator: "tz1a"},
+			delegation:    Delegation{Delegator: "tz1a"},
+			wantEventType: WebhookEventUndelegation,
+			wantMatch:     true,
+		},
+		{
+			name:          "Error case - event type not subscribed",
+			webhook:       Webhook{EventTypes: []WebhookEventType{WebhookEventRewards}},
+			delegation:    Delegation{Delegator: "tz1a", Delegate: "tz1baker"},
+			wantEventType: WebhookEventDelegation,
+		},
+		{
+			name:          "Error case - other baker",
+			webhook:       Webhook{Baker: "tz1baker"},
+			delegation:    Delegation{Delegator: "tz1a", Delegate: "tz1other"},
+			wantEventType: WebhookEventDelegation,
+		},
+		{
+			name:          "Error case - undelegation has no baker",
+			webhook:       Webhook{Baker: "tz1baker"},
+			delegation:    Delegation{Delegator: "tz1a"},
+			wantEventType: WebhookEventUndelegation,
+		},
+		{
+			name:          "Error case - other delegator",
+			webhook:       Webhook{Delegator: "tz1b"},
+			delegation:    Delegation{Delegator: "tz1a", Delegate: "tz1baker"},
+			wantEventType: WebhookEventDelegation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventType, ok := tt.webhook.MatchDelegation(tt.delegation)
+			assert.Equal(t, tt.wantEventType, eventType)
+			assert.Equal(t, tt.wantMatch, ok)
+		})
+	}
+}
+
+func Test_CreatedWebhook_MarshalJSON(t *testing.T) {
+	webhook := Webhook{ID: 1, URL: "https://example.com/hook", Secret: "whsec_secret", EventTypes: []WebhookEventType{WebhookEventRewards}}
+
+	// The secret is only exposed by the creation response.
+	data, err := json.Marshal(webhook)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "whsec_secret")
+
+	data, err = json.Marshal(CreatedWebhook{Webhook: webhook, Secret: webhook.Secret})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"secret":"whsec_secret"`)
+	assert.Contains(t, string(data), `"event_types":["rewards"]`)
+}
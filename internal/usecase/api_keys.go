@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const (
+	// apiKeyPrefix prefixes the API keys.
+	apiKeyPrefix = model.APIKeyPrefix
+
+	// apiKeyDisplayLength is the length of the start of a key kept to identify it.
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+	// maxAPIKeyNameLength bounds the name of an API key.
+	maxAPIKeyNameLength = 100
+)
+
+// manageAPIKeys handles business logic for the API keys.
+type manageAPIKeys struct {
+	dbAdapter database.Adapter
+}
+
+// CreateAPIKeyInput defines the input structure for creating an API key.
+type CreateAPIKeyInput struct {
+	Name       string
+	RateLimit  int
+	DailyQuota int
+}
+
+// CreateAPIKeyFunc defines the function signature for creating an API key.
+type CreateAPIKeyFunc func(ctx context.Context, input CreateAPIKeyInput) (*model.CreatedAPIKey, error)
+
+// GetAPIKeysFunc defines the function signature for listing the API keys.
+type GetAPIKeysFunc func(ctx context.Context) (*model.APIKeysResponse, error)
+
+// RevokeAPIKeyFunc defines the function signature for revoking an API key.
+type RevokeAPIKeyFunc func(ctx context.Context, id int64) error
+
+// AuthenticateAPIKeyFunc defines the function signature for finding the valid API key of a request.
+type AuthenticateAPIKeyFunc func(ctx context.Context, key string) (*model.APIKey, error)
+
+// CountAPIKeyRequestFunc defines the function signature for counting a request of an API key in its daily quota.
+type CountAPIKeyRequestFunc func(ctx context.Context, id int64, at time.Time) (int64, error)
+
+// NewCreateAPIKeyFunc creates a new instance of manageAPIKeys creating API keys.
+func NewCreateAPIKeyFunc(adapter database.Adapter, metricsClient metrics.Adapter) CreateAPIKeyFunc {
+	uc := &manageAPIKeys{dbAdapter: adapter}
+	return func(ctx context.Context, input CreateAPIKeyInput) (result *model.CreatedAPIKey, err error) {
+		defer uc.monitor("CreateAPIKey", time.Now(), metricsClient, &err)
+		return uc.CreateAPIKey(ctx, input)
+	}
+}
+
+// NewGetAPIKeysFunc creates a new instance of manageAPIKeys listing API keys.
+func NewGetAPIKeysFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetAPIKeysFunc {
+	uc := &manageAPIKeys{dbAdapter: adapter}
+	return func(ctx context.Context) (result *model.APIKeysResponse, err error) {
+		defer uc.monitor("GetAPIKeys", time.Now(), metricsClient, &err)
+		return uc.GetAPIKeys(ctx)
+	}
+}
+
+// NewRevokeAPIKeyFunc creates a new instance of manageAPIKeys revoking API keys.
+func NewRevokeAPIKeyFunc(adapter database.Adapter, metricsClient metrics.Adapter) RevokeAPIKeyFunc {
+	uc := &manageAPIKeys{dbAdapter: adapter}
+	return func(ctx context.Context, id int64) (err error) {
+		defer uc.monitor("RevokeAPIKey", time.Now(), metricsClient, &err)
+		return uc.RevokeAPIKey(ctx, id)
+	}
+}
+
+// NewCountAPIKeyRequestFunc creates a new instance of manageAPIKeys counting the requests of API keys.
+func NewCountAPIKeyRequestFunc(adapter database.Adapter, metricsClient metrics.Adapter) CountAPIKeyRequestFunc {
+	uc := &manageAPIKeys{dbAdapter: adapter}
+	return func(ctx context.Context, id int64, at time.Time) (result int64, err error) {
+		defer uc.monitor("CountAPIKeyRequest", time.Now(), metricsClient, &err)
+		return uc.dbAdapter.IncrementAPIKeyUsage(ctx, id, at)
+	}
+}
+
+// CreateAPIKey saves a new random API key, returned once.
+func (uc *manageAPIKeys) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*model.CreatedAPIKey, error) {
+	name := strings.TrimSpace(input.Name)
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	case len(name) > maxAPIKeyNameLength:
+		return nil, fmt.Errorf("%w: name exceeds %d characters", ErrInvalidAPIKey, maxAPIKeyNameLength)
+	case input.RateLimit < 0 || input.DailyQuota < 0:
+		return nil, fmt.Errorf("%w: rate limit and daily quota must not be negative", ErrInvalidAPIKey)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("error generating API key: %w", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(b)
+
+	apiKey := model.APIKey{
+		Name:       name,
+		Prefix:     key[:apiKeyDisplayLength],
+		Hash:       model.HashAPIKey(key),
+		RateLimit:  input.RateLimit,
+		DailyQuota: input.DailyQuota,
+	}
+	if err := uc.dbAdapter.SaveAPIKey(ctx, &apiKey); err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// GetAPIKeys returns every API key, revoked ones included.
+func (uc *manageAPIKeys) GetAPIKeys(ctx context.Context) (*model.APIKeysResponse, error) {
+	keys, err := uc.dbAdapter.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []model.APIKey{}
+	}
+	return &model.APIKeysResponse{APIKeys: keys}, nil
+}
+
+// RevokeAPIKey revokes an API key, it is refused once the authentication caches expire.
+func (uc *manageAPIKeys) RevokeAPIKey(ctx context.Context, id int64) error {
+	err := uc.dbAdapter.RevokeAPIKey(ctx, id, time.Now().UTC())
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %d", ErrAPIKeyNotFound, id)
+	}
+	return err
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *manageAPIKeys) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
+
+// cachedAPIKey is a key looked up by its hash.
+type cachedAPIKey struct {
+	key       *model.APIKey
+	expiresAt time.Time
+}
+
+// authenticateAPIKey handles business logic for authenticating requests, caching the keys looked up.
+type authenticateAPIKey struct {
+	dbAdapter database.Adapter
+	cacheTTL  time.Duration
+	now       func() time.Time
+	// unknownKeys caches the hashes which match no key. It evicts the least recently used ones, so that a flood of
+	// random keys neither reaches the database nor evicts the known keys.
+	unknownKeys cache.Adapter
+
+	mu sync.Mutex
+	// cache holds the known keys, bounded by the keys created by the admin.
+	cache map[string]cachedAPIKey
+}
+
+// NewAuthenticateAPIKeyFunc creates a new instance of authenticateAPIKey and returns an AuthenticateAPIKeyFunc.
+// Keys are cached for cacheTTL, so that a revocation applies after at most this delay, 0 disables the cache.
+// The hashes matching no key are cached in unknownKeys, which should be bounded.
+func NewAuthenticateAPIKeyFunc(cacheTTL time.Duration, unknownKeys cache.Adapter, adapter database.Adapter, metricsClient metrics.Adapter) AuthenticateAPIKeyFunc {
+	uc := &authenticateAPIKey{
+		dbAdapter:   adapter,
+		cacheTTL:    cacheTTL,
+		now:         time.Now,
+		unknownKeys: unknownKeys,
+		cache:       make(map[string]cachedAPIKey),
+	}
+	return func(ctx context.Context, key string) (result *model.APIKey, err error) {
+		defer uc.monitor("AuthenticateAPIKey", time.Now(), metricsClient, &err)
+		return uc.Authenticate(ctx, key)
+	}
+}
+
+// Authenticate returns the API key of a request, or ErrUnauthorized when it is unknown or revoked.
+func (uc *authenticateAPIKey) Authenticate(ctx context.Context, key string) (*model.APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrUnauthorized
+	}
+	hash := model.HashAPIKey(key)
+
+	apiKey, ok := uc.cached(hash)
+	if !ok {
+		var err error
+		apiKey, err = uc.dbAdapter.GetAPIKeyByHash(ctx, hash)
+		if errors.Is(err, sql.ErrNoRows) {
+			apiKey, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		uc.store(hash, apiKey)
+	}
+
+	if apiKey == nil || apiKey.IsRevoked() {
+		return nil, ErrUnauthorized
+	}
+	return apiKey, nil
+}
+
+// cached returns the key cached for a hash, nil when the hash is cached as unknown.
+func (uc *authenticateAPIKey) cached(hash string) (*model.APIKey, bool) {
+	if uc.unknownKeys != nil {
+		if _, ok := uc.unknownKeys.Get(hash); ok {
+			return nil, true
+		}
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	entry, ok := uc.cache[hash]
+	if !ok || !uc.now().Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.key, true
+}
+
+// store caches the key of a hash, nil when it is unknown.
+func (uc *authenticateAPIKey) store(hash string, key *model.APIKey) {
+	if uc.cacheTTL <= 0 {
+		return
+	}
+	if key == nil {
+		if uc.unknownKeys != nil {
+			uc.unknownKeys.Set(hash, true, uc.cacheTTL)
+		}
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.cache[hash] = cachedAPIKey{key: key, expiresAt: uc.now().Add(uc.cacheTTL)}
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *authenticateAPIKey) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
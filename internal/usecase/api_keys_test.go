@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tezos-delegation-service/internal/adapter/cache/impl/lru"
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_manageAPIKeys_CreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    CreateAPIKeyInput
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "Nominal case",
+			input:    CreateAPIKeyInput{Name: " explorer ", RateLimit: 100, DailyQuota: 1000},
+			wantName: "explorer",
+		},
+		{
+			name:    "Error case - missing name",
+			input:   CreateAPIKeyInput{Name: " "},
+			wantErr: ErrInvalidAPIKey,
+		},
+		{
+			name:    "Error case - name too long",
+			input:   CreateAPIKeyInput{Name: strings.Repeat("a", maxAPIKeyNameLength+1)},
+			wantErr: ErrInvalidAPIKey,
+		},
+		{
+			name:    "Error case - negative rate limit",
+			input:   CreateAPIKeyInput{Name: "explorer", RateLimit: -1},
+			wantErr: ErrInvalidAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := databasememory.New()
+			uc := &manageAPIKeys{dbAdapter: db}
+
+			got, err := uc.CreateAPIKey(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), got.ID)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.True(t, strings.HasPrefix(got.Key, apiKeyPrefix))
+			assert.Len(t, got.Key, len(apiKeyPrefix)+64)
+			assert.Equal(t, got.Key[:apiKeyDisplayLength], got.Prefix)
+
+			// Only the hash of the key is saved.
+			keys, err := db.GetAPIKeys(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, keys, 1)
+			assert.NotContains(t, keys[0].Hash, got.Key)
+		})
+	}
+}
+
+func Test_manageAPIKeys_RevokeAPIKey(t *testing.T) {
+	db := databasememory.New()
+	uc := &manageAPIKeys{dbAdapter: db}
+
+	created, err := uc.CreateAPIKey(context.Background(), CreateAPIKeyInput{Name: "explorer"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      int64
+		wantErr error
+	}{
+		{
+			name: "Nominal case",
+			id:   created.ID,
+		},
+		{
+			name:    "Error case - unknown key",
+			id:      created.ID + 1,
+			wantErr: ErrAPIKeyNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.RevokeAPIKey(context.Background(), tt.id)
+			assert.True(t, errors.Is(err, tt.wantErr), "error = %v", err)
+		})
+	}
+}
+
+func Test_authenticateAPIKey_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	manage := &manageAPIKeys{dbAdapter: db}
+
+	valid, err := manage.CreateAPIKey(ctx, CreateAPIKeyInput{Name: "valid"})
+	assert.NoError(t, err)
+	revoked, err := manage.CreateAPIKey(ctx, CreateAPIKeyInput{Name: "revoked"})
+	assert.NoError(t, err)
+	assert.NoError(t, manage.RevokeAPIKey(ctx, revoked.ID))
+
+	tests := []struct {
+		name    string
+		key     string
+		wantID  int64
+		wantErr error
+	}{
+		{
+			name:   "Nominal case",
+			key:    valid.Key,
+			wantID: valid.ID,
+		},
+		{
+			name:    "Error case - revoked key",
+			key:     revoked.Key,
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name:    "Error case - unknown key",
+			key:     apiKeyPrefix + strings.Repeat("0", 64),
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name:    "Error case - not an API key",
+			key:     "secret",
+			wantErr: ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &authenticateAPIKey{dbAdapter: db, now: time.Now, cache: make(map[string]cachedAPIKey)}
+
+			got, err := uc.Authenticate(ctx, tt.key)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func Test_authenticateAPIKey_Authenticate_cache(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	manage := &manageAPIKeys{dbAdapter: db}
+
+	created, err := manage.CreateAPIKey(ctx, CreateAPIKeyInput{Name: "explorer"})
+	assert.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uc := &authenticateAPIKey{
+		dbAdapter: db,
+		cacheTTL:  time.Minute,
+		now:       func() time.Time { return now },
+		cache:     make(map[string]cachedAPIKey),
+	}
+
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.NoError(t, manage.RevokeAPIKey(ctx, created.ID))
+
+	// The revoked key is still accepted until its cache entry expires.
+	now = now.Add(59 * time.Second)
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+
+	now = now.Add(time.Second)
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.True(t, errors.Is(err, ErrUnauthorized), "error = %v", err)
+}
+
+func Test_authenticateAPIKey_Authenticate_unknownKeys(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	manage := &manageAPIKeys{dbAdapter: db}
+
+	created, err := manage.CreateAPIKey(ctx, CreateAPIKeyInput{Name: "explorer"})
+	assert.NoError(t, err)
+
+	unknownKeys := lru.New(lru.Config{MaxEntries: 2})
+	uc := &authenticateAPIKey{
+		dbAdapter:   db,
+		cacheTTL:    time.Minute,
+		now:         time.Now,
+		unknownKeys: unknownKeys,
+		cache:       make(map[string]cachedAPIKey),
+	}
+
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+
+	// A flood of unknown keys evicts the least recently used unknown ones, not the known keys.
+	unknown := []string{apiKeyPrefix + strings.Repeat("1", 64), apiKeyPrefix + strings.Repeat("2", 64), apiKeyPrefix + strings.Repeat("3", 64)}
+	for _, key := range unknown {
+		_, err = uc.Authenticate(ctx, key)
+		assert.True(t, errors.Is(err, ErrUnauthorized), "error = %v", err)
+	}
+	assert.Equal(t, 2, unknownKeys.Len())
+	_, ok := unknownKeys.Get(model.HashAPIKey(unknown[0]))
+	assert.False(t, ok)
+	_, ok = unknownKeys.Get(model.HashAPIKey(unknown[2]))
+	assert.True(t, ok)
+
+	// The known key is still served from the cache after its revocation.
+	assert.NoError(t, manage.RevokeAPIKey(ctx, created.ID))
+	_, err = uc.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import "github.com/tezos-delegation-service/internal/model"
+
+// parseCursor decodes the cursor of a list request, nil when the list is paginated by page.
+func parseCursor(cursorStr string) (*model.Cursor, error) {
+	if cursorStr == "" {
+		return nil, nil
+	}
+	return model.ParseCursor(cursorStr)
+}
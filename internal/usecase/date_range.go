@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+)
+
+// unixBounds returns the unix timestamps of a date range, from included and to excluded, where 0 leaves a bound open.
+// The handlers turn the inclusive to of a request into the end of its day or second, so a single day is a valid range.
+func unixBounds(fromDate, toDate *time.Time) (int64, int64, error) {
+	var fromTimestamp, toTimestamp int64
+	if fromDate != nil {
+		fromTimestamp = fromDate.Unix()
+	}
+	if toDate != nil {
+		toTimestamp = toDate.Unix()
+	}
+
+	if toTimestamp > 0 && fromTimestamp >= toTimestamp {
+		return 0, 0, fmt.Errorf("%w: from cannot be after to", ErrInvalidRequest)
+	}
+	return fromTimestamp, toTimestamp, nil
+}
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const (
+	defaultWebhookTimeout                = 10 * time.Second
+	defaultWebhookMaxAttempts            = 8
+	defaultWebhookBackoffBase            = 30 * time.Second
+	defaultWebhookBackoffMax             = 6 * time.Hour
+	defaultWebhookMaxConsecutiveFailures = 20
+	defaultWebhookBatchSize              = 100
+	defaultWebhookConcurrency            = 4
+
+	// Sync cursors of the events already queued: the id of the last delegation and the last rewards cycle.
+	webhookCursorDelegations = "webhooks_delegations"
+	webhookCursorRewards     = "webhooks_rewards"
+
+	// Headers of the webhook requests, the signature covers the timestamp and the body.
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookDeliveryConfig configures the webhook deliveries, zero values select the defaults.
+type WebhookDeliveryConfig struct {
+	// Timeout bounds every POST, a slower endpoint counts as a failure.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxAttempts is the number of attempts of a delivery before it is given up.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// BackoffBase is the delay before the second attempt, doubled after every failed attempt up to BackoffMax.
+	BackoffBase time.Duration `mapstructure:"backoff_base"`
+	BackoffMax  time.Duration `mapstructure:"backoff_max"`
+	// MaxConsecutiveFailures is the number of failed attempts in a row disabling a webhook.
+	MaxConsecutiveFailures int `mapstructure:"max_consecutive_failures"`
+	// BatchSize is the number of due deliveries sent per run, Concurrency the number sent at once.
+	BatchSize   int `mapstructure:"batch_size"`
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+// withDefaults returns the configuration with its zero values replaced by the defaults.
+func (cfg WebhookDeliveryConfig) withDefaults() WebhookDeliveryConfig {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = defaultWebhookBackoffBase
+	}
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = defaultWebhookBackoffMax
+	}
+	if cfg.MaxConsecutiveFailures <= 0 {
+		cfg.MaxConsecutiveFailures = defaultWebhookMaxConsecutiveFailures
+	}
+	if cfg.BatchSize <= 0 || cfg.BatchSize > int(^uint16(0)) {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultWebhookConcurrency
+	}
+	return cfg
+}
+
+// SignWebhookPayload returns the signature of a webhook request: the hex HMAC-SHA256, keyed by the secret of the
+// webhook, of its timestamp and body joined by a dot. Receivers recompute it to authenticate the request.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// EnqueueWebhookEvents handles business logic for queuing the indexed events to the webhooks subscribing to them.
+type EnqueueWebhookEvents struct {
+	dbAdapter database.Adapter
+	logger    *logrus.Entry
+}
+
+// NewEnqueueWebhookEventsFunc creates a new instance of EnqueueWebhookEvents and returns a SyncFunc.
+func NewEnqueueWebhookEventsFunc(dbAdapter database.Adapter, metricsClient metrics.Adapter, logger *logrus.Entry) model.SyncFunc {
+	uc := &EnqueueWebhookEvents{
+		dbAdapter: dbAdapter,
+		logger:    logger.WithField("usecase", "enqueue_webhook_events"),
+	}
+	return func(ctx context.Context) (err error) {
+		defer uc.monitor("EnqueueWebhookEvents", time.Now(), metricsClient, &err)
+		return uc.Sync(ctx)
+	}
+}
+
+// Sync queues the delegations and the rewards cycles indexed since its last run for the enabled webhooks.
+// The first run only records the current positions, the events indexed before are not delivered.
+func (uc *EnqueueWebhookEvents) Sync(ctx context.Context) error {
+	webhooks, err := uc.dbAdapter.GetWebhooks(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	enabled := webhooks[:0]
+	for _, w := range webhooks {
+		if !w.IsDisabled() {
+			enabled = append(enabled, w)
+		}
+	}
+
+	if err := uc.enqueueDelegations(ctx, enabled); err != nil {
+		return fmt.Errorf("error queuing delegation events: %w", err)
+	}
+	if err := uc.enqueueRewards(ctx, enabled); err != nil {
+		return fmt.Errorf("error queuing rewards events: %w", err)
+	}
+	return nil
+}
+
+// enqueueDelegations queues the delegations saved after the delegation cursor, batch by batch.
+// Queuing is idempotent, a batch queued again after a failure to save the cursor is not delivered twice.
+func (uc *EnqueueWebhookEvents) enqueueDelegations(ctx context.Context, webhooks []model.Webhook) error {
+	afterID, err := uc.dbAdapter.GetSyncCursor(ctx, webhookCursorDelegations)
+	if errors.Is(err, sql.ErrNoRows) {
+		latest, err := uc.dbAdapter.GetLatestDelegation(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if latest != nil {
+			afterID = latest.ID
+		}
+		return uc.dbAdapter.SaveSyncCursor(ctx, webhookCursorDelegations, afterID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		delegations, err := uc.dbAdapter.GetDelegationsAfter(ctx, afterID, model.DelegationFilter{}, streamBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(delegations) == 0 {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		var deliveries []model.WebhookDelivery
+		for _, d := range delegations {
+			event := model.NewDelegationEvent(d)
+			for _, w := range webhooks {
+				eventType, ok := w.MatchDelegation(d)
+				if !ok {
+					continue
+				}
+				delivery, err := newWebhookDelivery(w.ID, "delegation-"+strconv.FormatInt(d.ID, 10), eventType, event, now)
+				if err != nil {
+					return err
+				}
+				deliveries = append(deliveries, delivery)
+			}
+		}
+
+		if err := uc.dbAdapter.SaveWebhookDeliveries(ctx, deliveries); err != nil {
+			return err
+		}
+		afterID = delegations[len(delegations)-1].ID
+		if err := uc.dbAdapter.SaveSyncCursor(ctx, webhookCursorDelegations, afterID); err != nil {
+			return err
+		}
+		if len(deliveries) > 0 {
+			uc.logger.Infof("Queued %d delegation deliveries up to delegation %d", len(deliveries), afterID)
+		}
+
+		if len(delegations) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+// enqueueRewards queues a rewards event for every cycle synced after the rewards cursor, once its statistics are
+// refreshed. A webhook filtering on a baker or a delegator is only notified of the cycles paying it.
+func (uc *EnqueueWebhookEvents) enqueueRewards(ctx context.Context, webhooks []model.Webhook) error {
+	lastSyncedCycle, err := uc.dbAdapter.GetLastSyncedRewardCycle(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cursor, err := uc.dbAdapter.GetSyncCursor(ctx, webhookCursorRewards)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uc.dbAdapter.SaveSyncCursor(ctx, webhookCursorRewards, int64(lastSyncedCycle))
+	}
+	if err != nil {
+		return err
+	}
+
+	for cycle := int(cursor) + 1; cycle <= lastSyncedCycle; cycle++ {
+		deliveries, err := uc.rewardsDeliveries(ctx, webhooks, cycle)
+		if err != nil {
+			return err
+		}
+		if err := uc.dbAdapter.SaveWebhookDeliveries(ctx, deliveries); err != nil {
+			return err
+		}
+		if err := uc.dbAdapter.SaveSyncCursor(ctx, webhookCursorRewards, int64(cycle)); err != nil {
+			return err
+		}
+		if len(deliveries) > 0 {
+			uc.logger.Infof("Queued %d rewards deliveries for cycle %d", len(deliveries), cycle)
+		}
+	}
+	return nil
+}
+
+// rewardsDeliveries returns the rewards deliveries of a cycle for the webhooks subscribing to them.
+func (uc *EnqueueWebhookEvents) rewardsDeliveries(ctx context.Context, webhooks []model.Webhook, cycle int) ([]model.WebhookDelivery, error) {
+	var bakers []model.BakerCycleRewards
+	bakersLoaded := false
+	now := time.Now().UTC()
+
+	var deliveries []model.WebhookDelivery
+	for _, w := range webhooks {
+		if !w.Subscribes(model.WebhookEventRewards) {
+			continue
+		}
+
+		event := model.RewardsIndexedEvent{Cycle: cycle}
+		if w.Baker != "" || w.Delegator == "" {
+			if !bakersLoaded {
+				var err error
+				if bakers, err = uc.dbAdapter.GetBakerRewardStats(ctx, cycle, cycle, ""); err != nil {
+					return nil, err
+				}
+				for i, s := range bakers {
+					bakers[i].RewardsTez = s.Rewards.Tez()
+				}
+				bakersLoaded = true
+			}
+			for _, b := range bakers {
+				if w.Baker == "" || b.Baker == w.Baker {
+					event.Bakers = append(event.Bakers, b)
+				}
+			}
+			if w.Baker != "" && len(event.Bakers) == 0 {
+				continue
+			}
+		}
+		if w.Delegator != "" {
+			delegators, err := uc.dbAdapter.GetDelegatorRewardStats(ctx, cycle, cycle, w.Delegator)
+			if err != nil {
+				return nil, err
+			}
+			if len(delegators) == 0 {
+				continue
+			}
+			for i, s := range delegators {
+				delegators[i].RewardsTez = s.Rewards.Tez()
+			}
+			event.Delegators = delegators
+		}
+
+		delivery, err := newWebhookDelivery(w.ID, "rewards-"+strconv.Itoa(cycle), model.WebhookEventRewards, event, now)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// newWebhookDelivery returns a pending delivery of an event, due now.
+func newWebhookDelivery(webhookID int64, eventID string, eventType model.WebhookEventType, data interface{}, now time.Time) (model.WebhookDelivery, error) {
+	payload, err := json.Marshal(model.WebhookEvent{ID: eventID, Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		return model.WebhookDelivery{}, fmt.Errorf("error encoding webhook event %s: %w", eventID, err)
+	}
+	return model.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        model.WebhookDeliveryPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// DeliverWebhooks handles business logic for POSTing the due deliveries to their webhooks.
+type DeliverWebhooks struct {
+	cfg        WebhookDeliveryConfig
+	dbAdapter  database.Adapter
+	httpClient *http.Client
+	logger     *logrus.Entry
+	now        func() time.Time
+}
+
+// NewDeliverWebhooksFunc creates a new instance of DeliverWebhooks and returns a SyncFunc.
+// Redirects are not followed, an endpoint must answer the POST itself, and only public addresses are dialed, without
+// proxy, whatever the endpoint host resolves to at delivery time.
+func NewDeliverWebhooksFunc(cfg WebhookDeliveryConfig, dbAdapter database.Adapter, metricsClient metrics.Adapter, logger *logrus.Entry) model.SyncFunc {
+	cfg = cfg.withDefaults()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   cfg.Timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   publicDialControl,
+	}).DialContext
+	uc := &DeliverWebhooks{
+		cfg:       cfg,
+		dbAdapter: dbAdapter,
+		httpClient: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger.WithField("usecase", "deliver_webhooks"),
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+	return func(ctx context.Context) (err error) {
+		defer uc.monitor("DeliverWebhooks", time.Now(), metricsClient, &err)
+		return uc.Sync(ctx)
+	}
+}
+
+// Sync POSTs a batch of due deliveries, Concurrency at once.
+func (uc *DeliverWebhooks) Sync(ctx context.Context) error {
+	deliveries, err := uc.dbAdapter.GetDueWebhookDeliveries(ctx, uc.now(), uint16(uc.cfg.BatchSize))
+	if err != nil || len(deliveries) == 0 {
+		return err
+	}
+
+	webhooks, err := uc.dbAdapter.GetWebhooks(ctx, 0)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]model.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, uc.cfg.Concurrency)
+	for _, d := range deliveries {
+		webhook, ok := byID[d.WebhookID]
+		if !ok || webhook.IsDisabled() {
+			continue // deleted or disabled since the deliveries were read
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(webhook model.Webhook, delivery model.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := uc.deliver(ctx, webhook, delivery); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(webhook, d)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// deliver attempts a delivery and records the attempt. A failed delivery is retried after an exponential backoff
+// until its last attempt, and its webhook is disabled once it failed MaxConsecutiveFailures attempts in a row.
+func (uc *DeliverWebhooks) deliver(ctx context.Context, webhook model.Webhook, delivery model.WebhookDelivery) error {
+	attempt := uc.post(ctx, webhook, delivery)
+
+	delivery.AttemptCount++
+	switch {
+	case attempt.Succeeded():
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.LastError = ""
+	default:
+		delivery.LastError = attempt.Error
+		if delivery.LastError == "" {
+			delivery.LastError = fmt.Sprintf("unexpected status %d", attempt.StatusCode)
+		}
+		if delivery.AttemptCount >= uc.cfg.MaxAttempts {
+			delivery.Status = model.WebhookDeliveryFailed
+		} else {
+			delivery.NextAttemptAt = attempt.AttemptedAt.Add(uc.backoff(delivery.AttemptCount))
+		}
+	}
+
+	failures, err := uc.dbAdapter.SaveWebhookDeliveryAttempt(ctx, delivery, attempt)
+	if err != nil {
+		return fmt.Errorf("error recording delivery %d attempt: %w", delivery.ID, err)
+	}
+
+	if !attempt.Succeeded() && failures >= uc.cfg.MaxConsecutiveFailures {
+		if err := uc.dbAdapter.DisableWebhook(ctx, webhook.ID, attempt.AttemptedAt); err != nil {
+			return fmt.Errorf("error disabling webhook %d: %w", webhook.ID, err)
+		}
+		uc.logger.WithField("webhookID", webhook.ID).Warnf("Webhook disabled after %d consecutive failures", failures)
+	}
+	return nil
+}
+
+// post sends a delivery to its webhook, signed with the secret of the webhook, and returns the attempt.
+func (uc *DeliverWebhooks) post(ctx context.Context, webhook model.Webhook, delivery model.WebhookDelivery) model.WebhookDeliveryAttempt {
+	attempt := model.WebhookDeliveryAttempt{DeliveryID: delivery.ID, AttemptedAt: uc.now()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	timestamp := attempt.AttemptedAt.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "tezos-delegation-service-webhooks")
+	req.Header.Set(WebhookHeaderEvent, delivery.EventType.String())
+	req.Header.Set(WebhookHeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+	resp, err := uc.httpClient.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	// Draining a bounded part of the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = resp.StatusCode
+	return attempt
+}
+
+// backoff returns the delay after the given number of failed attempts: BackoffBase doubled per attempt, up to BackoffMax.
+func (uc *DeliverWebhooks) backoff(attempts int) time.Duration {
+	delay := uc.cfg.BackoffBase
+	for i := 1; i < attempts && delay < uc.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > uc.cfg.BackoffMax {
+		delay = uc.cfg.BackoffMax
+	}
+	return delay
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *EnqueueWebhookEvents) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *DeliverWebhooks) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
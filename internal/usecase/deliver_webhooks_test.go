@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_SignWebhookPayload(t *testing.T) {
+	// Computed with: printf '1700000000.{"id":"delegation-1"}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t,
+		"sha256=72f1a7490955d6cacfb14aea554bf3b3f4448e3b412e36b5f24a0d006a7c2615",
+		SignWebhookPayload("whsec_test", 1700000000, []byte(`{"id":"delegation-1"}`)),
+	)
+}
+
+func Test_EnqueueWebhookEvents_Sync(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	uc := &EnqueueWebhookEvents{dbAdapter: db, logger: logrus.NewEntry(logrus.New())}
+
+	baker := &model.Webhook{URL: "https://example.com/baker", EventTypes: []model.WebhookEventType{model.WebhookEventDelegation, model.WebhookEventRewards}, Baker: "tz1baker"}
+	all := &model.Webhook{URL: "https://example.com/all"}
+	for _, w := range []*model.Webhook{baker, all} {
+		assert.NoError(t, db.SaveWebhook(ctx, w))
+	}
+
+	// The events indexed before the first run are not delivered.
+	assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{{Delegator: "tz1a", Delegate: "tz1baker", Level: 1}}))
+	assert.NoError(t, db.SaveRewards(ctx, []model.Reward{{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 10, Amount: 1000}}))
+	assert.NoError(t, db.SaveLastSyncedRewardCycle(ctx, 10))
+	assert.NoError(t, uc.Sync(ctx))
+	due, err := db.GetDueWebhookDeliveries(ctx, time.Now().Add(time.Minute), 100)
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{
+		{Delegator: "tz1a", Delegate: "tz1baker", Level: 2},
+		{Delegator: "tz1b", Delegate: "tz1other", Level: 3},
+		{Delegator: "tz1a", Level: 4},
+	}))
+	assert.NoError(t, db.SaveRewards(ctx, []model.Reward{
+		{RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 11, Amount: 2000},
+		{RecipientAddress: "tz1b", SourceAddress: "tz1other", Cycle: 12, Amount: 3000},
+	}))
+	assert.NoError(t, db.RefreshRewardStats(ctx, 11, 12))
+	assert.NoError(t, db.SaveLastSyncedRewardCycle(ctx, 12))
+	assert.NoError(t, uc.Sync(ctx))
+	// A second run queues nothing new.
+	assert.NoError(t, uc.Sync(ctx))
+
+	eventIDs := func(webhookID int64) []string {
+		deliveries, err := db.GetWebhookDeliveries(ctx, webhookID, 100)
+		assert.NoError(t, err)
+		var ids []string
+		for i := len(deliveries) - 1; i >= 0; i-- {
+			ids = append(ids, deliveries[i].EventID)
+		}
+		return ids
+	}
+
+	delegations, err := db.GetDelegationsAfter(ctx, 1, model.DelegationFilter{}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, delegations, 3)
+	id := func(i int) string { return "delegation-" + strconv.FormatInt(delegations[i].ID, 10) }
+
+	assert.Equal(t, []string{id(0), "rewards-11"}, eventIDs(baker.ID))
+	assert.Equal(t, []string{id(0), id(1), id(2), "rewards-11", "rewards-12"}, eventIDs(all.ID))
+
+	deliveries, err := db.GetWebhookDeliveries(ctx, baker.ID, 1)
+	assert.NoError(t, err)
+	var event struct {
+		ID   string                    `json:"id"`
+		Type model.WebhookEventType    `json:"type"`
+		Data model.RewardsIndexedEvent `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(deliveries[0].Payload, &event))
+	assert.Equal(t, "rewards-11", event.ID)
+	assert.Equal(t, model.WebhookEventRewards, event.Type)
+	assert.Len(t, event.Data.Bakers, 1)
+	assert.Equal(t, model.WalletAddress("tz1baker"), event.Data.Bakers[0].Baker)
+}
+
+// newTestDeliverWebhooks returns a DeliverWebhooks over db at a fixed date.
+func newTestDeliverWebhooks(db *databasememory.Memory, cfg WebhookDeliveryConfig, now time.Time) *DeliverWebhooks {
+	cfg = cfg.withDefaults()
+	return &DeliverWebhooks{
+		cfg:        cfg,
+		dbAdapter:  db,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		logger:     logrus.NewEntry(logrus.New()),
+		now:        func() time.Time { return now },
+	}
+}
+
+func Test_DeliverWebhooks_Sync(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Nominal case - signed delivery", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		db := databasememory.New()
+		webhook := &model.Webhook{URL: srv.URL, Secret: "whsec_test"}
+		assert.NoError(t, db.SaveWebhook(ctx, webhook))
+		assert.NoError(t, db.SaveWebhookDeliveries(ctx, []model.WebhookDelivery{
+			{WebhookID: webhook.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{"id":"delegation-1"}`), NextAttemptAt: now},
+		}))
+
+		assert.NoError(t, newTestDeliverWebhooks(db, WebhookDeliveryConfig{}, now).Sync(ctx))
+
+		assert.NotNil(t, received)
+		assert.Equal(t, `{"id":"delegation-1"}`, string(body))
+		assert.Equal(t, "delegation", received.Header.Get(WebhookHeaderEvent))
+		assert.Equal(t, "1", received.Header.Get(WebhookHeaderDelivery))
+		assert.Equal(t, strconv.FormatInt(now.Unix(), 10), received.Header.Get(WebhookHeaderTimestamp))
+		assert.Equal(t, SignWebhookPayload("whsec_test", now.Unix(), body), received.Header.Get(WebhookHeaderSignature))
+
+		deliveries, err := db.GetWebhookDeliveries(ctx, webhook.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[0].Status)
+		assert.Equal(t, 1, deliveries[0].AttemptCount)
+		assert.Equal(t, http.StatusNoContent, deliveries[0].Attempts[0].StatusCode)
+	})
+
+	t.Run("Nominal case - failed delivery retried with backoff", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		db := databasememory.New()
+		webhook := &model.Webhook{URL: srv.URL, Secret: "whsec_test"}
+		assert.NoError(t, db.SaveWebhook(ctx, webhook))
+		assert.NoError(t, db.SaveWebhookDeliveries(ctx, []model.WebhookDelivery{
+			{WebhookID: webhook.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+		}))
+
+		cfg := WebhookDeliveryConfig{BackoffBase: time.Minute}
+		assert.NoError(t, newTestDeliverWebhooks(db, cfg, now).Sync(ctx))
+
+		deliveries, err := db.GetWebhookDeliveries(ctx, webhook.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryPending, deliveries[0].Status)
+		assert.Equal(t, "unexpected status 500", deliveries[0].LastError)
+		assert.Equal(t, now.Add(time.Minute), deliveries[0].NextAttemptAt)
+
+		// Not due yet.
+		assert.NoError(t, newTestDeliverWebhooks(db, cfg, now.Add(30*time.Second)).Sync(ctx))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		assert.NoError(t, newTestDeliverWebhooks(db, cfg, now.Add(time.Minute)).Sync(ctx))
+		deliveries, err = db.GetWebhookDeliveries(ctx, webhook.ID, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, model.WebhookDeliveryDelivered, deliveries[0].Status)
+		assert.Len(t, deliveries[0].Attempts, 2)
+	})
+
+	t.Run("Error case - webhook disabled after consecutive failures", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		db := databasememory.New()
+		webhook := &model.Webhook{URL: srv.URL, Secret: "whsec_test"}
+		assert.NoError(t, db.SaveWebhook(ctx, webhook))
+		assert.NoError(t, db.SaveWebhookDeliveries(ctx, []model.WebhookDelivery{
+			{WebhookID: webhook.ID, EventID: "delegation-1", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+			{WebhookID: webhook.ID, EventID: "delegation-2", EventType: model.WebhookEventDelegation, Payload: []byte(`{}`), NextAttemptAt: now},
+		}))
+
+		cfg := WebhookDeliveryConfig{MaxAttempts: 1, MaxConsecutiveFailures: 2, Concurrency: 1}
+		assert.NoError(t, newTestDeliverWebhooks(db, cfg, now).Sync(ctx))
+
+		saved, err := db.GetWebhook(ctx, webhook.ID)
+		assert.NoError(t, err)
+		assert.True(t, saved.IsDisabled())
+		assert.Equal(t, 2, saved.ConsecutiveFailures)
+
+		deliveries, err := db.GetWebhookDeliveries(ctx, webhook.ID, 10)
+		assert.NoError(t, err)
+		for _, d := range deliveries {
+			assert.Equal(t, model.WebhookDeliveryFailed, d.Status)
+		}
+	})
+}
+
+func Test_DeliverWebhooks_backoff(t *testing.T) {
+	uc := &DeliverWebhooks{cfg: WebhookDeliveryConfig{BackoffBase: 30 * time.Second, BackoffMax: 5 * time.Minute}}
+
+	assert.Equal(t, 30*time.Second, uc.backoff(1))
+	assert.Equal(t, time.Minute, uc.backoff(2))
+	assert.Equal(t, 4*time.Minute, uc.backoff(4))
+	assert.Equal(t, 5*time.Minute, uc.backoff(5))
+	assert.Equal(t, 5*time.Minute, uc.backoff(20))
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import "github.com/tezos-delegation-service/internal/apperror"
+
+// ErrSnapshotUnavailable is returned when a point-in-time query targets a level or cycle which is not indexed yet.
+var ErrSnapshotUnavailable = apperror.New(apperror.KindNotFound, "snapshot_unavailable", "snapshot unavailable")
+
+// ErrAccountNotFound is returned when an address is not known by the indexer.
+var ErrAccountNotFound = apperror.New(apperror.KindNotFound, "account_not_found", "account not found")
+
+// ErrBakerNotFound is returned when an address is not a staking pool known by the indexer.
+var ErrBakerNotFound = apperror.New(apperror.KindNotFound, "baker_not_found", "baker not found")
+
+// ErrTooManySubscribers is returned when the delegation stream already serves its maximum number of subscribers.
+var ErrTooManySubscribers = apperror.New(apperror.KindUnavailable, "too_many_subscribers", "too many stream subscribers")
+
+// ErrSubscriberTooSlow ends a subscription whose buffer is full because its events are not read fast enough.
+var ErrSubscriberTooSlow = apperror.New(apperror.KindUnavailable, "subscriber_too_slow", "stream subscriber too slow")
+
+// ErrStreamClosed is returned when the delegation stream is stopped.
+var ErrStreamClosed = apperror.New(apperror.KindUnavailable, "stream_closed", "stream closed")
+
+// ErrWebhookNotFound is returned when a webhook id is unknown.
+var ErrWebhookNotFound = apperror.New(apperror.KindNotFound, "webhook_not_found", "webhook not found")
+
+// ErrInvalidWebhook is returned when a webhook cannot be created as requested.
+var ErrInvalidWebhook = apperror.New(apperror.KindValidation, "invalid_webhook", "invalid webhook")
+
+// ErrAPIKeyNotFound is returned when an API key id is unknown.
+var ErrAPIKeyNotFound = apperror.New(apperror.KindNotFound, "api_key_not_found", "API key not found")
+
+// ErrInvalidAPIKey is returned when an API key cannot be created as requested.
+var ErrInvalidAPIKey = apperror.New(apperror.KindValidation, "invalid_api_key", "invalid API key")
+
+// ErrUnauthorized is returned when an API key is unknown or revoked.
+var ErrUnauthorized = apperror.New(apperror.KindUnauthorized, "unauthorized", "unauthorized")
+
+// ErrInvalidRequest is returned when the parameters of a request are invalid.
+var ErrInvalidRequest = apperror.New(apperror.KindValidation, apperror.CodeInvalidRequest, "invalid request")
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// exports handles business logic for the streaming exports of delegations, operations and rewards.
+// Exports accept the filters of the paginated lists, their page, limit and cursor are ignored.
+type exports struct {
+	dbAdapter   database.Adapter
+	delegations *getDelegations
+}
+
+// ExportDelegationsFunc defines the function signature for streaming every delegation matching the filters to fn.
+type ExportDelegationsFunc func(ctx context.Context, input GetDelegationsInput, fn func(model.Delegation) error) error
+
+// ExportOperationsFunc defines the function signature for streaming every operation matching the filters to fn.
+type ExportOperationsFunc func(ctx context.Context, input GetOperationsInput, fn func(model.Operation) error) error
+
+// ExportRewardsFunc defines the function signature for streaming every reward matching the filters to fn.
+type ExportRewardsFunc func(ctx context.Context, input GetRewardsInput, fn func(model.Reward) error) error
+
+// NewExportDelegationsFunc creates a new instance of exports streaming delegations.
+func NewExportDelegationsFunc(adapter database.Adapter, metricsClient metrics.Adapter) ExportDelegationsFunc {
+	uc := newExports(adapter)
+	return func(ctx context.Context, input GetDelegationsInput, fn func(model.Delegation) error) (err error) {
+		defer uc.monitor("ExportDelegations", time.Now(), metricsClient, &err)
+		return uc.ExportDelegations(ctx, input, fn)
+	}
+}
+
+// NewExportOperationsFunc creates a new instance of exports streaming operations.
+func NewExportOperationsFunc(adapter database.Adapter, metricsClient metrics.Adapter) ExportOperationsFunc {
+	uc := newExports(adapter)
+	return func(ctx context.Context, input GetOperationsInput, fn func(model.Operation) error) (err error) {
+		defer uc.monitor("ExportOperations", time.Now(), metricsClient, &err)
+		return uc.ExportOperations(ctx, input, fn)
+	}
+}
+
+// NewExportRewardsFunc creates a new instance of exports streaming rewards.
+func NewExportRewardsFunc(adapter database.Adapter, metricsClient metrics.Adapter) ExportRewardsFunc {
+	uc := newExports(adapter)
+	return func(ctx context.Context, input GetRewardsInput, fn func(model.Reward) error) (err error) {
+		defer uc.monitor("ExportRewards", time.Now(), metricsClient, &err)
+		return uc.ExportRewards(ctx, input, fn)
+	}
+}
+
+// newExports creates the exports sharing the filter validation of the delegation list.
+func newExports(adapter database.Adapter) *exports {
+	return &exports{
+		dbAdapter:   adapter,
+		delegations: &getDelegations{dbAdapter: adapter},
+	}
+}
+
+// ExportDelegations streams every delegation matching the filters, in their order.
+func (uc *exports) ExportDelegations(ctx context.Context, input GetDelegationsInput, fn func(model.Delegation) error) error {
+	year, err := uc.delegations.parseYear(input.Year)
+	if err != nil {
+		return err
+	}
+
+	filter, err := uc.delegations.buildFilter(input, year)
+	if err != nil {
+		return err
+	}
+
+	return uc.dbAdapter.StreamDelegations(ctx, filter, func(d model.Delegation) error {
+		d.TimestampTime = time.Unix(d.Timestamp, 0).UTC().Format(time.RFC3339)
+		d.AmountTez = d.Amount.Tez()
+		return fn(d)
+	})
+}
+
+// ExportOperations streams every operation matching the filters, by decreasing timestamp.
+func (uc *exports) ExportOperations(ctx context.Context, input GetOperationsInput, fn func(model.Operation) error) error {
+	fromTimestamp, toTimestamp, err := unixBounds(input.FromDate, input.ToDate)
+	if err != nil {
+		return err
+	}
+
+	return uc.dbAdapter.StreamOperations(ctx, fromTimestamp, toTimestamp, input.Type, input.Wallet, input.Backer, func(o model.Operation) error {
+		o.TimestampTime = time.Unix(o.Timestamp, 0).UTC().Format(time.RFC3339)
+		o.AmountTez = o.Amount.Tez()
+		return fn(o)
+	})
+}
+
+// ExportRewards streams every reward matching the filters, by decreasing timestamp.
+func (uc *exports) ExportRewards(ctx context.Context, input GetRewardsInput, fn func(model.Reward) error) error {
+	fromTimestamp, toTimestamp, err := unixBounds(input.FromDate, input.ToDate)
+	if err != nil {
+		return err
+	}
+
+	return uc.dbAdapter.StreamRewards(ctx, fromTimestamp, toTimestamp, input.Wallet, input.Backer, func(r model.Reward) error {
+		r.TimestampTime = time.Unix(r.Timestamp, 0).UTC().Format(time.RFC3339)
+		r.AmountTez = r.Amount.Tez()
+		return fn(r)
+	})
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *exports) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_exports_ExportDelegations(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetDelegationsInput
+		want      []model.Delegation
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				filter := model.DelegationFilter{Year: 2024, Delegator: "tz1delegator", Sort: model.DelegationSortAmount}
+				mockDB.On("StreamDelegations", mock.Anything, filter).
+					Return([]model.Delegation{{Delegator: "tz1delegator", Timestamp: 1704067200, Amount: 1500000, Level: 10}}, nil)
+				return mockDB
+			}(),
+			input: GetDelegationsInput{Year: "2024", Delegator: "tz1delegator", Sort: model.DelegationSortAmount},
+			want: []model.Delegation{{
+				Delegator:     "tz1delegator",
+				Timestamp:     1704067200,
+				TimestampTime: "2024-01-01T00:00:00Z",
+				Amount:        1500000,
+				AmountTez:     "1.500000",
+				Level:         10,
+			}},
+		},
+		{
+			name:    "Error case - invalid year",
+			input:   GetDelegationsInput{Year: "year"},
+			wantErr: true,
+		},
+		{
+			name:    "Error case - invalid sort",
+			input:   GetDelegationsInput{Sort: "delegator"},
+			wantErr: true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamDelegations", mock.Anything, model.DelegationFilter{}).
+					Return([]model.Delegation(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.Delegation
+			err := NewExportDelegationsFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input, func(d model.Delegation) error {
+				got = append(got, d)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportDelegations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExportDelegations() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_exports_ExportOperations(t *testing.T) {
+	fromDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetOperationsInput
+		want      []model.Operation
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamOperations", mock.Anything, fromDate.Unix(), int64(0), model.OperationTypeStake, model.WalletAddress("tz1wallet"), model.WalletAddress("tz1baker")).
+					Return([]model.Operation{{ID: 1, Timestamp: 1704067200, Amount: 2000000, Type: model.OperationTypeStake}}, nil)
+				return mockDB
+			}(),
+			input: GetOperationsInput{FromDate: &fromDate, Type: model.OperationTypeStake, Wallet: "tz1wallet", Backer: "tz1baker"},
+			want: []model.Operation{{
+				ID:            1,
+				Timestamp:     1704067200,
+				TimestampTime: "2024-01-01T00:00:00Z",
+				Amount:        2000000,
+				AmountTez:     "2.000000",
+				Type:          model.OperationTypeStake,
+			}},
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamOperations", mock.Anything, int64(0), int64(0), model.OperationType(""), model.WalletAddress(""), model.WalletAddress("")).
+					Return([]model.Operation(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.Operation
+			err := NewExportOperationsFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input, func(o model.Operation) error {
+				got = append(got, o)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportOperations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExportOperations() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_exports_ExportRewards(t *testing.T) {
+	toDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetRewardsInput
+		fnErr     error
+		want      []model.Reward
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamRewards", mock.Anything, int64(0), toDate.Unix(), model.WalletAddress("tz1wallet"), model.WalletAddress("tz1baker")).
+					Return([]model.Reward{{ID: 1, Cycle: 700, Timestamp: 1704067200, Amount: 250000}}, nil)
+				return mockDB
+			}(),
+			input: GetRewardsInput{ToDate: &toDate, Wallet: "tz1wallet", Backer: "tz1baker"},
+			want: []model.Reward{{
+				ID:            1,
+				Cycle:         700,
+				Timestamp:     1704067200,
+				TimestampTime: "2024-01-01T00:00:00Z",
+				Amount:        250000,
+				AmountTez:     "0.250000",
+			}},
+		},
+		{
+			name: "Error case - writing a row fails",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamRewards", mock.Anything, int64(0), int64(0), model.WalletAddress(""), model.WalletAddress("")).
+					Return([]model.Reward{{ID: 1}, {ID: 2}}, nil)
+				return mockDB
+			}(),
+			fnErr:   errors.New("broken pipe"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []model.Reward
+			err := NewExportRewardsFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input, func(r model.Reward) error {
+				if tt.fnErr != nil {
+					return tt.fnErr
+				}
+				got = append(got, r)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExportRewards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExportRewards() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// getAccountProfile handles business logic for the profile of an address.
+type getAccountProfile struct {
+	dbAdapter database.Adapter
+}
+
+// GetAccountProfileFunc defines the function signature for fetching the profile of an address.
+type GetAccountProfileFunc func(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error)
+
+// NewGetAccountProfileFunc creates a new instance of getAccountProfile.
+func NewGetAccountProfileFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetAccountProfileFunc {
+	uc := &getAccountProfile{
+		dbAdapter: adapter,
+	}
+	return uc.withMonitorer(uc.GetAccountProfile, metricsClient)
+}
+
+// GetAccountProfile returns the account of an address with its delegation timeline, rewards and staking activity.
+func (uc *getAccountProfile) GetAccountProfile(ctx context.Context, address model.WalletAddress) (*model.AccountProfile, error) {
+	account, err := uc.dbAdapter.GetAccount(ctx, address)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := uc.dbAdapter.GetDelegatorDelegations(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	totalRewards, err := uc.dbAdapter.GetTotalRewards(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.dbAdapter.GetStakingOperationCounts(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &model.AccountProfile{
+		Address:            account.Address,
+		Alias:              account.Alias,
+		Type:               account.Type,
+		DelegationTimeline: model.BakerChangesFromDelegations(delegations),
+		TotalRewards:       totalRewards,
+		TotalRewardsTez:    totalRewards.Tez(),
+		StakingOperations:  make(map[string]int64, len(counts)),
+	}
+
+	// Delegations are sorted by increasing level, the last one sets the current baker.
+	if len(delegations) > 0 {
+		profile.FirstSeenLevel = delegations[0].Level
+		profile.LastActiveLevel = delegations[len(delegations)-1].Level
+		profile.CurrentBaker = delegations[len(delegations)-1].Delegate
+	}
+
+	for _, c := range counts {
+		profile.StakingOperations[c.Entrypoint] = c.Count
+	}
+
+	return profile, nil
+}
+
+// withMonitorer wraps the GetAccountProfile function with telemetry monitoring.
+func (uc *getAccountProfile) withMonitorer(getAccountProfile GetAccountProfileFunc, metricsClient metrics.Adapter) GetAccountProfileFunc {
+	return func(ctx context.Context, address model.WalletAddress) (result *model.AccountProfile, err error) {
+		startTime := time.Now()
+
+		defer func() {
+			if metricsClient != nil {
+				duration := time.Since(startTime)
+				metricsClient.RecordServiceOperation("GetAccountProfile", "UseCase", duration, err)
+			}
+		}()
+
+		return getAccountProfile(ctx, address)
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getAccountProfile_GetAccountProfile(t *testing.T) {
+	errDatabase := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		want      *model.AccountProfile
+		wantErr   error
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetAccount", mock.Anything, model.WalletAddress("tz1a")).
+					Return(&model.Account{Address: "tz1a", Alias: "Alice", Type: model.AccountTypeUser}, nil)
+				mockDB.On("GetDelegatorDelegations", mock.Anything, model.WalletAddress("tz1a")).
+					Return([]model.Delegation{
+						{Delegator: "tz1a", Delegate: "tz1old", Level: 100, Timestamp: 0},
+						{Delegator: "tz1a", Delegate: "tz1old", Level: 150, Timestamp: 60},
+						{Delegator: "tz1a", Delegate: "tz1new", Level: 200, Timestamp: 120},
+					}, nil)
+				mockDB.On("GetTotalRewards", mock.Anything, model.WalletAddress("tz1a")).
+					Return(model.Mutez(1500000), nil)
+				mockDB.On("GetStakingOperationCounts", mock.Anything, model.WalletAddress("tz1a")).
+					Return([]model.StakingOperationCount{{Entrypoint: "stake", Count: 2}}, nil)
+				return mockDB
+			}(),
+			want: &model.AccountProfile{
+				Address:         "tz1a",
+				Alias:           "Alice",
+				Type:            model.AccountTypeUser,
+				FirstSeenLevel:  100,
+				LastActiveLevel: 200,
+				CurrentBaker:    "tz1new",
+				DelegationTimeline: []model.BakerChange{
+					{Baker: "tz1old", Level: 100, TimestampTime: "1970-01-01T00:00:00Z"},
+					{Baker: "tz1new", Level: 200, TimestampTime: "1970-01-01T00:02:00Z"},
+				},
+				TotalRewards:      1500000,
+				TotalRewardsTez:   "1.500000",
+				StakingOperations: map[string]int64{"stake": 2},
+			},
+		},
+		{
+			name: "Nominal case - no activity",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetAccount", mock.Anything, model.WalletAddress("tz1a")).
+					Return(&model.Account{Address: "tz1a", Type: model.AccountTypeDelegate}, nil)
+				mockDB.On("GetDelegatorDelegations", mock.Anything, model.WalletAddress("tz1a")).
+					Return([]model.Delegation{}, nil)
+				mockDB.On("GetTotalRewards", mock.Anything, model.WalletAddress("tz1a")).
+					Return(model.Mutez(0), nil)
+				mockDB.On("GetStakingOperationCounts", mock.Anything, model.WalletAddress("tz1a")).
+					Return([]model.StakingOperationCount{}, nil)
+				return mockDB
+			}(),
+			want: &model.AccountProfile{
+				Address:            "tz1a",
+				Type:               model.AccountTypeDelegate,
+				DelegationTimeline: []model.BakerChange{},
+				TotalRewardsTez:    "0.000000",
+				StakingOperations:  map[string]int64{},
+			},
+		},
+		{
+			name: "Error case - unknown account",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetAccount", mock.Anything, model.WalletAddress("tz1a")).
+					Return((*model.Account)(nil), sql.ErrNoRows)
+				return mockDB
+			}(),
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetAccount", mock.Anything, model.WalletAddress("tz1a")).
+					Return(&model.Account{Address: "tz1a"}, nil)
+				mockDB.On("GetDelegatorDelegations", mock.Anything, model.WalletAddress("tz1a")).
+					Return([]model.Delegation(nil), errDatabase)
+				return mockDB
+			}(),
+			wantErr: errDatabase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetAccountProfileFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), "tz1a")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetAccountProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAccountProfile() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// maxBakerDelegatorsLimit is the maximum number of delegators per page.
+const maxBakerDelegatorsLimit = 500
+
+// getBakerDelegators handles business logic for the current delegators of a baker.
+type getBakerDelegators struct {
+	dbAdapter    database.Adapter
+	defaultLimit uint16
+}
+
+// GetBakerDelegatorsInput defines the input structure for fetching the delegators of a baker.
+// AsOfLevel or AsOfCycle, when positive, select the delegators at the end of that level or cycle instead of the current ones.
+type GetBakerDelegatorsInput struct {
+	Baker     model.WalletAddress
+	AsOfLevel int64
+	AsOfCycle int
+	Page      string
+	Limit     string
+}
+
+// GetBakerDelegatorsFunc defines the function signature for fetching the current delegators of a baker.
+type GetBakerDelegatorsFunc func(ctx context.Context, input GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error)
+
+// NewGetBakerDelegatorsFunc creates a new instance of getBakerDelegators.
+func NewGetBakerDelegatorsFunc(defaultLimit uint16, adapter database.Adapter, metricsClient metrics.Adapter) GetBakerDelegatorsFunc {
+	uc := &getBakerDelegators{
+		dbAdapter:    adapter,
+		defaultLimit: defaultLimit,
+	}
+	return uc.withMonitorer(uc.GetBakerDelegators, metricsClient)
+}
+
+// GetBakerDelegators returns the delegators of a baker by decreasing balance, currently or at the end of a level or cycle.
+func (uc *getBakerDelegators) GetBakerDelegators(ctx context.Context, input GetBakerDelegatorsInput) (*model.BakerDelegatorsResponse, error) {
+	page, err := uc.parsePage(input.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := uc.parseLimit(input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	asOfLevel, err := uc.resolveLevel(ctx, input.AsOfLevel, input.AsOfCycle)
+	if err != nil {
+		return nil, err
+	}
+
+	delegators, err := uc.dbAdapter.GetBakerDelegators(ctx, input.Baker, asOfLevel, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page may be followed by another one, the next page is then possibly empty.
+	hasNextPage := len(delegators) == int(limit)
+
+	for i, d := range delegators {
+		delegators[i].BalanceTez = d.Balance.Tez()
+	}
+
+	pageInt := int(page)
+	paginationInfo := model.PaginationInfo{
+		CurrentPage: pageInt,
+		PerPage:     int(limit),
+		HasPrevPage: page > 1,
+		HasNextPage: hasNextPage,
+	}
+	if page > 1 {
+		paginationInfo.PrevPage = pageInt - 1
+	}
+	if hasNextPage {
+		paginationInfo.NextPage = pageInt + 1
+	}
+
+	return &model.BakerDelegatorsResponse{
+		Delegators: delegators,
+		AsOfLevel:  asOfLevel,
+		AsOfCycle:  input.AsOfCycle,
+		Pagination: paginationInfo,
+	}, nil
+}
+
+// resolveLevel returns the level of a point-in-time query, the last level of asOfCycle when set, 0 for the current state.
+// Levels which are not indexed yet are rejected, as their delegation set may still change.
+func (uc *getBakerDelegators) resolveLevel(ctx context.Context, asOfLevel int64, asOfCycle int) (int64, error) {
+	if asOfCycle > 0 {
+		cycle, err := uc.dbAdapter.GetCycle(ctx, asOfCycle)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: cycle %d is not synced", ErrSnapshotUnavailable, asOfCycle)
+		}
+		if err != nil {
+			return 0, err
+		}
+		asOfLevel = cycle.LastLevel
+	}
+
+	if asOfLevel <= 0 {
+		return 0, nil
+	}
+
+	highestLevel, err := uc.dbAdapter.GetHighestBlockLevel(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(asOfLevel) > highestLevel {
+		return 0, fmt.Errorf("%w: level %d is not indexed yet, the highest indexed level is %d", ErrSnapshotUnavailable, asOfLevel, highestLevel)
+	}
+
+	return asOfLevel, nil
+}
+
+// parsePage parses the page from the string and returns it as an integer.
+func (uc *getBakerDelegators) parsePage(pageStr string) (uint16, error) {
+	if pageStr == "" {
+		return 1, nil
+	}
+
+	p, err := strconv.Atoi(pageStr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: page must be a number: %s", ErrInvalidRequest, pageStr)
+	}
+	if p <= 0 {
+		return 0, fmt.Errorf("%w: page must be a positive number", ErrInvalidRequest)
+	}
+	if p > int(^uint16(0)) {
+		return 0, fmt.Errorf("%w: page number exceeds maximum allowed value of 65535", ErrInvalidRequest)
+	}
+	return uint16(p), nil
+}
+
+// parseLimit parses the limit from the string and returns it as an integer.
+func (uc *getBakerDelegators) parseLimit(limitStr string) (uint16, error) {
+	if limitStr == "" {
+		return uc.defaultLimit, nil
+	}
+
+	l, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: limit must be a number: %s", ErrInvalidRequest, limitStr)
+	}
+	if l <= 0 {
+		return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidRequest)
+	}
+	if l > maxBakerDelegatorsLimit {
+		return 0, fmt.Errorf("%w: limit exceeds maximum allowed value of 500", ErrInvalidRequest)
+	}
+	return uint16(l), nil
+}
+
+// withMonitorer wraps the GetBakerDelegators function with telemetry monitoring.
+func (uc *getBakerDelegators) withMonitorer(getBakerDelegators GetBakerDelegatorsFunc, metricsClient metrics.Adapter) GetBakerDelegatorsFunc {
+	return func(ctx context.Context, input GetBakerDelegatorsInput) (result *model.BakerDelegatorsResponse, err error) {
+		startTime := time.Now()
+
+		defer func() {
+			if metricsClient != nil {
+				duration := time.Since(startTime)
+				metricsClient.RecordServiceOperation("GetBakerDelegators", "UseCase", duration, err)
+			}
+		}()
+
+		return getBakerDelegators(ctx, input)
+	}
+}
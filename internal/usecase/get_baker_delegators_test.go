@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getBakerDelegators_GetBakerDelegators(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetBakerDelegatorsInput
+		want      *model.BakerDelegatorsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case - full page",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakerDelegators", mock.Anything, model.WalletAddress("tz1baker"), int64(0), uint16(2), uint16(1)).
+					Return([]model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 1500000}}, nil)
+				return mockDB
+			}(),
+			input: GetBakerDelegatorsInput{Baker: "tz1baker", Page: "2", Limit: "1"},
+			want: &model.BakerDelegatorsResponse{
+				Delegators: []model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 1500000, BalanceTez: "1.500000"}},
+				Pagination: model.PaginationInfo{CurrentPage: 2, PerPage: 1, HasPrevPage: true, HasNextPage: true, PrevPage: 1, NextPage: 3},
+			},
+		},
+		{
+			name: "Nominal case - default page and limit",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakerDelegators", mock.Anything, model.WalletAddress("tz1baker"), int64(0), uint16(1), uint16(50)).
+					Return([]model.CurrentDelegation{}, nil)
+				return mockDB
+			}(),
+			input: GetBakerDelegatorsInput{Baker: "tz1baker"},
+			want: &model.BakerDelegatorsResponse{
+				Delegators: []model.CurrentDelegation{},
+				Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 50},
+			},
+		},
+		{
+			name: "Nominal case - as of cycle",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetCycle", mock.Anything, 700).
+					Return(&model.Cycle{Index: 700, FirstLevel: 901, LastLevel: 1000}, nil)
+				mockDB.On("GetHighestBlockLevel", mock.Anything).
+					Return(uint64(2000), nil)
+				mockDB.On("GetBakerDelegators", mock.Anything, model.WalletAddress("tz1baker"), int64(1000), uint16(1), uint16(50)).
+					Return([]model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 1000000}}, nil)
+				return mockDB
+			}(),
+			input: GetBakerDelegatorsInput{Baker: "tz1baker", AsOfCycle: 700},
+			want: &model.BakerDelegatorsResponse{
+				Delegators: []model.CurrentDelegation{{Delegator: "tz1a", Baker: "tz1baker", SinceLevel: 100, Balance: 1000000, BalanceTez: "1.000000"}},
+				AsOfLevel:  1000,
+				AsOfCycle:  700,
+				Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 50},
+			},
+		},
+		{
+			name: "Error case - cycle not synced",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetCycle", mock.Anything, 900).
+					Return((*model.Cycle)(nil), sql.ErrNoRows)
+				return mockDB
+			}(),
+			input:   GetBakerDelegatorsInput{Baker: "tz1baker", AsOfCycle: 900},
+			wantErr: true,
+		},
+		{
+			name: "Error case - level not indexed",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetHighestBlockLevel", mock.Anything).
+					Return(uint64(2000), nil)
+				return mockDB
+			}(),
+			input:   GetBakerDelegatorsInput{Baker: "tz1baker", AsOfLevel: 2001},
+			wantErr: true,
+		},
+		{
+			name:      "Error case - invalid limit",
+			dbAdapter: dbmock.New(),
+			input:     GetBakerDelegatorsInput{Baker: "tz1baker", Limit: "501"},
+			wantErr:   true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakerDelegators", mock.Anything, model.WalletAddress("tz1baker"), int64(0), uint16(1), uint16(50)).
+					Return([]model.CurrentDelegation(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			input:   GetBakerDelegatorsInput{Baker: "tz1baker"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetBakerDelegatorsFunc(50, tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBakerDelegators() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBakerDelegators() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// maxBakersLimit is the maximum number of bakers per page.
+const maxBakersLimit = 100
+
+// getBakers handles business logic for the baker directory.
+type getBakers struct {
+	dbAdapter    database.Adapter
+	defaultLimit uint16
+}
+
+// GetBakersInput defines the input structure for fetching the baker directory.
+// FromDate and ToDate are UTC days, both included, bounding the delegation activity and the rewards of the bakers.
+type GetBakersInput struct {
+	Search   string
+	FromDate *time.Time
+	ToDate   *time.Time
+	Sort     model.BakerSort
+	Order    model.SortOrder
+	Page     string
+	Limit    string
+}
+
+// GetBakerInput defines the input structure for fetching a baker with its per-cycle history.
+// FromDate and ToDate bound its delegation activity and rewards, FromCycle and ToCycle its history, where 0 leaves a bound open.
+type GetBakerInput struct {
+	Address   model.WalletAddress
+	FromDate  *time.Time
+	ToDate    *time.Time
+	FromCycle int
+	ToCycle   int
+}
+
+// GetBakersFunc defines the function signature for fetching the baker directory.
+type GetBakersFunc func(ctx context.Context, input GetBakersInput) (*model.BakersResponse, error)
+
+// GetBakerFunc defines the function signature for fetching a baker with its per-cycle history.
+type GetBakerFunc func(ctx context.Context, input GetBakerInput) (*model.BakerResponse, error)
+
+// NewGetBakersFunc creates a new instance of getBakers serving the baker directory.
+func NewGetBakersFunc(defaultLimit uint16, adapter database.Adapter, metricsClient metrics.Adapter) GetBakersFunc {
+	uc := &getBakers{dbAdapter: adapter, defaultLimit: defaultLimit}
+	getBakersFunc := uc.GetBakers
+	return func(ctx context.Context, input GetBakersInput) (result *model.BakersResponse, err error) {
+		defer uc.monitor("GetBakers", time.Now(), metricsClient, &err)
+		return getBakersFunc(ctx, input)
+	}
+}
+
+// NewGetBakerFunc creates a new instance of getBakers serving a baker.
+func NewGetBakerFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetBakerFunc {
+	uc := &getBakers{dbAdapter: adapter}
+	getBakerFunc := uc.GetBaker
+	return func(ctx context.Context, input GetBakerInput) (result *model.BakerResponse, err error) {
+		defer uc.monitor("GetBaker", time.Now(), metricsClient, &err)
+		return getBakerFunc(ctx, input)
+	}
+}
+
+// GetBakers returns the staking pools matching the search, sorted by their delegators or their activity over the period.
+func (uc *getBakers) GetBakers(ctx context.Context, input GetBakersInput) (*model.BakersResponse, error) {
+	page, err := uc.parsePage(input.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := uc.parseLimit(input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Sort != "" && !input.Sort.IsValid() {
+		return nil, fmt.Errorf("%w: invalid sort: %s", ErrInvalidRequest, input.Sort)
+	}
+	if input.Order != "" && !input.Order.IsValid() {
+		return nil, fmt.Errorf("%w: invalid order: %s", ErrInvalidRequest, input.Order)
+	}
+
+	fromDate, toDate := periodBounds(input.FromDate, input.ToDate)
+	filter := model.BakerFilter{
+		Search:   input.Search,
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Sort:     input.Sort,
+		Order:    input.Order,
+	}
+
+	bakers, err := uc.dbAdapter.GetBakers(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page may be followed by another one, the next page is then possibly empty.
+	hasNextPage := len(bakers) == int(limit)
+
+	for i := range bakers {
+		bakers[i].SetTez()
+	}
+
+	pageInt := int(page)
+	paginationInfo := model.PaginationInfo{
+		CurrentPage: pageInt,
+		PerPage:     int(limit),
+		HasPrevPage: page > 1,
+		HasNextPage: hasNextPage,
+	}
+	if page > 1 {
+		paginationInfo.PrevPage = pageInt - 1
+	}
+	if hasNextPage {
+		paginationInfo.NextPage = pageInt + 1
+	}
+
+	return &model.BakersResponse{
+		Bakers:     bakers,
+		Pagination: paginationInfo,
+	}, nil
+}
+
+// GetBaker returns a staking pool with its activity over the period and its rewards and delegators per cycle.
+func (uc *getBakers) GetBaker(ctx context.Context, input GetBakerInput) (*model.BakerResponse, error) {
+	fromDate, toDate := periodBounds(input.FromDate, input.ToDate)
+
+	baker, err := uc.dbAdapter.GetBaker(ctx, input.Address, fromDate, toDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrBakerNotFound, input.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	baker.SetTez()
+
+	history, err := uc.dbAdapter.GetBakerRewardStats(ctx, input.FromCycle, input.ToCycle, input.Address)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range history {
+		history[i].RewardsTez = s.Rewards.Tez()
+	}
+	if history == nil {
+		history = []model.BakerCycleRewards{}
+	}
+
+	return &model.BakerResponse{
+		Baker:   *baker,
+		History: history,
+	}, nil
+}
+
+// periodBounds returns the unix bounds of a period of UTC days, the end excluded, where 0 leaves a bound open.
+func periodBounds(fromDate, toDate *time.Time) (int64, int64) {
+	var from, to int64
+	if fromDate != nil {
+		from = model.DayStart(fromDate.Unix())
+	}
+	if toDate != nil {
+		to = model.DayStart(toDate.Unix()) + model.SecondsPerDay
+	}
+	return from, to
+}
+
+// parsePage parses the page from the string and returns it as an integer.
+func (uc *getBakers) parsePage(pageStr string) (uint16, error) {
+	if pageStr == "" {
+		return 1, nil
+	}
+
+	p, err := strconv.Atoi(pageStr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: page must be a number: %s", ErrInvalidRequest, pageStr)
+	}
+	if p <= 0 {
+		return 0, fmt.Errorf("%w: page must be a positive number", ErrInvalidRequest)
+	}
+	if p > int(^uint16(0)) {
+		return 0, fmt.Errorf("%w: page number exceeds maximum allowed value of 65535", ErrInvalidRequest)
+	}
+	return uint16(p), nil
+}
+
+// parseLimit parses the limit from the string and returns it as an integer.
+func (uc *getBakers) parseLimit(limitStr string) (uint16, error) {
+	if limitStr == "" {
+		if uc.defaultLimit > maxBakersLimit {
+			return maxBakersLimit, nil
+		}
+		return uc.defaultLimit, nil
+	}
+
+	l, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: limit must be a number: %s", ErrInvalidRequest, limitStr)
+	}
+	if l <= 0 {
+		return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidRequest)
+	}
+	if l > maxBakersLimit {
+		return 0, fmt.Errorf("%w: limit exceeds maximum allowed value of 100", ErrInvalidRequest)
+	}
+	return uint16(l), nil
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *getBakers) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getBakers_GetBakers(t *testing.T) {
+	fromDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetBakersInput
+		want      *model.BakersResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				filter := model.BakerFilter{Search: "pool", FromDate: fromDate.Unix(), ToDate: toDate.Unix() + model.SecondsPerDay, Sort: model.BakerSortRewards}
+				mockDB.On("GetBakers", mock.Anything, filter, uint16(2), uint16(1)).
+					Return([]model.Baker{{Address: "tz1pool", StakingBalance: 1000000, DelegatedVolume: 2000000, Rewards: 3000000}}, nil)
+				return mockDB
+			}(),
+			input: GetBakersInput{Search: "pool", FromDate: &fromDate, ToDate: &toDate, Sort: model.BakerSortRewards, Page: "2", Limit: "1"},
+			want: &model.BakersResponse{
+				Bakers: []model.Baker{{
+					Address:            "tz1pool",
+					StakingBalance:     1000000,
+					StakingBalanceTez:  "1.000000",
+					DelegatedVolume:    2000000,
+					DelegatedVolumeTez: "2.000000",
+					Rewards:            3000000,
+					RewardsTez:         "3.000000",
+				}},
+				Pagination: model.PaginationInfo{CurrentPage: 2, PerPage: 1, HasPrevPage: true, HasNextPage: true, PrevPage: 1, NextPage: 3},
+			},
+		},
+		{
+			name:    "Error case - invalid sort",
+			input:   GetBakersInput{Sort: "alias"},
+			wantErr: true,
+		},
+		{
+			name:    "Error case - limit too high",
+			input:   GetBakersInput{Limit: "101"},
+			wantErr: true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakers", mock.Anything, model.BakerFilter{}, uint16(1), uint16(50)).
+					Return([]model.Baker(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetBakersFunc(50, tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBakers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBakers() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getBakers_GetBaker(t *testing.T) {
+	errDatabase := errors.New("db error")
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetBakerInput
+		want      *model.BakerResponse
+		wantErr   error
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBaker", mock.Anything, model.WalletAddress("tz1pool"), int64(0), int64(0)).
+					Return(&model.Baker{Address: "tz1pool", Delegators: 2}, nil)
+				mockDB.On("GetBakerRewardStats", mock.Anything, 700, 0, model.WalletAddress("tz1pool")).
+					Return([]model.BakerCycleRewards{{Cycle: 700, Baker: "tz1pool", Rewards: 2000000, Delegators: 2}}, nil)
+				return mockDB
+			}(),
+			input: GetBakerInput{Address: "tz1pool", FromCycle: 700},
+			want: &model.BakerResponse{
+				Baker: model.Baker{
+					Address:            "tz1pool",
+					Delegators:         2,
+					StakingBalanceTez:  "0.000000",
+					DelegatedVolumeTez: "0.000000",
+					RewardsTez:         "0.000000",
+				},
+				History: []model.BakerCycleRewards{{Cycle: 700, Baker: "tz1pool", Rewards: 2000000, RewardsTez: "2.000000", Delegators: 2}},
+			},
+		},
+		{
+			name: "Error case - unknown baker",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBaker", mock.Anything, model.WalletAddress("tz1pool"), int64(0), int64(0)).
+					Return((*model.Baker)(nil), sql.ErrNoRows)
+				return mockDB
+			}(),
+			input:   GetBakerInput{Address: "tz1pool"},
+			wantErr: ErrBakerNotFound,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBaker", mock.Anything, model.WalletAddress("tz1pool"), int64(0), int64(0)).
+					Return(&model.Baker{Address: "tz1pool"}, nil)
+				mockDB.On("GetBakerRewardStats", mock.Anything, 0, 0, model.WalletAddress("tz1pool")).
+					Return([]model.BakerCycleRewards(nil), errDatabase)
+				return mockDB
+			}(),
+			input:   GetBakerInput{Address: "tz1pool"},
+			wantErr: errDatabase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetBakerFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetBaker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBaker() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// defaultStatsDays is the number of days returned when no date range is given.
+const defaultStatsDays = 30
+
+// getDelegationStats handles business logic for the daily delegation statistics.
+type getDelegationStats struct {
+	dbAdapter database.Adapter
+	now       func() time.Time
+}
+
+// GetDelegationStatsInput defines the input structure for fetching the daily delegation statistics.
+type GetDelegationStatsInput struct {
+	FromDate *time.Time
+	ToDate   *time.Time
+	Baker    model.WalletAddress
+}
+
+// GetDelegationStatsFunc defines the function signature for fetching the daily delegation statistics.
+type GetDelegationStatsFunc func(ctx context.Context, input GetDelegationStatsInput) (*model.DelegationStatsResponse, error)
+
+// NewGetDelegationStatsFunc creates a new instance of getDelegationStats.
+func NewGetDelegationStatsFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetDelegationStatsFunc {
+	uc := &getDelegationStats{
+		dbAdapter: adapter,
+		now:       time.Now,
+	}
+	return uc.withMonitorer(uc.GetDelegationStats, metricsClient)
+}
+
+// GetDelegationStats returns the daily delegation statistics, of the last 30 days when no date range is given.
+func (uc *getDelegationStats) GetDelegationStats(ctx context.Context, input GetDelegationStatsInput) (*model.DelegationStatsResponse, error) {
+	var fromTimestamp, toTimestamp int64
+	if input.FromDate != nil {
+		fromTimestamp = input.FromDate.Unix()
+	}
+	if input.ToDate != nil {
+		toTimestamp = input.ToDate.Unix()
+	}
+	if input.FromDate == nil && input.ToDate == nil {
+		fromTimestamp = uc.now().AddDate(0, 0, -defaultStatsDays).Unix()
+	}
+
+	stats, err := uc.dbAdapter.GetDailyDelegationStats(ctx, fromTimestamp, toTimestamp, input.Baker)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range stats {
+		stats[i].DayDate = time.Unix(s.Day, 0).UTC().Format("2006-01-02")
+		stats[i].DelegatedVolumeTez = s.DelegatedVolume.Tez()
+	}
+
+	return &model.DelegationStatsResponse{
+		Stats: stats,
+	}, nil
+}
+
+// withMonitorer wraps the GetDelegationStats function with telemetry monitoring.
+func (uc *getDelegationStats) withMonitorer(getDelegationStats GetDelegationStatsFunc, metricsClient metrics.Adapter) GetDelegationStatsFunc {
+	return func(ctx context.Context, input GetDelegationStatsInput) (result *model.DelegationStatsResponse, err error) {
+		startTime := time.Now()
+
+		defer func() {
+			if metricsClient != nil {
+				duration := time.Since(startTime)
+				metricsClient.RecordServiceOperation("GetDelegationStats", "UseCase", duration, err)
+			}
+		}()
+
+		return getDelegationStats(ctx, input)
+	}
+}
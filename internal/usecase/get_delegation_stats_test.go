@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getDelegationStats_GetDelegationStats(t *testing.T) {
+	now := time.Date(2025, 3, 31, 10, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetDelegationStatsInput
+		want      *model.DelegationStatsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDailyDelegationStats", mock.Anything, from.Unix(), to.Unix(), model.WalletAddress("tz1baker")).
+					Return([]model.DailyDelegationStats{
+						{Day: from.Unix(), Baker: "tz1baker", NewDelegations: 2, Undelegations: 1, DelegatedVolume: 1500000},
+					}, nil)
+				return mockDB
+			}(),
+			input: GetDelegationStatsInput{FromDate: &from, ToDate: &to, Baker: "tz1baker"},
+			want: &model.DelegationStatsResponse{
+				Stats: []model.DailyDelegationStats{
+					{
+						Day:                from.Unix(),
+						DayDate:            "2025-03-01",
+						Baker:              "tz1baker",
+						NewDelegations:     2,
+						Undelegations:      1,
+						DelegatedVolume:    1500000,
+						DelegatedVolumeTez: "1.500000",
+					},
+				},
+			},
+		},
+		{
+			name: "Nominal case - last 30 days by default",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDailyDelegationStats", mock.Anything, now.AddDate(0, 0, -30).Unix(), int64(0), model.WalletAddress("")).
+					Return([]model.DailyDelegationStats(nil), nil)
+				return mockDB
+			}(),
+			want: &model.DelegationStatsResponse{},
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDailyDelegationStats", mock.Anything, from.Unix(), int64(0), model.WalletAddress("")).
+					Return([]model.DailyDelegationStats(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			input:   GetDelegationStatsInput{FromDate: &from},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &getDelegationStats{
+				dbAdapter: tt.dbAdapter,
+				now:       func() time.Time { return now },
+			}
+			got, err := uc.GetDelegationStats(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDelegationStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDelegationStats() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
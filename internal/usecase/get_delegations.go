@@ -2,7 +2,7 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -17,8 +17,30 @@ type getDelegations struct {
 	defaultLimit uint16
 }
 
+// GetDelegationsInput defines the input structure for fetching delegations.
+// Cursor, when set, selects the page after that cursor instead of Page, and requires the default order.
+// FromDate is included and ToDate excluded, like the dates of the operations and rewards.
+type GetDelegationsInput struct {
+	Page            string
+	Limit           string
+	Year            string
+	Cursor          string
+	MaxDelegationID int64
+	Delegator       model.WalletAddress
+	Delegate        model.WalletAddress
+	FromLevel       int64
+	ToLevel         int64
+	FromDate        *time.Time
+	ToDate          *time.Time
+	MinAmount       *model.Mutez
+	MaxAmount       *model.Mutez
+	Kind            model.DelegationKind
+	Sort            model.DelegationSort
+	Order           model.SortOrder
+}
+
 // GetDelegationsFunc defines the function signature for fetching delegations.
-type GetDelegationsFunc func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationsResponse, error)
+type GetDelegationsFunc func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error)
 
 // NewGetDelegationsFunc creates a new instance of getDelegations.
 func NewGetDelegationsFunc(defaultLimit uint16, adapter database.Adapter, metricsClient metrics.Adapter) GetDelegationsFunc {
@@ -29,28 +51,42 @@ func NewGetDelegationsFunc(defaultLimit uint16, adapter database.Adapter, metric
 	return uc.withMonitorer(uc.GetDelegations, metricsClient)
 }
 
-// GetDelegations returns delegations with pagination and optional year filter.
-func (uc *getDelegations) GetDelegations(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationsResponse, error) {
-	page, err := uc.parsePage(pageStr)
+// GetDelegations returns delegations by page or after a cursor, with optional filters and order.
+func (uc *getDelegations) GetDelegations(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
+	page, err := uc.parsePage(input.Page)
 	if err != nil {
 		return nil, err
 	}
 
-	limit, err := uc.parseLimit(limitStr)
+	limit, err := uc.parseLimit(input.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	year, err := uc.parseYear(yearStr)
+	year, err := uc.parseYear(input.Year)
 	if err != nil {
 		return nil, err
 	}
 
+	cursor, err := parseCursor(input.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := uc.buildFilter(input, year)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != nil && !filter.IsDefaultOrder() {
+		return nil, fmt.Errorf("%w: cursors only paginate delegations by decreasing timestamp", model.ErrInvalidCursor)
+	}
+
 	maxDelegationIDUint := uint64(0)
-	if maxDelegationID > 0 {
-		maxDelegationIDUint = uint64(maxDelegationID)
+	if input.MaxDelegationID > 0 {
+		maxDelegationIDUint = uint64(input.MaxDelegationID)
 	}
-	delegations, err := uc.dbAdapter.GetDelegations(ctx, page, limit, year, maxDelegationIDUint)
+	delegations, err := uc.dbAdapter.GetDelegations(ctx, page, limit, filter, maxDelegationIDUint, cursor)
 	if err != nil {
 		return nil, err
 	}
@@ -61,27 +97,80 @@ func (uc *getDelegations) GetDelegations(ctx context.Context, pageStr, limitStr,
 			maxID = delegation.ID
 		}
 		delegations[i].TimestampTime = time.Unix(delegation.Timestamp, 0).UTC().Format(time.RFC3339)
-		delegations[i].Amount = delegation.Amount * 1000000.0 // Convert tez to mutez
+		delegations[i].AmountTez = delegation.Amount.Tez()
 	}
 
 	pageInt := int(page)
 	limitInt := int(limit)
+	hasNextPage := len(delegations) == limitInt
 
 	paginationInfo := model.PaginationInfo{
 		CurrentPage: pageInt,
 		PerPage:     limitInt,
 		HasPrevPage: page > 1,
+		HasNextPage: hasNextPage,
 	}
 
 	if page > 1 {
 		paginationInfo.PrevPage = pageInt - 1
 	}
+	if hasNextPage {
+		paginationInfo.NextPage = pageInt + 1
+	}
 
-	return &model.DelegationsResponse{
+	response := &model.DelegationsResponse{
 		Delegations:     delegations,
 		Pagination:      paginationInfo,
 		MaxDelegationID: maxID,
-	}, nil
+	}
+
+	// A full page may be followed by another one, which starts after its last delegation
+	if hasNextPage && filter.IsDefaultOrder() {
+		last := delegations[len(delegations)-1]
+		response.NextCursor = model.NewCursor(last.Timestamp, last.ID).Encode()
+	}
+
+	return response, nil
+}
+
+// buildFilter checks the filters of the input and returns them with the year.
+func (uc *getDelegations) buildFilter(input GetDelegationsInput, year uint16) (model.DelegationFilter, error) {
+	filter := model.DelegationFilter{
+		Year:      year,
+		Delegator: input.Delegator,
+		Delegate:  input.Delegate,
+		FromLevel: input.FromLevel,
+		ToLevel:   input.ToLevel,
+		MinAmount: input.MinAmount,
+		MaxAmount: input.MaxAmount,
+		Kind:      input.Kind,
+		Sort:      input.Sort,
+		Order:     input.Order,
+	}
+
+	var err error
+	if filter.FromDate, filter.ToDate, err = unixBounds(input.FromDate, input.ToDate); err != nil {
+		return filter, err
+	}
+
+	switch {
+	case filter.Kind != "" && !filter.Kind.IsValid():
+		return filter, fmt.Errorf("%w: invalid delegation kind: %s", ErrInvalidRequest, filter.Kind)
+	case filter.Sort != "" && !filter.Sort.IsValid():
+		return filter, fmt.Errorf("%w: invalid sort field: %s", ErrInvalidRequest, filter.Sort)
+	case filter.Order != "" && !filter.Order.IsValid():
+		return filter, fmt.Errorf("%w: invalid sort order: %s", ErrInvalidRequest, filter.Order)
+	case filter.FromLevel < 0 || filter.ToLevel < 0:
+		return filter, fmt.Errorf("%w: levels must be positive numbers", ErrInvalidRequest)
+	case filter.ToLevel > 0 && filter.FromLevel > filter.ToLevel:
+		return filter, fmt.Errorf("%w: from_level cannot exceed to_level", ErrInvalidRequest)
+	case filter.MinAmount != nil && *filter.MinAmount < 0, filter.MaxAmount != nil && *filter.MaxAmount < 0:
+		return filter, fmt.Errorf("%w: amounts must be positive numbers", ErrInvalidRequest)
+	case filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount:
+		return filter, fmt.Errorf("%w: min_amount cannot exceed max_amount", ErrInvalidRequest)
+	}
+
+	return filter, nil
 }
 
 // parsePage parses the page from the string and returns it as an integer.
@@ -90,13 +179,13 @@ func (uc *getDelegations) parsePage(pageStr string) (uint32, error) {
 	if pageStr != "" {
 		p, err := strconv.Atoi(pageStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: page must be a number: %s", ErrInvalidRequest, pageStr)
 		}
 		if p <= 0 {
-			return 0, errors.New("page must be a positive number")
+			return 0, fmt.Errorf("%w: page must be a positive number", ErrInvalidRequest)
 		}
 		if p > int(^uint32(0)) {
-			return 0, errors.New("page number exceeds maximum allowed value of 4294967295")
+			return 0, fmt.Errorf("%w: page number exceeds maximum allowed value of 4294967295", ErrInvalidRequest)
 		}
 		page = uint32(p)
 	}
@@ -109,13 +198,13 @@ func (uc *getDelegations) parseLimit(limitStr string) (uint16, error) {
 	if limitStr != "" {
 		l, err := strconv.Atoi(limitStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: limit must be a number: %s", ErrInvalidRequest, limitStr)
 		}
 		if l <= 0 {
-			return 0, errors.New("limit must be a positive number")
+			return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidRequest)
 		}
 		if l > 500 {
-			return 0, errors.New("limit exceeds maximum allowed value of 500")
+			return 0, fmt.Errorf("%w: limit exceeds maximum allowed value of 500", ErrInvalidRequest)
 		}
 		limit = uint16(l)
 	}
@@ -128,15 +217,15 @@ func (uc *getDelegations) parseYear(yearStr string) (uint16, error) {
 	if yearStr != "" {
 		y, err := strconv.Atoi(yearStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: year must be a number: %s", ErrInvalidRequest, yearStr)
 		}
 		if y <= 0 {
-			return 0, errors.New("year must be a positive number")
+			return 0, fmt.Errorf("%w: year must be a positive number", ErrInvalidRequest)
 		}
 
 		currentYear := time.Now().Year()
 		if y > currentYear {
-			return 0, errors.New("year cannot exceed the current year")
+			return 0, fmt.Errorf("%w: year cannot exceed the current year", ErrInvalidRequest)
 		}
 
 		year = uint16(y)
@@ -146,7 +235,7 @@ func (uc *getDelegations) parseYear(yearStr string) (uint16, error) {
 
 // withMonitorer wraps the GetDelegations function with telemetry monitoring.
 func (uc *getDelegations) withMonitorer(getDelegations GetDelegationsFunc, metricsClient metrics.Adapter) GetDelegationsFunc {
-	return func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (result *model.DelegationsResponse, err error) {
+	return func(ctx context.Context, input GetDelegationsInput) (result *model.DelegationsResponse, err error) {
 		startTime := time.Now()
 
 		defer func() {
@@ -160,6 +249,6 @@ func (uc *getDelegations) withMonitorer(getDelegations GetDelegationsFunc, metri
 			}
 		}()
 
-		return getDelegations(ctx, pageStr, limitStr, yearStr, maxDelegationID)
+		return getDelegations(ctx, input)
 	}
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -72,17 +73,14 @@ func Test_NewGetDelegationsFunc(t *testing.T) {
 
 func Test_getDelegations_GetDelegations(t *testing.T) {
 	type args struct {
-		ctx             context.Context
-		pageStr         string
-		limitStr        string
-		yearStr         string
-		maxDelegationID int64
+		ctx   context.Context
+		input GetDelegationsInput
 	}
 	tests := []struct {
 		name      string
 		dbAdapter database.Adapter
 		args      args
-		want      *model.DelegationResponse
+		want      *model.DelegationsResponse
 		wantErr   bool
 	}{
 		{
@@ -94,7 +92,7 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 						ID:        1,
 						Delegator: "tz1...",
 						Delegate:  "tz2...",
-						Amount:    100.0,
+						Amount:    100000000,
 						Timestamp: func() int64 {
 							t, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
 							return t.Unix()
@@ -102,24 +100,27 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 						Level: 1000,
 					},
 				}
-				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(2025), uint64(0)).
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{Year: 2025}, uint64(0), (*model.Cursor)(nil)).
 					Return(delegations, nil)
 				return mockDB
 			}(),
 			args: args{
-				ctx:             context.Background(),
-				pageStr:         "1",
-				limitStr:        "10",
-				yearStr:         "2025",
-				maxDelegationID: 0,
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Page:            "1",
+					Limit:           "10",
+					Year:            "2025",
+					MaxDelegationID: 0,
+				},
 			},
-			want: &model.DelegationResponse{
-				Data: []model.Delegation{
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{
 					{
 						ID:        1,
 						Delegator: "tz1...",
 						Delegate:  "tz2...",
-						Amount:    100000000.0, // Converti en mutez
+						Amount:    100000000,
+						AmountTez: "100.000000",
 						Timestamp: func() int64 {
 							t, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
 							return t.Unix()
@@ -147,7 +148,7 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 						ID:        50,
 						Delegator: "tz1...",
 						Delegate:  "tz2...",
-						Amount:    100.0,
+						Amount:    100000000,
 						Timestamp: func() int64 {
 							t, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
 							return t.Unix()
@@ -155,24 +156,27 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 						Level: 1000,
 					},
 				}
-				mockDB.On("GetDelegations", mock.Anything, uint32(2), uint16(10), uint16(2025), uint64(100)).
-					Return(delegations, 60, nil)
+				mockDB.On("GetDelegations", mock.Anything, uint32(2), uint16(10), model.DelegationFilter{Year: 2025}, uint64(100), (*model.Cursor)(nil)).
+					Return(delegations, nil)
 				return mockDB
 			}(),
 			args: args{
-				ctx:             context.Background(),
-				pageStr:         "2",
-				limitStr:        "10",
-				yearStr:         "2025",
-				maxDelegationID: 100,
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Page:            "2",
+					Limit:           "10",
+					Year:            "2025",
+					MaxDelegationID: 100,
+				},
 			},
-			want: &model.DelegationResponse{
-				Data: []model.Delegation{
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{
 					{
 						ID:        50,
 						Delegator: "tz1...",
 						Delegate:  "tz2...",
-						Amount:    100000000.0,
+						Amount:    100000000,
+						AmountTez: "100.000000",
 						Timestamp: func() int64 {
 							t, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
 							return t.Unix()
@@ -185,31 +189,165 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 					CurrentPage: 2,
 					PerPage:     10,
 					HasPrevPage: true,
-					HasNextPage: true,
 					PrevPage:    1,
-					NextPage:    3,
 				},
 				MaxDelegationID: 50,
 			},
 			wantErr: false,
 		},
+		{
+			name: "With cursor - full page",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(1), model.DelegationFilter{}, uint64(0), model.NewCursor(1735689600, 60)).
+					Return([]model.Delegation{{ID: 50, Delegator: "tz1...", Amount: 1, Timestamp: 1735689600, Level: 1000}}, nil)
+				return mockDB
+			}(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Limit:  "1",
+					Cursor: model.Cursor{Timestamp: 1735689600, ID: 60}.Encode(),
+				},
+			},
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{
+					{
+						ID:            50,
+						Delegator:     "tz1...",
+						Amount:        1,
+						AmountTez:     "0.000001",
+						Timestamp:     1735689600,
+						TimestampTime: "2025-01-01T00:00:00Z",
+						Level:         1000,
+					},
+				},
+				NextCursor: model.Cursor{Timestamp: 1735689600, ID: 50}.Encode(),
+				Pagination: model.PaginationInfo{
+					CurrentPage: 1,
+					PerPage:     1,
+					HasNextPage: true,
+					NextPage:    2,
+				},
+				MaxDelegationID: 50,
+			},
+			wantErr: false,
+		},
+		{
+			name: "With filters and sort - full page without cursor",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(1), model.DelegationFilter{
+					Delegate:  "tz1baker",
+					FromLevel: 900,
+					FromDate:  1735689600,
+					Kind:      model.DelegationKindDelegation,
+					Sort:      model.DelegationSortAmount,
+					Order:     model.SortOrderAsc,
+				}, uint64(0), (*model.Cursor)(nil)).
+					Return([]model.Delegation{{ID: 50, Delegator: "tz1...", Delegate: "tz1baker", Amount: 1, Timestamp: 1735689600, Level: 1000}}, nil)
+				return mockDB
+			}(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Limit:     "1",
+					Delegate:  "tz1baker",
+					FromLevel: 900,
+					FromDate:  func() *time.Time { t := time.Unix(1735689600, 0); return &t }(),
+					Kind:      model.DelegationKindDelegation,
+					Sort:      model.DelegationSortAmount,
+					Order:     model.SortOrderAsc,
+				},
+			},
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{
+					{
+						ID:            50,
+						Delegator:     "tz1...",
+						Delegate:      "tz1baker",
+						Amount:        1,
+						AmountTez:     "0.000001",
+						Timestamp:     1735689600,
+						TimestampTime: "2025-01-01T00:00:00Z",
+						Level:         1000,
+					},
+				},
+				Pagination: model.PaginationInfo{
+					CurrentPage: 1,
+					PerPage:     1,
+					HasNextPage: true,
+					NextPage:    2,
+				},
+				MaxDelegationID: 50,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Error case - cursor with a sort",
+			dbAdapter: dbmock.New(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Cursor: model.Cursor{Timestamp: 1735689600, ID: 60}.Encode(),
+					Sort:   model.DelegationSortLevel,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Error case - invalid level range",
+			dbAdapter: dbmock.New(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					FromLevel: 200,
+					ToLevel:   100,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Error case - invalid kind",
+			dbAdapter: dbmock.New(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Kind: "transfer",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Invalid cursorStr",
+			dbAdapter: dbmock.New(),
+			args: args{
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Cursor: "not a cursor",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Invalid pageStr",
 			dbAdapter: func() database.Adapter {
 				mockDB := dbmock.New()
-				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(0), uint64(0)).
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{}, uint64(0), (*model.Cursor)(nil)).
 					Return([]model.Delegation{}, nil)
 				return mockDB
 			}(),
 			args: args{
-				ctx:             context.Background(),
-				pageStr:         "invalid",
-				limitStr:        "10",
-				yearStr:         "",
-				maxDelegationID: 0,
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Page:            "invalid",
+					Limit:           "10",
+					Year:            "",
+					MaxDelegationID: 0,
+				},
 			},
-			want: &model.DelegationResponse{
-				Data: []model.Delegation{},
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{},
 				Pagination: model.PaginationInfo{
 					CurrentPage: 1,
 					PerPage:     10,
@@ -224,19 +362,21 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 			name: "Invalid limitStr",
 			dbAdapter: func() database.Adapter {
 				mockDB := dbmock.New()
-				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(50), uint16(0), uint64(0)).
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(50), model.DelegationFilter{}, uint64(0), (*model.Cursor)(nil)).
 					Return([]model.Delegation{}, nil)
 				return mockDB
 			}(),
 			args: args{
-				ctx:             context.Background(),
-				pageStr:         "1",
-				limitStr:        "invalid",
-				yearStr:         "",
-				maxDelegationID: 0,
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Page:            "1",
+					Limit:           "invalid",
+					Year:            "",
+					MaxDelegationID: 0,
+				},
 			},
-			want: &model.DelegationResponse{
-				Data: []model.Delegation{},
+			want: &model.DelegationsResponse{
+				Delegations: []model.Delegation{},
 				Pagination: model.PaginationInfo{
 					CurrentPage: 1,
 					PerPage:     50,
@@ -251,16 +391,18 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 			name: "Database error",
 			dbAdapter: func() database.Adapter {
 				mockDB := dbmock.New()
-				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), uint16(0), uint64(0)).
+				mockDB.On("GetDelegations", mock.Anything, uint32(1), uint16(10), model.DelegationFilter{}, uint64(0), (*model.Cursor)(nil)).
 					Return([]model.Delegation{}, fmt.Errorf("database error"))
 				return mockDB
 			}(),
 			args: args{
-				ctx:             context.Background(),
-				pageStr:         "1",
-				limitStr:        "10",
-				yearStr:         "",
-				maxDelegationID: 0,
+				ctx: context.Background(),
+				input: GetDelegationsInput{
+					Page:            "1",
+					Limit:           "10",
+					Year:            "",
+					MaxDelegationID: 0,
+				},
 			},
 			wantErr: true,
 		},
@@ -270,7 +412,7 @@ func Test_getDelegations_GetDelegations(t *testing.T) {
 			uc := &getDelegations{
 				dbAdapter: tt.dbAdapter,
 			}
-			got, err := uc.GetDelegations(tt.args.ctx, tt.args.pageStr, tt.args.limitStr, tt.args.yearStr, tt.args.maxDelegationID)
+			got, err := uc.GetDelegations(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GetDelegations() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -429,28 +571,28 @@ func Test_getDelegations_parseYear(t *testing.T) {
 			yearStr:  "invalid",
 			want:     0,
 			wantErr:  true,
-			errorMsg: "strconv.Atoi: parsing \"invalid\": invalid syntax",
+			errorMsg: "invalid request: year must be a number: invalid",
 		},
 		{
 			name:     "Negative yearStr",
 			yearStr:  "-2025",
 			want:     0,
 			wantErr:  true,
-			errorMsg: "year must be a positive number",
+			errorMsg: "invalid request: year must be a positive number",
 		},
 		{
 			name:     "Zero yearStr",
 			yearStr:  "0",
 			want:     0,
 			wantErr:  true,
-			errorMsg: "year must be a positive number",
+			errorMsg: "invalid request: year must be a positive number",
 		},
 		{
 			name:     "Future year",
 			yearStr:  fmt.Sprintf("%d", time.Now().Year()+1),
 			want:     0,
 			wantErr:  true,
-			errorMsg: "year cannot exceed the current year",
+			errorMsg: "invalid request: year cannot exceed the current year",
 		},
 	}
 	for _, tt := range tests {
@@ -466,6 +608,9 @@ func Test_getDelegations_parseYear(t *testing.T) {
 				t.Errorf("parseYear() error = %v, wantErrMsg %v", err, tt.errorMsg)
 				return
 			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidRequest) {
+				t.Errorf("parseYear() error = %v, want ErrInvalidRequest", err)
+			}
 
 			if !tt.wantErr && got != tt.want {
 				t.Errorf("parseYear() = %v, want %v", got, tt.want)
@@ -494,13 +639,13 @@ func Test_getDelegations_withMonitorer(t *testing.T) {
 				dbAdapter: dbmock.New(),
 			},
 			args: args{
-				getDelegations: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
-					return &model.DelegationResponse{}, nil
+				getDelegations: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
+					return &model.DelegationsResponse{}, nil
 				},
 				metricsClient: metricsnoop.New(),
 			},
-			want: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
-				return &model.DelegationResponse{}, nil
+			want: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
+				return &model.DelegationsResponse{}, nil
 			},
 		},
 		{
@@ -509,12 +654,12 @@ func Test_getDelegations_withMonitorer(t *testing.T) {
 				dbAdapter: dbmock.New(),
 			},
 			args: args{
-				getDelegations: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
+				getDelegations: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
 					return nil, nil
 				},
 				metricsClient: nil,
 			},
-			want: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
+			want: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return nil, nil
 			},
 		},
@@ -524,12 +669,12 @@ func Test_getDelegations_withMonitorer(t *testing.T) {
 				dbAdapter: dbmock.New(),
 			},
 			args: args{
-				getDelegations: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
+				getDelegations: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
 					return nil, fmt.Errorf("error")
 				},
 				metricsClient: metricsnoop.New(),
 			},
-			want: func(ctx context.Context, pageStr, limitStr, yearStr string, maxDelegationID int64) (*model.DelegationResponse, error) {
+			want: func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
 				return nil, fmt.Errorf("error")
 			},
 		},
@@ -552,8 +697,8 @@ func Test_getDelegations_withMonitorer(t *testing.T) {
 
 			if tt.want != nil && got != nil {
 				ctx := context.Background()
-				gotResp, gotErr := got(ctx, "1", "10", "2025", 0)
-				wantResp, wantErr := tt.want(ctx, "1", "10", "2025", 0)
+				gotResp, gotErr := got(ctx, GetDelegationsInput{Page: "1", Limit: "10", Year: "2025"})
+				wantResp, wantErr := tt.want(ctx, GetDelegationsInput{Page: "1", Limit: "10", Year: "2025"})
 
 				if (gotErr == nil) != (wantErr == nil) {
 					t.Errorf("withMonitorer() error = %v, want error = %v", gotErr, wantErr)
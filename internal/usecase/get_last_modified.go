@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+)
+
+// GetLastModifiedFunc defines the function signature for fetching the date of the latest indexed level.
+type GetLastModifiedFunc func(ctx context.Context) (time.Time, error)
+
+// getLastModified handles business logic for the date of the indexed data, caching it for a short while.
+type getLastModified struct {
+	dbAdapter database.Adapter
+	cacheTTL  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	cached    time.Time
+	expiresAt time.Time
+}
+
+// NewGetLastModifiedFunc creates a new instance of getLastModified and returns a GetLastModifiedFunc.
+// The date is read at most once per cacheTTL, 0 reads it on every call.
+func NewGetLastModifiedFunc(cacheTTL time.Duration, adapter database.Adapter, metricsClient metrics.Adapter) GetLastModifiedFunc {
+	uc := &getLastModified{
+		dbAdapter: adapter,
+		cacheTTL:  cacheTTL,
+		now:       time.Now,
+	}
+	return func(ctx context.Context) (result time.Time, err error) {
+		defer uc.monitor("GetLastModified", time.Now(), metricsClient, &err)
+		return uc.GetLastModified(ctx)
+	}
+}
+
+// GetLastModified returns the timestamp of the latest indexed delegation, zero when none is indexed.
+// The lock only guards the cached date, so a slow database read does not queue the other requests behind it.
+func (uc *getLastModified) GetLastModified(ctx context.Context) (time.Time, error) {
+	uc.mu.Lock()
+	now := uc.now()
+	if now.Before(uc.expiresAt) {
+		cached := uc.cached
+		uc.mu.Unlock()
+		return cached, nil
+	}
+	uc.mu.Unlock()
+
+	latest, err := uc.dbAdapter.GetLatestDelegation(ctx)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && latest == nil {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	lastModified := time.Unix(latest.Timestamp, 0).UTC()
+	uc.mu.Lock()
+	if expiresAt := now.Add(uc.cacheTTL); !expiresAt.Before(uc.expiresAt) {
+		uc.cached = lastModified
+		uc.expiresAt = expiresAt
+	}
+	uc.mu.Unlock()
+	return lastModified, nil
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *getLastModified) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
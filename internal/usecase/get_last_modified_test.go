@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getLastModified_GetLastModified(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	uc := &getLastModified{dbAdapter: db, cacheTTL: time.Second, now: func() time.Time { return now }}
+
+	// Nothing is indexed yet.
+	got, err := uc.GetLastModified(ctx)
+	assert.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{{ID: 1, Level: 100, Timestamp: 1704067200, Delegator: "tz1a"}}))
+	got, err = uc.GetLastModified(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1704067200, 0).UTC(), got)
+
+	// The date is reused until the cache expires.
+	assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{{ID: 2, Level: 101, Timestamp: 1704067260, Delegator: "tz1a"}}))
+	got, err = uc.GetLastModified(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1704067200, 0).UTC(), got)
+
+	now = now.Add(time.Second)
+	got, err = uc.GetLastModified(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1704067260, 0).UTC(), got)
+}
+
+func Test_getLastModified_GetLastModified_readOutsideLock(t *testing.T) {
+	reading, release := make(chan struct{}), make(chan struct{})
+	mockDB := dbmock.New()
+	mockDB.On("GetLatestDelegation", mock.Anything).
+		Run(func(mock.Arguments) {
+			close(reading)
+			<-release
+		}).
+		Return(&model.Delegation{Timestamp: 1704067200}, nil)
+	uc := &getLastModified{dbAdapter: mockDB, cacheTTL: time.Second, now: time.Now}
+
+	done := make(chan time.Time)
+	go func() {
+		got, _ := uc.GetLastModified(context.Background())
+		done <- got
+	}()
+
+	// The lock is free while the database is read.
+	<-reading
+	assert.True(t, uc.mu.TryLock())
+	uc.mu.Unlock()
+
+	close(release)
+	assert.Equal(t, time.Unix(1704067200, 0).UTC(), <-done)
+	assert.Equal(t, time.Unix(1704067200, 0).UTC(), uc.cached)
+}
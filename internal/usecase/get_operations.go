@@ -2,7 +2,7 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -18,11 +18,13 @@ type getOperations struct {
 }
 
 // GetOperationsInput defines the input structure for fetching delegations.
+// FromDate is included and ToDate excluded, nil leaves a bound open.
 type GetOperationsInput struct {
 	FromDate *time.Time
 	ToDate   *time.Time
 	Page     string
 	Limit    string
+	Cursor   string
 	Wallet   model.WalletAddress
 	Backer   model.WalletAddress
 	Type     model.OperationType
@@ -40,14 +42,11 @@ func NewGetOperationsFunc(defaultLimit uint16, adapter database.Adapter, metrics
 	return uc.withMonitorer(uc.GetOperations, metricsClient)
 }
 
-// GetOperations returns delegations with pagination and optional year filter.
+// GetOperations returns operations by page or after a cursor, with optional filters.
 func (uc *getOperations) GetOperations(ctx context.Context, input GetOperationsInput) (*model.OperationsResponse, error) {
-	var fromTimestamp, toTimestamp int64
-	if input.FromDate != nil {
-		fromTimestamp = input.FromDate.Unix()
-	}
-	if input.ToDate != nil {
-		toTimestamp = input.ToDate.Unix()
+	fromTimestamp, toTimestamp, err := unixBounds(input.FromDate, input.ToDate)
+	if err != nil {
+		return nil, err
 	}
 
 	page, err := uc.parsePage(input.Page)
@@ -60,32 +59,51 @@ func (uc *getOperations) GetOperations(ctx context.Context, input GetOperationsI
 		return nil, err
 	}
 
-	operations, err := uc.dbAdapter.GetOperations(ctx, fromTimestamp, toTimestamp, page, limit, input.Type, input.Wallet, input.Backer)
+	cursor, err := parseCursor(input.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := uc.dbAdapter.GetOperations(ctx, fromTimestamp, toTimestamp, page, limit, input.Type, input.Wallet, input.Backer, cursor)
 	if err != nil {
 		return nil, err
 	}
 
 	for i, operation := range operations {
 		operations[i].TimestampTime = time.Unix(operation.Timestamp, 0).UTC().Format(time.RFC3339)
+		operations[i].AmountTez = operation.Amount.Tez()
 	}
 
 	pageInt := int(page)
 	limitInt := int(limit)
+	hasNextPage := len(operations) == limitInt
 
 	paginationInfo := model.PaginationInfo{
 		CurrentPage: pageInt,
 		PerPage:     limitInt,
 		HasPrevPage: page > 1,
+		HasNextPage: hasNextPage,
 	}
 
 	if page > 1 {
 		paginationInfo.PrevPage = pageInt - 1
 	}
+	if hasNextPage {
+		paginationInfo.NextPage = pageInt + 1
+	}
 
-	return &model.OperationsResponse{
+	response := &model.OperationsResponse{
 		Operations: operations,
 		Pagination: paginationInfo,
-	}, nil
+	}
+
+	// A full page may be followed by another one, which starts after its last operation
+	if hasNextPage {
+		last := operations[len(operations)-1]
+		response.NextCursor = model.NewCursor(last.Timestamp, last.ID).Encode()
+	}
+
+	return response, nil
 }
 
 // parsePage parses the page from the string and returns it as an integer.
@@ -94,13 +112,13 @@ func (uc *getOperations) parsePage(pageStr string) (uint16, error) {
 	if pageStr != "" {
 		p, err := strconv.Atoi(pageStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: page must be a number: %s", ErrInvalidRequest, pageStr)
 		}
 		if p <= 0 {
-			return 0, errors.New("page must be a positive number")
+			return 0, fmt.Errorf("%w: page must be a positive number", ErrInvalidRequest)
 		}
 		if p > int(^uint16(0)) {
-			return 0, errors.New("page number exceeds maximum allowed value of 4294967295")
+			return 0, fmt.Errorf("%w: page number exceeds maximum allowed value of 4294967295", ErrInvalidRequest)
 		}
 		page = uint16(p)
 	}
@@ -113,13 +131,13 @@ func (uc *getOperations) parseLimit(limitStr string) (uint16, error) {
 	if limitStr != "" {
 		l, err := strconv.Atoi(limitStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: limit must be a number: %s", ErrInvalidRequest, limitStr)
 		}
 		if l <= 0 {
-			return 0, errors.New("limit must be a positive number")
+			return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidRequest)
 		}
 		if l > 500 {
-			return 0, errors.New("limit exceeds maximum allowed value of 500")
+			return 0, fmt.Errorf("%w: limit exceeds maximum allowed value of 500", ErrInvalidRequest)
 		}
 		limit = uint16(l)
 	}
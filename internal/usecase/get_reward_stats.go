@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// getRewardStats handles business logic for the per-cycle reward statistics.
+type getRewardStats struct {
+	dbAdapter database.Adapter
+}
+
+// GetRewardStatsInput defines the input structure for fetching the per-cycle reward statistics.
+// A zero cycle leaves the bound open, Address filters on a baker or a delegator.
+type GetRewardStatsInput struct {
+	FromCycle int
+	ToCycle   int
+	Address   model.WalletAddress
+}
+
+// GetBakerRewardStatsFunc defines the function signature for fetching the per-cycle rewards of bakers.
+type GetBakerRewardStatsFunc func(ctx context.Context, input GetRewardStatsInput) (*model.BakerRewardStatsResponse, error)
+
+// GetDelegatorRewardStatsFunc defines the function signature for fetching the per-cycle rewards of delegators.
+type GetDelegatorRewardStatsFunc func(ctx context.Context, input GetRewardStatsInput) (*model.DelegatorRewardStatsResponse, error)
+
+// NewGetBakerRewardStatsFunc creates a new instance of getRewardStats serving the rewards of bakers.
+func NewGetBakerRewardStatsFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetBakerRewardStatsFunc {
+	uc := &getRewardStats{dbAdapter: adapter}
+	getBakerRewardStats := uc.GetBakerRewardStats
+	return func(ctx context.Context, input GetRewardStatsInput) (result *model.BakerRewardStatsResponse, err error) {
+		defer uc.monitor("GetBakerRewardStats", time.Now(), metricsClient, &err)
+		return getBakerRewardStats(ctx, input)
+	}
+}
+
+// NewGetDelegatorRewardStatsFunc creates a new instance of getRewardStats serving the rewards of delegators.
+func NewGetDelegatorRewardStatsFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetDelegatorRewardStatsFunc {
+	uc := &getRewardStats{dbAdapter: adapter}
+	getDelegatorRewardStats := uc.GetDelegatorRewardStats
+	return func(ctx context.Context, input GetRewardStatsInput) (result *model.DelegatorRewardStatsResponse, err error) {
+		defer uc.monitor("GetDelegatorRewardStats", time.Now(), metricsClient, &err)
+		return getDelegatorRewardStats(ctx, input)
+	}
+}
+
+// GetBakerRewardStats returns the per-cycle rewards of bakers.
+func (uc *getRewardStats) GetBakerRewardStats(ctx context.Context, input GetRewardStatsInput) (*model.BakerRewardStatsResponse, error) {
+	stats, err := uc.dbAdapter.GetBakerRewardStats(ctx, input.FromCycle, input.ToCycle, input.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range stats {
+		stats[i].RewardsTez = s.Rewards.Tez()
+	}
+
+	return &model.BakerRewardStatsResponse{
+		Stats: stats,
+	}, nil
+}
+
+// GetDelegatorRewardStats returns the per-cycle rewards of delegators.
+func (uc *getRewardStats) GetDelegatorRewardStats(ctx context.Context, input GetRewardStatsInput) (*model.DelegatorRewardStatsResponse, error) {
+	stats, err := uc.dbAdapter.GetDelegatorRewardStats(ctx, input.FromCycle, input.ToCycle, input.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range stats {
+		stats[i].RewardsTez = s.Rewards.Tez()
+	}
+
+	return &model.DelegatorRewardStatsResponse{
+		Stats: stats,
+	}, nil
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *getRewardStats) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
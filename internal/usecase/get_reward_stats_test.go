@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getRewardStats_GetBakerRewardStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetRewardStatsInput
+		want      *model.BakerRewardStatsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakerRewardStats", mock.Anything, 700, 710, model.WalletAddress("tz1baker")).
+					Return([]model.BakerCycleRewards{{Cycle: 700, Baker: "tz1baker", Rewards: 2000000, Delegators: 3}}, nil)
+				return mockDB
+			}(),
+			input: GetRewardStatsInput{FromCycle: 700, ToCycle: 710, Address: "tz1baker"},
+			want: &model.BakerRewardStatsResponse{
+				Stats: []model.BakerCycleRewards{{Cycle: 700, Baker: "tz1baker", Rewards: 2000000, RewardsTez: "2.000000", Delegators: 3}},
+			},
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetBakerRewardStats", mock.Anything, 0, 0, model.WalletAddress("")).
+					Return([]model.BakerCycleRewards(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetBakerRewardStatsFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBakerRewardStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBakerRewardStats() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getRewardStats_GetDelegatorRewardStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetRewardStatsInput
+		want      *model.DelegatorRewardStatsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDelegatorRewardStats", mock.Anything, 700, 0, model.WalletAddress("tz1delegator")).
+					Return([]model.DelegatorCycleRewards{{Cycle: 701, Delegator: "tz1delegator", Rewards: 250000, Bakers: 1}}, nil)
+				return mockDB
+			}(),
+			input: GetRewardStatsInput{FromCycle: 700, Address: "tz1delegator"},
+			want: &model.DelegatorRewardStatsResponse{
+				Stats: []model.DelegatorCycleRewards{{Cycle: 701, Delegator: "tz1delegator", Rewards: 250000, RewardsTez: "0.250000", Bakers: 1}},
+			},
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetDelegatorRewardStats", mock.Anything, 0, 0, model.WalletAddress("")).
+					Return([]model.DelegatorCycleRewards(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetDelegatorRewardStatsFunc(tt.dbAdapter, nil)(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDelegatorRewardStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDelegatorRewardStats() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package usecase
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -18,9 +18,13 @@ type getRewards struct {
 }
 
 // GetRewardsInput defines the input structure for fetching delegations.
+// FromDate is included and ToDate excluded, nil leaves a bound open.
 type GetRewardsInput struct {
 	FromDate *time.Time
 	ToDate   *time.Time
+	Page     string
+	Limit    string
+	Cursor   string
 	Wallet   model.WalletAddress
 	Backer   model.WalletAddress
 }
@@ -37,23 +41,67 @@ func NewGetRewardsFunc(defaultLimit uint16, adapter database.Adapter, metricsCli
 	return uc.withMonitorer(uc.GetRewards, metricsClient)
 }
 
-// GetRewards returns delegations with pagination and optional year filter.
+// GetRewards returns rewards by page or after a cursor, with optional date range.
 func (uc *getRewards) GetRewards(ctx context.Context, input GetRewardsInput) (*model.RewardsResponse, error) {
-	var fromTimestamp, toTimestamp int64
-	if input.FromDate != nil {
-		fromTimestamp = input.FromDate.Unix()
+	fromTimestamp, toTimestamp, err := unixBounds(input.FromDate, input.ToDate)
+	if err != nil {
+		return nil, err
 	}
-	if input.ToDate != nil {
-		toTimestamp = input.ToDate.Unix()
+
+	page, err := uc.parsePage(input.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := uc.parseLimit(input.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := parseCursor(input.Cursor)
+	if err != nil {
+		return nil, err
 	}
-	rewards, err := uc.dbAdapter.GetRewards(ctx, fromTimestamp, toTimestamp, input.Wallet, input.Backer)
+
+	rewards, err := uc.dbAdapter.GetRewards(ctx, fromTimestamp, toTimestamp, input.Wallet, input.Backer, page, limit, cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.RewardsResponse{
-		Rewards: rewards,
-	}, nil
+	for i, reward := range rewards {
+		rewards[i].TimestampTime = time.Unix(reward.Timestamp, 0).UTC().Format(time.RFC3339)
+		rewards[i].AmountTez = reward.Amount.Tez()
+	}
+
+	pageInt := int(page)
+	hasNextPage := len(rewards) == int(limit)
+
+	paginationInfo := model.PaginationInfo{
+		CurrentPage: pageInt,
+		PerPage:     int(limit),
+		HasPrevPage: page > 1,
+		HasNextPage: hasNextPage,
+	}
+
+	if page > 1 {
+		paginationInfo.PrevPage = pageInt - 1
+	}
+	if hasNextPage {
+		paginationInfo.NextPage = pageInt + 1
+	}
+
+	response := &model.RewardsResponse{
+		Rewards:    rewards,
+		Pagination: paginationInfo,
+	}
+
+	// A full page may be followed by another one, which starts after its last reward
+	if hasNextPage {
+		last := rewards[len(rewards)-1]
+		response.NextCursor = model.NewCursor(last.Timestamp, last.ID).Encode()
+	}
+
+	return response, nil
 }
 
 // parsePage parses the page from the string and returns it as an integer.
@@ -62,13 +110,13 @@ func (uc *getRewards) parsePage(pageStr string) (uint32, error) {
 	if pageStr != "" {
 		p, err := strconv.Atoi(pageStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: page must be a number: %s", ErrInvalidRequest, pageStr)
 		}
 		if p <= 0 {
-			return 0, errors.New("page must be a positive number")
+			return 0, fmt.Errorf("%w: page must be a positive number", ErrInvalidRequest)
 		}
 		if p > int(^uint32(0)) {
-			return 0, errors.New("page number exceeds maximum allowed value of 4294967295")
+			return 0, fmt.Errorf("%w: page number exceeds maximum allowed value of 4294967295", ErrInvalidRequest)
 		}
 		page = uint32(p)
 	}
@@ -81,13 +129,13 @@ func (uc *getRewards) parseLimit(limitStr string) (uint16, error) {
 	if limitStr != "" {
 		l, err := strconv.Atoi(limitStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: limit must be a number: %s", ErrInvalidRequest, limitStr)
 		}
 		if l <= 0 {
-			return 0, errors.New("limit must be a positive number")
+			return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidRequest)
 		}
-		if l > 500 {
-			return 0, errors.New("limit exceeds maximum allowed value of 500")
+		if l > model.MaxRewardsLimit {
+			return 0, fmt.Errorf("%w: limit exceeds maximum allowed value of %d", ErrInvalidRequest, model.MaxRewardsLimit)
 		}
 		limit = uint16(l)
 	}
@@ -100,15 +148,15 @@ func (uc *getRewards) parseYear(yearStr string) (uint16, error) {
 	if yearStr != "" {
 		y, err := strconv.Atoi(yearStr)
 		if err != nil {
-			return 0, err
+			return 0, fmt.Errorf("%w: year must be a number: %s", ErrInvalidRequest, yearStr)
 		}
 		if y <= 0 {
-			return 0, errors.New("year must be a positive number")
+			return 0, fmt.Errorf("%w: year must be a positive number", ErrInvalidRequest)
 		}
 
 		currentYear := time.Now().Year()
 		if y > currentYear {
-			return 0, errors.New("year cannot exceed the current year")
+			return 0, fmt.Errorf("%w: year cannot exceed the current year", ErrInvalidRequest)
 		}
 
 		year = uint16(y)
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// priceLookback is how far before the fiscal year a reward of its first days may find the latest known price.
+const priceLookback = 7 * model.SecondsPerDay
+
+// importPricesBatchSize is the number of prices saved per statement by an import.
+const importPricesBatchSize = 1000
+
+// rewardsReport handles business logic for the tax reports of rewards and the prices valuing them.
+type rewardsReport struct {
+	dbAdapter database.Adapter
+}
+
+// GetRewardsReportInput defines the input structure for building the rewards report of a wallet over a fiscal year.
+type GetRewardsReportInput struct {
+	Wallet   model.WalletAddress
+	Year     int
+	Currency model.Currency
+}
+
+// GetRewardsReportFunc defines the function signature for building the rewards report of a wallet over a fiscal year.
+type GetRewardsReportFunc func(ctx context.Context, input GetRewardsReportInput) (*model.RewardsReport, error)
+
+// ImportPricesFunc defines the function signature for importing daily prices from a CSV, returning the number of prices saved.
+type ImportPricesFunc func(ctx context.Context, r io.Reader, currency model.Currency) (int, error)
+
+// NewGetRewardsReportFunc creates a new instance of rewardsReport building the rewards reports.
+func NewGetRewardsReportFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetRewardsReportFunc {
+	uc := &rewardsReport{dbAdapter: adapter}
+	return func(ctx context.Context, input GetRewardsReportInput) (result *model.RewardsReport, err error) {
+		defer uc.monitor("GetRewardsReport", time.Now(), metricsClient, &err)
+		return uc.GetRewardsReport(ctx, input)
+	}
+}
+
+// NewImportPricesFunc creates a new instance of rewardsReport importing daily prices.
+func NewImportPricesFunc(adapter database.Adapter, metricsClient metrics.Adapter) ImportPricesFunc {
+	uc := &rewardsReport{dbAdapter: adapter}
+	return func(ctx context.Context, r io.Reader, currency model.Currency) (count int, err error) {
+		defer uc.monitor("ImportPrices", time.Now(), metricsClient, &err)
+		return uc.ImportPrices(ctx, r, currency)
+	}
+}
+
+// GetRewardsReport lists the rewards received by the wallet during the UTC year, valued with the stored daily prices.
+func (uc *rewardsReport) GetRewardsReport(ctx context.Context, input GetRewardsReportInput) (*model.RewardsReport, error) {
+	if input.Year <= 0 || input.Year > time.Now().UTC().Year() {
+		return nil, fmt.Errorf("%w: invalid year: %d", ErrInvalidRequest, input.Year)
+	}
+	if !input.Currency.IsValid() {
+		return nil, fmt.Errorf("%w: invalid currency: %q", ErrInvalidRequest, input.Currency)
+	}
+	if input.Wallet == "" {
+		return nil, fmt.Errorf("%w: missing wallet address", ErrInvalidRequest)
+	}
+
+	fromDate := time.Date(input.Year, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	toDate := time.Date(input.Year+1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	var rewards []model.Reward
+	err := uc.dbAdapter.StreamRewards(ctx, fromDate, toDate-1, input.Wallet, "", func(r model.Reward) error {
+		rewards = append(rewards, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := uc.dbAdapter.GetPrices(ctx, input.Currency, fromDate-priceLookback, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewRewardsReport(input.Wallet, input.Year, input.Currency, rewards, prices), nil
+}
+
+// ImportPrices saves the daily prices of a CSV, replacing the ones already saved for their day and currency.
+// currency is the currency of the lines when the CSV has no currency column.
+func (uc *rewardsReport) ImportPrices(ctx context.Context, r io.Reader, currency model.Currency) (int, error) {
+	prices, err := model.ParsePrices(r, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(prices); start += importPricesBatchSize {
+		end := start + importPricesBatchSize
+		if end > len(prices) {
+			end = len(prices)
+		}
+		if err := uc.dbAdapter.SavePrices(ctx, prices[start:end]); err != nil {
+			return start, err
+		}
+	}
+	return len(prices), nil
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *rewardsReport) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_rewardsReport_GetRewardsReport(t *testing.T) {
+	const (
+		yearStart = int64(1704067200) // 2024-01-01
+		yearEnd   = int64(1735689600) // 2025-01-01
+	)
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetRewardsReportInput
+		want      *model.RewardsReport
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamRewards", mock.Anything, yearStart, yearEnd-1, model.WalletAddress("tz1wallet"), model.WalletAddress("")).
+					Return([]model.Reward{{SourceAddress: "tz1baker", Cycle: 700, Amount: 2_000_000, Timestamp: yearStart + 60}}, nil)
+				mockDB.On("GetPrices", mock.Anything, model.Currency("EUR"), yearStart-priceLookback, yearEnd).
+					Return([]model.Price{{Day: yearStart - model.SecondsPerDay, Currency: "EUR", Price: "0.8"}}, nil)
+				return mockDB
+			}(),
+			input: GetRewardsReportInput{Wallet: "tz1wallet", Year: 2024, Currency: "EUR"},
+			want: &model.RewardsReport{
+				Wallet:   "tz1wallet",
+				Year:     2024,
+				Currency: "EUR",
+				Rewards: []model.ReportReward{
+					{Cycle: 700, Date: "2024-01-01", Baker: "tz1baker", Amount: 2_000_000, AmountTez: "2.000000", Price: "0.8", Value: "1.60"},
+				},
+				TotalAmount:    2_000_000,
+				TotalAmountTez: "2.000000",
+				TotalValue:     "1.60",
+			},
+		},
+		{
+			name:    "Error case - future year",
+			input:   GetRewardsReportInput{Wallet: "tz1wallet", Year: 3000, Currency: "EUR"},
+			wantErr: true,
+		},
+		{
+			name:    "Error case - invalid currency",
+			input:   GetRewardsReportInput{Wallet: "tz1wallet", Year: 2024, Currency: "euro"},
+			wantErr: true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("StreamRewards", mock.Anything, yearStart, yearEnd-1, model.WalletAddress("tz1wallet"), model.WalletAddress("")).
+					Return([]model.Reward(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			input:   GetRewardsReportInput{Wallet: "tz1wallet", Year: 2024, Currency: "EUR"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetRewardsReportFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRewardsReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_rewardsReport_ImportPrices(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		csv       string
+		want      int
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("SavePrices", mock.Anything, []model.Price{{Day: 1704067200, Currency: "EUR", Price: "0.95"}}).
+					Return(nil)
+				return mockDB
+			}(),
+			csv:  "date,price\n2024-01-01,0.95\n",
+			want: 1,
+		},
+		{
+			name:    "Error case - invalid file",
+			csv:     "day,value\n",
+			wantErr: true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("SavePrices", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				return mockDB
+			}(),
+			csv:     "date,price\n2024-01-01,0.95\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewImportPricesFunc(tt.dbAdapter, metricsnoop.New())(context.Background(), strings.NewReader(tt.csv), "EUR")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImportPrices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
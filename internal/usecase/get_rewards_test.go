@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	dbmock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+func Test_getRewards_GetRewards(t *testing.T) {
+	reward := model.Reward{ID: 7, RecipientAddress: "tz1a", SourceAddress: "tz1baker", Cycle: 700, Amount: 1500000, Timestamp: 1672531199}
+	wantReward := reward
+	wantReward.AmountTez = "1.500000"
+	wantReward.TimestampTime = "2022-12-31T23:59:59Z"
+
+	tests := []struct {
+		name      string
+		dbAdapter database.Adapter
+		input     GetRewardsInput
+		want      *model.RewardsResponse
+		wantErr   bool
+	}{
+		{
+			name: "Nominal case - full page",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetRewards", mock.Anything, int64(0), int64(0), model.WalletAddress("tz1a"), model.WalletAddress("tz1baker"), uint32(70000), uint16(1), (*model.Cursor)(nil)).
+					Return([]model.Reward{reward}, nil)
+				return mockDB
+			}(),
+			input: GetRewardsInput{Wallet: "tz1a", Backer: "tz1baker", Page: "70000", Limit: "1"},
+			want: &model.RewardsResponse{
+				Rewards:    []model.Reward{wantReward},
+				NextCursor: model.NewCursor(1672531199, 7).Encode(),
+				Pagination: model.PaginationInfo{CurrentPage: 70000, PerPage: 1, HasPrevPage: true, HasNextPage: true, PrevPage: 69999, NextPage: 70001},
+			},
+		},
+		{
+			name: "Nominal case - last page",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetRewards", mock.Anything, int64(0), int64(0), model.WalletAddress("tz1a"), model.WalletAddress("tz1baker"), uint32(1), uint16(50), (*model.Cursor)(nil)).
+					Return([]model.Reward{reward}, nil)
+				return mockDB
+			}(),
+			input: GetRewardsInput{Wallet: "tz1a", Backer: "tz1baker"},
+			want: &model.RewardsResponse{
+				Rewards:    []model.Reward{wantReward},
+				Pagination: model.PaginationInfo{CurrentPage: 1, PerPage: 50},
+			},
+		},
+		{
+			name:      "Error case - limit above the maximum",
+			dbAdapter: dbmock.New(),
+			input:     GetRewardsInput{Wallet: "tz1a", Backer: "tz1baker", Limit: "101"},
+			wantErr:   true,
+		},
+		{
+			name:      "Error case - from after to",
+			dbAdapter: dbmock.New(),
+			input: GetRewardsInput{
+				Wallet:   "tz1a",
+				Backer:   "tz1baker",
+				FromDate: func() *time.Time { d := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC); return &d }(),
+				ToDate:   func() *time.Time { d := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); return &d }(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - database error",
+			dbAdapter: func() database.Adapter {
+				mockDB := dbmock.New()
+				mockDB.On("GetRewards", mock.Anything, int64(0), int64(0), model.WalletAddress("tz1a"), model.WalletAddress("tz1baker"), uint32(1), uint16(50), (*model.Cursor)(nil)).
+					Return([]model.Reward(nil), errors.New("db error"))
+				return mockDB
+			}(),
+			input:   GetRewardsInput{Wallet: "tz1a", Backer: "tz1baker"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGetRewardsFunc(50, tt.dbAdapter, metricsnoop.New())(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRewards() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRewards() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
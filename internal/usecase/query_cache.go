@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/cache"
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const (
+	defaultQueryCacheTTL           = 10 * time.Second
+	defaultQueryCacheHistoricalTTL = time.Hour
+	defaultQueryCacheHistoricalAge = 24 * time.Hour
+	defaultQueryCachePollInterval  = 10 * time.Second
+
+	// Results of the query cache lookups: served from the cache, loaded, or waiting for the load of another request.
+	cacheResultHit    = "hit"
+	cacheResultMiss   = "miss"
+	cacheResultShared = "shared"
+)
+
+// QueryCacheConfig configures the caching of the query results, zero values select the defaults.
+type QueryCacheConfig struct {
+	// TTL is the lifetime of the results including recent data, which are also dropped once new levels are indexed.
+	TTL time.Duration `mapstructure:"ttl"`
+	// HistoricalTTL is the lifetime of the results of the ranges ended HistoricalAge ago, kept across new levels.
+	HistoricalTTL time.Duration `mapstructure:"historical_ttl"`
+	HistoricalAge time.Duration `mapstructure:"historical_age"`
+	// PollInterval is the delay between two reads of the indexed levels when no notification is received.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// indexedHead is the position of the indexer, the cached live results are dropped when it moves.
+type indexedHead struct {
+	level       uint64
+	rewardCycle int
+}
+
+// QueryCache caches the results of the delegation, operation and reward queries, and coalesces the identical
+// queries running concurrently. The cached results are shared by the requests and must not be modified.
+type QueryCache struct {
+	cache         cache.Adapter
+	dbAdapter     database.Adapter
+	metricsClient metrics.Adapter
+	cfg           QueryCacheConfig
+	now           func() time.Time
+
+	// generation is part of the keys of the live results, so that incrementing it drops them all.
+	generation atomic.Uint64
+	flights    flightGroup
+}
+
+// NewQueryCache creates a new query cache, whose live results are dropped on new indexed levels once Run is called.
+func NewQueryCache(cacheAdapter cache.Adapter, dbAdapter database.Adapter, metricsClient metrics.Adapter, cfg QueryCacheConfig) *QueryCache {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultQueryCacheTTL
+	}
+	if cfg.HistoricalTTL <= 0 {
+		cfg.HistoricalTTL = defaultQueryCacheHistoricalTTL
+	}
+	if cfg.HistoricalAge <= 0 {
+		cfg.HistoricalAge = defaultQueryCacheHistoricalAge
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultQueryCachePollInterval
+	}
+
+	return &QueryCache{
+		cache:         cacheAdapter,
+		dbAdapter:     dbAdapter,
+		metricsClient: metricsClient,
+		cfg:           cfg,
+		now:           time.Now,
+	}
+}
+
+// Run drops the live results every time the job indexes new levels or reward cycles, until ctx is done.
+// New levels are noticed when the database notifies the saved delegations, or every poll interval.
+func (c *QueryCache) Run(ctx context.Context) error {
+	signals, err := c.dbAdapter.ListenDelegations(ctx)
+	if err != nil && !errors.Is(err, database.ErrListenUnsupported) {
+		return err
+	}
+
+	head, err := c.readHead(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-signals:
+			if !ok {
+				signals = nil // the listener stopped, polling carries on
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		// Failures are recorded by readHead and retried on the next signal or tick.
+		next, err := c.readHead(ctx)
+		if err == nil && next != head {
+			head = next
+			c.Invalidate()
+		}
+	}
+}
+
+// Invalidate drops the cached live results, the historical ones are kept.
+func (c *QueryCache) Invalidate() {
+	c.generation.Add(1)
+}
+
+// CacheGetDelegations wraps a GetDelegationsFunc with the query cache.
+func (c *QueryCache) CacheGetDelegations(getDelegations GetDelegationsFunc) GetDelegationsFunc {
+	return func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
+		end := rangeEnd(input.ToDate)
+		if year, err := strconv.Atoi(input.Year); err == nil && year > 0 {
+			yearEnd := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			if end.IsZero() || yearEnd.Before(end) {
+				end = yearEnd
+			}
+		}
+
+		return cachedQuery(ctx, c, "GetDelegations", input, end, func(ctx context.Context) (*model.DelegationsResponse, error) {
+			return getDelegations(ctx, input)
+		})
+	}
+}
+
+// CacheGetOperations wraps a GetOperationsFunc with the query cache.
+func (c *QueryCache) CacheGetOperations(getOperations GetOperationsFunc) GetOperationsFunc {
+	return func(ctx context.Context, input GetOperationsInput) (*model.OperationsResponse, error) {
+		return cachedQuery(ctx, c, "GetOperations", input, rangeEnd(input.ToDate), func(ctx context.Context) (*model.OperationsResponse, error) {
+			return getOperations(ctx, input)
+		})
+	}
+}
+
+// CacheGetRewards wraps a GetRewardsFunc with the query cache.
+func (c *QueryCache) CacheGetRewards(getRewards GetRewardsFunc) GetRewardsFunc {
+	return func(ctx context.Context, input GetRewardsInput) (*model.RewardsResponse, error) {
+		return cachedQuery(ctx, c, "GetRewards", input, rangeEnd(input.ToDate), func(ctx context.Context) (*model.RewardsResponse, error) {
+			return getRewards(ctx, input)
+		})
+	}
+}
+
+// cachedQuery returns the cached result of a query, or loads it once for the concurrent identical queries.
+// A query whose range ended before the historical age is cached for the historical TTL, and kept across new levels.
+func cachedQuery[T any](ctx context.Context, c *QueryCache, name string, input any, end time.Time, load func(context.Context) (T, error)) (T, error) {
+	params, err := json.Marshal(input)
+	if err != nil {
+		return load(ctx)
+	}
+
+	historical := !end.IsZero() && !end.After(c.now().Add(-c.cfg.HistoricalAge))
+	key, ttl := name+":historical:"+string(params), c.cfg.HistoricalTTL
+	if !historical {
+		key, ttl = name+":"+strconv.FormatUint(c.generation.Load(), 10)+":"+string(params), c.cfg.TTL
+	}
+
+	if value, ok := c.cache.Get(key); ok {
+		c.record(name, cacheResultHit)
+		return value.(T), nil
+	}
+
+	value, shared, err := c.flights.do(ctx, key, func(ctx context.Context) (any, error) {
+		result, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.cache.Set(key, result, ttl)
+		return result, nil
+	})
+	if shared {
+		c.record(name, cacheResultShared)
+	} else {
+		c.record(name, cacheResultMiss)
+	}
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// rangeEnd returns the end of a date range, zero when it is open.
+func rangeEnd(toDate *time.Time) time.Time {
+	if toDate == nil {
+		return time.Time{}
+	}
+	return *toDate
+}
+
+// readHead reads the position of the indexer.
+func (c *QueryCache) readHead(ctx context.Context) (head indexedHead, err error) {
+	defer c.monitor("ReadIndexedHead", time.Now(), c.metricsClient, &err)
+
+	if head.level, err = c.dbAdapter.GetHighestBlockLevel(ctx); err != nil {
+		return head, err
+	}
+	head.rewardCycle, err = c.dbAdapter.GetLastSyncedRewardCycle(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+	return head, err
+}
+
+// record counts a lookup of the cache of a query by result.
+func (c *QueryCache) record(name, result string) {
+	if c.metricsClient != nil {
+		c.metricsClient.RecordCacheRequest(name, result)
+	}
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (c *QueryCache) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
+
+// flight is a load shared by the identical queries running concurrently.
+type flight struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// flightGroup coalesces the concurrent loads of a key.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs fn once for the concurrent calls with the same key, and returns its result with whether it was started by
+// another call. fn runs without the cancellation of ctx, so that a caller going away does not fail the others, and
+// each caller stops waiting once its own ctx is done.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, bool, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, shared := g.flights[key]
+	if !shared {
+		f = &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		go func() {
+			defer close(f.done)
+			f.value, f.err = fn(context.WithoutCancel(ctx))
+
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, shared, f.err
+	case <-ctx.Done():
+		return nil, shared, ctx.Err()
+	}
+}
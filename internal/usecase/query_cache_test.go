@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsmemory "github.com/tezos-delegation-service/internal/adapter/metrics/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// ttlCache is a cache keeping its values forever, recording the TTL they were set with.
+type ttlCache struct {
+	mu     sync.Mutex
+	values map[string]any
+	ttls   []time.Duration
+}
+
+func (c *ttlCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *ttlCache) Set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		c.values = make(map[string]any)
+	}
+	c.values[key] = value
+	c.ttls = append(c.ttls, ttl)
+}
+
+// inFlight returns the number of loads running in the query cache.
+func inFlight(c *QueryCache) int {
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+	return len(c.flights.flights)
+}
+
+func Test_QueryCache_CacheGetDelegations(t *testing.T) {
+	ctx := context.Background()
+	to := time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		input      GetDelegationsInput
+		invalidate bool
+		wantTTL    time.Duration
+		wantCalls  int32
+	}{
+		{
+			name:      "Nominal case - live range",
+			input:     GetDelegationsInput{Page: "1"},
+			wantTTL:   defaultQueryCacheTTL,
+			wantCalls: 1,
+		},
+		{
+			name:       "Nominal case - live range dropped on new levels",
+			input:      GetDelegationsInput{Page: "1"},
+			invalidate: true,
+			wantTTL:    defaultQueryCacheTTL,
+			wantCalls:  2,
+		},
+		{
+			name:       "Nominal case - historical year kept on new levels",
+			input:      GetDelegationsInput{Page: "1", Year: "2023"},
+			invalidate: true,
+			wantTTL:    defaultQueryCacheHistoricalTTL,
+			wantCalls:  1,
+		},
+		{
+			name:       "Nominal case - historical date range",
+			input:      GetDelegationsInput{Page: "1", Year: "2024", ToDate: &to},
+			invalidate: true,
+			wantTTL:    defaultQueryCacheHistoricalTTL,
+			wantCalls:  1,
+		},
+		{
+			name:       "Nominal case - range ended recently",
+			input:      GetDelegationsInput{Page: "1", ToDate: &recent},
+			invalidate: true,
+			wantTTL:    defaultQueryCacheTTL,
+			wantCalls:  2,
+		},
+		{
+			name:       "Nominal case - current year",
+			input:      GetDelegationsInput{Page: "1", Year: "2024"},
+			invalidate: true,
+			wantTTL:    defaultQueryCacheTTL,
+			wantCalls:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheAdapter := &ttlCache{}
+			metricsClient := metricsmemory.New()
+			c := NewQueryCache(cacheAdapter, databasememory.New(), metricsClient, QueryCacheConfig{})
+			c.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+			var calls atomic.Int32
+			getDelegations := c.CacheGetDelegations(func(ctx context.Context, input GetDelegationsInput) (*model.DelegationsResponse, error) {
+				calls.Add(1)
+				return &model.DelegationsResponse{}, nil
+			})
+
+			first, err := getDelegations(ctx, tt.input)
+			assert.NoError(t, err)
+			if tt.invalidate {
+				c.Invalidate()
+			}
+			second, err := getDelegations(ctx, tt.input)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			assert.Equal(t, tt.wantTTL, cacheAdapter.ttls[0])
+			if tt.wantCalls == 1 {
+				assert.Same(t, first, second)
+				assert.Equal(t, 1, metricsClient.CacheRequests[metricsmemory.CacheRequest{Cache: "GetDelegations", Result: cacheResultHit}])
+			} else {
+				assert.Equal(t, 2, metricsClient.CacheRequests[metricsmemory.CacheRequest{Cache: "GetDelegations", Result: cacheResultMiss}])
+			}
+		})
+	}
+}
+
+func Test_QueryCache_CacheGetRewards(t *testing.T) {
+	ctx := context.Background()
+	c := NewQueryCache(&ttlCache{}, databasememory.New(), metricsnoop.New(), QueryCacheConfig{})
+
+	var calls atomic.Int32
+	getRewards := c.CacheGetRewards(func(ctx context.Context, input GetRewardsInput) (*model.RewardsResponse, error) {
+		calls.Add(1)
+		if input.Wallet == "" {
+			return nil, errors.New("missing wallet")
+		}
+		return &model.RewardsResponse{}, nil
+	})
+
+	// Errors are not cached.
+	for i := 0; i < 2; i++ {
+		_, err := getRewards(ctx, GetRewardsInput{})
+		assert.Error(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+
+	// The queries of other parameters are cached apart.
+	for _, wallet := range []model.WalletAddress{"tz1a", "tz1b", "tz1a"} {
+		_, err := getRewards(ctx, GetRewardsInput{Wallet: wallet})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func Test_QueryCache_CacheGetOperations_coalescing(t *testing.T) {
+	ctx := context.Background()
+	// The late callers find the result in the cache, so that it is loaded once whatever the scheduling.
+	c := NewQueryCache(&ttlCache{}, databasememory.New(), metricsnoop.New(), QueryCacheConfig{})
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	getOperations := c.CacheGetOperations(func(ctx context.Context, input GetOperationsInput) (*model.OperationsResponse, error) {
+		calls.Add(1)
+		<-release
+		return &model.OperationsResponse{}, nil
+	})
+
+	// A caller going away does not fail the load shared with the others.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	leaderDone := make(chan error)
+	go func() {
+		_, err := getOperations(cancelledCtx, GetOperationsInput{Page: "1"})
+		leaderDone <- err
+	}()
+	for inFlight(c) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	assert.ErrorIs(t, <-leaderDone, context.Canceled)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]*model.OperationsResponse, callers)
+	for i := 0; i < callers; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := getOperations(ctx, GetOperationsInput{Page: "1"})
+			assert.NoError(t, err)
+			results[i] = result
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+}
+
+func Test_QueryCache_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db := databasememory.New()
+	c := NewQueryCache(&ttlCache{}, db, metricsnoop.New(), QueryCacheConfig{PollInterval: time.Hour})
+
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-done)
+	}()
+
+	// The notification of the saved delegations drops the live results. Delegations are saved until Run reads the
+	// first level and listens to the next ones.
+	deadline := time.Now().Add(time.Second)
+	for id := int64(1); c.generation.Load() == 0; id++ {
+		if time.Now().After(deadline) {
+			t.Fatalf("live results not dropped")
+		}
+		assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{{ID: id, Level: 100 + id, Delegator: "tz1a"}}))
+		time.Sleep(5 * time.Millisecond)
+	}
+}
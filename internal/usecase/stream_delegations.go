@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const (
+	defaultStreamBufferSize     = 256
+	defaultStreamMaxSubscribers = 1000
+	defaultStreamPollInterval   = 10 * time.Second
+
+	// streamBatchSize is the number of delegations read per query, by the stream and by the replays.
+	streamBatchSize = 500
+)
+
+// DelegationStreamConfig bounds the live delegation stream, zero values select the defaults.
+type DelegationStreamConfig struct {
+	// BufferSize is the number of events queued per subscriber before it is disconnected as too slow.
+	BufferSize     int `mapstructure:"buffer_size"`
+	MaxSubscribers int `mapstructure:"max_subscribers"`
+	// PollInterval is the delay between two reads of the new delegations when no notification is received.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// SubscribeDelegationsInput defines the input structure for subscribing to the live delegations.
+type SubscribeDelegationsInput struct {
+	// Filter selects the events by delegator, baker and kind.
+	Filter model.DelegationFilter
+	// LastEventID replays the matching delegations saved after it before the live ones, when positive.
+	LastEventID int64
+}
+
+// SubscribeDelegationsFunc defines the function signature for subscribing to the live delegations until ctx is done.
+type SubscribeDelegationsFunc func(ctx context.Context, input SubscribeDelegationsInput) (*DelegationSubscription, error)
+
+// DelegationStream reads the delegations saved by the job when the database notifies it, or every poll interval,
+// and pushes them to its subscribers.
+type DelegationStream struct {
+	dbAdapter     database.Adapter
+	metricsClient metrics.Adapter
+	cfg           DelegationStreamConfig
+
+	mu          sync.Mutex
+	lastID      int64
+	subscribers map[*DelegationSubscription]struct{}
+	ready       chan struct{}
+	stopped     chan struct{}
+}
+
+// DelegationSubscription receives the events of the delegations matching its filter.
+type DelegationSubscription struct {
+	filter model.DelegationFilter
+	live   chan model.DelegationEvent
+	events chan model.DelegationEvent
+
+	endOnce sync.Once
+	done    chan struct{}
+	err     error
+}
+
+// NewDelegationStream creates a new delegation stream, which serves its subscribers once Run is called.
+func NewDelegationStream(adapter database.Adapter, metricsClient metrics.Adapter, cfg DelegationStreamConfig) *DelegationStream {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultStreamBufferSize
+	}
+	if cfg.MaxSubscribers <= 0 {
+		cfg.MaxSubscribers = defaultStreamMaxSubscribers
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultStreamPollInterval
+	}
+
+	return &DelegationStream{
+		dbAdapter:     adapter,
+		metricsClient: metricsClient,
+		cfg:           cfg,
+		subscribers:   make(map[*DelegationSubscription]struct{}),
+		ready:         make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// Run pushes the new delegations to the subscribers until ctx is done, then ends every subscription.
+func (s *DelegationStream) Run(ctx context.Context) error {
+	defer s.stop()
+
+	// Listening starts first, so that no delegation saved while catching up is missed.
+	signals, err := s.dbAdapter.ListenDelegations(ctx)
+	if err != nil && !errors.Is(err, database.ErrListenUnsupported) {
+		return err
+	}
+
+	latest, err := s.dbAdapter.GetLatestDelegation(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if latest != nil {
+		s.lastID = latest.ID
+	}
+	// The latest delegation is the one of the highest level, the ones of higher ids are skipped before serving.
+	if err := s.publish(ctx); err != nil {
+		return err
+	}
+	close(s.ready)
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-signals:
+			if !ok {
+				signals = nil // the listener stopped, polling carries on
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		// Failures are recorded by publish and retried on the next signal or tick.
+		_ = s.publish(ctx)
+	}
+}
+
+// Subscribe subscribes to the delegations matching the filter of input until ctx is done or the subscription is closed.
+func (s *DelegationStream) Subscribe(ctx context.Context, input SubscribeDelegationsInput) (sub *DelegationSubscription, err error) {
+	defer s.monitor("SubscribeDelegations", time.Now(), s.metricsClient, &err)
+
+	select {
+	case <-s.ready:
+	case <-s.stopped:
+		return nil, ErrStreamClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	s.mu.Lock()
+	select {
+	case <-s.stopped:
+		s.mu.Unlock()
+		return nil, ErrStreamClosed
+	default:
+	}
+	if len(s.subscribers) >= s.cfg.MaxSubscribers {
+		s.mu.Unlock()
+		return nil, ErrTooManySubscribers
+	}
+
+	sub = &DelegationSubscription{
+		filter: input.Filter,
+		live:   make(chan model.DelegationEvent, s.cfg.BufferSize),
+		events: make(chan model.DelegationEvent),
+		done:   make(chan struct{}),
+	}
+	s.subscribers[sub] = struct{}{}
+	// Every delegation up to replayUntil is replayed, the later ones are pushed live.
+	replayUntil := s.lastID
+	s.mu.Unlock()
+
+	go s.forward(ctx, sub, input.LastEventID, replayUntil)
+	return sub, nil
+}
+
+// publish pushes the delegations saved after the last one published to the subscribers whose filter matches them.
+// A subscriber whose buffer is full is ended, it resumes from its last event once reconnected.
+func (s *DelegationStream) publish(ctx context.Context) (err error) {
+	defer s.monitor("PublishDelegations", time.Now(), s.metricsClient, &err)
+
+	for {
+		var delegations []model.Delegation
+		delegations, err = s.dbAdapter.GetDelegationsAfter(ctx, s.lastID, model.DelegationFilter{}, streamBatchSize)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		for _, d := range delegations {
+			event := model.NewDelegationEvent(d)
+			for sub := range s.subscribers {
+				if !sub.filter.Match(d) {
+					continue
+				}
+				select {
+				case sub.live <- event:
+				default:
+					delete(s.subscribers, sub)
+					sub.end(ErrSubscriberTooSlow)
+				}
+			}
+			s.lastID = d.ID
+		}
+		s.mu.Unlock()
+
+		if len(delegations) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+// forward sends to the subscriber the delegations saved between lastEventID and replayUntil, then its live events.
+func (s *DelegationStream) forward(ctx context.Context, sub *DelegationSubscription, lastEventID, replayUntil int64) {
+	defer close(sub.events)
+	defer s.unsubscribe(sub)
+
+	send := func(event model.DelegationEvent) bool {
+		select {
+		case sub.events <- event:
+			return true
+		case <-sub.done:
+		case <-ctx.Done():
+		}
+		return false
+	}
+
+	for afterID := lastEventID; afterID > 0 && afterID < replayUntil; {
+		delegations, err := s.dbAdapter.GetDelegationsAfter(ctx, afterID, sub.filter, streamBatchSize)
+		if err != nil {
+			sub.end(err)
+			return
+		}
+		for _, d := range delegations {
+			if d.ID > replayUntil {
+				afterID = replayUntil // pushed live
+				break
+			}
+			if !send(model.NewDelegationEvent(d)) {
+				return
+			}
+			afterID = d.ID
+		}
+		if len(delegations) < streamBatchSize {
+			break
+		}
+	}
+
+	for {
+		select {
+		case event := <-sub.live:
+			if !send(event) {
+				return
+			}
+		case <-sub.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// unsubscribe removes a subscriber and ends its subscription.
+func (s *DelegationStream) unsubscribe(sub *DelegationSubscription) {
+	s.mu.Lock()
+	delete(s.subscribers, sub)
+	s.mu.Unlock()
+	sub.end(nil)
+}
+
+// stop ends every subscription and refuses the new ones.
+func (s *DelegationStream) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	close(s.stopped)
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		sub.end(ErrStreamClosed)
+	}
+}
+
+// Events returns the events of the subscription, in increasing id order. The channel is closed once it ends.
+func (sub *DelegationSubscription) Events() <-chan model.DelegationEvent {
+	return sub.events
+}
+
+// Err returns why the subscription ended early: ErrSubscriberTooSlow, ErrStreamClosed or a database error.
+// It returns nil while the subscription runs and once it was closed or its context is done.
+func (sub *DelegationSubscription) Err() error {
+	select {
+	case <-sub.done:
+		return sub.err
+	default:
+		return nil
+	}
+}
+
+// Close ends the subscription.
+func (sub *DelegationSubscription) Close() {
+	sub.end(nil)
+}
+
+// end ends the subscription with err, the first call only is effective.
+func (sub *DelegationSubscription) end(err error) {
+	sub.endOnce.Do(func() {
+		sub.err = err
+		close(sub.done)
+	})
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (s *DelegationStream) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
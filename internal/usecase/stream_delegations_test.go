@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
+	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+// startDelegationStream runs a delegation stream over db until the test ends.
+func startDelegationStream(t *testing.T, db *databasememory.Memory, cfg DelegationStreamConfig) *DelegationStream {
+	t.Helper()
+
+	stream := NewDelegationStream(db, metricsnoop.New(), cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = stream.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return stream
+}
+
+// nextEvent returns the next event of sub, failing the test after a second.
+func nextEvent(t *testing.T, sub *DelegationSubscription) (model.DelegationEvent, bool) {
+	t.Helper()
+
+	select {
+	case event, ok := <-sub.Events():
+		return event, ok
+	case <-time.After(time.Second):
+		t.Fatalf("no event received")
+		return model.DelegationEvent{}, false
+	}
+}
+
+func Test_DelegationStream_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		saved     []*model.Delegation
+		input     SubscribeDelegationsInput
+		published []*model.Delegation
+		want      []model.DelegationEvent
+	}{
+		{
+			name:  "Nominal case - live delegations of a baker",
+			input: SubscribeDelegationsInput{Filter: model.DelegationFilter{Delegate: "tz1baker"}},
+			published: []*model.Delegation{
+				{Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 1704067200, Amount: 1500000, Level: 10},
+				{Delegator: "tz1b", Delegate: "tz1other", Timestamp: 1704067200, Amount: 10, Level: 10},
+				{Delegator: "tz1c", Delegate: "tz1baker", Timestamp: 1704067260, Amount: 20, Level: 11},
+			},
+			want: []model.DelegationEvent{
+				{ID: 1, Kind: model.DelegationKindDelegation, Delegation: model.Delegation{ID: 1, Delegator: "tz1a", Delegate: "tz1baker", Timestamp: 1704067200, TimestampTime: "2024-01-01T00:00:00Z", Amount: 1500000, AmountTez: "1.500000", Level: 10}},
+				{ID: 3, Kind: model.DelegationKindDelegation, Delegation: model.Delegation{ID: 3, Delegator: "tz1c", Delegate: "tz1baker", Timestamp: 1704067260, TimestampTime: "2024-01-01T00:01:00Z", Amount: 20, AmountTez: "0.000020", Level: 11}},
+			},
+		},
+		{
+			name: "Nominal case - undelegations replayed after the last event id, then live",
+			saved: []*model.Delegation{
+				{Delegator: "tz1a", Delegate: "", Timestamp: 1704067200, Level: 10},
+				{Delegator: "tz1b", Delegate: "", Timestamp: 1704067200, Level: 10},
+				{Delegator: "tz1c", Delegate: "tz1baker", Timestamp: 1704067200, Level: 10},
+			},
+			input: SubscribeDelegationsInput{Filter: model.DelegationFilter{Kind: model.DelegationKindUndelegation}, LastEventID: 1},
+			published: []*model.Delegation{
+				{Delegator: "tz1d", Delegate: "", Timestamp: 1704067260, Level: 11},
+			},
+			want: []model.DelegationEvent{
+				{ID: 2, Kind: model.DelegationKindUndelegation, Delegation: model.Delegation{ID: 2, Delegator: "tz1b", Timestamp: 1704067200, TimestampTime: "2024-01-01T00:00:00Z", AmountTez: "0.000000", Level: 10}},
+				{ID: 4, Kind: model.DelegationKindUndelegation, Delegation: model.Delegation{ID: 4, Delegator: "tz1d", Timestamp: 1704067260, TimestampTime: "2024-01-01T00:01:00Z", AmountTez: "0.000000", Level: 11}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := databasememory.New()
+			assert.NoError(t, db.SaveDelegations(ctx, tt.saved))
+			stream := startDelegationStream(t, db, DelegationStreamConfig{PollInterval: time.Hour})
+
+			sub, err := stream.Subscribe(ctx, tt.input)
+			if err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+			defer sub.Close()
+			assert.NoError(t, db.SaveDelegations(ctx, tt.published))
+
+			var got []model.DelegationEvent
+			for len(got) < len(tt.want) {
+				event, ok := nextEvent(t, sub)
+				if !ok {
+					t.Fatalf("subscription ended: %v", sub.Err())
+				}
+				event.Delegation.CreatedAt = time.Time{}
+				got = append(got, event)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_DelegationStream_Subscribe_limits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Error case - too many subscribers", func(t *testing.T) {
+		stream := startDelegationStream(t, databasememory.New(), DelegationStreamConfig{MaxSubscribers: 1, PollInterval: time.Hour})
+
+		sub, err := stream.Subscribe(ctx, SubscribeDelegationsInput{})
+		assert.NoError(t, err)
+		defer sub.Close()
+
+		_, err = stream.Subscribe(ctx, SubscribeDelegationsInput{})
+		assert.True(t, errors.Is(err, ErrTooManySubscribers), "error = %v", err)
+	})
+
+	t.Run("Error case - slow subscriber disconnected", func(t *testing.T) {
+		db := databasememory.New()
+		stream := startDelegationStream(t, db, DelegationStreamConfig{BufferSize: 1, PollInterval: time.Hour})
+
+		sub, err := stream.Subscribe(ctx, SubscribeDelegationsInput{})
+		assert.NoError(t, err)
+		defer sub.Close()
+
+		// The first event waits to be read, the second one fills the buffer, the third one overflows it.
+		for level := int64(1); level <= 3; level++ {
+			assert.NoError(t, db.SaveDelegations(ctx, []*model.Delegation{{Delegator: "tz1a", Delegate: "tz1baker", Level: level}}))
+		}
+
+		deadline := time.After(time.Second)
+		for {
+			select {
+			case _, ok := <-sub.Events():
+				if !ok {
+					assert.True(t, errors.Is(sub.Err(), ErrSubscriberTooSlow), "error = %v", sub.Err())
+					return
+				}
+			case <-deadline:
+				t.Fatalf("slow subscriber not disconnected")
+			}
+		}
+	})
+
+	t.Run("Error case - stream stopped", func(t *testing.T) {
+		stream := NewDelegationStream(databasememory.New(), metricsnoop.New(), DelegationStreamConfig{PollInterval: time.Hour})
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = stream.Run(runCtx)
+		}()
+
+		sub, err := stream.Subscribe(ctx, SubscribeDelegationsInput{})
+		assert.NoError(t, err)
+		cancel()
+		<-done
+
+		_, ok := nextEvent(t, sub)
+		assert.False(t, ok)
+		assert.True(t, errors.Is(sub.Err(), ErrStreamClosed), "error = %v", sub.Err())
+
+		_, err = stream.Subscribe(ctx, SubscribeDelegationsInput{})
+		assert.True(t, errors.Is(err, ErrStreamClosed), "error = %v", err)
+	})
+}
@@ -15,7 +15,6 @@ import (
 
 // syncDelegations handles business logic for syncing delegations.
 type syncDelegations struct {
-	batchSizeDB             int
 	batchSizeAPIHistoric    uint16
 	batchSizeAPIIncremental uint8
 	dbAdapter               database.Adapter
@@ -23,12 +22,17 @@ type syncDelegations struct {
 	tzktApiAdapter          tzktapi.Adapter
 	maxWorkers              int
 	isHistoricalSyncDone    bool
+	// statsFrom and statsTo bound the timestamps of the delegations whose daily statistics are not refreshed yet.
+	statsFrom int64
+	statsTo   int64
 }
 
 // NewSyncDelegationsFunc creates a new instance of syncDelegations.
 func NewSyncDelegationsFunc(tzktAdapter tzktapi.Adapter, dbAdapter database.Adapter, metricsClient metrics.Adapter, logger *logrus.Entry) model.SyncFunc {
+	if tzktAdapter == nil || dbAdapter == nil {
+		return nil
+	}
 	uc := &syncDelegations{
-		batchSizeDB:             100,
 		batchSizeAPIHistoric:    1000,
 		batchSizeAPIIncremental: 150,
 		dbAdapter:               dbAdapter,
@@ -148,7 +152,7 @@ func (uc *syncDelegations) processDelegations(ctx context.Context, delegations m
 		modelDelegation := &model.Delegation{
 			Delegator: model.WalletAddress(d.Sender.Address),
 			Delegate:  model.WalletAddress(d.Delegate.Address),
-			Amount:    float64(d.Amount) / 1000000.0, // Convert mutez to tez
+			Amount:    model.Mutez(d.Amount),
 			Timestamp: d.Timestamp.Unix(),
 			Level:     d.Level,
 		}
@@ -167,11 +171,17 @@ func (uc *syncDelegations) processDelegations(ctx context.Context, delegations m
 	}
 
 	if len(modelDelegations) > 0 {
+		// The current state is saved first: a failed batch is fetched again, as the highest saved level does not move.
+		if err := uc.dbAdapter.SaveCurrentDelegations(ctx, model.CurrentDelegationsFromDelegations(modelDelegations)); err != nil {
+			return fmt.Errorf("error saving current delegations: %w", err)
+		}
+
 		if err := uc.saveDelegations(ctx, modelDelegations, offset); err != nil {
 			return fmt.Errorf("error saving delegations: %w", err)
 		}
 		uc.logger.Infof("Synced %d new delegations (offset: %d)\n", len(modelDelegations), offset)
 
+		uc.refreshStats(ctx, modelDelegations)
 	} else {
 		uc.logger.Info("No new delegations to sync")
 	}
@@ -211,23 +221,8 @@ func (uc *syncDelegations) saveAccountsBatch(ctx context.Context, modelAccounts
 		accounts = append(accounts, *account)
 	}
 
-	for i := 0; i < len(accounts); i += uc.batchSizeDB {
-		end := i + uc.batchSizeDB
-		if end > len(accounts) {
-			end = len(accounts)
-		}
-
-		batch := accounts[i:end]
-		if err := uc.dbAdapter.SaveAccounts(ctx, batch); err != nil {
-			return fmt.Errorf("error saving accounts batch (offset %d, batch %d-%d): %w",
-				offset, i, end-1, err)
-		}
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(50 * time.Millisecond):
-		}
+	if err := uc.dbAdapter.SaveAccounts(ctx, accounts); err != nil {
+		return fmt.Errorf("error saving accounts batch (offset %d): %w", offset, err)
 	}
 
 	uc.logger.Infof("Successfully saved %d accounts (offset %d)", len(accounts), offset)
@@ -248,23 +243,12 @@ func (uc *syncDelegations) saveStakingPoolsBatch(ctx context.Context, modelAccou
 		}
 	}
 
-	for i := 0; i < len(stakingPools); i += uc.batchSizeDB {
-		end := i + uc.batchSizeDB
-		if end > len(stakingPools) {
-			end = len(stakingPools)
-		}
-
-		batch := stakingPools[i:end]
-
-		if err := uc.dbAdapter.SaveStakingPools(ctx, batch); err != nil {
-			return fmt.Errorf("error saving staking pools batch (offset %d, batch %d-%d): %w", offset, i, end-1, err)
-		}
+	if len(stakingPools) == 0 {
+		return nil
+	}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(50 * time.Millisecond):
-		}
+	if err := uc.dbAdapter.SaveStakingPools(ctx, stakingPools); err != nil {
+		return fmt.Errorf("error saving staking pools batch (offset %d): %w", offset, err)
 	}
 
 	uc.logger.Infof("Successfully saved %d staking pools (offset %d)", len(stakingPools), offset)
@@ -272,28 +256,34 @@ func (uc *syncDelegations) saveStakingPoolsBatch(ctx context.Context, modelAccou
 }
 
 // saveDelegations saves a batch of delegations to the database.
+// The database adapter splits the batch into bulk statements according to its configured batch size.
 func (uc *syncDelegations) saveDelegations(ctx context.Context, delegations []*model.Delegation, offset int) error {
-	for i := 0; i < len(delegations); i += uc.batchSizeDB {
-		end := i + uc.batchSizeDB
-		if end > len(delegations) {
-			end = len(delegations)
-		}
+	if err := uc.dbAdapter.SaveDelegations(ctx, delegations); err != nil {
+		return fmt.Errorf("error saving delegations batch (offset %d): %w", offset, err)
+	}
 
-		batch := delegations[i:end]
-		if err := uc.dbAdapter.SaveDelegations(ctx, batch); err != nil {
-			return fmt.Errorf("error saving delegations batch (offset %d, batch %d-%d): %w",
-				offset, i, end-1, err)
-		}
+	uc.logger.Infof("Successfully saved %d delegations (offset %d)", len(delegations), offset)
+	return nil
+}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(50 * time.Millisecond):
+// refreshStats refreshes the daily delegation statistics of the days of the saved delegations.
+// On failure, the days are kept and refreshed along with the ones of the next batch.
+func (uc *syncDelegations) refreshStats(ctx context.Context, delegations []*model.Delegation) {
+	for _, d := range delegations {
+		if uc.statsFrom == 0 || d.Timestamp < uc.statsFrom {
+			uc.statsFrom = d.Timestamp
+		}
+		if d.Timestamp > uc.statsTo {
+			uc.statsTo = d.Timestamp
 		}
 	}
 
-	uc.logger.Infof("Successfully saved %d delegations (offset %d)", len(delegations), offset)
-	return nil
+	if err := uc.dbAdapter.RefreshDelegationStats(ctx, uc.statsFrom, uc.statsTo); err != nil {
+		uc.logger.Warnf("Error refreshing delegation stats: %v", err)
+		return
+	}
+
+	uc.statsFrom, uc.statsTo = 0, 0
 }
 
 // withMonitorer wraps the SyncDelegations function with monitoring capabilities.
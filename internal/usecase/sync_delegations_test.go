@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/tezos-delegation-service/internal/adapter/database"
+	databasememory "github.com/tezos-delegation-service/internal/adapter/database/impl/memory"
 	databasemock "github.com/tezos-delegation-service/internal/adapter/database/impl/mock"
 	"github.com/tezos-delegation-service/internal/adapter/metrics"
 	metricsnoop "github.com/tezos-delegation-service/internal/adapter/metrics/impl/noop"
@@ -33,7 +34,7 @@ func Test_NewSyncDelegationsFunc(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want SyncDelegationsFunc
+		want model.SyncFunc
 	}{
 		{
 			name: "nominal case",
@@ -43,7 +44,7 @@ func Test_NewSyncDelegationsFunc(t *testing.T) {
 				metricsClient: providedMetricsClient,
 				logger:        logrus.NewEntry(logrus.New()),
 			},
-			want: func() SyncDelegationsFunc {
+			want: func() model.SyncFunc {
 				return NewSyncDelegationsFunc(providedTZKTAPI, mockDbAdapter, providedMetricsClient, logrus.NewEntry(logrus.New()))
 			}(),
 		},
@@ -102,7 +103,7 @@ func Test_syncDelegations_SyncDelegations(t *testing.T) {
 				logger: logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					tzkt := tzktapimock.New()
-					tzkt.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					tzkt.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(150)).
 						Return(model.TzktDelegationResponse{}, nil)
 					return tzkt
 				}(),
@@ -122,7 +123,7 @@ func Test_syncDelegations_SyncDelegations(t *testing.T) {
 				logger: logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					tzkt := tzktapimock.New()
-					tzkt.On("FetchDelegationsFromLevel", mock.AnythingOfType("*context.timerCtx"), uint64(100)).
+					tzkt.On("FetchDelegationsFromLevel", mock.AnythingOfType("*context.timerCtx"), uint64(100), uint8(150)).
 						Return(model.TzktDelegationResponse{}, nil)
 					return tzkt
 				}(),
@@ -142,7 +143,7 @@ func Test_syncDelegations_SyncDelegations(t *testing.T) {
 				logger: logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegations", mock.Anything, batchSize, 0).
+					m.On("FetchDelegations", mock.Anything, uint16(1000), 0).
 						Return(model.TzktDelegationResponse{}, nil)
 					return m
 				}(),
@@ -154,9 +155,12 @@ func Test_syncDelegations_SyncDelegations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := &syncDelegations{
-				dbAdapter:      tt.fields.dbAdapter,
-				logger:         tt.fields.logger,
-				tzktApiAdapter: tt.fields.tzktApiAdapter,
+				batchSizeAPIHistoric:    1000,
+				batchSizeAPIIncremental: 150,
+				dbAdapter:               tt.fields.dbAdapter,
+				logger:                  tt.fields.logger,
+				tzktApiAdapter:          tt.fields.tzktApiAdapter,
+				isHistoricalSyncDone:    true,
 			}
 			if err := uc.SyncDelegations(tt.ctx); (err != nil) != tt.wantErr {
 				t.Errorf("SyncDelegations() error = %v, wantErr %v", err, tt.wantErr)
@@ -185,14 +189,22 @@ func Test_syncDelegations_syncHistoricalDelegations(t *testing.T) {
 			fields: fields{
 				dbAdapter: func() database.Adapter {
 					db := databasemock.New()
+					db.On("SaveAccounts", mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveStakingPools", mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveCurrentDelegations", mock.Anything, mock.Anything).
+						Return(nil)
 					db.On("SaveDelegations", mock.Anything, mock.Anything).
 						Return(nil)
+					db.On("RefreshDelegationStats", mock.Anything, mock.Anything, mock.Anything).
+						Return(nil)
 					return db
 				}(),
 				logger: logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					tzkt := tzktapimock.New()
-					tzkt.On("FetchDelegations", mock.Anything, batchSize, 0).
+					tzkt.On("FetchDelegations", mock.Anything, uint16(1000), 0).
 						Return(model.TzktDelegationResponse{
 							{
 								Status:    "applied",
@@ -203,7 +215,7 @@ func Test_syncDelegations_syncHistoricalDelegations(t *testing.T) {
 								Amount:    1000000,
 							},
 						}, nil)
-					tzkt.On("FetchDelegations", mock.Anything, batchSize, batchSize).
+					tzkt.On("FetchDelegations", mock.Anything, uint16(1000), 1000).
 						Return(model.TzktDelegationResponse{}, nil)
 					return tzkt
 				}(),
@@ -236,7 +248,7 @@ func Test_syncDelegations_syncHistoricalDelegations(t *testing.T) {
 				logger:    logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegations", mock.Anything, batchSize, 0).
+					m.On("FetchDelegations", mock.Anything, uint16(1000), 0).
 						Return(model.TzktDelegationResponse{}, fmt.Errorf("api error"))
 					return m
 				}(),
@@ -250,9 +262,11 @@ func Test_syncDelegations_syncHistoricalDelegations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := &syncDelegations{
-				dbAdapter:      tt.fields.dbAdapter,
-				logger:         tt.fields.logger,
-				tzktApiAdapter: tt.fields.tzktApiAdapter,
+				batchSizeAPIHistoric:    1000,
+				batchSizeAPIIncremental: 150,
+				dbAdapter:               tt.fields.dbAdapter,
+				logger:                  tt.fields.logger,
+				tzktApiAdapter:          tt.fields.tzktApiAdapter,
 			}
 			if err := uc.syncHistoricalDelegations(tt.args.ctx); (err != nil) != tt.wantErr {
 				t.Errorf("syncHistoricalDelegations() error = %v, wantErr %v", err, tt.wantErr)
@@ -282,14 +296,22 @@ func Test_syncDelegations_syncIncrementalDelegations(t *testing.T) {
 			fields: fields{
 				dbAdapter: func() database.Adapter {
 					db := databasemock.New()
+					db.On("SaveAccounts", mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveStakingPools", mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveCurrentDelegations", mock.Anything, mock.Anything).
+						Return(nil)
 					db.On("SaveDelegations", mock.Anything, mock.Anything).
 						Return(nil)
+					db.On("RefreshDelegationStats", mock.Anything, mock.Anything, mock.Anything).
+						Return(nil)
 					return db
 				}(),
 				logger: logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					tzkt := tzktapimock.New()
-					tzkt.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					tzkt.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(150)).
 						Return(model.TzktDelegationResponse{
 							{
 								Status:    "applied",
@@ -323,7 +345,7 @@ func Test_syncDelegations_syncIncrementalDelegations(t *testing.T) {
 						default:
 							return false
 						}
-					}), uint64(100)).
+					}), uint64(100), uint8(150)).
 						Return(model.TzktDelegationResponse{}, context.Canceled)
 					return m
 				}(),
@@ -345,7 +367,7 @@ func Test_syncDelegations_syncIncrementalDelegations(t *testing.T) {
 				logger:    logrus.NewEntry(logrus.New()),
 				tzktApiAdapter: func() tzktapi.Adapter {
 					m := tzktapimock.New()
-					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100)).
+					m.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(150)).
 						Return(model.TzktDelegationResponse{}, fmt.Errorf("api error"))
 					return m
 				}(),
@@ -360,9 +382,11 @@ func Test_syncDelegations_syncIncrementalDelegations(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := &syncDelegations{
-				dbAdapter:      tt.fields.dbAdapter,
-				logger:         tt.fields.logger,
-				tzktApiAdapter: tt.fields.tzktApiAdapter,
+				batchSizeAPIHistoric:    1000,
+				batchSizeAPIIncremental: 150,
+				dbAdapter:               tt.fields.dbAdapter,
+				logger:                  tt.fields.logger,
+				tzktApiAdapter:          tt.fields.tzktApiAdapter,
 			}
 			if err := uc.syncIncrementalDelegations(tt.args.ctx, tt.args.level); (err != nil) != tt.wantErr {
 				t.Errorf("syncIncrementalDelegations() error = %v, wantErr %v", err, tt.wantErr)
@@ -371,6 +395,82 @@ func Test_syncDelegations_syncIncrementalDelegations(t *testing.T) {
 	}
 }
 
+func Test_syncDelegations_syncIncrementalDelegations_memoryStore(t *testing.T) {
+	ctx := context.Background()
+	db := databasememory.New()
+	timestamp := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	tzkt := tzktapimock.New()
+	tzkt.On("FetchDelegationsFromLevel", mock.Anything, uint64(100), uint8(150)).
+		Return(model.TzktDelegationResponse{
+			{
+				Status:    "applied",
+				Level:     101,
+				Timestamp: timestamp,
+				Sender:    model.TzktAddress{Address: "tz1sender"},
+				Delegate:  model.TzktDelegate{Address: "tz1delegate"},
+				Amount:    1250000,
+			},
+		}, nil)
+
+	uc := &syncDelegations{
+		batchSizeAPIHistoric:    1000,
+		batchSizeAPIIncremental: 150,
+		dbAdapter:               db,
+		logger:                  logrus.NewEntry(logrus.New()),
+		tzktApiAdapter:          tzkt,
+	}
+	if err := uc.syncIncrementalDelegations(ctx, 100); err != nil {
+		t.Fatalf("syncIncrementalDelegations() error = %v", err)
+	}
+
+	level, err := db.GetHighestBlockLevel(ctx)
+	if err != nil || level != 101 {
+		t.Errorf("GetHighestBlockLevel() = %v, %v, want 101", level, err)
+	}
+
+	got, err := NewGetDelegationsFunc(50, db, nil)(ctx, GetDelegationsInput{Year: "2025"})
+	if err != nil {
+		t.Fatalf("GetDelegations() error = %v", err)
+	}
+	if len(got.Delegations) != 1 {
+		t.Fatalf("GetDelegations() returned %d delegations, want 1", len(got.Delegations))
+	}
+	delegation := got.Delegations[0]
+	if delegation.Delegator != "tz1sender" || delegation.Delegate != "tz1delegate" || delegation.AmountTez != "1.250000" {
+		t.Errorf("GetDelegations() = %+v", delegation)
+	}
+	if delegation.TimestampTime != "2025-02-01T12:00:00Z" {
+		t.Errorf("GetDelegations() timestamp = %v", delegation.TimestampTime)
+	}
+}
+
+func Test_syncDelegations_refreshStats(t *testing.T) {
+	ctx := context.Background()
+	db := databasemock.New()
+	db.On("RefreshDelegationStats", mock.Anything, int64(1000), int64(2000)).
+		Return(errors.New("refresh error")).Once()
+	db.On("RefreshDelegationStats", mock.Anything, int64(500), int64(2000)).
+		Return(nil).Once()
+
+	uc := &syncDelegations{
+		dbAdapter: db,
+		logger:    logrus.NewEntry(logrus.New()),
+	}
+
+	uc.refreshStats(ctx, []*model.Delegation{{Timestamp: 2000}, {Timestamp: 1000}})
+	if uc.statsFrom != 1000 || uc.statsTo != 2000 {
+		t.Errorf("refreshStats() pending range = [%d, %d], want [1000, 2000]", uc.statsFrom, uc.statsTo)
+	}
+
+	uc.refreshStats(ctx, []*model.Delegation{{Timestamp: 500}})
+	if uc.statsFrom != 0 || uc.statsTo != 0 {
+		t.Errorf("refreshStats() pending range = [%d, %d], want none", uc.statsFrom, uc.statsTo)
+	}
+
+	db.AssertExpectations(t)
+}
+
 func Test_syncDelegations_withMonitorer(t *testing.T) {
 	type fields struct {
 		dbAdapter      database.Adapter
@@ -378,14 +478,14 @@ func Test_syncDelegations_withMonitorer(t *testing.T) {
 		tzktApiAdapter tzktapi.Adapter
 	}
 	type args struct {
-		syncDelegations SyncDelegationsFunc
+		syncDelegations model.SyncFunc
 		metricsClient   metrics.Adapter
 	}
 	tests := []struct {
 		name   string
 		fields fields
 		args   args
-		want   SyncDelegationsFunc
+		want   model.SyncFunc
 	}{
 		{
 			name: "nominal case",
@@ -430,9 +530,11 @@ func Test_syncDelegations_withMonitorer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			uc := &syncDelegations{
-				dbAdapter:      tt.fields.dbAdapter,
-				logger:         tt.fields.logger,
-				tzktApiAdapter: tt.fields.tzktApiAdapter,
+				batchSizeAPIHistoric:    1000,
+				batchSizeAPIIncremental: 150,
+				dbAdapter:               tt.fields.dbAdapter,
+				logger:                  tt.fields.logger,
+				tzktApiAdapter:          tt.fields.tzktApiAdapter,
 			}
 			got := uc.withMonitorer(tt.args.syncDelegations, tt.args.metricsClient)
 			err := got(context.Background())
@@ -53,6 +53,11 @@ func (uc *SyncRewards) Sync(ctx context.Context) error {
 		lastSyncedCycle = 0
 	}
 
+	// Cycles synced before their levels were stored would not resolve in point-in-time queries
+	if lastSyncedCycle > 0 {
+		uc.backfillCycles(ctx, lastSyncedCycle)
+	}
+
 	startCycle := lastSyncedCycle + 1
 	if startCycle > currentCycle {
 		uc.logger.Info("Rewards are already up to date")
@@ -84,11 +89,23 @@ func (uc *SyncRewards) Sync(ctx context.Context) error {
 
 		uc.logger.Infof("Processing rewards for cycle %d", cycle)
 
+		// Store the cycle boundaries first, its bakers and the point-in-time queries resolve it to its last level
+		boundaries, err := uc.saveCycle(ctx, cycle)
+		if err != nil {
+			return err
+		}
+
+		// The bakers of a past cycle are the ones at its last level, the current cycle uses the current delegations
+		var asOfLevel int64
+		if cycle < currentCycle {
+			asOfLevel = boundaries.LastLevel
+		}
+
 		// For each delegator, fetch their rewards for this cycle
 		var allRewards []model.Reward
 		for _, delegator := range delegators {
 			// Get the baker for this delegator during this cycle
-			baker, err := uc.dbAdapter.GetBakerForDelegatorAtCycle(ctx, delegator, cycle)
+			baker, err := uc.dbAdapter.GetBakerForDelegatorAtLevel(ctx, delegator, asOfLevel)
 			if err != nil {
 				uc.logger.Warnf("Error getting baker for delegator %s at cycle %d: %v, skipping", delegator, cycle, err)
 				continue
@@ -124,6 +141,11 @@ func (uc *SyncRewards) Sync(ctx context.Context) error {
 			uc.logger.Infof("No rewards found for cycle %d", cycle)
 		}
 
+		// Refresh the reward statistics before marking the cycle as synced, so a failed refresh is retried
+		if err := uc.dbAdapter.RefreshRewardStats(ctx, cycle, cycle); err != nil {
+			return fmt.Errorf("error refreshing reward stats for cycle %d: %w", cycle, err)
+		}
+
 		// Update the last synced cycle in the database
 		if err := uc.dbAdapter.SaveLastSyncedRewardCycle(ctx, cycle); err != nil {
 			return fmt.Errorf("error saving last synced reward cycle: %w", err)
@@ -137,6 +159,41 @@ func (uc *SyncRewards) Sync(ctx context.Context) error {
 	return nil
 }
 
+// backfillCycles saves the levels of the synced cycles that are missing them.
+// Errors are only logged, the remaining cycles are retried on the next run.
+func (uc *SyncRewards) backfillCycles(ctx context.Context, lastSyncedCycle int) {
+	missing, err := uc.dbAdapter.GetMissingCycles(ctx, 1, lastSyncedCycle)
+	if err != nil {
+		uc.logger.Warnf("Error getting cycles missing their levels: %v", err)
+		return
+	}
+
+	if len(missing) > 0 {
+		uc.logger.Infof("Backfilling the levels of %d cycles", len(missing))
+	}
+
+	for _, cycle := range missing {
+		if _, err := uc.saveCycle(ctx, cycle); err != nil {
+			uc.logger.Warnf("Error backfilling cycle %d: %v", cycle, err)
+			return
+		}
+	}
+}
+
+// saveCycle fetches the first and last levels of a cycle, saves them to the database and returns them.
+func (uc *SyncRewards) saveCycle(ctx context.Context, cycle int) (model.Cycle, error) {
+	boundaries, err := uc.tzktApiAdapter.FetchCycle(ctx, cycle)
+	if err != nil {
+		return model.Cycle{}, fmt.Errorf("error fetching cycle %d: %w", cycle, err)
+	}
+
+	if err := uc.dbAdapter.SaveCycles(ctx, []model.Cycle{boundaries}); err != nil {
+		return model.Cycle{}, fmt.Errorf("error saving cycle %d: %w", cycle, err)
+	}
+
+	return boundaries, nil
+}
+
 // saveRewardsBatch saves a batch of rewards to the database.
 func (uc *SyncRewards) saveRewardsBatch(ctx context.Context, rewards []model.Reward, cycle int) error {
 	if len(rewards) == 0 {
@@ -156,10 +213,8 @@ func (uc *SyncRewards) saveRewardsBatch(ctx context.Context, rewards []model.Rew
 				cycle, i, end-1, err)
 		}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(50 * time.Millisecond):
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 	}
 
@@ -75,6 +75,58 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(10, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 10).
+						Return([]int{}, nil)
+					return db
+				}(),
+				logger: logrus.NewEntry(logrus.New()),
+				tzktApiAdapter: func() tzktapi.Adapter {
+					tzkt := tzktapimock.New()
+					tzkt.On("GetCurrentCycle", mock.Anything).
+						Return(10, nil)
+					return tzkt
+				}(),
+			},
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name: "nominal case - backfills the missing cycles",
+			fields: fields{
+				dbAdapter: func() database.Adapter {
+					db := databasemock.New()
+					db.On("GetLastSyncedRewardCycle", mock.Anything).
+						Return(10, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 10).
+						Return([]int{3, 4}, nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 3, FirstLevel: 201, LastLevel: 300}}).
+						Return(nil)
+					return db
+				}(),
+				logger: logrus.NewEntry(logrus.New()),
+				tzktApiAdapter: func() tzktapi.Adapter {
+					tzkt := tzktapimock.New()
+					tzkt.On("GetCurrentCycle", mock.Anything).
+						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 3).
+						Return(model.Cycle{Index: 3, FirstLevel: 201, LastLevel: 300}, nil)
+					tzkt.On("FetchCycle", mock.Anything, 4).
+						Return(model.Cycle{}, errors.New("api error"))
+					return tzkt
+				}(),
+			},
+			ctx:     context.Background(),
+			wantErr: false, // A failed backfill is retried on the next run
+		},
+		{
+			name: "nominal case - GetMissingCycles error",
+			fields: fields{
+				dbAdapter: func() database.Adapter {
+					db := databasemock.New()
+					db.On("GetLastSyncedRewardCycle", mock.Anything).
+						Return(10, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 10).
+						Return([]int{}, errors.New("db error"))
 					return db
 				}(),
 				logger: logrus.NewEntry(logrus.New()),
@@ -95,14 +147,20 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.Anything).
 						Return([]model.WalletAddress{"tz1delegator1", "tz1delegator2"}, nil)
-					db.On("GetBakerForDelegatorAtCycle", mock.Anything, model.WalletAddress("tz1delegator1"), 10).
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(0)).
 						Return(model.WalletAddress("tz1baker1"), nil)
-					db.On("GetBakerForDelegatorAtCycle", mock.Anything, model.WalletAddress("tz1delegator2"), 10).
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator2"), int64(0)).
 						Return(model.WalletAddress("tz1baker2"), nil)
 					db.On("SaveRewards", mock.Anything, mock.Anything).
 						Return(nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 10, FirstLevel: 1001, LastLevel: 1100}}).
+						Return(nil)
+					db.On("RefreshRewardStats", mock.Anything, 10, 10).
+						Return(nil)
 					db.On("SaveLastSyncedRewardCycle", mock.Anything, 10).
 						Return(nil)
 					return db
@@ -112,13 +170,15 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					tzkt := tzktapimock.New()
 					tzkt.On("GetCurrentCycle", mock.Anything).
 						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{Index: 10, FirstLevel: 1001, LastLevel: 1100}, nil)
 					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker1"), 10).
 						Return([]model.Reward{
 							{
 								RecipientAddress: "tz1delegator1",
 								SourceAddress:    "tz1baker1",
 								Cycle:            10,
-								Amount:           5.5,
+								Amount:           5500000,
 								Timestamp:        time.Now().Unix(),
 							},
 						}, nil)
@@ -128,7 +188,7 @@ func Test_SyncRewards_Sync(t *testing.T) {
 								RecipientAddress: "tz1delegator2",
 								SourceAddress:    "tz1baker2",
 								Cycle:            10,
-								Amount:           3.3,
+								Amount:           3300000,
 								Timestamp:        time.Now().Unix(),
 							},
 						}, nil)
@@ -138,6 +198,50 @@ func Test_SyncRewards_Sync(t *testing.T) {
 			ctx:     context.Background(),
 			wantErr: false,
 		},
+		{
+			name: "nominal case - past cycle resolved at its last level",
+			fields: fields{
+				dbAdapter: func() database.Adapter {
+					db := databasemock.New()
+					db.On("GetLastSyncedRewardCycle", mock.Anything).
+						Return(8, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 8).
+						Return([]int{}, nil)
+					db.On("GetActiveDelegators", mock.Anything).
+						Return([]model.WalletAddress{"tz1delegator1"}, nil)
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(1000)).
+						Return(model.WalletAddress("tz1baker1"), nil)
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(0)).
+						Return(model.WalletAddress("tz1baker2"), nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 9, FirstLevel: 901, LastLevel: 1000}}).
+						Return(nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 10, FirstLevel: 1001, LastLevel: 1100}}).
+						Return(nil)
+					db.On("RefreshRewardStats", mock.Anything, mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveLastSyncedRewardCycle", mock.Anything, mock.Anything).
+						Return(nil)
+					return db
+				}(),
+				logger: logrus.NewEntry(logrus.New()),
+				tzktApiAdapter: func() tzktapi.Adapter {
+					tzkt := tzktapimock.New()
+					tzkt.On("GetCurrentCycle", mock.Anything).
+						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 9).
+						Return(model.Cycle{Index: 9, FirstLevel: 901, LastLevel: 1000}, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{Index: 10, FirstLevel: 1001, LastLevel: 1100}, nil)
+					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker1"), 9).
+						Return([]model.Reward{}, nil)
+					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker2"), 10).
+						Return([]model.Reward{}, nil)
+					return tzkt
+				}(),
+			},
+			ctx:     context.Background(),
+			wantErr: false,
+		},
 		{
 			name: "error case - nil context",
 			fields: fields{
@@ -145,6 +249,8 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.AnythingOfType("*context.timerCtx")).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.AnythingOfType("*context.timerCtx"), 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.AnythingOfType("*context.timerCtx")).
 						Return([]model.WalletAddress{}, nil)
 					return db
@@ -182,6 +288,8 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(0, errors.New("db error"))
+					db.On("GetActiveDelegators", mock.Anything).
+						Return([]model.WalletAddress{}, nil)
 					return db
 				}(),
 				logger: logrus.NewEntry(logrus.New()),
@@ -202,6 +310,8 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.Anything).
 						Return([]model.WalletAddress{}, errors.New("delegators error"))
 					return db
@@ -224,6 +334,8 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.Anything).
 						Return([]model.WalletAddress{"tz1delegator1"}, nil)
 					return db
@@ -250,9 +362,13 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.Anything).
 						Return([]model.WalletAddress{"tz1delegator1"}, nil)
-					db.On("GetBakerForDelegatorAtCycle", mock.Anything, model.WalletAddress("tz1delegator1"), 10).
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 10, FirstLevel: 1001, LastLevel: 1100}}).
+						Return(nil)
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(0)).
 						Return(model.WalletAddress("tz1baker1"), nil)
 					db.On("SaveRewards", mock.Anything, mock.Anything).
 						Return(errors.New("save error"))
@@ -263,13 +379,15 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					tzkt := tzktapimock.New()
 					tzkt.On("GetCurrentCycle", mock.Anything).
 						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{Index: 10, FirstLevel: 1001, LastLevel: 1100}, nil)
 					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker1"), 10).
 						Return([]model.Reward{
 							{
 								RecipientAddress: "tz1delegator1",
 								SourceAddress:    "tz1baker1",
 								Cycle:            10,
-								Amount:           5.5,
+								Amount:           5500000,
 								Timestamp:        time.Now().Unix(),
 							},
 						}, nil)
@@ -286,12 +404,18 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					db := databasemock.New()
 					db.On("GetLastSyncedRewardCycle", mock.Anything).
 						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
 					db.On("GetActiveDelegators", mock.Anything).
 						Return([]model.WalletAddress{"tz1delegator1"}, nil)
-					db.On("GetBakerForDelegatorAtCycle", mock.Anything, model.WalletAddress("tz1delegator1"), 10).
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(0)).
 						Return(model.WalletAddress("tz1baker1"), nil)
 					db.On("SaveRewards", mock.Anything, mock.Anything).
 						Return(nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 10, FirstLevel: 1001, LastLevel: 1100}}).
+						Return(nil)
+					db.On("RefreshRewardStats", mock.Anything, 10, 10).
+						Return(nil)
 					db.On("SaveLastSyncedRewardCycle", mock.Anything, 10).
 						Return(errors.New("sync save error"))
 					return db
@@ -301,13 +425,59 @@ func Test_SyncRewards_Sync(t *testing.T) {
 					tzkt := tzktapimock.New()
 					tzkt.On("GetCurrentCycle", mock.Anything).
 						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{Index: 10, FirstLevel: 1001, LastLevel: 1100}, nil)
+					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker1"), 10).
+						Return([]model.Reward{
+							{
+								RecipientAddress: "tz1delegator1",
+								SourceAddress:    "tz1baker1",
+								Cycle:            10,
+								Amount:           5500000,
+								Timestamp:        time.Now().Unix(),
+							},
+						}, nil)
+					return tzkt
+				}(),
+			},
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		{
+			name: "error case - RefreshRewardStats error",
+			fields: fields{
+				dbAdapter: func() database.Adapter {
+					db := databasemock.New()
+					db.On("GetLastSyncedRewardCycle", mock.Anything).
+						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
+					db.On("GetActiveDelegators", mock.Anything).
+						Return([]model.WalletAddress{"tz1delegator1"}, nil)
+					db.On("GetBakerForDelegatorAtLevel", mock.Anything, model.WalletAddress("tz1delegator1"), int64(0)).
+						Return(model.WalletAddress("tz1baker1"), nil)
+					db.On("SaveRewards", mock.Anything, mock.Anything).
+						Return(nil)
+					db.On("SaveCycles", mock.Anything, []model.Cycle{{Index: 10, FirstLevel: 1001, LastLevel: 1100}}).
+						Return(nil)
+					db.On("RefreshRewardStats", mock.Anything, 10, 10).
+						Return(errors.New("refresh error"))
+					return db
+				}(),
+				logger: logrus.NewEntry(logrus.New()),
+				tzktApiAdapter: func() tzktapi.Adapter {
+					tzkt := tzktapimock.New()
+					tzkt.On("GetCurrentCycle", mock.Anything).
+						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{Index: 10, FirstLevel: 1001, LastLevel: 1100}, nil)
 					tzkt.On("FetchRewardsForCycle", mock.Anything, model.WalletAddress("tz1delegator1"), model.WalletAddress("tz1baker1"), 10).
 						Return([]model.Reward{
 							{
 								RecipientAddress: "tz1delegator1",
 								SourceAddress:    "tz1baker1",
 								Cycle:            10,
-								Amount:           5.5,
+								Amount:           5500000,
 								Timestamp:        time.Now().Unix(),
 							},
 						}, nil)
@@ -317,6 +487,33 @@ func Test_SyncRewards_Sync(t *testing.T) {
 			ctx:     context.Background(),
 			wantErr: true,
 		},
+		{
+			name: "error case - FetchCycle error",
+			fields: fields{
+				dbAdapter: func() database.Adapter {
+					// The cycle is saved before its bakers are resolved, which a failed fetch stops
+					db := databasemock.New()
+					db.On("GetLastSyncedRewardCycle", mock.Anything).
+						Return(9, nil)
+					db.On("GetMissingCycles", mock.Anything, 1, 9).
+						Return([]int{}, nil)
+					db.On("GetActiveDelegators", mock.Anything).
+						Return([]model.WalletAddress{"tz1delegator1"}, nil)
+					return db
+				}(),
+				logger: logrus.NewEntry(logrus.New()),
+				tzktApiAdapter: func() tzktapi.Adapter {
+					tzkt := tzktapimock.New()
+					tzkt.On("GetCurrentCycle", mock.Anything).
+						Return(10, nil)
+					tzkt.On("FetchCycle", mock.Anything, 10).
+						Return(model.Cycle{}, errors.New("api error"))
+					return tzkt
+				}(),
+			},
+			ctx:     context.Background(),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -329,6 +526,9 @@ func Test_SyncRewards_Sync(t *testing.T) {
 			if err := uc.Sync(tt.ctx); (err != nil) != tt.wantErr {
 				t.Errorf("Sync() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if db, ok := tt.fields.dbAdapter.(*databasemock.Mock); ok {
+				db.AssertExpectations(t)
+			}
 		})
 	}
 }
@@ -384,21 +584,21 @@ func Test_SyncRewards_saveRewardsBatch(t *testing.T) {
 						RecipientAddress: "tz1delegator1",
 						SourceAddress:    "tz1baker1",
 						Cycle:            10,
-						Amount:           5.5,
+						Amount:           5500000,
 						Timestamp:        time.Now().Unix(),
 					},
 					{
 						RecipientAddress: "tz1delegator2",
 						SourceAddress:    "tz1baker2",
 						Cycle:            10,
-						Amount:           3.3,
+						Amount:           3300000,
 						Timestamp:        time.Now().Unix(),
 					},
 					{
 						RecipientAddress: "tz1delegator3",
 						SourceAddress:    "tz1baker3",
 						Cycle:            10,
-						Amount:           7.7,
+						Amount:           7700000,
 						Timestamp:        time.Now().Unix(),
 					},
 				},
@@ -425,7 +625,7 @@ func Test_SyncRewards_saveRewardsBatch(t *testing.T) {
 						RecipientAddress: "tz1delegator1",
 						SourceAddress:    "tz1baker1",
 						Cycle:            10,
-						Amount:           5.5,
+						Amount:           5500000,
 						Timestamp:        time.Now().Unix(),
 					},
 				},
@@ -456,14 +656,14 @@ func Test_SyncRewards_saveRewardsBatch(t *testing.T) {
 						RecipientAddress: "tz1delegator1",
 						SourceAddress:    "tz1baker1",
 						Cycle:            10,
-						Amount:           5.5,
+						Amount:           5500000,
 						Timestamp:        time.Now().Unix(),
 					},
 					{
 						RecipientAddress: "tz1delegator2",
 						SourceAddress:    "tz1baker2",
 						Cycle:            10,
-						Amount:           3.3,
+						Amount:           3300000,
 						Timestamp:        time.Now().Unix(),
 					},
 				},
@@ -495,7 +695,7 @@ func Test_SyncRewards_withMonitorer(t *testing.T) {
 		tzktApiAdapter tzktapi.Adapter
 	}
 	type args struct {
-		syncRewards  model.SyncFunc
+		syncRewards   model.SyncFunc
 		metricsClient metrics.Adapter
 	}
 	tests := []struct {
@@ -561,4 +761,4 @@ func Test_SyncRewards_withMonitorer(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/tezos-delegation-service/internal/adapter/database"
+	"github.com/tezos-delegation-service/internal/adapter/metrics"
+	"github.com/tezos-delegation-service/internal/model"
+)
+
+const (
+	// webhookSecretPrefix prefixes the signing secrets, so that they are recognized when leaked.
+	webhookSecretPrefix = "whsec_"
+
+	// maxWebhookDeliveriesLimit is the maximum number of deliveries listed per webhook.
+	maxWebhookDeliveriesLimit = 100
+)
+
+// nonPublicNetworks are the reserved ranges which net.IP does not classify, webhooks must not reach them.
+var nonPublicNetworks = func() []*net.IPNet {
+	cidrs := []string{
+		"0.0.0.0/8",       // "this" network
+		"100.64.0.0/10",   // carrier-grade NAT
+		"192.0.0.0/24",    // IETF protocol assignments
+		"192.0.2.0/24",    // TEST-NET-1
+		"198.18.0.0/15",   // benchmarking
+		"198.51.100.0/24", // TEST-NET-2
+		"203.0.113.0/24",  // TEST-NET-3
+		"240.0.0.0/4",     // reserved, broadcast included
+		"64:ff9b::/96",    // NAT64, embedding any IPv4
+		"2001:db8::/32",   // documentation
+	}
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// manageWebhooks handles business logic for the webhook subscriptions.
+type manageWebhooks struct {
+	dbAdapter database.Adapter
+	// lookupIP resolves the host of the webhook URLs.
+	lookupIP func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// newManageWebhooks creates a manageWebhooks resolving hosts with the default resolver.
+func newManageWebhooks(adapter database.Adapter) *manageWebhooks {
+	return &manageWebhooks{
+		dbAdapter: adapter,
+		lookupIP: func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		},
+	}
+}
+
+// CreateWebhookInput defines the input structure for creating a webhook.
+type CreateWebhookInput struct {
+	// APIKeyID is the API key creating the webhook, the only one managing it afterwards.
+	APIKeyID    int64
+	URL         string
+	EventTypes  []model.WebhookEventType
+	Baker       model.WalletAddress
+	Delegator   model.WalletAddress
+	Description string
+}
+
+// CreateWebhookFunc defines the function signature for creating a webhook.
+type CreateWebhookFunc func(ctx context.Context, input CreateWebhookInput) (*model.CreatedWebhook, error)
+
+// GetWebhooksFunc defines the function signature for listing the webhooks of an API key.
+type GetWebhooksFunc func(ctx context.Context, apiKeyID int64) (*model.WebhooksResponse, error)
+
+// DeleteWebhookFunc defines the function signature for deleting a webhook of an API key.
+type DeleteWebhookFunc func(ctx context.Context, apiKeyID, id int64) error
+
+// EnableWebhookFunc defines the function signature for re-enabling a webhook of an API key.
+type EnableWebhookFunc func(ctx context.Context, apiKeyID, id int64) (*model.Webhook, error)
+
+// GetWebhookDeliveriesFunc defines the function signature for listing the last deliveries of a webhook of an API key.
+type GetWebhookDeliveriesFunc func(ctx context.Context, apiKeyID, id int64, limit uint16) (*model.WebhookDeliveriesResponse, error)
+
+// NewCreateWebhookFunc creates a new instance of manageWebhooks creating webhooks.
+func NewCreateWebhookFunc(adapter database.Adapter, metricsClient metrics.Adapter) CreateWebhookFunc {
+	uc := newManageWebhooks(adapter)
+	return func(ctx context.Context, input CreateWebhookInput) (result *model.CreatedWebhook, err error) {
+		defer uc.monitor("CreateWebhook", time.Now(), metricsClient, &err)
+		return uc.CreateWebhook(ctx, input)
+	}
+}
+
+// NewGetWebhooksFunc creates a new instance of manageWebhooks listing webhooks.
+func NewGetWebhooksFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetWebhooksFunc {
+	uc := newManageWebhooks(adapter)
+	return func(ctx context.Context, apiKeyID int64) (result *model.WebhooksResponse, err error) {
+		defer uc.monitor("GetWebhooks", time.Now(), metricsClient, &err)
+		return uc.GetWebhooks(ctx, apiKeyID)
+	}
+}
+
+// NewDeleteWebhookFunc creates a new instance of manageWebhooks deleting webhooks.
+func NewDeleteWebhookFunc(adapter database.Adapter, metricsClient metrics.Adapter) DeleteWebhookFunc {
+	uc := newManageWebhooks(adapter)
+	return func(ctx context.Context, apiKeyID, id int64) (err error) {
+		defer uc.monitor("DeleteWebhook", time.Now(), metricsClient, &err)
+		return uc.DeleteWebhook(ctx, apiKeyID, id)
+	}
+}
+
+// NewEnableWebhookFunc creates a new instance of manageWebhooks re-enabling webhooks.
+func NewEnableWebhookFunc(adapter database.Adapter, metricsClient metrics.Adapter) EnableWebhookFunc {
+	uc := newManageWebhooks(adapter)
+	return func(ctx context.Context, apiKeyID, id int64) (result *model.Webhook, err error) {
+		defer uc.monitor("EnableWebhook", time.Now(), metricsClient, &err)
+		return uc.EnableWebhook(ctx, apiKeyID, id)
+	}
+}
+
+// NewGetWebhookDeliveriesFunc creates a new instance of manageWebhooks listing the deliveries of webhooks.
+func NewGetWebhookDeliveriesFunc(adapter database.Adapter, metricsClient metrics.Adapter) GetWebhookDeliveriesFunc {
+	uc := newManageWebhooks(adapter)
+	return func(ctx context.Context, apiKeyID, id int64, limit uint16) (result *model.WebhookDeliveriesResponse, err error) {
+		defer uc.monitor("GetWebhookDeliveries", time.Now(), metricsClient, &err)
+		return uc.GetWebhookDeliveries(ctx, apiKeyID, id, limit)
+	}
+}
+
+// CreateWebhook saves a webhook with a new signing secret, returned once.
+func (uc *manageWebhooks) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*model.CreatedWebhook, error) {
+	endpoint, err := url.Parse(input.URL)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if endpoint.User != nil {
+		return nil, fmt.Errorf("%w: url must not contain credentials", ErrInvalidWebhook)
+	}
+	if err := uc.checkPublicHost(ctx, endpoint.Hostname()); err != nil {
+		return nil, err
+	}
+
+	// Event types are deduplicated, the order of the request is kept.
+	eventTypes := make([]model.WebhookEventType, 0, len(input.EventTypes))
+	seen := make(map[model.WebhookEventType]bool, len(input.EventTypes))
+	for _, t := range input.EventTypes {
+		if !t.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event type %s", ErrInvalidWebhook, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			eventTypes = append(eventTypes, t)
+		}
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := model.Webhook{
+		APIKeyID:    input.APIKeyID,
+		URL:         endpoint.String(),
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Baker:       input.Baker,
+		Delegator:   input.Delegator,
+		Description: input.Description,
+	}
+	if err := uc.dbAdapter.SaveWebhook(ctx, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &model.CreatedWebhook{Webhook: webhook, Secret: secret}, nil
+}
+
+// GetWebhooks returns the webhooks of an API key, disabled ones included, without their secrets.
+func (uc *manageWebhooks) GetWebhooks(ctx context.Context, apiKeyID int64) (*model.WebhooksResponse, error) {
+	webhooks, err := uc.dbAdapter.GetWebhooks(ctx, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []model.Webhook{}
+	}
+	return &model.WebhooksResponse{Webhooks: webhooks}, nil
+}
+
+// DeleteWebhook deletes a webhook of an API key, its pending deliveries are dropped.
+func (uc *manageWebhooks) DeleteWebhook(ctx context.Context, apiKeyID, id int64) error {
+	if _, err := uc.ownedWebhook(ctx, apiKeyID, id); err != nil {
+		return err
+	}
+	err := uc.dbAdapter.DeleteWebhook(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+	}
+	return err
+}
+
+// EnableWebhook resumes the deliveries to a webhook of an API key, disabled after too many failures, and resets its
+// consecutive failures. The deliveries still pending are sent again by the next delivery run.
+func (uc *manageWebhooks) EnableWebhook(ctx context.Context, apiKeyID, id int64) (*model.Webhook, error) {
+	if _, err := uc.ownedWebhook(ctx, apiKeyID, id); err != nil {
+		return nil, err
+	}
+	err := uc.dbAdapter.EnableWebhook(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+	} else if err != nil {
+		return nil, err
+	}
+	return uc.ownedWebhook(ctx, apiKeyID, id)
+}
+
+// GetWebhookDeliveries returns the last deliveries of a webhook of an API key with their attempts, latest first.
+func (uc *manageWebhooks) GetWebhookDeliveries(ctx context.Context, apiKeyID, id int64, limit uint16) (*model.WebhookDeliveriesResponse, error) {
+	if _, err := uc.ownedWebhook(ctx, apiKeyID, id); err != nil {
+		return nil, err
+	}
+
+	if limit == 0 || limit > maxWebhookDeliveriesLimit {
+		limit = maxWebhookDeliveriesLimit
+	}
+	deliveries, err := uc.dbAdapter.GetWebhookDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+	return &model.WebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+// ownedWebhook returns a webhook of an API key, the webhooks of other keys are reported as not found.
+func (uc *manageWebhooks) ownedWebhook(ctx context.Context, apiKeyID, id int64) (*model.Webhook, error) {
+	webhook, err := uc.dbAdapter.GetWebhook(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && webhook.APIKeyID != apiKeyID) {
+		return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+	}
+	return webhook, err
+}
+
+// checkPublicHost rejects the hosts which are, or resolve to, a non public address: loopback, private, link-local
+// (the cloud metadata endpoints included) or reserved. The delivery client checks the address again when dialing,
+// as the host may resolve differently by then.
+func (uc *manageWebhooks) checkPublicHost(ctx context.Context, host string) error {
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		if ips, err = uc.lookupIP(ctx, host); err != nil || len(ips) == 0 {
+			return fmt.Errorf("%w: url host %s does not resolve", ErrInvalidWebhook, host)
+		}
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("%w: url host %s is not a public address", ErrInvalidWebhook, host)
+		}
+	}
+	return nil
+}
+
+// isPublicIP reports whether ip is a public unicast address.
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// publicDialControl is a net.Dialer Control refusing the connections to non public addresses, checked after the
+// resolution so that a host rebinding to a private address after the webhook creation is not reached.
+func publicDialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("webhook endpoint %s is not a public address", host)
+	}
+	return nil
+}
+
+// newWebhookSecret returns a random signing secret.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// monitor records the telemetry of operation started at startTime.
+func (uc *manageWebhooks) monitor(operation string, startTime time.Time, metricsClient metrics.Adapter, err *error) {
+	if metricsClient != nil {
+		metricsClient.RecordServiceOperation(operation, "UseCase", time.Since(startTime), *err)
+	}
+}
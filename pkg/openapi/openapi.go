@@ -0,0 +1,338 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is an OpenAPI 3.0 document, reduced to what the validation of the requests and responses uses.
+type Spec struct {
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components Components           `yaml:"components"`
+
+	patterns sync.Map // compiled schema patterns by expression
+}
+
+// PathItem holds the operations of a path.
+type PathItem struct {
+	Get     *Operation `yaml:"get"`
+	Post    *Operation `yaml:"post"`
+	Put     *Operation `yaml:"put"`
+	Patch   *Operation `yaml:"patch"`
+	Delete  *Operation `yaml:"delete"`
+	Head    *Operation `yaml:"head"`
+	Options *Operation `yaml:"options"`
+}
+
+// Operation describes the parameters, body and responses of a method on a path.
+type Operation struct {
+	OperationID string               `yaml:"operationId"`
+	Parameters  []*Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody         `yaml:"requestBody"`
+	Responses   map[string]*Response `yaml:"responses"`
+}
+
+// Parameter describes a query, path, header or cookie parameter.
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// RequestBody describes the body of a request by media type.
+type RequestBody struct {
+	Required bool                  `yaml:"required"`
+	Content  map[string]*MediaType `yaml:"content"`
+}
+
+// Response describes a response by media type.
+type Response struct {
+	Ref     string                `yaml:"$ref"`
+	Content map[string]*MediaType `yaml:"content"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Components holds the definitions referenced by $ref.
+type Components struct {
+	Schemas    map[string]*Schema    `yaml:"schemas"`
+	Parameters map[string]*Parameter `yaml:"parameters"`
+	Responses  map[string]*Response  `yaml:"responses"`
+}
+
+// Load parses an OpenAPI document and resolves the references of its parameters and responses.
+func Load(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse OpenAPI document: %w", err)
+	}
+
+	for path, item := range spec.Paths {
+		for method, op := range item.operations() {
+			for i, param := range op.Parameters {
+				resolved, err := spec.resolveParameter(param)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+				op.Parameters[i] = resolved
+			}
+			for status, response := range op.Responses {
+				resolved, err := spec.resolveResponse(response)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+				op.Responses[status] = resolved
+			}
+		}
+	}
+	return &spec, nil
+}
+
+// MustLoad is like Load but panics when the document is invalid, for the documents embedded in the binaries.
+func MustLoad(data []byte) *Spec {
+	spec, err := Load(data)
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+// Operation returns the operation of a method on a path template, such as /bakers/{address}.
+func (s *Spec) Operation(method, path string) (*Operation, bool) {
+	item, ok := s.Paths[path]
+	if !ok {
+		return nil, false
+	}
+	op, ok := item.operations()[strings.ToUpper(method)]
+	return op, ok
+}
+
+// Routes returns the method and path template of every operation, sorted.
+func (s *Spec) Routes() []string {
+	var routes []string
+	for path, item := range s.Paths {
+		for method := range item.operations() {
+			routes = append(routes, method+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// ValidateRequest checks the query and path parameters, and the JSON body, of a request to an operation. The body is
+// read and restored for the handler. Unknown query parameters are refused, header and cookie parameters are not
+// checked.
+func (s *Spec) ValidateRequest(op *Operation, r *http.Request, pathParams map[string]string) error {
+	var errs []error
+
+	query := r.URL.Query()
+	known := make(map[string]bool)
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "query":
+			known[param.Name] = true
+			values, ok := query[param.Name]
+			if !ok {
+				if param.Required {
+					errs = append(errs, fmt.Errorf("query parameter '%s' is required", param.Name))
+				}
+				continue
+			}
+			for _, value := range values {
+				if err := s.validateParameter(param, value); err != nil {
+					errs = append(errs, fmt.Errorf("query parameter '%s': %w", param.Name, err))
+				}
+			}
+		case "path":
+			if err := s.validateParameter(param, pathParams[param.Name]); err != nil {
+				errs = append(errs, fmt.Errorf("path parameter '%s': %w", param.Name, err))
+			}
+		}
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("unknown query parameter '%s'", name))
+	}
+
+	if op.RequestBody != nil && r.Body != nil {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("read request body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err := s.validateRequestBody(op.RequestBody, r.Header.Get("Content-Type"), body); err != nil {
+			errs = append(errs, fmt.Errorf("request body: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateResponse checks that a response of an operation has a documented status and media type, and that a JSON
+// body matches its schema. An empty body is not checked, as answered to HEAD requests or with 204 and 304.
+func (s *Spec) ValidateResponse(op *Operation, status int, contentType string, body []byte) error {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = op.Responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("undocumented status %d", status)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	mediaType, schema, err := matchContent(response.Content, contentType)
+	if err != nil {
+		return fmt.Errorf("status %d: %w", status, err)
+	}
+	if err := s.validateBody(mediaType, schema, body); err != nil {
+		return fmt.Errorf("status %d: %w", status, err)
+	}
+	return nil
+}
+
+// validateParameter converts the value of a parameter to the type of its schema and checks it.
+func (s *Spec) validateParameter(param *Parameter, value string) error {
+	if param.Schema == nil {
+		return nil
+	}
+
+	var v any = value
+	switch s.resolveSchema(param.Schema).Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		v = json.Number(value)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		v = b
+	}
+	return s.validateSchema(param.Schema, v, "")
+}
+
+// validateRequestBody checks the body of a request against the documented media types.
+func (s *Spec) validateRequestBody(requestBody *RequestBody, contentType string, body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if requestBody.Required {
+			return errors.New("is required")
+		}
+		return nil
+	}
+
+	mediaType, schema, err := matchContent(requestBody.Content, contentType)
+	if err != nil {
+		return err
+	}
+	return s.validateBody(mediaType, schema, body)
+}
+
+// validateBody decodes a JSON body and checks it against its schema, the other media types are not checked.
+func (s *Spec) validateBody(mediaType string, schema *Schema, body []byte) error {
+	if schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return s.validateSchema(schema, value, "")
+}
+
+// matchContent returns the documented media type of a Content-Type, and its schema.
+func matchContent(content map[string]*MediaType, contentType string) (string, *Schema, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid content type %q", contentType)
+	}
+
+	for _, candidate := range []string{mediaType, strings.Split(mediaType, "/")[0] + "/*", "*/*"} {
+		if m, ok := content[candidate]; ok {
+			if m == nil {
+				return mediaType, nil, nil
+			}
+			return mediaType, m.Schema, nil
+		}
+	}
+	return "", nil, fmt.Errorf("undocumented content type %s", mediaType)
+}
+
+// isJSON reports whether a media type is JSON, such as application/json or application/problem+json.
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// operations returns the operations of a path by method.
+func (p *PathItem) operations() map[string]*Operation {
+	operations := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{
+		http.MethodGet:     p.Get,
+		http.MethodPost:    p.Post,
+		http.MethodPut:     p.Put,
+		http.MethodPatch:   p.Patch,
+		http.MethodDelete:  p.Delete,
+		http.MethodHead:    p.Head,
+		http.MethodOptions: p.Options,
+	} {
+		if op != nil {
+			operations[method] = op
+		}
+	}
+	return operations
+}
+
+// resolveParameter returns the parameter a $ref points to.
+func (s *Spec) resolveParameter(param *Parameter) (*Parameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	resolved, ok := s.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+	if !ok {
+		return nil, fmt.Errorf("unknown parameter %s", param.Ref)
+	}
+	return resolved, nil
+}
+
+// resolveResponse returns the response a $ref points to.
+func (s *Spec) resolveResponse(response *Response) (*Response, error) {
+	if response == nil || response.Ref == "" {
+		return response, nil
+	}
+	resolved, ok := s.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+	if !ok {
+		return nil, fmt.Errorf("unknown response %s", response.Ref)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,339 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+paths:
+  /bakers:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - name: active
+          in: query
+          schema:
+            type: boolean
+        - name: sort
+          in: query
+          required: true
+          schema:
+            type: string
+            enum: [amount, delegators]
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Baker'
+            text/csv:
+              schema:
+                type: string
+        '304':
+          description: Not modified
+        '4XX':
+          $ref: '#/components/responses/Error'
+  /bakers/{address}:
+    get:
+      parameters:
+        - name: address
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: '^tz1'
+      responses:
+        default:
+          $ref: '#/components/responses/Error'
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Baker'
+      responses:
+        '201':
+          description: Created
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        minimum: 1
+        maximum: 100
+  responses:
+    Error:
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            required: [error]
+            properties:
+              error:
+                type: string
+  schemas:
+    Baker:
+      type: object
+      required: [address]
+      additionalProperties: false
+      properties:
+        address:
+          type: string
+        delegators:
+          type: integer
+`
+
+func Test_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name: "Nominal case",
+			data: testSpec,
+		},
+		{
+			name:        "Error case - invalid YAML",
+			data:        "paths: [",
+			expectedErr: "parse OpenAPI document",
+		},
+		{
+			name:        "Error case - unknown parameter",
+			data:        "paths:\n  /a:\n    get:\n      parameters:\n        - $ref: '#/components/parameters/Page'\n",
+			expectedErr: "GET /a: unknown parameter #/components/parameters/Page",
+		},
+		{
+			name:        "Error case - unknown response",
+			data:        "paths:\n  /a:\n    get:\n      responses:\n        '200':\n          $ref: '#/components/responses/OK'\n",
+			expectedErr: "GET /a: unknown response #/components/responses/OK",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := Load([]byte(tt.data))
+			if tt.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.expectedErr)
+				}
+				assert.Nil(t, spec)
+				return
+			}
+			if assert.NoError(t, err) {
+				op, ok := spec.Operation("get", "/bakers")
+				if assert.True(t, ok) {
+					assert.Equal(t, "limit", op.Parameters[0].Name)
+					assert.NotNil(t, op.Responses["4XX"].Content["application/problem+json"])
+				}
+			}
+		})
+	}
+}
+
+func Test_MustLoad(t *testing.T) {
+	assert.Panics(t, func() { MustLoad([]byte("paths: [")) })
+	assert.NotPanics(t, func() { MustLoad([]byte(testSpec)) })
+}
+
+func Test_Spec_Operation(t *testing.T) {
+	spec := MustLoad([]byte(testSpec))
+
+	_, ok := spec.Operation("POST", "/bakers/{address}")
+	assert.True(t, ok)
+	_, ok = spec.Operation("DELETE", "/bakers/{address}")
+	assert.False(t, ok)
+	_, ok = spec.Operation("GET", "/bakers/:address")
+	assert.False(t, ok)
+}
+
+func Test_Spec_Routes(t *testing.T) {
+	spec := MustLoad([]byte(testSpec))
+
+	assert.Equal(t, []string{"GET /bakers", "GET /bakers/{address}", "POST /bakers/{address}"}, spec.Routes())
+}
+
+func Test_Spec_ValidateRequest(t *testing.T) {
+	spec := MustLoad([]byte(testSpec))
+
+	type args struct {
+		method      string
+		path        string
+		url         string
+		pathParams  map[string]string
+		contentType string
+		body        string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectedErr string
+	}{
+		{
+			name: "Nominal case",
+			args: args{method: "GET", path: "/bakers", url: "/bakers?sort=amount&limit=10&active=true"},
+		},
+		{
+			name: "Nominal case - path parameter",
+			args: args{method: "GET", path: "/bakers/{address}", url: "/bakers/tz1a", pathParams: map[string]string{"address": "tz1a"}},
+		},
+		{
+			name: "Nominal case - body",
+			args: args{method: "POST", path: "/bakers/{address}", url: "/bakers/tz1a", contentType: "application/json", body: `{"address":"tz1a","delegators":3}`},
+		},
+		{
+			name:        "Error case - missing required parameter",
+			args:        args{method: "GET", path: "/bakers", url: "/bakers?limit=10"},
+			expectedErr: "query parameter 'sort' is required",
+		},
+		{
+			name:        "Error case - invalid parameters",
+			args:        args{method: "GET", path: "/bakers", url: "/bakers?sort=name&limit=0&active=maybe"},
+			expectedErr: "query parameter 'limit': 0 is less than the minimum 1\nquery parameter 'active': \"maybe\" is not a boolean\nquery parameter 'sort': name is not one of [amount delegators]",
+		},
+		{
+			name:        "Error case - not a number",
+			args:        args{method: "GET", path: "/bakers", url: "/bakers?sort=amount&limit=ten"},
+			expectedErr: "query parameter 'limit': \"ten\" is not a number",
+		},
+		{
+			name:        "Error case - unknown parameters",
+			args:        args{method: "GET", path: "/bakers", url: "/bakers?sort=amount&page=2&cursor=a"},
+			expectedErr: "unknown query parameter 'cursor'\nunknown query parameter 'page'",
+		},
+		{
+			name:        "Error case - invalid path parameter",
+			args:        args{method: "GET", path: "/bakers/{address}", url: "/bakers/KT1a", pathParams: map[string]string{"address": "KT1a"}},
+			expectedErr: "path parameter 'address': \"KT1a\" does not match ^tz1",
+		},
+		{
+			name:        "Error case - missing body",
+			args:        args{method: "POST", path: "/bakers/{address}", url: "/bakers/tz1a", contentType: "application/json"},
+			expectedErr: "request body: is required",
+		},
+		{
+			name:        "Error case - invalid body",
+			args:        args{method: "POST", path: "/bakers/{address}", url: "/bakers/tz1a", contentType: "application/json", body: `{"address":"tz1a","fee":1}`},
+			expectedErr: "request body: unknown property 'fee'",
+		},
+		{
+			name:        "Error case - undocumented content type",
+			args:        args{method: "POST", path: "/bakers/{address}", url: "/bakers/tz1a", contentType: "text/plain", body: "tz1a"},
+			expectedErr: "request body: undocumented content type text/plain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := spec.Operation(tt.args.method, tt.args.path)
+			if !ok {
+				t.Fatalf("operation %s %s not found", tt.args.method, tt.args.path)
+			}
+			req, _ := http.NewRequest(tt.args.method, tt.args.url, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", tt.args.contentType)
+
+			err := spec.ValidateRequest(op, req, tt.args.pathParams)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_Spec_ValidateRequest_restoresBody(t *testing.T) {
+	spec := MustLoad([]byte(testSpec))
+	op, _ := spec.Operation("POST", "/bakers/{address}")
+	req, _ := http.NewRequest("POST", "/bakers/tz1a", strings.NewReader(`{"address":"tz1a"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	assert.NoError(t, spec.ValidateRequest(op, req, nil))
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address":"tz1a"}`, string(body))
+}
+
+func Test_Spec_ValidateResponse(t *testing.T) {
+	spec := MustLoad([]byte(testSpec))
+
+	type args struct {
+		method      string
+		path        string
+		status      int
+		contentType string
+		body        string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		expectedErr string
+	}{
+		{
+			name: "Nominal case",
+			args: args{method: "GET", path: "/bakers", status: 200, contentType: "application/json; charset=utf-8", body: `{"address":"tz1a","delegators":3}`},
+		},
+		{
+			name: "Nominal case - not JSON",
+			args: args{method: "GET", path: "/bakers", status: 200, contentType: "text/csv", body: "address\ntz1a\n"},
+		},
+		{
+			name: "Nominal case - empty body",
+			args: args{method: "GET", path: "/bakers", status: 304},
+		},
+		{
+			name: "Nominal case - status range",
+			args: args{method: "GET", path: "/bakers", status: 404, contentType: "application/problem+json", body: `{"error":"not found"}`},
+		},
+		{
+			name: "Nominal case - default response",
+			args: args{method: "GET", path: "/bakers/{address}", status: 500, contentType: "application/problem+json", body: `{"error":"boom"}`},
+		},
+		{
+			name:        "Error case - undocumented status",
+			args:        args{method: "GET", path: "/bakers", status: 500, contentType: "application/json", body: `{}`},
+			expectedErr: "undocumented status 500",
+		},
+		{
+			name:        "Error case - undocumented content type",
+			args:        args{method: "GET", path: "/bakers", status: 200, contentType: "text/html", body: "<p>tz1a</p>"},
+			expectedErr: "status 200: undocumented content type text/html",
+		},
+		{
+			name:        "Error case - invalid content type",
+			args:        args{method: "GET", path: "/bakers", status: 200, contentType: ";", body: `{}`},
+			expectedErr: "status 200: invalid content type \";\"",
+		},
+		{
+			name:        "Error case - invalid JSON",
+			args:        args{method: "GET", path: "/bakers", status: 200, contentType: "application/json", body: `{"address":`},
+			expectedErr: "status 200: invalid JSON: unexpected EOF",
+		},
+		{
+			name:        "Error case - body mismatch",
+			args:        args{method: "GET", path: "/bakers", status: 200, contentType: "application/json", body: `{"address":"tz1a","delegators":1.5}`},
+			expectedErr: "status 200: delegators: 1.5 is not an integer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := spec.Operation(tt.args.method, tt.args.path)
+			if !ok {
+				t.Fatalf("operation %s %s not found", tt.args.method, tt.args.path)
+			}
+
+			err := spec.ValidateResponse(op, tt.args.status, tt.args.contentType, []byte(tt.args.body))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
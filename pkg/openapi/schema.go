@@ -0,0 +1,248 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is an OpenAPI 3.0 schema object, reduced to the keywords checked by the validation.
+type Schema struct {
+	Ref                  string                `yaml:"$ref"`
+	Type                 string                `yaml:"type"`
+	Format               string                `yaml:"format"`
+	Nullable             bool                  `yaml:"nullable"`
+	Enum                 []any                 `yaml:"enum"`
+	Pattern              string                `yaml:"pattern"`
+	Minimum              *float64              `yaml:"minimum"`
+	Maximum              *float64              `yaml:"maximum"`
+	MinLength            *int                  `yaml:"minLength"`
+	MaxLength            *int                  `yaml:"maxLength"`
+	Items                *Schema               `yaml:"items"`
+	Required             []string              `yaml:"required"`
+	Properties           map[string]*Schema    `yaml:"properties"`
+	AdditionalProperties *AdditionalProperties `yaml:"additionalProperties"`
+	AllOf                []*Schema             `yaml:"allOf"`
+}
+
+// AdditionalProperties is the additionalProperties keyword: false forbids the undeclared properties, a schema checks
+// them.
+type AdditionalProperties struct {
+	Forbidden bool
+	Schema    *Schema
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (a *AdditionalProperties) UnmarshalYAML(value *yaml.Node) error {
+	if value.Tag == "!!bool" {
+		var allowed bool
+		if err := value.Decode(&allowed); err != nil {
+			return err
+		}
+		a.Forbidden = !allowed
+		return nil
+	}
+	return value.Decode(&a.Schema)
+}
+
+// resolveSchema returns the schema a $ref points to, the schema itself without $ref. Unknown references resolve to
+// an empty schema, which accepts any value.
+func (s *Spec) resolveSchema(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		resolved, ok := s.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			return &Schema{}
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+// validateSchema checks a value decoded from JSON, with numbers as json.Number, against a schema. path locates the
+// value in the body for the error messages.
+func (s *Spec) validateSchema(schema *Schema, value any, path string) error {
+	schema = s.resolveSchema(schema)
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return fmt.Errorf("%sis null", at(path))
+	}
+
+	for _, sub := range schema.AllOf {
+		if err := s.validateSchema(sub, value, path); err != nil {
+			return err
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return fmt.Errorf("%s%v is not one of %v", at(path), value, schema.Enum)
+	}
+
+	switch schema.Type {
+	case "string":
+		return s.validateString(schema, value, path)
+	case "integer", "number":
+		return validateNumber(schema, value, path)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%sis not a boolean", at(path))
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%sis not an array", at(path))
+		}
+		if schema.Items != nil {
+			for i, item := range items {
+				if err := s.validateSchema(schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%sis not an object", at(path))
+		}
+		return s.validateObject(schema, object, path)
+	}
+	return nil
+}
+
+// validateString checks the length, pattern and format of a string.
+func (s *Spec) validateString(schema *Schema, value any, path string) error {
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%sis not a string", at(path))
+	}
+
+	length := utf8.RuneCountInString(str)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return fmt.Errorf("%s%q is shorter than %d characters", at(path), str, *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return fmt.Errorf("%s%q is longer than %d characters", at(path), str, *schema.MaxLength)
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := s.pattern(schema.Pattern)
+		if err != nil {
+			return err
+		}
+		if !pattern.MatchString(str) {
+			return fmt.Errorf("%s%q does not match %s", at(path), str, schema.Pattern)
+		}
+	}
+
+	switch schema.Format {
+	case "date":
+		if _, err := time.Parse("2006-01-02", str); err != nil {
+			return fmt.Errorf("%s%q is not a date", at(path), str)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return fmt.Errorf("%s%q is not a date-time", at(path), str)
+		}
+	}
+	return nil
+}
+
+// validateNumber checks the type and bounds of a number.
+func validateNumber(schema *Schema, value any, path string) error {
+	number, ok := value.(json.Number)
+	if !ok {
+		return fmt.Errorf("%sis not a number", at(path))
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return fmt.Errorf("%s%s is not a number", at(path), number)
+	}
+	if schema.Type == "integer" {
+		if _, err := number.Int64(); err != nil {
+			return fmt.Errorf("%s%s is not an integer", at(path), number)
+		}
+	}
+	if schema.Minimum != nil && f < *schema.Minimum {
+		return fmt.Errorf("%s%s is less than the minimum %v", at(path), number, *schema.Minimum)
+	}
+	if schema.Maximum != nil && f > *schema.Maximum {
+		return fmt.Errorf("%s%s is greater than the maximum %v", at(path), number, *schema.Maximum)
+	}
+	return nil
+}
+
+// validateObject checks the required, declared and additional properties of an object.
+func (s *Spec) validateObject(schema *Schema, object map[string]any, path string) error {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%smissing property '%s'", at(path), name)
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, declared := schema.Properties[name]
+		switch {
+		case declared:
+		case schema.AdditionalProperties == nil:
+			continue
+		case schema.AdditionalProperties.Forbidden:
+			return fmt.Errorf("%sunknown property '%s'", at(path), name)
+		case schema.AdditionalProperties.Schema != nil:
+			property = schema.AdditionalProperties.Schema
+		default:
+			continue
+		}
+		if err := s.validateSchema(property, object[name], path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pattern returns the compiled expression of a pattern keyword.
+func (s *Spec) pattern(expr string) (*regexp.Regexp, error) {
+	if compiled, ok := s.patterns.Load(expr); ok {
+		return compiled.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.New("invalid pattern " + expr)
+	}
+	s.patterns.Store(expr, compiled)
+	return compiled, nil
+}
+
+// inEnum reports whether a value is one of the values of an enum keyword, compared by their text.
+func inEnum(enum []any, value any) bool {
+	text := fmt.Sprint(value)
+	for _, v := range enum {
+		if fmt.Sprint(v) == text {
+			return true
+		}
+	}
+	return false
+}
+
+// at prefixes a message with the location of a value in a body.
+func at(path string) string {
+	if path == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path, ".") + ": "
+}
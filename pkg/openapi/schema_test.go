@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchemas = `
+components:
+  schemas:
+    Address:
+      type: string
+      pattern: '^(tz1|KT1)[1-9A-HJ-NP-Za-km-z]{3}$'
+    Delegation:
+      type: object
+      required: [delegator, amount]
+      properties:
+        delegator:
+          $ref: '#/components/schemas/Address'
+        amount:
+          type: integer
+          minimum: 0
+        date:
+          type: string
+          format: date
+        timestamp:
+          type: string
+          format: date-time
+        alias:
+          type: string
+          nullable: true
+          minLength: 1
+          maxLength: 5
+        tags:
+          type: array
+          items:
+            type: string
+        active:
+          type: boolean
+        counts:
+          type: object
+          additionalProperties:
+            type: integer
+    Page:
+      allOf:
+        - $ref: '#/components/schemas/Delegation'
+        - type: object
+          required: [page]
+          properties:
+            page:
+              type: number
+              maximum: 10
+    Unknown:
+      $ref: '#/components/schemas/Missing'
+    Invalid:
+      type: string
+      pattern: '('
+`
+
+func Test_Spec_validateSchema(t *testing.T) {
+	spec := MustLoad([]byte(testSchemas))
+
+	tests := []struct {
+		name        string
+		schema      string
+		value       string
+		expectedErr string
+	}{
+		{
+			name:   "Nominal case",
+			schema: "Delegation",
+			value:  `{"delegator":"tz1abc","amount":10,"date":"2024-03-01","timestamp":"2024-03-01T12:00:00Z","alias":null,"tags":["a"],"active":true,"counts":{"stake":2},"other":1}`,
+		},
+		{
+			name:   "Nominal case - allOf",
+			schema: "Page",
+			value:  `{"delegator":"KT1abc","amount":0,"page":2.5}`,
+		},
+		{
+			name:   "Nominal case - unknown reference",
+			schema: "Unknown",
+			value:  `[1, "a"]`,
+		},
+		{
+			name:        "Error case - missing property",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc"}`,
+			expectedErr: "missing property 'amount'",
+		},
+		{
+			name:        "Error case - null",
+			schema:      "Delegation",
+			value:       `null`,
+			expectedErr: "is null",
+		},
+		{
+			name:        "Error case - not an object",
+			schema:      "Delegation",
+			value:       `"tz1abc"`,
+			expectedErr: "is not an object",
+		},
+		{
+			name:        "Error case - pattern",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz2abc","amount":1}`,
+			expectedErr: `delegator: "tz2abc" does not match ^(tz1|KT1)[1-9A-HJ-NP-Za-km-z]{3}$`,
+		},
+		{
+			name:        "Error case - minimum",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":-1}`,
+			expectedErr: "amount: -1 is less than the minimum 0",
+		},
+		{
+			name:        "Error case - not a number",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":"1"}`,
+			expectedErr: "amount: is not a number",
+		},
+		{
+			name:        "Error case - date",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"date":"01/03/2024"}`,
+			expectedErr: `date: "01/03/2024" is not a date`,
+		},
+		{
+			name:        "Error case - date-time",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"timestamp":"2024-03-01"}`,
+			expectedErr: `timestamp: "2024-03-01" is not a date-time`,
+		},
+		{
+			name:        "Error case - shorter than minLength",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"alias":""}`,
+			expectedErr: `alias: "" is shorter than 1 characters`,
+		},
+		{
+			name:        "Error case - longer than maxLength",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"alias":"Baker Ltd"}`,
+			expectedErr: `alias: "Baker Ltd" is longer than 5 characters`,
+		},
+		{
+			name:        "Error case - array item",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"tags":["a",2]}`,
+			expectedErr: "tags[1]: is not a string",
+		},
+		{
+			name:        "Error case - not an array",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"tags":"a"}`,
+			expectedErr: "tags: is not an array",
+		},
+		{
+			name:        "Error case - not a boolean",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"active":"yes"}`,
+			expectedErr: "active: is not a boolean",
+		},
+		{
+			name:        "Error case - additional property",
+			schema:      "Delegation",
+			value:       `{"delegator":"tz1abc","amount":1,"counts":{"stake":"2"}}`,
+			expectedErr: "counts.stake: is not a number",
+		},
+		{
+			name:        "Error case - allOf",
+			schema:      "Page",
+			value:       `{"delegator":"tz1abc","amount":1,"page":11}`,
+			expectedErr: "page: 11 is greater than the maximum 10",
+		},
+		{
+			name:        "Error case - invalid pattern",
+			schema:      "Invalid",
+			value:       `"a"`,
+			expectedErr: "invalid pattern (",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			decoder := json.NewDecoder(strings.NewReader(tt.value))
+			decoder.UseNumber()
+			if err := decoder.Decode(&value); err != nil {
+				t.Fatalf("decode %s: %v", tt.value, err)
+			}
+
+			err := spec.validateSchema(&Schema{Ref: "#/components/schemas/" + tt.schema}, value, "")
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_Spec_validateSchema_enum(t *testing.T) {
+	spec := MustLoad([]byte(testSchemas))
+	schema := &Schema{Type: "integer", Enum: []any{1, 2}}
+
+	assert.NoError(t, spec.validateSchema(schema, json.Number("2"), ""))
+	assert.EqualError(t, spec.validateSchema(schema, json.Number("3"), "level"), "level: 3 is not one of [1 2]")
+}